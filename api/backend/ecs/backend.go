@@ -6,9 +6,11 @@ import (
 	"github.com/quintilesims/layer0/common/aws/ec2"
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/aws/elb"
+	"github.com/quintilesims/layer0/common/aws/elbv2"
 	"github.com/quintilesims/layer0/common/aws/iam"
 	"github.com/quintilesims/layer0/common/aws/s3"
 	"github.com/quintilesims/layer0/common/db/tag_store"
+	"github.com/quintilesims/layer0/common/docker"
 )
 
 // todo: this is an awkward design pattern - we don't need to split the ECSBackend
@@ -20,6 +22,7 @@ type ECSBackend struct {
 	*ECSDeployManager
 	*ECSLoadBalancerManager
 	*ECSTaskManager
+	*ECSSecretManager
 }
 
 func NewBackend(
@@ -29,6 +32,7 @@ func NewBackend(
 	ec2 ec2.Provider,
 	ecs ecs.Provider,
 	elb elb.Provider,
+	elbv2 elbv2.Provider,
 	autoscaling autoscaling.Provider,
 	cloudWatchLogs cloudwatchlogs.Provider,
 ) *ECSBackend {
@@ -36,10 +40,11 @@ func NewBackend(
 	backend := &ECSBackend{}
 
 	backend.ECSEnvironmentManager = NewECSEnvironmentManager(ecs, ec2, autoscaling, backend)
-	backend.ECSServiceManager = NewECSServiceManager(ecs, ec2, cloudWatchLogs, backend)
-	backend.ECSLoadBalancerManager = NewECSLoadBalancerManager(ec2, elb, iam, backend)
+	backend.ECSServiceManager = NewECSServiceManager(ecs, ec2, elbv2, cloudWatchLogs, backend)
+	backend.ECSLoadBalancerManager = NewECSLoadBalancerManager(ec2, elb, elbv2, iam, backend)
 	backend.ECSDeployManager = NewECSDeployManager(ecs)
-	backend.ECSTaskManager = NewECSTaskManager(ecs, cloudWatchLogs, backend)
+	backend.ECSTaskManager = NewECSTaskManager(ecs, ec2, cloudWatchLogs, docker.NewDocker, backend)
+	backend.ECSSecretManager = NewECSSecretManager(s3, iam)
 
 	return backend
 }
@@ -0,0 +1,571 @@
+package ecsbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/quintilesims/layer0/common/aws/ecs"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/shellutils"
+	"gopkg.in/yaml.v2"
+)
+
+// composeFile is the subset of the Docker Compose v2 and v3 file formats that can be converted to a Dockerrun.
+// Keys that are not supported are collected in the Unsupported maps so they can be reported.
+type composeFile struct {
+	Version     string                     `yaml:"version"`
+	Services    map[string]*composeService `yaml:"services"`
+	Volumes     map[string]*composeVolume  `yaml:"volumes"`
+	Unsupported map[string]interface{}     `yaml:",inline"`
+}
+
+type composeService struct {
+	Command        composeStringOrList    `yaml:"command"`
+	CPUShares      int64                  `yaml:"cpu_shares"`
+	Deploy         *composeDeploy         `yaml:"deploy"`
+	Entrypoint     composeStringOrList    `yaml:"entrypoint"`
+	Environment    composeMapOrList       `yaml:"environment"`
+	Hostname       string                 `yaml:"hostname"`
+	Image          string                 `yaml:"image"`
+	Labels         composeMapOrList       `yaml:"labels"`
+	Links          []string               `yaml:"links"`
+	Logging        *composeLogging        `yaml:"logging"`
+	MemLimit       composeMemory          `yaml:"mem_limit"`
+	MemReservation composeMemory          `yaml:"mem_reservation"`
+	Ports          []composePort          `yaml:"ports"`
+	Privileged     bool                   `yaml:"privileged"`
+	User           string                 `yaml:"user"`
+	Volumes        []string               `yaml:"volumes"`
+	WorkingDir     string                 `yaml:"working_dir"`
+	Unsupported    map[string]interface{} `yaml:",inline"`
+}
+
+type composeVolume struct {
+	Unsupported map[string]interface{} `yaml:",inline"`
+}
+
+type composeLogging struct {
+	Driver      string                 `yaml:"driver"`
+	Options     composeMapOrList       `yaml:"options"`
+	Unsupported map[string]interface{} `yaml:",inline"`
+}
+
+type composeDeploy struct {
+	Resources *struct {
+		Limits       *composeResources      `yaml:"limits"`
+		Reservations *composeResources      `yaml:"reservations"`
+		Unsupported  map[string]interface{} `yaml:",inline"`
+	} `yaml:"resources"`
+	Unsupported map[string]interface{} `yaml:",inline"`
+}
+
+type composeResources struct {
+	Memory      composeMemory          `yaml:"memory"`
+	Unsupported map[string]interface{} `yaml:",inline"`
+}
+
+// composeStringOrList is a list of arguments that can be written as a list or as a single string
+type composeStringOrList []string
+
+func (c *composeStringOrList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*c = list
+		return nil
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return fmt.Errorf("must be a string or a list of strings")
+	}
+
+	// like docker-compose, a string is split into arguments the way a shell would split it
+	args, err := shellutils.Split(str)
+	if err != nil {
+		return fmt.Errorf("invalid command '%s': %v", str, err)
+	}
+
+	*c = args
+	return nil
+}
+
+// composeMapOrList is a set of key/value pairs that can be written as a map or as a list of 'KEY=VAL' strings
+type composeMapOrList map[string]string
+
+func (c *composeMapOrList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	result := map[string]string{}
+
+	var m map[string]interface{}
+	if err := unmarshal(&m); err == nil {
+		for key, val := range m {
+			if val != nil {
+				result[key] = fmt.Sprint(val)
+			} else {
+				result[key] = ""
+			}
+		}
+
+		*c = result
+		return nil
+	}
+
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return fmt.Errorf("must be a map or a list of 'KEY=VAL' strings")
+	}
+
+	for _, item := range list {
+		split := strings.SplitN(item, "=", 2)
+		if len(split) == 2 {
+			result[split[0]] = split[1]
+		} else {
+			result[split[0]] = ""
+		}
+	}
+
+	*c = result
+	return nil
+}
+
+// composeMemory is an amount of memory in MiB, written as a number of bytes or as a string with a unit, e.g. '512m'.
+// Amounts are rounded up to the nearest MiB.
+type composeMemory int64
+
+// ECS requires containers to have at least 4 MiB of memory
+const minComposeMemory = 4
+
+var composeMemoryExpr = regexp.MustCompile(`^(\d+)\s*([bkmg]?)b?$`)
+
+func (c *composeMemory) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var bytes int64
+	if err := unmarshal(&bytes); err != nil {
+		var str string
+		if err := unmarshal(&str); err != nil {
+			return fmt.Errorf("must be a number of bytes or a string such as '512m'")
+		}
+
+		match := composeMemoryExpr.FindStringSubmatch(strings.ToLower(str))
+		if match == nil {
+			return fmt.Errorf("invalid memory value '%s'", str)
+		}
+
+		value, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return err
+		}
+
+		switch match[2] {
+		case "", "b":
+			bytes = value
+		case "k":
+			bytes = value * 1024
+		case "m":
+			bytes = value * 1024 * 1024
+		case "g":
+			bytes = value * 1024 * 1024 * 1024
+		}
+	}
+
+	mebibytes := (bytes + 1024*1024 - 1) / (1024 * 1024)
+	if mebibytes < minComposeMemory {
+		return fmt.Errorf("memory must be at least %dm", minComposeMemory)
+	}
+
+	*c = composeMemory(mebibytes)
+	return nil
+}
+
+type composePort struct {
+	ContainerPort int64
+	HostPort      *int64
+	Protocol      string
+}
+
+func (c *composePort) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var long struct {
+		Target      int64                  `yaml:"target"`
+		Published   int64                  `yaml:"published"`
+		Protocol    string                 `yaml:"protocol"`
+		Unsupported map[string]interface{} `yaml:",inline"`
+	}
+
+	if err := unmarshal(&long); err == nil {
+		if len(long.Unsupported) > 0 {
+			return fmt.Errorf("unsupported port key(s) %s", strings.Join(sortedComposeKeys(long.Unsupported), ", "))
+		}
+
+		c.ContainerPort = long.Target
+		if long.Published != 0 {
+			c.HostPort = aws.Int64(long.Published)
+		}
+
+		c.Protocol = long.Protocol
+		if c.Protocol == "" {
+			c.Protocol = "tcp"
+		}
+
+		return nil
+	}
+
+	var short string
+	if err := unmarshal(&short); err != nil {
+		return fmt.Errorf("must be a string, a number, or a map")
+	}
+
+	return c.parse(short)
+}
+
+// parse parses the short port syntax: 'CONTAINER[/PROTOCOL]' or 'HOST:CONTAINER[/PROTOCOL]'
+func (c *composePort) parse(short string) error {
+	c.Protocol = "tcp"
+	if split := strings.SplitN(short, "/", 2); len(split) == 2 {
+		short = split[0]
+		c.Protocol = split[1]
+	}
+
+	if strings.Contains(short, "-") {
+		return fmt.Errorf("port ranges are not supported ('%s')", short)
+	}
+
+	split := strings.Split(short, ":")
+	switch len(split) {
+	case 1:
+	case 2:
+		hostPort, err := strconv.ParseInt(split[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid host port '%s'", split[0])
+		}
+
+		c.HostPort = aws.Int64(hostPort)
+	default:
+		return fmt.Errorf("binding ports to a host ip is not supported ('%s')", short)
+	}
+
+	containerPort, err := strconv.ParseInt(split[len(split)-1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid container port '%s'", split[len(split)-1])
+	}
+
+	c.ContainerPort = containerPort
+	return nil
+}
+
+// ConvertCompose converts a Docker Compose v2 or v3 file to a Dockerrun.
+// Each compose service becomes an essential container definition with the same name.
+func ConvertCompose(body []byte) ([]byte, error) {
+	var compose composeFile
+	if err := yaml.Unmarshal(body, &compose); err != nil {
+		return nil, errors.Newf(errors.InvalidCompose, "Failed to decode compose file: %v", err)
+	}
+
+	if !strings.HasPrefix(compose.Version, "2") && !strings.HasPrefix(compose.Version, "3") {
+		return nil, errors.Newf(errors.InvalidCompose, "Compose file must specify version 2 or 3")
+	}
+
+	if err := checkComposeKeys("compose file", compose.Unsupported); err != nil {
+		return nil, err
+	}
+
+	if len(compose.Services) == 0 {
+		return nil, errors.Newf(errors.InvalidCompose, "Compose file must have at least one service")
+	}
+
+	volumeNames := []string{}
+	for name := range compose.Volumes {
+		volumeNames = append(volumeNames, name)
+	}
+
+	sort.Strings(volumeNames)
+
+	volumes := newComposeVolumeSet()
+	for _, name := range volumeNames {
+		if volume := compose.Volumes[name]; volume != nil {
+			if err := checkComposeKeys(fmt.Sprintf("volume '%s'", name), volume.Unsupported); err != nil {
+				return nil, err
+			}
+		}
+
+		volumes.addNamed(name)
+	}
+
+	serviceNames := []string{}
+	for name := range compose.Services {
+		serviceNames = append(serviceNames, name)
+	}
+
+	sort.Strings(serviceNames)
+
+	dockerrun := models.Dockerrun{}
+	for _, name := range serviceNames {
+		container, err := convertComposeService(name, compose.Services[name], compose.Volumes, volumes)
+		if err != nil {
+			return nil, err
+		}
+
+		dockerrun.ContainerDefinitions = append(dockerrun.ContainerDefinitions, container)
+	}
+
+	dockerrun.Volumes = volumes.volumes
+	return json.Marshal(dockerrun)
+}
+
+func convertComposeService(name string, service *composeService, namedVolumes map[string]*composeVolume, volumes *composeVolumeSet) (*ecs.ContainerDefinition, error) {
+	if service == nil || service.Image == "" {
+		return nil, errors.Newf(errors.InvalidCompose, "Service '%s' must specify an image", name)
+	}
+
+	context := fmt.Sprintf("service '%s'", name)
+	if err := checkComposeKeys(context, service.Unsupported); err != nil {
+		return nil, err
+	}
+
+	container := &awsecs.ContainerDefinition{
+		Essential: aws.Bool(true),
+		Image:     aws.String(service.Image),
+		Name:      aws.String(name),
+	}
+
+	if len(service.Command) > 0 {
+		container.Command = aws.StringSlice(service.Command)
+	}
+
+	if len(service.Entrypoint) > 0 {
+		container.EntryPoint = aws.StringSlice(service.Entrypoint)
+	}
+
+	environmentKeys := []string{}
+	for key := range service.Environment {
+		environmentKeys = append(environmentKeys, key)
+	}
+
+	sort.Strings(environmentKeys)
+	for _, key := range environmentKeys {
+		container.Environment = append(container.Environment, &awsecs.KeyValuePair{
+			Name:  aws.String(key),
+			Value: aws.String(service.Environment[key]),
+		})
+	}
+
+	if len(service.Labels) > 0 {
+		container.DockerLabels = aws.StringMap(service.Labels)
+	}
+
+	if len(service.Links) > 0 {
+		container.Links = aws.StringSlice(service.Links)
+	}
+
+	if service.CPUShares != 0 {
+		container.Cpu = aws.Int64(service.CPUShares)
+	}
+
+	if service.Hostname != "" {
+		container.Hostname = aws.String(service.Hostname)
+	}
+
+	if service.User != "" {
+		container.User = aws.String(service.User)
+	}
+
+	if service.WorkingDir != "" {
+		container.WorkingDirectory = aws.String(service.WorkingDir)
+	}
+
+	if service.Privileged {
+		container.Privileged = aws.Bool(true)
+	}
+
+	memory, memoryReservation, err := composeServiceMemory(context, service)
+	if err != nil {
+		return nil, err
+	}
+
+	if memory != 0 {
+		container.Memory = aws.Int64(memory)
+	}
+
+	if memoryReservation != 0 {
+		container.MemoryReservation = aws.Int64(memoryReservation)
+	}
+
+	if memory == 0 && memoryReservation == 0 {
+		return nil, errors.Newf(errors.InvalidCompose, "Service '%s' must specify mem_limit or mem_reservation", name)
+	}
+
+	for _, port := range service.Ports {
+		container.PortMappings = append(container.PortMappings, &awsecs.PortMapping{
+			ContainerPort: aws.Int64(port.ContainerPort),
+			HostPort:      port.HostPort,
+			Protocol:      aws.String(port.Protocol),
+		})
+	}
+
+	for _, volume := range service.Volumes {
+		mountPoint, err := volumes.mountPoint(context, volume, namedVolumes)
+		if err != nil {
+			return nil, err
+		}
+
+		container.MountPoints = append(container.MountPoints, mountPoint)
+	}
+
+	if logging := service.Logging; logging != nil {
+		if err := checkComposeKeys(context+" logging", logging.Unsupported); err != nil {
+			return nil, err
+		}
+
+		container.LogConfiguration = &awsecs.LogConfiguration{
+			LogDriver: aws.String(logging.Driver),
+		}
+
+		if len(logging.Options) > 0 {
+			container.LogConfiguration.Options = aws.StringMap(logging.Options)
+		}
+	}
+
+	return &ecs.ContainerDefinition{container}, nil
+}
+
+// composeServiceMemory returns the memory limit and reservation of a service in MiB.
+// Values from the v3 'deploy.resources' section take precedence over 'mem_limit' and 'mem_reservation'.
+func composeServiceMemory(context string, service *composeService) (int64, int64, error) {
+	memory := int64(service.MemLimit)
+	memoryReservation := int64(service.MemReservation)
+
+	deploy := service.Deploy
+	if deploy == nil {
+		return memory, memoryReservation, nil
+	}
+
+	if err := checkComposeKeys(context+" deploy", deploy.Unsupported); err != nil {
+		return 0, 0, err
+	}
+
+	resources := deploy.Resources
+	if resources == nil {
+		return memory, memoryReservation, nil
+	}
+
+	if err := checkComposeKeys(context+" deploy resources", resources.Unsupported); err != nil {
+		return 0, 0, err
+	}
+
+	if limits := resources.Limits; limits != nil {
+		if err := checkComposeKeys(context+" deploy resources limits", limits.Unsupported); err != nil {
+			return 0, 0, err
+		}
+
+		if limits.Memory != 0 {
+			memory = int64(limits.Memory)
+		}
+	}
+
+	if reservations := resources.Reservations; reservations != nil {
+		if err := checkComposeKeys(context+" deploy resources reservations", reservations.Unsupported); err != nil {
+			return 0, 0, err
+		}
+
+		if reservations.Memory != 0 {
+			memoryReservation = int64(reservations.Memory)
+		}
+	}
+
+	return memory, memoryReservation, nil
+}
+
+// composeVolumeSet tracks the task volumes used by the mount points of all services
+type composeVolumeSet struct {
+	volumes   []*ecs.Volume
+	hostPaths map[string]string
+}
+
+func newComposeVolumeSet() *composeVolumeSet {
+	return &composeVolumeSet{
+		volumes:   []*ecs.Volume{},
+		hostPaths: map[string]string{},
+	}
+}
+
+func (c *composeVolumeSet) addNamed(name string) {
+	c.volumes = append(c.volumes, &ecs.Volume{&awsecs.Volume{Name: aws.String(name)}})
+}
+
+func (c *composeVolumeSet) addHostPath(path string) string {
+	if name, ok := c.hostPaths[path]; ok {
+		return name
+	}
+
+	name := fmt.Sprintf("host-volume-%d", len(c.hostPaths)+1)
+	c.hostPaths[path] = name
+	c.volumes = append(c.volumes, &ecs.Volume{&awsecs.Volume{
+		Host: &awsecs.HostVolumeProperties{SourcePath: aws.String(path)},
+		Name: aws.String(name),
+	}})
+
+	return name
+}
+
+// mountPoint parses the short volume syntax: 'SOURCE:CONTAINER_PATH[:MODE]'.
+// SOURCE must be an absolute host path or a named volume declared at the top level of the compose file.
+func (c *composeVolumeSet) mountPoint(context, volume string, namedVolumes map[string]*composeVolume) (*awsecs.MountPoint, error) {
+	split := strings.Split(volume, ":")
+	if len(split) < 2 || len(split) > 3 {
+		return nil, errors.Newf(errors.InvalidCompose, "Volume '%s' in %s must be in format 'SOURCE:CONTAINER_PATH[:MODE]'", volume, context)
+	}
+
+	mountPoint := &awsecs.MountPoint{
+		ContainerPath: aws.String(split[1]),
+		ReadOnly:      aws.Bool(false),
+	}
+
+	if len(split) == 3 {
+		switch split[2] {
+		case "ro":
+			mountPoint.ReadOnly = aws.Bool(true)
+		case "rw":
+		default:
+			return nil, errors.Newf(errors.InvalidCompose, "Volume '%s' in %s has unsupported mode '%s'", volume, context, split[2])
+		}
+	}
+
+	source := split[0]
+	switch {
+	case strings.HasPrefix(source, "/"):
+		mountPoint.SourceVolume = aws.String(c.addHostPath(source))
+	case strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~"):
+		return nil, errors.Newf(errors.InvalidCompose, "Volume '%s' in %s uses a relative host path, which is not supported", volume, context)
+	default:
+		if _, ok := namedVolumes[source]; !ok {
+			return nil, errors.Newf(errors.InvalidCompose, "Volume '%s' in %s refers to undeclared volume '%s'", volume, context, source)
+		}
+
+		mountPoint.SourceVolume = aws.String(source)
+	}
+
+	return mountPoint, nil
+}
+
+func checkComposeKeys(context string, unsupported map[string]interface{}) error {
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	keys := sortedComposeKeys(unsupported)
+	return errors.Newf(errors.InvalidCompose, "Unsupported key(s) in %s: %s", context, strings.Join(keys, ", "))
+}
+
+func sortedComposeKeys(m map[string]interface{}) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,172 @@
+package ecsbackend
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestConvertCompose(t *testing.T) {
+	compose := []byte(`
+version: "3"
+services:
+  web:
+    image: web:latest
+    command: npm start
+    environment:
+      MODE: prod
+      PORT: 8080
+    links:
+      - db:database
+    ports:
+      - "80:8080"
+      - 9000/udp
+    volumes:
+      - /var/log:/logs:ro
+      - data:/data
+    mem_limit: 512m
+    logging:
+      driver: awslogs
+      options:
+        awslogs-group: web
+  db:
+    image: postgres
+    environment:
+      - POSTGRES_DB=app
+    deploy:
+      resources:
+        limits:
+          memory: 1g
+        reservations:
+          memory: 256m
+volumes:
+  data:
+`)
+
+	body, err := ConvertCompose(compose)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dockerrun, err := MarshalDockerrun(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(dockerrun.ContainerDefinitions), 2)
+
+	db := dockerrun.ContainerDefinitions[0]
+	testutils.AssertEqual(t, aws.StringValue(db.Name), "db")
+	testutils.AssertEqual(t, aws.StringValue(db.Image), "postgres")
+	testutils.AssertEqual(t, aws.BoolValue(db.Essential), true)
+	testutils.AssertEqual(t, aws.Int64Value(db.Memory), int64(1024))
+	testutils.AssertEqual(t, aws.Int64Value(db.MemoryReservation), int64(256))
+	testutils.AssertEqual(t, db.Environment, []*awsecs.KeyValuePair{
+		{Name: aws.String("POSTGRES_DB"), Value: aws.String("app")},
+	})
+
+	web := dockerrun.ContainerDefinitions[1]
+	testutils.AssertEqual(t, aws.StringValue(web.Name), "web")
+	testutils.AssertEqual(t, aws.StringValueSlice(web.Command), []string{"npm", "start"})
+	testutils.AssertEqual(t, aws.Int64Value(web.Memory), int64(512))
+	testutils.AssertEqual(t, aws.StringValueSlice(web.Links), []string{"db:database"})
+	testutils.AssertEqual(t, web.Environment, []*awsecs.KeyValuePair{
+		{Name: aws.String("MODE"), Value: aws.String("prod")},
+		{Name: aws.String("PORT"), Value: aws.String("8080")},
+	})
+	testutils.AssertEqual(t, web.PortMappings, []*awsecs.PortMapping{
+		{ContainerPort: aws.Int64(8080), HostPort: aws.Int64(80), Protocol: aws.String("tcp")},
+		{ContainerPort: aws.Int64(9000), Protocol: aws.String("udp")},
+	})
+	testutils.AssertEqual(t, web.MountPoints, []*awsecs.MountPoint{
+		{ContainerPath: aws.String("/logs"), ReadOnly: aws.Bool(true), SourceVolume: aws.String("host-volume-1")},
+		{ContainerPath: aws.String("/data"), ReadOnly: aws.Bool(false), SourceVolume: aws.String("data")},
+	})
+	testutils.AssertEqual(t, aws.StringValue(web.LogConfiguration.LogDriver), "awslogs")
+	testutils.AssertEqual(t, aws.StringValueMap(web.LogConfiguration.Options), map[string]string{"awslogs-group": "web"})
+
+	testutils.AssertEqual(t, len(dockerrun.Volumes), 2)
+	testutils.AssertEqual(t, aws.StringValue(dockerrun.Volumes[0].Name), "data")
+	testutils.AssertEqual(t, aws.StringValue(dockerrun.Volumes[1].Name), "host-volume-1")
+	testutils.AssertEqual(t, aws.StringValue(dockerrun.Volumes[1].Host.SourcePath), "/var/log")
+}
+
+func TestConvertCompose_memoryUnits(t *testing.T) {
+	cases := map[string]int64{
+		"mem_limit: 536870912": 512,
+		"mem_limit: 524288k":   512,
+		"mem_limit: 512mb":     512,
+		"mem_limit: 2g":        2048,
+		"mem_limit: 5000000":   5,
+		"mem_limit: 4097k":     5,
+	}
+
+	for memory, expected := range cases {
+		compose := "version: '2'\nservices:\n  api:\n    image: api\n    " + memory + "\n"
+
+		body, err := ConvertCompose([]byte(compose))
+		if err != nil {
+			t.Fatalf("Case %s: %v", memory, err)
+		}
+
+		dockerrun, err := MarshalDockerrun(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertEqual(t, aws.Int64Value(dockerrun.ContainerDefinitions[0].Memory), expected)
+	}
+}
+
+func TestConvertCompose_command(t *testing.T) {
+	compose := "version: '2'\nservices:\n  api:\n    image: api\n    mem_limit: 128m\n" +
+		"    entrypoint: /bin/sh -c\n    command: 'echo \"hello  world\" && exec npm start'\n"
+
+	body, err := ConvertCompose([]byte(compose))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dockerrun, err := MarshalDockerrun(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	container := dockerrun.ContainerDefinitions[0]
+	testutils.AssertEqual(t, aws.StringValueSlice(container.EntryPoint), []string{"/bin/sh", "-c"})
+	testutils.AssertEqual(t, aws.StringValueSlice(container.Command), []string{"echo", "hello  world", "&&", "exec", "npm", "start"})
+}
+
+func TestConvertCompose_errors(t *testing.T) {
+	service := "version: '2'\nservices:\n  api:\n    image: api\n    mem_limit: 128m\n"
+
+	cases := map[string]string{
+		"Invalid YAML":            "version: '2'\nservices: [",
+		"Missing version":         "services:\n  api:\n    image: api\n    mem_limit: 128m\n",
+		"Unsupported version":     "version: '1'\nservices:\n  api:\n    image: api\n    mem_limit: 128m\n",
+		"No services":             "version: '2'\n",
+		"Unsupported top level":   service + "networks:\n  front: {}\n",
+		"Missing image":           "version: '2'\nservices:\n  api:\n    mem_limit: 128m\n",
+		"Missing memory":          "version: '2'\nservices:\n  api:\n    image: api\n",
+		"Unsupported service key": service + "    depends_on:\n      - db\n",
+		"Unsupported deploy key":  service + "    deploy:\n      replicas: 2\n",
+		"Port range":              service + "    ports:\n      - 8000-8010:8000-8010\n",
+		"Port host ip":            service + "    ports:\n      - 127.0.0.1:80:80\n",
+		"Relative host path":      service + "    volumes:\n      - ./data:/data\n",
+		"Undeclared volume":       service + "    volumes:\n      - data:/data\n",
+		"Anonymous volume":        service + "    volumes:\n      - /data\n",
+		"Unsupported volume mode": service + "    volumes:\n      - /data:/data:z\n",
+		"Unsupported volume key":  service + "volumes:\n  data:\n    driver: rexray\n",
+		"Invalid memory":          "version: '2'\nservices:\n  api:\n    image: api\n    mem_limit: lots\n",
+		"Memory below minimum":    "version: '2'\nservices:\n  api:\n    image: api\n    mem_limit: 1048576\n",
+		"Unterminated quote":      service + "    command: echo 'hello\n",
+	}
+
+	for name, compose := range cases {
+		if _, err := ConvertCompose([]byte(compose)); err == nil {
+			t.Errorf("Case %s: error was nil!", name)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package ecsbackend
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"text/template"
 
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/aws/ecs"
@@ -69,13 +71,15 @@ func (this *ECSDeployManager) DeleteDeploy(deployID string) error {
 	return nil
 }
 
-func (this *ECSDeployManager) CreateDeploy(deployName string, body []byte) (*models.Deploy, error) {
+// CreateDeploy registers a task definition from body.
+// If variables is non-nil, body is rendered as a template with variables before it is used.
+func (this *ECSDeployManager) CreateDeploy(deployName string, body []byte, variables map[string]string) (*models.Deploy, error) {
 	// since we use '.' as our ID-Version delimiter, we don't allow it in deploy names
 	if strings.Contains(deployName, ".") {
 		return nil, errors.Newf(errors.InvalidDeployID, "Deploy names cannot contain '.'")
 	}
 
-	dockerrun, err := CreateRenderedDockerrun(body)
+	dockerrun, err := CreateRenderedDockerrun(body, variables)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +135,24 @@ func MarshalDockerrun(body []byte) (*models.Dockerrun, error) {
 	return &d, nil
 }
 
+// RenderDockerrunTemplate executes body as a text/template with variables as its data.
+// Referencing a variable that was not specified is an error.
+func RenderDockerrunTemplate(body []byte, variables map[string]string) ([]byte, error) {
+	tmpl, err := template.New("dockerrun").Option("missingkey=error").Parse(string(body))
+	if err != nil {
+		err := fmt.Errorf("Failed to parse deploy template: %s", err.Error())
+		return nil, errors.New(errors.InvalidDeployTemplate, err)
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, variables); err != nil {
+		err := fmt.Errorf("Failed to render deploy template: %s", err.Error())
+		return nil, errors.New(errors.InvalidDeployTemplate, err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
 func extractDockerrun(taskDef *ecs.TaskDefinition) ([]byte, error) {
 	containers := make([]*ecs.ContainerDefinition, len(taskDef.ContainerDefinitions))
 	for i, c := range taskDef.ContainerDefinitions {
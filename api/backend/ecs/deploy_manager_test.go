@@ -9,6 +9,7 @@ import (
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/aws/ecs/mock_ecs"
+	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 )
@@ -312,7 +313,7 @@ func TestCreateDeploy(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSDeployManager)
-				manager.CreateDeploy("some_name", dockerrun)
+				manager.CreateDeploy("some_name", dockerrun, nil)
 			},
 		},
 		{
@@ -344,7 +345,7 @@ func TestCreateDeploy(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSDeployManager)
-				manager.CreateDeploy("some_name", dockerrun)
+				manager.CreateDeploy("some_name", dockerrun, nil)
 			},
 		}, {
 			Name: "Should marshal dockerrun with placement constraints correctly",
@@ -375,7 +376,7 @@ func TestCreateDeploy(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSDeployManager)
-				manager.CreateDeploy("some_name", dockerrunWithPCs)
+				manager.CreateDeploy("some_name", dockerrunWithPCs, nil)
 			},
 		},
 		{
@@ -401,7 +402,7 @@ func TestCreateDeploy(t *testing.T) {
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSDeployManager)
 
-				deploy, err := manager.CreateDeploy("some_name", dockerrun)
+				deploy, err := manager.CreateDeploy("some_name", dockerrun, nil)
 				if err != nil {
 					reporter.Fatal(err)
 				}
@@ -410,6 +411,78 @@ func TestCreateDeploy(t *testing.T) {
 				reporter.AssertEqual(deploy.Version, "2")
 			},
 		},
+		{
+			Name: "Should render templates with the specified variables",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockDeploy := NewMockECSDeployManager(ctrl)
+
+				taskDefinition := fmt.Sprintf("%ssome_name:1", id.PREFIX)
+
+				task := &ecs.TaskDefinition{
+					&aws_ecs.TaskDefinition{
+						TaskDefinitionArn: stringp("arn:aws:ecs:us-west-2:12345678:task-definition/" + taskDefinition),
+					},
+				}
+
+				mockDeploy.ECS.EXPECT().
+					RegisterTaskDefinition(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(family, taskRoleARN, network string, containers []*ecs.ContainerDefinition, volumes []*ecs.Volume, placementConstraints []*ecs.PlacementConstraint) {
+						reporter.AssertEqual(len(containers), 1)
+						reporter.AssertEqual(*containers[0].Image, "quintilesims/test:v2")
+						reporter.AssertEqual(*containers[0].Memory, int64(512))
+					}).
+					Return(task, nil)
+
+				return mockDeploy.Deploy()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSDeployManager)
+
+				template := []byte(`{"ContainerDefinitions": [{"name": "test", "image": "quintilesims/test:{{ .tag }}", "memory": {{ .memory }}}]}`)
+				variables := map[string]string{"tag": "v2", "memory": "512"}
+
+				if _, err := manager.CreateDeploy("some_name", template, variables); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+		{
+			Name: "Should error if template references a missing variable",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockDeploy := NewMockECSDeployManager(ctrl)
+				return mockDeploy.Deploy()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSDeployManager)
+
+				template := []byte(`{"ContainerDefinitions": [{"name": "test", "image": "quintilesims/test:{{ .tag }}"}]}`)
+
+				_, err := manager.CreateDeploy("some_name", template, map[string]string{})
+				if err == nil {
+					reporter.Fatalf("Error was nil!")
+				}
+
+				if err, ok := err.(*errors.ServerError); !ok || err.Code != errors.InvalidDeployTemplate {
+					reporter.Errorf("Error was not InvalidDeployTemplate: %v", err)
+				}
+			},
+		},
+		{
+			Name: "Should error if rendered template is not a valid dockerrun",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockDeploy := NewMockECSDeployManager(ctrl)
+				return mockDeploy.Deploy()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSDeployManager)
+
+				template := []byte(`{"ContainerDefinitions": [{"name": "test", "memory": {{ .memory }}}]}`)
+
+				if _, err := manager.CreateDeploy("some_name", template, map[string]string{"memory": "lots"}); err == nil {
+					reporter.Fatalf("Error was nil!")
+				}
+			},
+		},
 		{
 			Name: "Should error if deployName contains '.'",
 			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
@@ -419,7 +492,7 @@ func TestCreateDeploy(t *testing.T) {
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSDeployManager)
 
-				if _, err := manager.CreateDeploy("some.name", dockerrun); err == nil {
+				if _, err := manager.CreateDeploy("some.name", dockerrun, nil); err == nil {
 					reporter.Errorf("Error was nil!")
 				}
 			},
@@ -438,7 +511,7 @@ func TestCreateDeploy(t *testing.T) {
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSDeployManager)
 
-				if _, err := manager.CreateDeploy("some_name", dockerrun); err == nil {
+				if _, err := manager.CreateDeploy("some_name", dockerrun, nil); err == nil {
 					reporter.Errorf("Error was nil!")
 				}
 			},
@@ -0,0 +1,263 @@
+package ecsbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	aws_ecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/api/scheduler/resource"
+	"github.com/quintilesims/layer0/common/aws/ecs"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/zpatrick/go-bytesize"
+)
+
+// network modes that can be used without the awsvpc network configuration layer0 does not provide
+var supportedNetworkModes = []string{"bridge", "host", "none"}
+
+// ValidateDeploy checks a dockerrun for problems that would otherwise surface as RegisterTaskDefinition
+// errors or as tasks that never get placed. If environmentID is set, the deploy is also checked against
+// the resources of a new instance in that environment.
+func (this *ECSBackend) ValidateDeploy(deployName string, body []byte, environmentID string) (*models.DeployValidation, error) {
+	dockerrun, validation := ValidateDockerrun(deployName, body)
+	if dockerrun == nil || environmentID == "" {
+		return validation, nil
+	}
+
+	resourceManager := NewECSResourceManager(this.ECSEnvironmentManager.ECS, this.ECSEnvironmentManager.AutoScaling)
+	provider, err := resourceManager.CalculateNewProvider(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	validation.Errors = append(validation.Errors, checkDockerrunFits(dockerrun, environmentID, provider)...)
+	validation.Valid = len(validation.Errors) == 0
+	return validation, nil
+}
+
+// ValidateDockerrun returns the decoded dockerrun along with any problems found in it.
+// The dockerrun is nil if the body could not be decoded.
+func ValidateDockerrun(deployName string, body []byte) (*models.Dockerrun, *models.DeployValidation) {
+	validation := &models.DeployValidation{
+		Errors:   []string{},
+		Warnings: []string{},
+	}
+
+	var dockerrun models.Dockerrun
+	if err := json.Unmarshal(body, &dockerrun); err != nil {
+		validation.Errors = append(validation.Errors, fmt.Sprintf("Failed to decode deploy: %s", err.Error()))
+		return nil, validation
+	}
+
+	validation.Warnings = append(validation.Warnings, findUnknownDockerrunFields(body)...)
+
+	if len(dockerrun.ContainerDefinitions) == 0 {
+		validation.Errors = append(validation.Errors, "Deploy must have at least one container definition")
+	}
+
+	if dockerrun.NetworkMode != "" && !containsString(supportedNetworkModes, dockerrun.NetworkMode) {
+		msg := fmt.Sprintf("Network mode '%s' is not supported by Layer0 (supported modes: %s)", dockerrun.NetworkMode, strings.Join(supportedNetworkModes, ", "))
+		validation.Errors = append(validation.Errors, msg)
+	}
+
+	familyName := id.L0DeployID(deployName).ECSDeployID().String()
+	if dockerrun.Family != "" && dockerrun.Family != familyName {
+		validation.Errors = append(validation.Errors, "Custom family names are currently unsupported in Layer0")
+	}
+
+	containerNames := map[string]bool{}
+	hostPorts := map[string]string{}
+	for i, container := range dockerrun.ContainerDefinitions {
+		name := aws.StringValue(container.Name)
+		if name == "" {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("Container definition %d does not have a name", i))
+			name = fmt.Sprintf("<container %d>", i)
+		} else if containerNames[name] {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("Container name '%s' is used by more than one container", name))
+		}
+
+		containerNames[name] = true
+
+		memory := aws.Int64Value(container.Memory)
+		memoryReservation := aws.Int64Value(container.MemoryReservation)
+		switch {
+		case memory == 0 && memoryReservation == 0:
+			validation.Errors = append(validation.Errors, fmt.Sprintf("Container '%s' must specify 'memory' or 'memoryReservation'", name))
+		case memory != 0 && memoryReservation > memory:
+			validation.Errors = append(validation.Errors, fmt.Sprintf("Container '%s' has a 'memoryReservation' greater than its 'memory'", name))
+		}
+
+		for _, portMapping := range container.PortMappings {
+			port, ok := GetStaticHostPort(dockerrun.NetworkMode, portMapping)
+			if !ok {
+				continue
+			}
+
+			protocol := aws.StringValue(portMapping.Protocol)
+			if protocol == "" {
+				protocol = aws_ecs.TransportProtocolTcp
+			}
+
+			key := fmt.Sprintf("%d/%s", port, protocol)
+			if owner, ok := hostPorts[key]; ok {
+				msg := fmt.Sprintf("Containers '%s' and '%s' both use host port %s", owner, name, key)
+				validation.Errors = append(validation.Errors, msg)
+				continue
+			}
+
+			hostPorts[key] = name
+		}
+	}
+
+	validation.Valid = len(validation.Errors) == 0
+	return &dockerrun, validation
+}
+
+// DockerrunResourceConsumers returns the memory and static host ports each container in the dockerrun
+// needs on the instance it is placed on
+func DockerrunResourceConsumers(dockerrun *models.Dockerrun) []resource.ResourceConsumer {
+	consumers := make([]resource.ResourceConsumer, len(dockerrun.ContainerDefinitions))
+	for i, container := range dockerrun.ContainerDefinitions {
+		var memory bytesize.Bytesize
+
+		if container.MemoryReservation != nil && *container.MemoryReservation != 0 {
+			memory = bytesize.MiB * bytesize.Bytesize(*container.MemoryReservation)
+		}
+
+		if container.Memory != nil && *container.Memory != 0 {
+			memory = bytesize.MiB * bytesize.Bytesize(*container.Memory)
+		}
+
+		ports := []int{}
+		for _, p := range container.PortMappings {
+			if port, ok := GetStaticHostPort(dockerrun.NetworkMode, p); ok {
+				ports = append(ports, port)
+			}
+		}
+
+		consumers[i] = resource.NewResourceConsumer(aws.StringValue(container.Name), memory, ports)
+	}
+
+	return consumers
+}
+
+// GetStaticHostPort returns the host port a port mapping reserves on the instance; mappings without a host port
+// (or with a host port of 0) are dynamically assigned by docker and never conflict with other consumers
+func GetStaticHostPort(networkMode string, portMapping *aws_ecs.PortMapping) (int, bool) {
+	if portMapping.HostPort != nil && *portMapping.HostPort != 0 {
+		return int(*portMapping.HostPort), true
+	}
+
+	// containers in host network mode always bind directly to their container port
+	if networkMode == "host" && portMapping.ContainerPort != nil {
+		return int(*portMapping.ContainerPort), true
+	}
+
+	return 0, false
+}
+
+// all of a task's containers are placed on the same instance, so each container
+// must fit on the new instance alongside the containers placed before it
+func checkDockerrunFits(dockerrun *models.Dockerrun, environmentID string, provider *resource.ResourceProvider) []string {
+	availableMemory := provider.ToModel().AvailableMemory
+
+	problems := []string{}
+	for _, consumer := range DockerrunResourceConsumers(dockerrun) {
+		if err := provider.SubtractResourcesFor(consumer); err != nil {
+			msg := fmt.Sprintf("Container '%s' does not fit on an instance in environment '%s' alongside the deploy's other containers (instances have %s of memory, and some host ports are reserved by the ecs agent)",
+				consumer.ID, environmentID, availableMemory)
+			problems = append(problems, msg)
+		}
+	}
+
+	return problems
+}
+
+// findUnknownDockerrunFields returns warnings for the fields in body that are ignored when decoding into a models.Dockerrun
+func findUnknownDockerrunFields(body []byte) []string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil
+	}
+
+	var containers struct {
+		ContainerDefinitions []map[string]json.RawMessage `json:"containerDefinitions"`
+	}
+
+	if err := json.Unmarshal(body, &containers); err != nil {
+		return nil
+	}
+
+	warnings := []string{}
+	dockerrunFields := knownJSONFields(reflect.TypeOf(models.Dockerrun{}))
+	// the elastic beanstalk version field is commonly included in dockerruns
+	dockerrunFields["awsebdockerrunversion"] = true
+
+	for _, field := range sortedKeys(fields) {
+		if !dockerrunFields[strings.ToLower(field)] {
+			warnings = append(warnings, fmt.Sprintf("Unknown field '%s' will be ignored", field))
+		}
+	}
+
+	containerFields := knownJSONFields(reflect.TypeOf(ecs.ContainerDefinition{}))
+	for i, container := range containers.ContainerDefinitions {
+		for _, field := range sortedKeys(container) {
+			if !containerFields[strings.ToLower(field)] {
+				warnings = append(warnings, fmt.Sprintf("Unknown field '%s' in container definition %d will be ignored", field, i))
+			}
+		}
+	}
+
+	return warnings
+}
+
+// knownJSONFields returns the lowercased names encoding/json will match to the fields of t
+func knownJSONFields(t reflect.Type) map[string]bool {
+	fields := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+
+			for name := range knownJSONFields(embedded) {
+				fields[name] = true
+			}
+
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" {
+			if tag == "-" {
+				continue
+			}
+
+			name = tag
+		}
+
+		fields[strings.ToLower(name)] = true
+	}
+
+	return fields
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
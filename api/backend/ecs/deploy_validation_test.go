@@ -0,0 +1,93 @@
+package ecsbackend
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/api/scheduler/resource"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/zpatrick/go-bytesize"
+)
+
+func TestValidateDockerrun(t *testing.T) {
+	body := []byte(`{
+		"AWSEBDockerrunVersion": 2,
+		"containerDefinitions": [
+			{"name": "api", "image": "api", "memory": 512, "portMappings": [{"hostPort": 80, "containerPort": 80}]},
+			{"name": "worker", "image": "worker", "memoryReservation": 128, "portMappings": [{"containerPort": 8080}]}
+		]
+	}`)
+
+	dockerrun, validation := ValidateDockerrun("name", body)
+	if dockerrun == nil {
+		t.Fatal("Dockerrun was nil!")
+	}
+
+	testutils.AssertEqual(t, validation.Valid, true)
+	testutils.AssertEqual(t, validation.Errors, []string{})
+	testutils.AssertEqual(t, validation.Warnings, []string{})
+}
+
+func TestValidateDockerrun_errors(t *testing.T) {
+	cases := map[string]string{
+		"Invalid JSON":               `{"containerDefinitions": "api"}`,
+		"No containers":              `{"containerDefinitions": []}`,
+		"Missing name":               `{"containerDefinitions": [{"memory": 128}]}`,
+		"Duplicate names":            `{"containerDefinitions": [{"name": "api", "memory": 128}, {"name": "api", "memory": 128}]}`,
+		"Missing memory":             `{"containerDefinitions": [{"name": "api"}]}`,
+		"Reservation exceeds memory": `{"containerDefinitions": [{"name": "api", "memory": 128, "memoryReservation": 256}]}`,
+		"Host port conflict":         `{"containerDefinitions": [{"name": "a", "memory": 128, "portMappings": [{"hostPort": 80, "containerPort": 80}]}, {"name": "b", "memory": 128, "portMappings": [{"hostPort": 80, "containerPort": 8080}]}]}`,
+		"Host network port conflict": `{"networkMode": "host", "containerDefinitions": [{"name": "a", "memory": 128, "portMappings": [{"containerPort": 80}]}, {"name": "b", "memory": 128, "portMappings": [{"containerPort": 80}]}]}`,
+		"Unsupported network mode":   `{"networkMode": "awsvpc", "containerDefinitions": [{"name": "api", "memory": 128}]}`,
+		"Custom family":              `{"family": "custom", "containerDefinitions": [{"name": "api", "memory": 128}]}`,
+	}
+
+	for name, body := range cases {
+		_, validation := ValidateDockerrun("name", []byte(body))
+		if validation.Valid || len(validation.Errors) == 0 {
+			t.Errorf("Case %s: deploy was valid!", name)
+		}
+	}
+}
+
+func TestValidateDockerrun_allowsMatchingFamily(t *testing.T) {
+	family := id.L0DeployID("name").ECSDeployID().String()
+	body := []byte(`{"family": "` + family + `", "containerDefinitions": [{"name": "api", "memory": 128}]}`)
+
+	_, validation := ValidateDockerrun("name", body)
+	testutils.AssertEqual(t, validation.Errors, []string{})
+}
+
+func TestValidateDockerrun_unknownFields(t *testing.T) {
+	body := []byte(`{"volumez": [], "containerDefinitions": [{"name": "api", "memory": 128, "imagee": "api"}]}`)
+
+	_, validation := ValidateDockerrun("name", body)
+
+	expected := []string{
+		"Unknown field 'volumez' will be ignored",
+		"Unknown field 'imagee' in container definition 0 will be ignored",
+	}
+
+	testutils.AssertEqual(t, validation.Valid, true)
+	testutils.AssertEqual(t, validation.Warnings, expected)
+}
+
+func TestCheckDockerrunFits(t *testing.T) {
+	body := []byte(`{"containerDefinitions": [
+		{"name": "a", "memory": 1024, "portMappings": [{"hostPort": 80, "containerPort": 80}]},
+		{"name": "b", "memory": 1024},
+		{"name": "c", "memory": 1024, "portMappings": [{"hostPort": 22, "containerPort": 22}]}
+	]}`)
+
+	dockerrun, _ := ValidateDockerrun("name", body)
+
+	newProvider := func(memory bytesize.Bytesize) *resource.ResourceProvider {
+		return resource.NewResourceProvider("<new instance>", false, memory, []int{22})
+	}
+
+	// 'c' always conflicts with the reserved ssh port
+	testutils.AssertEqual(t, len(checkDockerrunFits(dockerrun, "eid", newProvider(bytesize.GiB*4))), 1)
+
+	// 'b' and 'c' do not fit after 'a' is placed
+	testutils.AssertEqual(t, len(checkDockerrunFits(dockerrun, "eid", newProvider(bytesize.GiB))), 2)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/aws/aws-sdk-go/aws"
 	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
@@ -16,6 +17,7 @@ import (
 	"github.com/quintilesims/layer0/common/aws/ec2"
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/docker"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/waitutils"
@@ -197,6 +199,12 @@ func (e *ECSEnvironmentManager) CreateEnvironment(
 		return nil, err
 	}
 
+	if operatingSystem == "linux" {
+		if err := e.authorizeExecIngress(*groupID); err != nil {
+			return nil, err
+		}
+	}
+
 	securityGroups := []*string{groupID}
 	ecsRole := config.AWSECSInstanceProfile()
 	keyPair := config.AWSKeyPair()
@@ -327,28 +335,33 @@ func (e *ECSEnvironmentManager) DeleteEnvironment(environmentID string) error {
 	return nil
 }
 
-func (e *ECSEnvironmentManager) CreateEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error {
-	sourceECSID := id.L0EnvironmentID(sourceEnvironmentID).ECSEnvironmentID()
-	destECSID := id.L0EnvironmentID(destEnvironmentID).ECSEnvironmentID()
+func (e *ECSEnvironmentManager) CreateEnvironmentLink(environmentID string, link models.EnvironmentLink) error {
+	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
 
-	sourceGroup, err := e.getEnvironmentSecurityGroup(sourceECSID)
+	group, err := e.getEnvironmentSecurityGroup(ecsEnvironmentID)
 	if err != nil {
 		return err
 	}
 
-	destGroup, err := e.getEnvironmentSecurityGroup(destECSID)
-	if err != nil {
-		return err
+	peerGroupID := link.SecurityGroupID
+	if link.EnvironmentID != "" {
+		peerECSID := id.L0EnvironmentID(link.EnvironmentID).ECSEnvironmentID()
+		peerGroup, err := e.getEnvironmentSecurityGroup(peerECSID)
+		if err != nil {
+			return err
+		}
+
+		peerGroupID = *peerGroup.GroupId
 	}
 
-	if err := e.EC2.AuthorizeSecurityGroupIngressFromGroup(*sourceGroup.GroupId, *destGroup.GroupId); err != nil {
-		if !ContainsErrCode(err, "InvalidPermission.Duplicate") {
+	if link.Direction != "outbound" {
+		if err := e.authorizeLinkIngress(*group.GroupId, peerGroupID, link.CIDR, link.Rules); err != nil {
 			return err
 		}
 	}
 
-	if err := e.EC2.AuthorizeSecurityGroupIngressFromGroup(*destGroup.GroupId, *sourceGroup.GroupId); err != nil {
-		if !ContainsErrCode(err, "InvalidPermission.Duplicate") {
+	if link.Direction != "inbound" {
+		if err := e.authorizeLinkIngress(peerGroupID, *group.GroupId, "", link.Rules); err != nil {
 			return err
 		}
 	}
@@ -356,31 +369,84 @@ func (e *ECSEnvironmentManager) CreateEnvironmentLink(sourceEnvironmentID, destE
 	return nil
 }
 
-func (e *ECSEnvironmentManager) DeleteEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error {
-	sourceECSID := id.L0EnvironmentID(sourceEnvironmentID).ECSEnvironmentID()
-	destECSID := id.L0EnvironmentID(destEnvironmentID).ECSEnvironmentID()
+func (e *ECSEnvironmentManager) authorizeLinkIngress(groupID, sourceGroupID, cidr string, rules []models.EnvironmentLinkRule) error {
+	if cidr == "" && len(rules) == 0 {
+		if err := e.EC2.AuthorizeSecurityGroupIngressFromGroup(groupID, sourceGroupID); err != nil {
+			if !ContainsErrCode(err, "InvalidPermission.Duplicate") {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, permission := range linkPermissions(sourceGroupID, cidr, rules) {
+		if err := e.EC2.AuthorizeSecurityGroupIngressHelper(groupID, permission); err != nil {
+			if !ContainsErrCode(err, "InvalidPermission.Duplicate") {
+				return err
+			}
+		}
+	}
 
-	sourceGroup, err := e.EC2.DescribeSecurityGroup(sourceECSID.SecurityGroupName())
+	return nil
+}
+
+// authorizeExecIngress allows the api to reach the docker daemon on the environment's instances
+func (e *ECSEnvironmentManager) authorizeExecIngress(groupID string) error {
+	apiEnvironmentID := id.L0EnvironmentID(config.API_ENVIRONMENT_ID).ECSEnvironmentID()
+	apiGroup, err := e.EC2.DescribeSecurityGroup(apiEnvironmentID.SecurityGroupName())
+	if err != nil {
+		return err
+	}
+
+	if apiGroup == nil {
+		log.Warnf("Skipping exec ingress since security group '%s' does not exist", apiEnvironmentID.SecurityGroupName())
+		return nil
+	}
+
+	rule := models.EnvironmentLinkRule{
+		Protocol: "tcp",
+		FromPort: docker.DaemonPort,
+		ToPort:   docker.DaemonPort,
+	}
+
+	return e.authorizeLinkIngress(groupID, *apiGroup.GroupId, "", []models.EnvironmentLinkRule{rule})
+}
+
+func (e *ECSEnvironmentManager) DeleteEnvironmentLink(environmentID string, link models.EnvironmentLink) error {
+	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
+
+	group, err := e.EC2.DescribeSecurityGroup(ecsEnvironmentID.SecurityGroupName())
 	if err != nil {
 		return err
 	}
 
-	if sourceGroup == nil {
-		log.Warnf("Skipping environment unlink since security group '%s' does not exist", sourceECSID.SecurityGroupName())
+	if group == nil {
+		log.Warnf("Skipping environment unlink since security group '%s' does not exist", ecsEnvironmentID.SecurityGroupName())
 		return nil
 	}
 
-	destGroup, err := e.EC2.DescribeSecurityGroup(destECSID.SecurityGroupName())
+	if link.EnvironmentID == "" {
+		return e.revokeExternalLink(group, link)
+	}
+
+	peerECSID := id.L0EnvironmentID(link.EnvironmentID).ECSEnvironmentID()
+	peerGroup, err := e.EC2.DescribeSecurityGroup(peerECSID.SecurityGroupName())
 	if err != nil {
 		return err
 	}
 
-	if destGroup == nil {
-		log.Warnf("Skipping environment unlink since security group '%s' does not exist", destECSID.SecurityGroupName())
+	if peerGroup == nil {
+		log.Warnf("Skipping environment unlink since security group '%s' does not exist", peerECSID.SecurityGroupName())
 		return nil
 	}
 
+	// links without rules open all traffic, so remove every rule that references the other group
 	removeIngressRule := func(group *ec2.SecurityGroup, groupIDToRemove string) error {
+		if len(link.Rules) > 0 {
+			return e.revokeLinkIngress(*group.GroupId, groupIDToRemove, "", link.Rules)
+		}
+
 		for _, permission := range group.IpPermissions {
 			for _, pair := range permission.UserIdGroupPairs {
 				if *pair.GroupId == groupIDToRemove {
@@ -401,17 +467,85 @@ func (e *ECSEnvironmentManager) DeleteEnvironmentLink(sourceEnvironmentID, destE
 		return nil
 	}
 
-	if err := removeIngressRule(sourceGroup, *destGroup.GroupId); err != nil {
-		return err
+	if link.Direction != "outbound" {
+		if err := removeIngressRule(group, *peerGroup.GroupId); err != nil {
+			return err
+		}
 	}
 
-	if err := removeIngressRule(destGroup, *sourceGroup.GroupId); err != nil {
-		return err
+	if link.Direction != "inbound" {
+		if err := removeIngressRule(peerGroup, *group.GroupId); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+func (e *ECSEnvironmentManager) revokeExternalLink(group *ec2.SecurityGroup, link models.EnvironmentLink) error {
+	if link.Direction != "outbound" {
+		if err := e.revokeLinkIngress(*group.GroupId, link.SecurityGroupID, link.CIDR, link.Rules); err != nil {
+			return err
+		}
+	}
+
+	if link.Direction != "inbound" && link.SecurityGroupID != "" {
+		if err := e.revokeLinkIngress(link.SecurityGroupID, *group.GroupId, "", link.Rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *ECSEnvironmentManager) revokeLinkIngress(groupID, sourceGroupID, cidr string, rules []models.EnvironmentLinkRule) error {
+	for _, permission := range linkPermissions(sourceGroupID, cidr, rules) {
+		if err := e.EC2.RevokeSecurityGroupIngressHelper(groupID, permission); err != nil {
+			if !ContainsErrCode(err, "InvalidPermission.NotFound") && !ContainsErrCode(err, "InvalidGroup.NotFound") {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// linkPermissions converts link rules into ingress permissions from either a security group or a cidr;
+// an empty set of rules allows all traffic
+func linkPermissions(sourceGroupID, cidr string, rules []models.EnvironmentLinkRule) []ec2.IpPermission {
+	if len(rules) == 0 {
+		rules = []models.EnvironmentLinkRule{{Protocol: "all"}}
+	}
+
+	permissions := make([]ec2.IpPermission, len(rules))
+	for i, rule := range rules {
+		permission := &awsec2.IpPermission{
+			IpProtocol: aws.String(rule.Protocol),
+		}
+
+		if rule.Protocol == "all" {
+			permission.IpProtocol = aws.String("-1")
+		} else {
+			permission.FromPort = aws.Int64(int64(rule.FromPort))
+			permission.ToPort = aws.Int64(int64(rule.ToPort))
+		}
+
+		if cidr != "" {
+			permission.IpRanges = []*awsec2.IpRange{
+				{CidrIp: aws.String(cidr)},
+			}
+		} else {
+			permission.UserIdGroupPairs = []*awsec2.UserIdGroupPair{
+				{GroupId: aws.String(sourceGroupID)},
+			}
+		}
+
+		permissions[i] = ec2.IpPermission{permission}
+	}
+
+	return permissions
+}
+
 func (e *ECSEnvironmentManager) getEnvironmentSecurityGroup(environmentID id.ECSEnvironmentID) (*ec2.SecurityGroup, error) {
 	group, err := e.EC2.DescribeSecurityGroup(environmentID.SecurityGroupName())
 	if err != nil {
@@ -480,9 +614,11 @@ func renderUserData(ecsEnvironmentID id.ECSEnvironmentID, userData []byte) (stri
 	}
 
 	context := struct {
+		DockerPort       int
 		ECSEnvironmentID string
 		S3Bucket         string
 	}{
+		DockerPort:       docker.DaemonPort,
 		ECSEnvironmentID: ecsEnvironmentID.String(),
 		S3Bucket:         config.AWSS3Bucket(),
 	}
@@ -561,10 +697,17 @@ var defaultLinuxUserDataTemplate = []byte(
 	`#!/bin/bash
     echo ECS_CLUSTER={{ .ECSEnvironmentID }} >> /etc/ecs/ecs.config
     echo ECS_ENGINE_AUTH_TYPE=dockercfg >> /etc/ecs/ecs.config
+    echo ECS_ENABLE_TASK_IAM_ROLE=true >> /etc/ecs/ecs.config
+    echo ECS_ENABLE_CONTAINER_METADATA=true >> /etc/ecs/ecs.config
     yum install -y aws-cli awslogs jq
     aws s3 cp s3://{{ .S3Bucket }}/bootstrap/dockercfg dockercfg
     cfg=$(cat dockercfg)
     echo ECS_ENGINE_AUTH_DATA=$cfg >> /etc/ecs/ecs.config
+    mkdir -p /etc/docker/tls
+    aws s3 cp --recursive s3://{{ .S3Bucket }}/bootstrap/docker /etc/docker/tls
+    chmod 600 /etc/docker/tls/server-key.pem
+    sed -i 's|^OPTIONS="|OPTIONS="-H unix:///var/run/docker.sock -H tcp://0.0.0.0:{{ .DockerPort }} --tlsverify --tlscacert=/etc/docker/tls/ca.pem --tlscert=/etc/docker/tls/server-cert.pem --tlskey=/etc/docker/tls/server-key.pem |' /etc/sysconfig/docker
+    service docker restart
     docker pull amazon/amazon-ecs-agent:latest
     start ecs
 `)
@@ -18,6 +18,7 @@ import (
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/aws/ecs/mock_ecs"
 	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/docker"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 	"github.com/stretchr/testify/assert"
@@ -374,6 +375,24 @@ func TestCreateEnvironment(t *testing.T) {
 					AuthorizeSecurityGroupIngressFromGroup(securityGroupID, securityGroupID).
 					Return(nil)
 
+				apiSecurityGroupName := id.L0EnvironmentID(config.API_ENVIRONMENT_ID).ECSEnvironmentID().SecurityGroupName()
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(apiSecurityGroupName).
+					Return(ec2.NewSecurityGroup("api_sg_id"), nil)
+
+				checkExecIngress := func(groupID string, permission ec2.IpPermission) error {
+					reporter.AssertEqual(groupID, securityGroupID)
+					reporter.AssertEqual(aws.StringValue(permission.IpProtocol), "tcp")
+					reporter.AssertEqual(aws.Int64Value(permission.FromPort), int64(docker.DaemonPort))
+					reporter.AssertEqual(aws.Int64Value(permission.ToPort), int64(docker.DaemonPort))
+					reporter.AssertEqual(aws.StringValue(permission.UserIdGroupPairs[0].GroupId), "api_sg_id")
+					return nil
+				}
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngressHelper(securityGroupID, gomock.Any()).
+					Do(checkExecIngress)
+
 				var checkLaunchConfig = func(name, amiID, iamInstanceProfile, instanceType, keyName, userData *string, securityGroups []*string, volSizes map[string]int) error {
 					reporter.AssertEqualf(launchConfigurationName, *name, "LaunchConfigurationName")
 					reporter.AssertEqualf("amiid", *amiID, "AMI ID")
@@ -445,6 +464,14 @@ func TestCreateEnvironment(t *testing.T) {
 					AuthorizeSecurityGroupIngressFromGroup(gomock.Any(), gomock.Any()).
 					Return(nil)
 
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(id.L0EnvironmentID(config.API_ENVIRONMENT_ID).ECSEnvironmentID().SecurityGroupName()).
+					Return(ec2.NewSecurityGroup("api_sg_id"), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngressHelper(securityGroupID, gomock.Any()).
+					Return(nil)
+
 				userData := base64.StdEncoding.EncodeToString([]byte("user data"))
 				mockEnvironment.AutoScaling.EXPECT().
 					CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), &userData, gomock.Any(), gomock.Any()).
@@ -498,6 +525,14 @@ func TestCreateEnvironment(t *testing.T) {
 					AuthorizeSecurityGroupIngressFromGroup(gomock.Any(), gomock.Any()).
 					Return(nil)
 
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(id.L0EnvironmentID(config.API_ENVIRONMENT_ID).ECSEnvironmentID().SecurityGroupName()).
+					Return(ec2.NewSecurityGroup("api_sg_id"), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngressHelper(securityGroupID, gomock.Any()).
+					Return(nil)
+
 				mockEnvironment.AutoScaling.EXPECT().
 					CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
@@ -555,6 +590,11 @@ func TestCreateEnvironment(t *testing.T) {
 						Return(g.Error()).
 						AnyTimes()
 
+					mockEnvironment.EC2.EXPECT().
+						AuthorizeSecurityGroupIngressHelper(gomock.Any(), gomock.Any()).
+						Return(g.Error()).
+						AnyTimes()
+
 					mockEnvironment.AutoScaling.EXPECT().
 						CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 						Return(g.Error()).
@@ -582,7 +622,7 @@ func TestCreateEnvironment(t *testing.T) {
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				setup := target.(func(testutils.ErrorGenerator) interface{})
 
-				for i := 0; i < 5; i++ {
+				for i := 0; i < 7; i++ {
 					var g testutils.ErrorGenerator
 					g.Set(i+1, fmt.Errorf("some error"))
 
@@ -727,7 +767,89 @@ func TestCreateEnvironmentLink(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				manager.CreateEnvironmentLink("eid1", "eid2")
+				manager.CreateEnvironmentLink("eid1", models.EnvironmentLink{EnvironmentID: "eid2", Direction: "both"})
+			},
+		},
+		{
+			Name: "Should only authorize rules on the destination group for outbound links",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+
+				sourceEnvironmentID := id.L0EnvironmentID("eid1").ECSEnvironmentID()
+				destEnvironmentID := id.L0EnvironmentID("eid2").ECSEnvironmentID()
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(sourceEnvironmentID.SecurityGroupName()).
+					Return(ec2.NewSecurityGroup("eid1_sg"), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(destEnvironmentID.SecurityGroupName()).
+					Return(ec2.NewSecurityGroup("eid2_sg"), nil)
+
+				validatePermission := func(groupID string, permission ec2.IpPermission) {
+					reporter.AssertEqual(*permission.IpProtocol, "tcp")
+					reporter.AssertEqual(*permission.FromPort, int64(5432))
+					reporter.AssertEqual(*permission.ToPort, int64(5432))
+					reporter.AssertEqual(*permission.UserIdGroupPairs[0].GroupId, "eid1_sg")
+				}
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngressHelper("eid2_sg", gomock.Any()).
+					Do(validatePermission).
+					Return(nil)
+
+				return mockEnvironment.Environment()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSEnvironmentManager)
+
+				link := models.EnvironmentLink{
+					EnvironmentID: "eid2",
+					Direction:     "outbound",
+					Rules: []models.EnvironmentLinkRule{
+						{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+					},
+				}
+
+				if err := manager.CreateEnvironmentLink("eid1", link); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+		{
+			Name: "Should authorize all traffic from a cidr",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+
+				sourceEnvironmentID := id.L0EnvironmentID("eid1").ECSEnvironmentID()
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(sourceEnvironmentID.SecurityGroupName()).
+					Return(ec2.NewSecurityGroup("eid1_sg"), nil)
+
+				validatePermission := func(groupID string, permission ec2.IpPermission) {
+					reporter.AssertEqual(*permission.IpProtocol, "-1")
+					reporter.AssertEqual(*permission.IpRanges[0].CidrIp, "10.0.0.0/16")
+				}
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngressHelper("eid1_sg", gomock.Any()).
+					Do(validatePermission).
+					Return(nil)
+
+				return mockEnvironment.Environment()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSEnvironmentManager)
+
+				link := models.EnvironmentLink{
+					CIDR:      "10.0.0.0/16",
+					Direction: "inbound",
+				}
+
+				if err := manager.CreateEnvironmentLink("eid1", link); err != nil {
+					reporter.Fatal(err)
+				}
 			},
 		},
 		{
@@ -749,7 +871,7 @@ func TestCreateEnvironmentLink(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				if err := manager.CreateEnvironmentLink("eid1", "eid2"); err != nil {
+				if err := manager.CreateEnvironmentLink("eid1", models.EnvironmentLink{EnvironmentID: "eid2", Direction: "both"}); err != nil {
 					reporter.Fatal(err)
 				}
 			},
@@ -813,7 +935,52 @@ func TestDeleteEnvironmentLink(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				manager.DeleteEnvironmentLink("eid1", "eid2")
+				manager.DeleteEnvironmentLink("eid1", models.EnvironmentLink{EnvironmentID: "eid2", Direction: "both"})
+			},
+		},
+		{
+			Name: "Should only revoke the link rules",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+
+				sourceEnvironmentID := id.L0EnvironmentID("eid1").ECSEnvironmentID()
+				destEnvironmentID := id.L0EnvironmentID("eid2").ECSEnvironmentID()
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(sourceEnvironmentID.SecurityGroupName()).
+					Return(ec2.NewSecurityGroup("eid1_sg"), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(destEnvironmentID.SecurityGroupName()).
+					Return(ec2.NewSecurityGroup("eid2_sg"), nil)
+
+				validatePermission := func(groupID string, permission ec2.IpPermission) {
+					reporter.AssertEqual(*permission.IpProtocol, "udp")
+					reporter.AssertEqual(*permission.FromPort, int64(53))
+					reporter.AssertEqual(*permission.UserIdGroupPairs[0].GroupId, "eid2_sg")
+				}
+
+				mockEnvironment.EC2.EXPECT().
+					RevokeSecurityGroupIngressHelper("eid1_sg", gomock.Any()).
+					Do(validatePermission).
+					Return(nil)
+
+				return mockEnvironment.Environment()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSEnvironmentManager)
+
+				link := models.EnvironmentLink{
+					EnvironmentID: "eid2",
+					Direction:     "inbound",
+					Rules: []models.EnvironmentLinkRule{
+						{Protocol: "udp", FromPort: 53, ToPort: 53},
+					},
+				}
+
+				if err := manager.DeleteEnvironmentLink("eid1", link); err != nil {
+					reporter.Fatal(err)
+				}
 			},
 		},
 		{
@@ -831,7 +998,7 @@ func TestDeleteEnvironmentLink(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				manager.DeleteEnvironmentLink("eid1", "eid2")
+				manager.DeleteEnvironmentLink("eid1", models.EnvironmentLink{EnvironmentID: "eid2", Direction: "both"})
 			},
 		},
 		{
@@ -855,7 +1022,7 @@ func TestDeleteEnvironmentLink(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				manager.DeleteEnvironmentLink("eid1", "eid2")
+				manager.DeleteEnvironmentLink("eid1", models.EnvironmentLink{EnvironmentID: "eid2", Direction: "both"})
 			},
 		},
 	}
@@ -146,6 +146,10 @@ func (id ECSEnvironmentID) AutoScalingGroupName() string {
 	return id.String()
 }
 
+func (id ECSEnvironmentID) SecretsRoleName() string {
+	return fmt.Sprintf("%s-secrets", id.String())
+}
+
 func ClusterARNToECSEnvironmentID(arn string) ECSEnvironmentID {
 	clusterName := strings.SplitN(arn, "/", 2)[1]
 	return ECSEnvironmentID(clusterName)
@@ -3,16 +3,20 @@ package ecsbackend
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/aws/aws-sdk-go/aws"
 	awselb "github.com/aws/aws-sdk-go/service/elb"
+	awselbv2 "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/aws/ec2"
 	"github.com/quintilesims/layer0/common/aws/elb"
+	"github.com/quintilesims/layer0/common/aws/elbv2"
 	"github.com/quintilesims/layer0/common/aws/iam"
 	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/errors"
@@ -23,15 +27,17 @@ import (
 type ECSLoadBalancerManager struct {
 	EC2     ec2.Provider
 	ELB     elb.Provider
+	ELBV2   elbv2.Provider
 	IAM     iam.Provider
 	Backend backend.Backend
 	Clock   waitutils.Clock
 }
 
-func NewECSLoadBalancerManager(ec2 ec2.Provider, elb elb.Provider, iam iam.Provider, backend backend.Backend) *ECSLoadBalancerManager {
+func NewECSLoadBalancerManager(ec2 ec2.Provider, elb elb.Provider, elbv2 elbv2.Provider, iam iam.Provider, backend backend.Backend) *ECSLoadBalancerManager {
 	return &ECSLoadBalancerManager{
 		EC2:     ec2,
 		ELB:     elb,
+		ELBV2:   elbv2,
 		IAM:     iam,
 		Backend: backend,
 		Clock:   waitutils.RealClock{},
@@ -49,7 +55,25 @@ func (e *ECSLoadBalancerManager) ListLoadBalancers() ([]*models.LoadBalancer, er
 		if name := *description.LoadBalancerName; strings.HasPrefix(name, id.PREFIX) {
 			ecsLoadBalancerID := id.ECSLoadBalancerID(name)
 			loadBalancer := &models.LoadBalancer{
-				LoadBalancerID: ecsLoadBalancerID.L0LoadBalancerID(),
+				LoadBalancerID:   ecsLoadBalancerID.L0LoadBalancerID(),
+				LoadBalancerType: "elb",
+			}
+
+			loadBalancers = append(loadBalancers, loadBalancer)
+		}
+	}
+
+	applicationLoadBalancers, err := e.ELBV2.DescribeLoadBalancers()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, applicationLoadBalancer := range applicationLoadBalancers {
+		if name := aws.StringValue(applicationLoadBalancer.LoadBalancerName); strings.HasPrefix(name, id.PREFIX) {
+			ecsLoadBalancerID := id.ECSLoadBalancerID(name)
+			loadBalancer := &models.LoadBalancer{
+				LoadBalancerID:   ecsLoadBalancerID.L0LoadBalancerID(),
+				LoadBalancerType: "alb",
 			}
 
 			loadBalancers = append(loadBalancers, loadBalancer)
@@ -65,8 +89,7 @@ func (e *ECSLoadBalancerManager) GetLoadBalancer(loadBalancerID string) (*models
 	loadBalancer, err := e.ELB.DescribeLoadBalancer(ecsLoadBalancerID.String())
 	if err != nil {
 		if ContainsErrCode(err, "LoadBalancerNotFound") {
-			err := fmt.Errorf("LoadBalancer with id '%s' does not exist", loadBalancerID)
-			return nil, errors.New(errors.LoadBalancerDoesNotExist, err)
+			return e.getApplicationLoadBalancer(ecsLoadBalancerID)
 		}
 
 		return nil, err
@@ -113,7 +136,16 @@ func (e *ECSLoadBalancerManager) DeleteLoadBalancer(loadBalancerID string) error
 		return err
 	}
 
-	if err := e.ELB.DeleteLoadBalancer(ecsLoadBalancerID.String()); err != nil {
+	applicationLoadBalancer, err := e.describeApplicationLoadBalancer(ecsLoadBalancerID)
+	if err != nil {
+		return err
+	}
+
+	if applicationLoadBalancer != nil {
+		if err := e.deleteApplicationLoadBalancer(ecsLoadBalancerID, applicationLoadBalancer); err != nil {
+			return err
+		}
+	} else if err := e.ELB.DeleteLoadBalancer(ecsLoadBalancerID.String()); err != nil {
 		if !ContainsErrCode(err, "NoSuchEntity") {
 			return err
 		}
@@ -210,13 +242,31 @@ func (e *ECSLoadBalancerManager) populateModel(description *elb.LoadBalancerDesc
 	}
 
 	model := &models.LoadBalancer{
-		LoadBalancerID: ecsLoadBalancerID.L0LoadBalancerID(),
-		Ports:          ports,
-		IsPublic:       stringOrEmpty(description.Scheme) == "internet-facing",
-		URL:            stringOrEmpty(description.DNSName),
-		HealthCheck:    healthCheck,
-		IdleTimeout:    int(aws.Int64Value(lbAttributes.ConnectionSettings.IdleTimeout)),
-		CrossZone:      bool(aws.BoolValue(lbAttributes.CrossZoneLoadBalancing.Enabled)),
+		LoadBalancerID:   ecsLoadBalancerID.L0LoadBalancerID(),
+		LoadBalancerType: "elb",
+		Ports:            ports,
+		IsPublic:         stringOrEmpty(description.Scheme) == "internet-facing",
+		URL:              stringOrEmpty(description.DNSName),
+		HealthCheck:      healthCheck,
+		IdleTimeout:      int(aws.Int64Value(lbAttributes.ConnectionSettings.IdleTimeout)),
+		CrossZone:        bool(aws.BoolValue(lbAttributes.CrossZoneLoadBalancing.Enabled)),
+		Rules:            []models.LoadBalancerRule{},
+	}
+
+	if accessLog := lbAttributes.AccessLog; accessLog != nil {
+		model.AccessLogs = models.AccessLogs{
+			Enabled:        aws.BoolValue(accessLog.Enabled),
+			S3BucketName:   aws.StringValue(accessLog.S3BucketName),
+			S3BucketPrefix: aws.StringValue(accessLog.S3BucketPrefix),
+			EmitInterval:   int(aws.Int64Value(accessLog.EmitInterval)),
+		}
+	}
+
+	if connectionDraining := lbAttributes.ConnectionDraining; connectionDraining != nil {
+		model.ConnectionDraining = models.ConnectionDraining{
+			Enabled: aws.BoolValue(connectionDraining.Enabled),
+			Timeout: int(aws.Int64Value(connectionDraining.Timeout)),
+		}
 	}
 
 	return model
@@ -253,7 +303,8 @@ func (e *ECSLoadBalancerManager) getSecurityGroupIDByName(securityGroupName stri
 
 func (e *ECSLoadBalancerManager) CreateLoadBalancer(
 	loadBalancerName,
-	environmentID string,
+	environmentID,
+	loadBalancerType string,
 	isPublic bool,
 	ports []models.Port,
 	healthCheck models.HealthCheck,
@@ -265,34 +316,40 @@ func (e *ECSLoadBalancerManager) CreateLoadBalancer(
 	ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
 	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
 
+	if loadBalancerType == "alb" {
+		return e.createApplicationLoadBalancer(loadBalancerName, ecsLoadBalancerID, ecsEnvironmentID, isPublic, ports, healthCheck, idleTimeout)
+	}
+
 	if err := e.createLoadBalancer(ecsLoadBalancerID, ecsEnvironmentID, isPublic, ports); err != nil {
 		return nil, err
 	}
 
 	// Once the loadbalancer has been created, we can update its healthcheck
-	if err := e.updateHealthCheck(ecsLoadBalancerID, healthCheck); err != nil {
+	if err := e.configureHealthCheck(ecsLoadBalancerID, healthCheck); err != nil {
 		return nil, err
 	}
 
 	// Then set the load balancer's idle timeout
-	if err := e.setIdleTimeout(ecsLoadBalancerID, idleTimeout); err != nil {
+	if err := e.ELB.SetIdleTimeout(ecsLoadBalancerID.String(), idleTimeout); err != nil {
 		return nil, err
 	}
 
 	// Then set the load balancer's cross-zone load balancing
-	if err := e.setCrossZone(ecsLoadBalancerID, crossZone); err != nil {
+	if err := e.ELB.SetCrossZone(ecsLoadBalancerID.String(), crossZone); err != nil {
 		return nil, err
 	}
 
 	model := &models.LoadBalancer{
 		LoadBalancerID:   ecsLoadBalancerID.L0LoadBalancerID(),
 		LoadBalancerName: loadBalancerName,
+		LoadBalancerType: "elb",
 		EnvironmentID:    ecsEnvironmentID.L0EnvironmentID(),
 		IsPublic:         isPublic,
 		Ports:            ports,
 		HealthCheck:      healthCheck,
 		IdleTimeout:      idleTimeout,
 		CrossZone:        crossZone,
+		Rules:            []models.LoadBalancerRule{},
 	}
 
 	return model, nil
@@ -330,24 +387,11 @@ func (e *ECSLoadBalancerManager) createLoadBalancer(
 		return err
 	}
 
-	// only public load balancers get an additional security group
-	securityGroupIDs := []*string{}
-	if isPublic {
-		securityGroup, err := e.upsertSecurityGroup(ecsLoadBalancerID, ports)
-		if err != nil {
-			return err
-		}
-
-		securityGroupIDs = append(securityGroupIDs, securityGroup.GroupId)
-	}
-
-	environmentSecurityGroupID, err := e.getSecurityGroupIDByName(ecsEnvironmentID.SecurityGroupName())
+	securityGroupIDs, err := e.getSecurityGroupIDs(ecsLoadBalancerID, ecsEnvironmentID, isPublic, ports)
 	if err != nil {
-		return fmt.Errorf("Failed to find environment Security Group: %v", err)
+		return err
 	}
 
-	securityGroupIDs = append(securityGroupIDs, &environmentSecurityGroupID)
-
 	scheme := "internal"
 	if isPublic {
 		scheme = "internet-facing"
@@ -365,6 +409,32 @@ func (e *ECSLoadBalancerManager) createLoadBalancer(
 	return nil
 }
 
+func (e *ECSLoadBalancerManager) getSecurityGroupIDs(
+	ecsLoadBalancerID id.ECSLoadBalancerID,
+	ecsEnvironmentID id.ECSEnvironmentID,
+	isPublic bool,
+	ports []models.Port,
+) ([]*string, error) {
+	// only public load balancers get an additional security group
+	securityGroupIDs := []*string{}
+	if isPublic {
+		securityGroup, err := e.upsertSecurityGroup(ecsLoadBalancerID, ports)
+		if err != nil {
+			return nil, err
+		}
+
+		securityGroupIDs = append(securityGroupIDs, securityGroup.GroupId)
+	}
+
+	environmentSecurityGroupID, err := e.getSecurityGroupIDByName(ecsEnvironmentID.SecurityGroupName())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find environment Security Group: %v", err)
+	}
+
+	securityGroupIDs = append(securityGroupIDs, &environmentSecurityGroupID)
+	return securityGroupIDs, nil
+}
+
 func (e *ECSLoadBalancerManager) UpdateLoadBalancerHealthCheck(loadBalancerID string, healthCheck models.HealthCheck) (*models.LoadBalancer, error) {
 	ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
 	if err := e.updateHealthCheck(ecsLoadBalancerID, healthCheck); err != nil {
@@ -392,7 +462,122 @@ func (e *ECSLoadBalancerManager) UpdateLoadBalancerCrossZone(loadBalancerID stri
 	return e.GetLoadBalancer(loadBalancerID)
 }
 
+func (e *ECSLoadBalancerManager) UpdateLoadBalancerAccessLogs(loadBalancerID string, accessLogs models.AccessLogs) (*models.LoadBalancer, error) {
+	ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
+	if err := e.setAccessLogs(ecsLoadBalancerID, accessLogs); err != nil {
+		return nil, err
+	}
+
+	return e.GetLoadBalancer(loadBalancerID)
+}
+
+func (e *ECSLoadBalancerManager) UpdateLoadBalancerConnectionDraining(loadBalancerID string, connectionDraining models.ConnectionDraining) (*models.LoadBalancer, error) {
+	ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
+	if err := e.setConnectionDraining(ecsLoadBalancerID, connectionDraining); err != nil {
+		return nil, err
+	}
+
+	return e.GetLoadBalancer(loadBalancerID)
+}
+
+func (e *ECSLoadBalancerManager) GetLoadBalancerHealth(loadBalancerID string) (*models.LoadBalancerHealth, error) {
+	ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
+
+	states, err := e.ELB.DescribeInstanceHealth(ecsLoadBalancerID.String())
+	if err != nil {
+		if ContainsErrCode(err, "LoadBalancerNotFound") {
+			return e.getApplicationLoadBalancerHealth(ecsLoadBalancerID)
+		}
+
+		return nil, err
+	}
+
+	instances := []models.InstanceHealth{}
+	for _, state := range states {
+		instances = append(instances, models.InstanceHealth{
+			InstanceID:  aws.StringValue(state.InstanceId),
+			State:       aws.StringValue(state.State),
+			ReasonCode:  aws.StringValue(state.ReasonCode),
+			Description: aws.StringValue(state.Description),
+		})
+	}
+
+	model := &models.LoadBalancerHealth{
+		LoadBalancerID: loadBalancerID,
+		Instances:      instances,
+	}
+
+	return model, nil
+}
+
+func (e *ECSLoadBalancerManager) getApplicationLoadBalancerHealth(ecsLoadBalancerID id.ECSLoadBalancerID) (*models.LoadBalancerHealth, error) {
+	loadBalancer, err := e.describeApplicationLoadBalancer(ecsLoadBalancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if loadBalancer == nil {
+		err := fmt.Errorf("LoadBalancer with id '%s' does not exist", ecsLoadBalancerID.L0LoadBalancerID())
+		return nil, errors.New(errors.LoadBalancerDoesNotExist, err)
+	}
+
+	targetGroups, err := e.ELBV2.DescribeLoadBalancerTargetGroups(aws.StringValue(loadBalancer.LoadBalancerArn))
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []models.InstanceHealth{}
+	for _, targetGroup := range targetGroups {
+		descriptions, err := e.ELBV2.DescribeTargetHealth(aws.StringValue(targetGroup.TargetGroupArn))
+		if err != nil {
+			return nil, err
+		}
+
+		// each service behind an application load balancer registers its own target group
+		var serviceID string
+		if targetGroupName := aws.StringValue(targetGroup.TargetGroupName); targetGroupName != ecsLoadBalancerID.String() {
+			serviceID = id.ECSServiceID(targetGroupName).L0ServiceID()
+		}
+
+		for _, description := range descriptions {
+			instance := models.InstanceHealth{
+				InstanceID: aws.StringValue(description.Target.Id),
+				ServiceID:  serviceID,
+				State:      "Unknown",
+			}
+
+			if health := description.TargetHealth; health != nil {
+				instance.State = targetHealthToInstanceState(aws.StringValue(health.State))
+				instance.ReasonCode = aws.StringValue(health.Reason)
+				instance.Description = aws.StringValue(health.Description)
+			}
+
+			instances = append(instances, instance)
+		}
+	}
+
+	model := &models.LoadBalancerHealth{
+		LoadBalancerID: ecsLoadBalancerID.L0LoadBalancerID(),
+		Instances:      instances,
+	}
+
+	return model, nil
+}
+
 func (e *ECSLoadBalancerManager) updateHealthCheck(ecsLoadBalancerID id.ECSLoadBalancerID, healthCheck models.HealthCheck) error {
+	applicationLoadBalancer, err := e.describeApplicationLoadBalancer(ecsLoadBalancerID)
+	if err != nil {
+		return err
+	}
+
+	if applicationLoadBalancer != nil {
+		return e.updateTargetGroupHealthChecks(applicationLoadBalancer, healthCheck)
+	}
+
+	return e.configureHealthCheck(ecsLoadBalancerID, healthCheck)
+}
+
+func (e *ECSLoadBalancerManager) configureHealthCheck(ecsLoadBalancerID id.ECSLoadBalancerID, healthCheck models.HealthCheck) error {
 	elbHealthCheck := elb.NewHealthCheck(
 		healthCheck.Target,
 		int64(healthCheck.Interval),
@@ -405,13 +590,88 @@ func (e *ECSLoadBalancerManager) updateHealthCheck(ecsLoadBalancerID id.ECSLoadB
 }
 
 func (e *ECSLoadBalancerManager) setIdleTimeout(ecsLoadBalancerID id.ECSLoadBalancerID, idleTimeout int) error {
+	applicationLoadBalancer, err := e.describeApplicationLoadBalancer(ecsLoadBalancerID)
+	if err != nil {
+		return err
+	}
+
+	if applicationLoadBalancer != nil {
+		return e.ELBV2.SetIdleTimeout(aws.StringValue(applicationLoadBalancer.LoadBalancerArn), idleTimeout)
+	}
+
 	return e.ELB.SetIdleTimeout(ecsLoadBalancerID.String(), idleTimeout)
 }
 
 func (e *ECSLoadBalancerManager) setCrossZone(ecsLoadBalancerID id.ECSLoadBalancerID, crossZone bool) error {
+	applicationLoadBalancer, err := e.describeApplicationLoadBalancer(ecsLoadBalancerID)
+	if err != nil {
+		return err
+	}
+
+	// application load balancers always route traffic across availability zones
+	if applicationLoadBalancer != nil {
+		if !crossZone {
+			return errors.Newf(errors.InvalidLoadBalancerType, "Cross-zone load balancing cannot be disabled on application load balancers")
+		}
+
+		return nil
+	}
+
 	return e.ELB.SetCrossZone(ecsLoadBalancerID.String(), crossZone)
 }
 
+func (e *ECSLoadBalancerManager) setAccessLogs(ecsLoadBalancerID id.ECSLoadBalancerID, accessLogs models.AccessLogs) error {
+	applicationLoadBalancer, err := e.describeApplicationLoadBalancer(ecsLoadBalancerID)
+	if err != nil {
+		return err
+	}
+
+	if applicationLoadBalancer != nil {
+		return e.ELBV2.SetAccessLogs(
+			aws.StringValue(applicationLoadBalancer.LoadBalancerArn),
+			accessLogs.Enabled,
+			accessLogs.S3BucketName,
+			accessLogs.S3BucketPrefix)
+	}
+
+	return e.ELB.SetAccessLogs(
+		ecsLoadBalancerID.String(),
+		accessLogs.Enabled,
+		accessLogs.S3BucketName,
+		accessLogs.S3BucketPrefix,
+		accessLogs.EmitInterval)
+}
+
+func (e *ECSLoadBalancerManager) setConnectionDraining(ecsLoadBalancerID id.ECSLoadBalancerID, connectionDraining models.ConnectionDraining) error {
+	applicationLoadBalancer, err := e.describeApplicationLoadBalancer(ecsLoadBalancerID)
+	if err != nil {
+		return err
+	}
+
+	if applicationLoadBalancer == nil {
+		return e.ELB.SetConnectionDraining(ecsLoadBalancerID.String(), connectionDraining.Enabled, connectionDraining.Timeout)
+	}
+
+	// application load balancers drain each target group through its deregistration delay
+	timeout := 0
+	if connectionDraining.Enabled {
+		timeout = connectionDraining.Timeout
+	}
+
+	targetGroups, err := e.ELBV2.DescribeLoadBalancerTargetGroups(aws.StringValue(applicationLoadBalancer.LoadBalancerArn))
+	if err != nil {
+		return err
+	}
+
+	for _, targetGroup := range targetGroups {
+		if err := e.ELBV2.SetDeregistrationDelay(aws.StringValue(targetGroup.TargetGroupArn), timeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (e *ECSLoadBalancerManager) UpdateLoadBalancerPorts(loadBalancerID string, ports []models.Port) (*models.LoadBalancer, error) {
 	model, err := e.GetLoadBalancer(loadBalancerID)
 	if err != nil {
@@ -419,6 +679,16 @@ func (e *ECSLoadBalancerManager) UpdateLoadBalancerPorts(loadBalancerID string,
 	}
 
 	ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
+	if model.LoadBalancerType == "alb" {
+		updatedPorts, err := e.updateApplicationListeners(ecsLoadBalancerID, model.IsPublic, model.Ports, ports)
+		if err != nil {
+			return nil, err
+		}
+
+		model.Ports = updatedPorts
+		return model, nil
+	}
+
 	updatedPorts, err := e.updatePorts(ecsLoadBalancerID, model.IsPublic, model.Ports, ports)
 	if err != nil {
 		return nil, err
@@ -711,8 +981,17 @@ func (e *ECSLoadBalancerManager) generateRolePolicy(ecsLoadBalancerID id.ECSLoad
             "Resource": [
                 "arn:aws:elasticloadbalancing:%s:%s:loadbalancer/%s"
             ]
+        },
+        {
+            "Effect": "Allow",
+            "Action": [
+                "elasticloadbalancing:DeregisterTargets",
+                "elasticloadbalancing:RegisterTargets"
+            ],
+            "Resource": [
+                "arn:aws:elasticloadbalancing:%s:%s:targetgroup/%s*"
+            ]
         }
-
     ]
 }`
 	awsAccountID, err := e.IAM.GetAccountId()
@@ -720,7 +999,512 @@ func (e *ECSLoadBalancerManager) generateRolePolicy(ecsLoadBalancerID id.ECSLoad
 		return "", err
 	}
 
-	out := fmt.Sprintf(policy, config.AWSRegion(), awsAccountID, ecsLoadBalancerID.String())
+	region := config.AWSRegion()
+	out := fmt.Sprintf(policy, region, awsAccountID, ecsLoadBalancerID.String(), region, awsAccountID, id.PREFIX)
 	out = strings.Replace(out, "\n", "", -1) // AWS API requires no newlines
 	return out, nil
 }
+
+// application load balancers use a default target group with the same name as the load balancer;
+// each service placed behind the load balancer gets its own target group and listener rules
+func (e *ECSLoadBalancerManager) createApplicationLoadBalancer(
+	loadBalancerName string,
+	ecsLoadBalancerID id.ECSLoadBalancerID,
+	ecsEnvironmentID id.ECSEnvironmentID,
+	isPublic bool,
+	ports []models.Port,
+	healthCheck models.HealthCheck,
+	idleTimeout int,
+) (*models.LoadBalancer, error) {
+	for _, port := range ports {
+		if err := validateApplicationPort(port); err != nil {
+			return nil, err
+		}
+	}
+
+	roleName := ecsLoadBalancerID.RoleName()
+	if _, err := e.IAM.CreateRole(roleName, "ecs.amazonaws.com"); err != nil {
+		if !ContainsErrCode(err, "EntityAlreadyExists") {
+			return nil, err
+		}
+	}
+
+	policy, err := e.generateRolePolicy(ecsLoadBalancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.IAM.PutRolePolicy(roleName, policy); err != nil {
+		return nil, err
+	}
+
+	securityGroupIDs, err := e.getSecurityGroupIDs(ecsLoadBalancerID, ecsEnvironmentID, isPublic, ports)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := "internal"
+	if isPublic {
+		scheme = "internet-facing"
+	}
+
+	subnets, _, err := e.getSubnetsAndAvailZones(isPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	loadBalancer, err := e.ELBV2.CreateLoadBalancer(ecsLoadBalancerID.String(), scheme, securityGroupIDs, subnets)
+	if err != nil {
+		return nil, err
+	}
+
+	targetGroup, err := e.ELBV2.CreateTargetGroup(
+		ecsLoadBalancerID.String(),
+		"HTTP",
+		defaultTargetGroupPort(ports),
+		config.AWSVPCID(),
+		toTargetGroupHealthCheck(healthCheck))
+	if err != nil {
+		return nil, err
+	}
+
+	loadBalancerARN := aws.StringValue(loadBalancer.LoadBalancerArn)
+	targetGroupARN := aws.StringValue(targetGroup.TargetGroupArn)
+	for _, port := range ports {
+		listener, err := e.portToApplicationListener(port, targetGroupARN)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := e.ELBV2.CreateListener(loadBalancerARN, listener); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := e.ELBV2.SetIdleTimeout(loadBalancerARN, idleTimeout); err != nil {
+		return nil, err
+	}
+
+	model := &models.LoadBalancer{
+		LoadBalancerID:   ecsLoadBalancerID.L0LoadBalancerID(),
+		LoadBalancerName: loadBalancerName,
+		LoadBalancerType: "alb",
+		EnvironmentID:    ecsEnvironmentID.L0EnvironmentID(),
+		IsPublic:         isPublic,
+		Ports:            ports,
+		HealthCheck:      healthCheck,
+		IdleTimeout:      idleTimeout,
+		CrossZone:        true,
+		Rules:            []models.LoadBalancerRule{},
+		URL:              aws.StringValue(loadBalancer.DNSName),
+	}
+
+	return model, nil
+}
+
+// returns nil if the application load balancer does not exist
+func (e *ECSLoadBalancerManager) describeApplicationLoadBalancer(ecsLoadBalancerID id.ECSLoadBalancerID) (*elbv2.LoadBalancer, error) {
+	loadBalancer, err := e.ELBV2.DescribeLoadBalancer(ecsLoadBalancerID.String())
+	if err != nil {
+		if ContainsErrCode(err, "LoadBalancerNotFound") {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return loadBalancer, nil
+}
+
+func (e *ECSLoadBalancerManager) getApplicationLoadBalancer(ecsLoadBalancerID id.ECSLoadBalancerID) (*models.LoadBalancer, error) {
+	loadBalancer, err := e.describeApplicationLoadBalancer(ecsLoadBalancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if loadBalancer == nil {
+		err := fmt.Errorf("LoadBalancer with id '%s' does not exist", ecsLoadBalancerID.L0LoadBalancerID())
+		return nil, errors.New(errors.LoadBalancerDoesNotExist, err)
+	}
+
+	loadBalancerARN := aws.StringValue(loadBalancer.LoadBalancerArn)
+
+	targetGroup, err := e.ELBV2.DescribeTargetGroup(ecsLoadBalancerID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	listeners, err := e.ELBV2.DescribeListeners(loadBalancerARN)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := e.ELBV2.DescribeLoadBalancerAttributes(loadBalancerARN)
+	if err != nil {
+		return nil, err
+	}
+
+	targetGroupAttributes, err := e.ELBV2.DescribeTargetGroupAttributes(aws.StringValue(targetGroup.TargetGroupArn))
+	if err != nil {
+		return nil, err
+	}
+
+	ports := []models.Port{}
+	for _, listener := range listeners {
+		port := models.Port{
+			ContainerPort: aws.Int64Value(targetGroup.Port),
+			HostPort:      aws.Int64Value(listener.Port),
+			Protocol:      aws.StringValue(listener.Protocol),
+		}
+
+		if certificateARN := listener.CertificateARN(); certificateARN != "" {
+			port.CertificateARN = certificateARN
+			port.CertificateName = id.CertificateARNToName(certificateARN)
+		}
+
+		ports = append(ports, port)
+	}
+
+	// the same rules are added to each listener, so we only need to look at one of them
+	rules := []models.LoadBalancerRule{}
+	if len(listeners) > 0 {
+		r, err := e.getApplicationRules(aws.StringValue(listeners[0].ListenerArn))
+		if err != nil {
+			return nil, err
+		}
+
+		rules = r
+	}
+
+	idleTimeout, _ := strconv.Atoi(attributes["idle_timeout.timeout_seconds"])
+	accessLogsEnabled, _ := strconv.ParseBool(attributes["access_logs.s3.enabled"])
+	deregistrationDelay, _ := strconv.Atoi(targetGroupAttributes["deregistration_delay.timeout_seconds"])
+
+	// application load balancers always publish access logs every 5 minutes
+	accessLogs := models.AccessLogs{Enabled: accessLogsEnabled}
+	if accessLogsEnabled {
+		accessLogs.S3BucketName = attributes["access_logs.s3.bucket"]
+		accessLogs.S3BucketPrefix = attributes["access_logs.s3.prefix"]
+		accessLogs.EmitInterval = 5
+	}
+
+	// application load balancers always drain connections; a deregistration delay of 0 disables it
+	connectionDraining := models.ConnectionDraining{
+		Enabled: deregistrationDelay > 0,
+		Timeout: deregistrationDelay,
+	}
+
+	model := &models.LoadBalancer{
+		LoadBalancerID:     ecsLoadBalancerID.L0LoadBalancerID(),
+		LoadBalancerType:   "alb",
+		Ports:              ports,
+		Rules:              rules,
+		IsPublic:           aws.StringValue(loadBalancer.Scheme) == "internet-facing",
+		URL:                aws.StringValue(loadBalancer.DNSName),
+		HealthCheck:        fromTargetGroupHealthCheck(targetGroup),
+		IdleTimeout:        idleTimeout,
+		CrossZone:          true,
+		AccessLogs:         accessLogs,
+		ConnectionDraining: connectionDraining,
+	}
+
+	return model, nil
+}
+
+func (e *ECSLoadBalancerManager) getApplicationRules(listenerARN string) ([]models.LoadBalancerRule, error) {
+	listenerRules, err := e.ELBV2.DescribeRules(listenerARN)
+	if err != nil {
+		return nil, err
+	}
+
+	targetGroupARNs := []string{}
+	for _, rule := range listenerRules {
+		if !aws.BoolValue(rule.IsDefault) {
+			targetGroupARNs = append(targetGroupARNs, rule.TargetGroupARN())
+		}
+	}
+
+	targetGroups, err := e.ELBV2.DescribeTargetGroups(targetGroupARNs)
+	if err != nil {
+		return nil, err
+	}
+
+	// service target groups are named after the ecs service
+	serviceIDs := map[string]string{}
+	for _, targetGroup := range targetGroups {
+		ecsServiceID := id.ECSServiceID(aws.StringValue(targetGroup.TargetGroupName))
+		serviceIDs[aws.StringValue(targetGroup.TargetGroupArn)] = ecsServiceID.L0ServiceID()
+	}
+
+	rules := []models.LoadBalancerRule{}
+	for _, rule := range listenerRules {
+		if aws.BoolValue(rule.IsDefault) {
+			continue
+		}
+
+		rules = append(rules, models.LoadBalancerRule{
+			HostHeader:  rule.Condition("host-header"),
+			PathPattern: rule.Condition("path-pattern"),
+			Priority:    int(rule.PriorityValue()),
+			ServiceID:   serviceIDs[rule.TargetGroupARN()],
+		})
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+
+	return rules, nil
+}
+
+func (e *ECSLoadBalancerManager) deleteApplicationLoadBalancer(ecsLoadBalancerID id.ECSLoadBalancerID, loadBalancer *elbv2.LoadBalancer) error {
+	if err := e.ELBV2.DeleteLoadBalancer(aws.StringValue(loadBalancer.LoadBalancerArn)); err != nil {
+		if !ContainsErrCode(err, "LoadBalancerNotFound") {
+			return err
+		}
+	}
+
+	targetGroup, err := e.ELBV2.DescribeTargetGroup(ecsLoadBalancerID.String())
+	if err != nil {
+		if ContainsErrCode(err, "TargetGroupNotFound") {
+			return nil
+		}
+
+		return err
+	}
+
+	// the default target group cannot be deleted until the load balancer's listeners are gone
+	check := func() (bool, error) {
+		if err := e.ELBV2.DeleteTargetGroup(aws.StringValue(targetGroup.TargetGroupArn)); err != nil {
+			if ContainsErrCode(err, "ResourceInUse") {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	waiter := waitutils.Waiter{
+		Name:    fmt.Sprintf("TargetGroup delete for '%s'", ecsLoadBalancerID),
+		Retries: 50,
+		Delay:   time.Second * 5,
+		Clock:   e.Clock,
+		Check:   check,
+	}
+
+	return waiter.Wait()
+}
+
+func (e *ECSLoadBalancerManager) updateTargetGroupHealthChecks(loadBalancer *elbv2.LoadBalancer, healthCheck models.HealthCheck) error {
+	targetGroups, err := e.ELBV2.DescribeLoadBalancerTargetGroups(aws.StringValue(loadBalancer.LoadBalancerArn))
+	if err != nil {
+		return err
+	}
+
+	targetGroupHealthCheck := toTargetGroupHealthCheck(healthCheck)
+	for _, targetGroup := range targetGroups {
+		if err := e.ELBV2.ModifyTargetGroupHealthCheck(aws.StringValue(targetGroup.TargetGroupArn), targetGroupHealthCheck); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *ECSLoadBalancerManager) updateApplicationListeners(ecsLoadBalancerID id.ECSLoadBalancerID, isPublic bool, currentPorts, requestedPorts []models.Port) ([]models.Port, error) {
+	if reflect.DeepEqual(currentPorts, requestedPorts) {
+		return currentPorts, nil
+	}
+
+	for _, port := range requestedPorts {
+		if err := validateApplicationPort(port); err != nil {
+			return nil, err
+		}
+	}
+
+	loadBalancer, err := e.describeApplicationLoadBalancer(ecsLoadBalancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if loadBalancer == nil {
+		err := fmt.Errorf("LoadBalancer with id '%s' does not exist", ecsLoadBalancerID.L0LoadBalancerID())
+		return nil, errors.New(errors.LoadBalancerDoesNotExist, err)
+	}
+
+	loadBalancerARN := aws.StringValue(loadBalancer.LoadBalancerArn)
+	listeners, err := e.ELBV2.DescribeListeners(loadBalancerARN)
+	if err != nil {
+		return nil, err
+	}
+
+	// new listeners need the same service rules as the existing listeners
+	rules := []*elbv2.Rule{}
+	if len(listeners) > 0 {
+		listenerRules, err := e.ELBV2.DescribeRules(aws.StringValue(listeners[0].ListenerArn))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rule := range listenerRules {
+			if !aws.BoolValue(rule.IsDefault) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	// remove first so we don't duplicate host ports
+	for _, port := range portDifference(currentPorts, requestedPorts) {
+		for _, listener := range listeners {
+			if aws.Int64Value(listener.Port) == port.HostPort {
+				if err := e.ELBV2.DeleteListener(aws.StringValue(listener.ListenerArn)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	portsToAdd := portDifference(requestedPorts, currentPorts)
+	if len(portsToAdd) > 0 {
+		targetGroup, err := e.ELBV2.DescribeTargetGroup(ecsLoadBalancerID.String())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, port := range portsToAdd {
+			listener, err := e.portToApplicationListener(port, aws.StringValue(targetGroup.TargetGroupArn))
+			if err != nil {
+				return nil, err
+			}
+
+			if err := e.ELBV2.CreateListener(loadBalancerARN, listener); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(rules) > 0 {
+			updatedListeners, err := e.ELBV2.DescribeListeners(loadBalancerARN)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, listener := range updatedListeners {
+				for _, port := range portsToAdd {
+					if aws.Int64Value(listener.Port) != port.HostPort {
+						continue
+					}
+
+					for _, rule := range rules {
+						if err := e.ELBV2.CreateRule(aws.StringValue(listener.ListenerArn), rule); err != nil {
+							return nil, err
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// only public load balancers have an additional security group
+	if isPublic {
+		if _, err := e.upsertSecurityGroup(ecsLoadBalancerID, requestedPorts); err != nil {
+			return nil, err
+		}
+	}
+
+	return requestedPorts, nil
+}
+
+func (e *ECSLoadBalancerManager) portToApplicationListener(port models.Port, targetGroupARN string) (*elbv2.Listener, error) {
+	if err := validateApplicationPort(port); err != nil {
+		return nil, err
+	}
+
+	// use cert arn if specified by the user
+	// otherwise, if name is specified, convert it to an arn
+	certificateARN := port.CertificateARN
+	if certificateARN == "" && port.CertificateName != "" {
+		arn, err := e.getCertificateARN(port.CertificateName)
+		if err != nil {
+			return nil, err
+		}
+
+		certificateARN = arn
+	}
+
+	listener := elbv2.NewListener(port.HostPort, strings.ToUpper(port.Protocol), certificateARN, targetGroupARN)
+	return listener, nil
+}
+
+func validateApplicationPort(port models.Port) error {
+	protocol := strings.ToUpper(port.Protocol)
+	if protocol != "HTTP" && protocol != "HTTPS" {
+		return fmt.Errorf("Protocol '%s' is not valid for application load balancers, must be 'http' or 'https'", port.Protocol)
+	}
+
+	return nil
+}
+
+// the default target group receives traffic that doesn't match any service rule
+func defaultTargetGroupPort(ports []models.Port) int64 {
+	if len(ports) > 0 {
+		return ports[0].ContainerPort
+	}
+
+	return 80
+}
+
+// converts an elb-style health check target, e.g. 'HTTP:80/health', into a target group health check.
+// target groups always check the port that traffic is sent to, so the target port is ignored
+func toTargetGroupHealthCheck(healthCheck models.HealthCheck) *elbv2.HealthCheck {
+	protocol := "HTTP"
+	path := "/"
+
+	split := strings.SplitN(healthCheck.Target, ":", 2)
+	if p := strings.ToUpper(split[0]); p == "HTTP" || p == "HTTPS" {
+		protocol = p
+
+		if len(split) == 2 {
+			if i := strings.Index(split[1], "/"); i >= 0 {
+				path = split[1][i:]
+			}
+		}
+	}
+
+	return elbv2.NewHealthCheck(
+		protocol,
+		path,
+		int64(healthCheck.Interval),
+		int64(healthCheck.Timeout),
+		int64(healthCheck.HealthyThreshold),
+		int64(healthCheck.UnhealthyThreshold))
+}
+
+func fromTargetGroupHealthCheck(targetGroup *elbv2.TargetGroup) models.HealthCheck {
+	target := fmt.Sprintf("%s:%d%s",
+		aws.StringValue(targetGroup.HealthCheckProtocol),
+		aws.Int64Value(targetGroup.Port),
+		aws.StringValue(targetGroup.HealthCheckPath))
+
+	return models.HealthCheck{
+		Target:             target,
+		Interval:           int(aws.Int64Value(targetGroup.HealthCheckIntervalSeconds)),
+		Timeout:            int(aws.Int64Value(targetGroup.HealthCheckTimeoutSeconds)),
+		HealthyThreshold:   int(aws.Int64Value(targetGroup.HealthyThresholdCount)),
+		UnhealthyThreshold: int(aws.Int64Value(targetGroup.UnhealthyThresholdCount)),
+	}
+}
+
+// targetHealthToInstanceState converts target group health states
+// into the states reported by classic load balancers
+func targetHealthToInstanceState(state string) string {
+	switch state {
+	case awselbv2.TargetHealthStateEnumHealthy:
+		return "InService"
+	case awselbv2.TargetHealthStateEnumUnhealthy, awselbv2.TargetHealthStateEnumUnused:
+		return "OutOfService"
+	default:
+		return "Unknown"
+	}
+}
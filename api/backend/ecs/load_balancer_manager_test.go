@@ -13,6 +13,8 @@ import (
 	"github.com/quintilesims/layer0/common/aws/ec2/mock_ec2"
 	"github.com/quintilesims/layer0/common/aws/elb"
 	"github.com/quintilesims/layer0/common/aws/elb/mock_elb"
+	"github.com/quintilesims/layer0/common/aws/elbv2"
+	"github.com/quintilesims/layer0/common/aws/elbv2/mock_elbv2"
 	"github.com/quintilesims/layer0/common/aws/iam/mock_iam"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
@@ -21,6 +23,7 @@ import (
 type MockECSLoadBalancerManager struct {
 	EC2     *mock_ec2.MockProvider
 	ELB     *mock_elb.MockProvider
+	ELBV2   *mock_elbv2.MockProvider
 	IAM     *mock_iam.MockProvider
 	Backend *mock_backend.MockBackend
 }
@@ -29,13 +32,14 @@ func NewMockECSLoadBalancerManager(ctrl *gomock.Controller) *MockECSLoadBalancer
 	return &MockECSLoadBalancerManager{
 		EC2:     mock_ec2.NewMockProvider(ctrl),
 		ELB:     mock_elb.NewMockProvider(ctrl),
+		ELBV2:   mock_elbv2.NewMockProvider(ctrl),
 		IAM:     mock_iam.NewMockProvider(ctrl),
 		Backend: mock_backend.NewMockBackend(ctrl),
 	}
 }
 
 func (this *MockECSLoadBalancerManager) LoadBalancer() *ECSLoadBalancerManager {
-	return NewECSLoadBalancerManager(this.EC2, this.ELB, this.IAM, this.Backend)
+	return NewECSLoadBalancerManager(this.EC2, this.ELB, this.ELBV2, this.IAM, this.Backend)
 }
 
 func makeSubnet(az string) *ec2.Subnet {
@@ -142,6 +146,13 @@ func TestListLoadBalancers(t *testing.T) {
 					DescribeLoadBalancers().
 					Return([]*elb.LoadBalancerDescription{loadBalancer}, nil)
 
+				applicationLoadBalancerID := id.L0LoadBalancerID("albid").ECSLoadBalancerID()
+				applicationLoadBalancer := elbv2.NewLoadBalancer(applicationLoadBalancerID.String(), "internet-facing")
+
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancers().
+					Return([]*elbv2.LoadBalancer{applicationLoadBalancer}, nil)
+
 				return mockLB.LoadBalancer()
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
@@ -152,8 +163,11 @@ func TestListLoadBalancers(t *testing.T) {
 					reporter.Fatal(err)
 				}
 
-				reporter.AssertEqual(len(loadBalancers), 1)
+				reporter.AssertEqual(len(loadBalancers), 2)
 				reporter.AssertEqual(loadBalancers[0].LoadBalancerID, "lbid")
+				reporter.AssertEqual(loadBalancers[0].LoadBalancerType, "elb")
+				reporter.AssertEqual(loadBalancers[1].LoadBalancerID, "albid")
+				reporter.AssertEqual(loadBalancers[1].LoadBalancerType, "alb")
 			},
 		},
 		{
@@ -245,7 +259,81 @@ func TestCreateLoadBalancer(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSLoadBalancerManager)
-				manager.CreateLoadBalancer("lb_name", "envid", true, nil, models.HealthCheck{}, 60, true)
+				manager.CreateLoadBalancer("lb_name", "envid", "elb", true, nil, models.HealthCheck{}, 60, true)
+			},
+		},
+		{
+			Name: "Should create application load balancer with default target group and listeners",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				environmentID := id.L0EnvironmentID("envid").ECSEnvironmentID()
+				roleName := loadBalancerID.RoleName()
+
+				mockLB.IAM.EXPECT().
+					CreateRole(roleName, "ecs.amazonaws.com")
+
+				mockLB.IAM.EXPECT().
+					PutRolePolicy(roleName, gomock.Any())
+
+				mockLB.IAM.EXPECT().
+					GetAccountId().
+					Return("100", nil)
+
+				// getSubnetsAndAvailZones
+				mockLB.EC2.EXPECT().
+					DescribeSubnet(gomock.Any()).
+					Return(makeSubnet("a"), nil)
+
+				mockLB.EC2.EXPECT().
+					DescribeSubnet(gomock.Any()).
+					Return(makeSubnet("b"), nil)
+
+				mockLB.EC2.EXPECT().
+					DescribeSecurityGroup(loadBalancerID.SecurityGroupName()).
+					Return(sgList, nil)
+
+				mockLB.EC2.EXPECT().
+					DescribeSecurityGroup(environmentID.SecurityGroupName()).
+					Return(sgList, nil)
+
+				mockLB.EC2.EXPECT().
+					AuthorizeSecurityGroupIngress(gomock.Any()).
+					Return(nil)
+
+				loadBalancer := elbv2.NewLoadBalancer(loadBalancerID.String(), "internet-facing")
+				mockLB.ELBV2.EXPECT().
+					CreateLoadBalancer(loadBalancerID.String(), "internet-facing", gomock.Any(), gomock.Any()).
+					Return(loadBalancer, nil)
+
+				targetGroup := elbv2.NewTargetGroup(loadBalancerID.String(), 8080)
+				mockLB.ELBV2.EXPECT().
+					CreateTargetGroup(loadBalancerID.String(), "HTTP", int64(8080), gomock.Any(), gomock.Any()).
+					Return(targetGroup, nil)
+
+				listener := elbv2.NewListener(80, "HTTP", "", loadBalancerID.String())
+				mockLB.ELBV2.EXPECT().
+					CreateListener(loadBalancerID.String(), listener).
+					Return(nil)
+
+				mockLB.ELBV2.EXPECT().
+					SetIdleTimeout(loadBalancerID.String(), 60).
+					Return(nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				ports := []models.Port{{HostPort: 80, ContainerPort: 8080, Protocol: "http"}}
+				model, err := manager.CreateLoadBalancer("lb_name", "envid", "alb", true, ports, models.HealthCheck{}, 60, true)
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				reporter.AssertEqual(model.LoadBalancerType, "alb")
+				reporter.AssertEqual(model.CrossZone, true)
 			},
 		},
 		{
@@ -298,7 +386,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSLoadBalancerManager)
-				manager.CreateLoadBalancer("lb_name", "envid", false, nil, models.HealthCheck{}, 60, true)
+				manager.CreateLoadBalancer("lb_name", "envid", "elb", false, nil, models.HealthCheck{}, 60, true)
 			},
 		},
 		{
@@ -347,7 +435,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSLoadBalancerManager)
-				manager.CreateLoadBalancer("lb_name", "envid", true, nil, models.HealthCheck{}, 60, true)
+				manager.CreateLoadBalancer("lb_name", "envid", "elb", true, nil, models.HealthCheck{}, 60, true)
 			},
 		},
 		{
@@ -399,7 +487,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 				loadBalancerID := id.L0LoadBalancerID("lbid")
 				environmentID := id.L0EnvironmentID("envid")
 
-				model, err := manager.CreateLoadBalancer("lb_name", environmentID.String(), true, nil, models.HealthCheck{}, 60, true)
+				model, err := manager.CreateLoadBalancer("lb_name", environmentID.String(), "elb", true, nil, models.HealthCheck{}, 60, true)
 				if err != nil {
 					reporter.Fatal(err)
 				}
@@ -461,7 +549,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 					g.Set(i+1, fmt.Errorf("some error"))
 
 					manager := setup(g).(*ECSLoadBalancerManager)
-					if _, err := manager.CreateLoadBalancer("", "", true, nil, models.HealthCheck{}, 60, true); err == nil {
+					if _, err := manager.CreateLoadBalancer("", "", "elb", true, nil, models.HealthCheck{}, 60, true); err == nil {
 						reporter.Errorf("Error on variation %d, Error was nil!", i)
 					}
 				}
@@ -516,7 +604,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 				loadBalancerID := id.L0LoadBalancerID("lbid")
 				environmentID := id.L0EnvironmentID("envid")
 
-				model, err := manager.CreateLoadBalancer("lb_name", environmentID.String(), true, nil, models.HealthCheck{}, 60, false)
+				model, err := manager.CreateLoadBalancer("lb_name", environmentID.String(), "elb", true, nil, models.HealthCheck{}, 60, false)
 				if err != nil {
 					reporter.Fatal(err)
 				}
@@ -537,6 +625,11 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
 				mockLB := NewMockECSLoadBalancerManager(ctrl)
 
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancer(gomock.Any()).
+					Return(nil, awserr.New("LoadBalancerNotFound", "", nil)).
+					AnyTimes()
+
 				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
 				roleName := loadBalancerID.RoleName()
 				policyName := stringp("some_policy")
@@ -577,6 +670,11 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
 				mockLB := NewMockECSLoadBalancerManager(ctrl)
 
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancer(gomock.Any()).
+					Return(nil, awserr.New("LoadBalancerNotFound", "", nil)).
+					AnyTimes()
+
 				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
 
 				mockLB.IAM.EXPECT().
@@ -604,6 +702,11 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
 				mockLB := NewMockECSLoadBalancerManager(ctrl)
 
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancer(gomock.Any()).
+					Return(nil, awserr.New("LoadBalancerNotFound", "", nil)).
+					AnyTimes()
+
 				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
 				sgName := loadBalancerID.SecurityGroupName()
 				sg := ec2.NewSecurityGroup("some_id")
@@ -638,6 +741,11 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
 				mockLB := NewMockECSLoadBalancerManager(ctrl)
 
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancer(gomock.Any()).
+					Return(nil, awserr.New("LoadBalancerNotFound", "", nil)).
+					AnyTimes()
+
 				sg := ec2.NewSecurityGroup("some_id")
 
 				mockLB.IAM.EXPECT().
@@ -684,6 +792,16 @@ func TestDeleteLoadBalancer(t *testing.T) {
 				return func(g testutils.ErrorGenerator) interface{} {
 					mockLB := NewMockECSLoadBalancerManager(ctrl)
 
+					mockLB.ELBV2.EXPECT().
+						DescribeLoadBalancer(gomock.Any()).
+						Return(nil, awserr.New("LoadBalancerNotFound", "", nil)).
+						AnyTimes()
+
+					mockLB.ELBV2.EXPECT().
+						DescribeLoadBalancer(gomock.Any()).
+						Return(nil, awserr.New("LoadBalancerNotFound", "", nil)).
+						AnyTimes()
+
 					mockLB.IAM.EXPECT().
 						ListRolePolicies(gomock.Any()).
 						Return(nil, g.Error()).
@@ -733,6 +851,11 @@ func TestUpdateLoadBalancerHealthCheck(t *testing.T) {
 			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
 				mockLB := NewMockECSLoadBalancerManager(ctrl)
 
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancer(gomock.Any()).
+					Return(nil, awserr.New("LoadBalancerNotFound", "", nil)).
+					AnyTimes()
+
 				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
 				loadBalancer := elb.NewLoadBalancerDescription(loadBalancerID.String(), "", nil)
 
@@ -782,6 +905,11 @@ func TestUpdateLoadBalancerIdleTimeout(t *testing.T) {
 			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
 				mockLB := NewMockECSLoadBalancerManager(ctrl)
 
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancer(gomock.Any()).
+					Return(nil, awserr.New("LoadBalancerNotFound", "", nil)).
+					AnyTimes()
+
 				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
 				loadBalancer := elb.NewLoadBalancerDescription(loadBalancerID.String(), "", nil)
 
@@ -819,4 +947,254 @@ func TestUpdateLoadBalancerIdleTimeout(t *testing.T) {
 	testutils.RunTests(t, testCases)
 }
 
+func TestUpdateLoadBalancerAccessLogs(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should pass proper params to ELB.SetAccessLogs",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancer(gomock.Any()).
+					Return(nil, awserr.New("LoadBalancerNotFound", "", nil)).
+					AnyTimes()
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				loadBalancer := elb.NewLoadBalancerDescription(loadBalancerID.String(), "", nil)
+
+				mockLB.ELB.EXPECT().
+					SetAccessLogs(loadBalancerID.String(), true, "bucket", "prefix", 5).
+					Return(nil)
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(loadBalancer, nil)
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancerAttributes(gomock.Any()).
+					Return(elb.NewLoadBalancerAttributes(), nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				accessLogs := models.AccessLogs{
+					Enabled:        true,
+					S3BucketName:   "bucket",
+					S3BucketPrefix: "prefix",
+					EmitInterval:   5,
+				}
+
+				if _, err := manager.UpdateLoadBalancerAccessLogs("lbid", accessLogs); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestUpdateLoadBalancerConnectionDraining(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should pass proper params to ELB.SetConnectionDraining",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancer(gomock.Any()).
+					Return(nil, awserr.New("LoadBalancerNotFound", "", nil)).
+					AnyTimes()
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				loadBalancer := elb.NewLoadBalancerDescription(loadBalancerID.String(), "", nil)
+
+				mockLB.ELB.EXPECT().
+					SetConnectionDraining(loadBalancerID.String(), true, 120).
+					Return(nil)
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(loadBalancer, nil)
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancerAttributes(gomock.Any()).
+					Return(elb.NewLoadBalancerAttributes(), nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				connectionDraining := models.ConnectionDraining{
+					Enabled: true,
+					Timeout: 120,
+				}
+
+				if _, err := manager.UpdateLoadBalancerConnectionDraining("lbid", connectionDraining); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+		{
+			Name: "Should set deregistration delay on each target group for application load balancers",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				loadBalancer := elbv2.NewLoadBalancer(loadBalancerID.String(), "internet-facing")
+
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(loadBalancer, nil)
+
+				targetGroups := []*elbv2.TargetGroup{
+					elbv2.NewTargetGroup("tg1", 80),
+					elbv2.NewTargetGroup("tg2", 80),
+				}
+
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancerTargetGroups(loadBalancerID.String()).
+					Return(targetGroups, nil)
+
+				mockLB.ELBV2.EXPECT().
+					SetDeregistrationDelay("tg1", 0).
+					Return(nil)
+
+				mockLB.ELBV2.EXPECT().
+					SetDeregistrationDelay("tg2", 0).
+					Return(nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+				ecsLoadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+
+				connectionDraining := models.ConnectionDraining{
+					Enabled: false,
+					Timeout: 120,
+				}
+
+				if err := manager.setConnectionDraining(ecsLoadBalancerID, connectionDraining); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestGetLoadBalancerHealth(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should return instance states from ELB.DescribeInstanceHealth",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+
+				state := elb.NewInstanceState()
+				state.SetInstanceId("i-123")
+				state.SetState("OutOfService")
+				state.SetReasonCode("Instance")
+				state.SetDescription("Instance has failed at least the UnhealthyThreshold number of health checks consecutively.")
+
+				mockLB.ELB.EXPECT().
+					DescribeInstanceHealth(loadBalancerID.String()).
+					Return([]*elb.InstanceState{state}, nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				health, err := manager.GetLoadBalancerHealth("lbid")
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				expected := &models.LoadBalancerHealth{
+					LoadBalancerID: "lbid",
+					Instances: []models.InstanceHealth{
+						{
+							InstanceID:  "i-123",
+							State:       "OutOfService",
+							ReasonCode:  "Instance",
+							Description: "Instance has failed at least the UnhealthyThreshold number of health checks consecutively.",
+						},
+					},
+				}
+
+				reporter.AssertEqual(health, expected)
+			},
+		},
+		{
+			Name: "Should return target health for application load balancers",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				serviceID := id.L0ServiceID("svcid").ECSServiceID()
+				loadBalancer := elbv2.NewLoadBalancer(loadBalancerID.String(), "internet-facing")
+
+				mockLB.ELB.EXPECT().
+					DescribeInstanceHealth(loadBalancerID.String()).
+					Return(nil, awserr.New("LoadBalancerNotFound", "", nil))
+
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(loadBalancer, nil)
+
+				targetGroups := []*elbv2.TargetGroup{
+					elbv2.NewTargetGroup(loadBalancerID.String(), 80),
+					elbv2.NewTargetGroup(serviceID.String(), 80),
+				}
+
+				mockLB.ELBV2.EXPECT().
+					DescribeLoadBalancerTargetGroups(loadBalancerID.String()).
+					Return(targetGroups, nil)
+
+				mockLB.ELBV2.EXPECT().
+					DescribeTargetHealth(loadBalancerID.String()).
+					Return([]*elbv2.TargetHealthDescription{}, nil)
+
+				mockLB.ELBV2.EXPECT().
+					DescribeTargetHealth(serviceID.String()).
+					Return([]*elbv2.TargetHealthDescription{
+						elbv2.NewTargetHealthDescription("i-123", 32768, "healthy"),
+						elbv2.NewTargetHealthDescription("i-456", 32769, "unhealthy"),
+						elbv2.NewTargetHealthDescription("i-789", 32770, "initial"),
+					}, nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				health, err := manager.GetLoadBalancerHealth("lbid")
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				expected := &models.LoadBalancerHealth{
+					LoadBalancerID: "lbid",
+					Instances: []models.InstanceHealth{
+						{InstanceID: "i-123", ServiceID: "svcid", State: "InService"},
+						{InstanceID: "i-456", ServiceID: "svcid", State: "OutOfService"},
+						{InstanceID: "i-789", ServiceID: "svcid", State: "Unknown"},
+					},
+				}
+
+				reporter.AssertEqual(health, expected)
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
 // todo: UpdateLoadBalancerPorts
@@ -0,0 +1,229 @@
+package ecsbackend
+
+import (
+	"fmt"
+	"strings"
+
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/common/aws/ecs"
+	"github.com/quintilesims/layer0/common/aws/iam"
+	"github.com/quintilesims/layer0/common/aws/s3"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+// secrets are kept in the layer0 bucket under 'secrets/<environment id>/<secret name>'
+// ec2 instances in the environments are denied access to this prefix; each environment
+// with secrets has a task role that can only read its own
+const SECRET_KEY_PREFIX = "secrets"
+
+type ECSSecretManager struct {
+	S3     s3.Provider
+	IAM    iam.Provider
+	Bucket string
+}
+
+func NewECSSecretManager(s3Provider s3.Provider, iamProvider iam.Provider) *ECSSecretManager {
+	return &ECSSecretManager{
+		S3:     s3Provider,
+		IAM:    iamProvider,
+		Bucket: config.AWSS3Bucket(),
+	}
+}
+
+func (this *ECSSecretManager) ListSecrets(environmentID string) ([]*models.Secret, error) {
+	prefix := secretKeyPrefix(environmentID)
+	keys, err := this.S3.ListObjects(this.Bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := []*models.Secret{}
+	for _, key := range keys {
+		secretName := strings.TrimPrefix(key, prefix)
+		if secretName == "" || strings.Contains(secretName, "/") {
+			continue
+		}
+
+		secret := &models.Secret{
+			EnvironmentID: environmentID,
+			SecretName:    secretName,
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+func (this *ECSSecretManager) CreateSecret(environmentID, secretName, value string) (*models.Secret, error) {
+	if err := this.createSecretsRole(environmentID); err != nil {
+		return nil, err
+	}
+
+	key := secretKeyPrefix(environmentID) + secretName
+	if err := this.S3.PutEncryptedObject(this.Bucket, key, []byte(value)); err != nil {
+		return nil, err
+	}
+
+	secret := &models.Secret{
+		EnvironmentID: environmentID,
+		SecretName:    secretName,
+	}
+
+	return secret, nil
+}
+
+func (this *ECSSecretManager) DeleteSecret(environmentID, secretName string) error {
+	key := secretKeyPrefix(environmentID) + secretName
+	if err := this.S3.DeleteObject(this.Bucket, key); err != nil {
+		return err
+	}
+
+	secrets, err := this.ListSecrets(environmentID)
+	if err != nil {
+		return err
+	}
+
+	// the role is removed with the last secret of the environment, e.g. when the environment is deleted
+	if len(secrets) == 0 {
+		return this.deleteSecretsRole(environmentID)
+	}
+
+	return nil
+}
+
+// createSecretsRole creates the task role of the deploys that reference the secrets of the environment.
+// The role can only read the secrets of that environment.
+func (this *ECSSecretManager) createSecretsRole(environmentID string) error {
+	roleName := id.L0EnvironmentID(environmentID).ECSEnvironmentID().SecretsRoleName()
+	if _, err := this.IAM.CreateRole(roleName, "ecs-tasks.amazonaws.com"); err != nil {
+		if !ContainsErrCode(err, "EntityAlreadyExists") {
+			return err
+		}
+	}
+
+	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/%s*"]}]}`,
+		this.Bucket, secretKeyPrefix(environmentID))
+
+	return this.IAM.PutRolePolicy(roleName, policy)
+}
+
+func (this *ECSSecretManager) deleteSecretsRole(environmentID string) error {
+	roleName := id.L0EnvironmentID(environmentID).ECSEnvironmentID().SecretsRoleName()
+	policies, err := this.IAM.ListRolePolicies(roleName)
+	if err != nil {
+		if ContainsErrCode(err, "NoSuchEntity") {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, policy := range policies {
+		if err := this.IAM.DeleteRolePolicy(roleName, pstring(policy)); err != nil {
+			return err
+		}
+	}
+
+	if err := this.IAM.DeleteRole(roleName); err != nil && !ContainsErrCode(err, "NoSuchEntity") {
+		return err
+	}
+
+	return nil
+}
+
+func secretKeyPrefix(environmentID string) string {
+	return fmt.Sprintf("%s/%s/", SECRET_KEY_PREFIX, environmentID)
+}
+
+func secretsRoleARN(environmentID string) string {
+	roleName := id.L0EnvironmentID(environmentID).ECSEnvironmentID().SecretsRoleName()
+	return fmt.Sprintf("arn:aws:iam::%s:role/%s", config.AWSAccountID(), roleName)
+}
+
+// containers that reference secrets are started through l0-secrets, which replaces the references in
+// the container's environment with the values of the secrets before running the container's entrypoint.
+// The binary is shared with the containers through a volume of the non-essential l0-secrets container,
+// and the secrets are read with the task role of the environment they belong to, so their values are
+// never part of the task definition and the containers cannot read the secrets of other environments.
+const (
+	SECRETS_CONTAINER_NAME = "l0-secrets"
+	SECRETS_VOLUME_PATH    = "/layer0"
+	SECRETS_ENTRYPOINT     = SECRETS_VOLUME_PATH + "/l0-secrets"
+)
+
+func addSecretsContainer(dockerrun *models.Dockerrun) error {
+	referencing := []*ecs.ContainerDefinition{}
+	var environmentID string
+	for _, container := range dockerrun.ContainerDefinitions {
+		if pstring(container.Name) == SECRETS_CONTAINER_NAME {
+			return nil
+		}
+
+		var references bool
+		for _, variable := range container.Environment {
+			if !models.IsSecretReference(pstring(variable.Value)) {
+				continue
+			}
+
+			referenceEnvironmentID, _, err := models.ParseSecretReference(pstring(variable.Value))
+			if err != nil {
+				return err
+			}
+
+			if environmentID != "" && referenceEnvironmentID != environmentID {
+				return fmt.Errorf("Deploys can only reference the secrets of a single environment, but references environments '%s' and '%s'", environmentID, referenceEnvironmentID)
+			}
+
+			environmentID = referenceEnvironmentID
+			references = true
+		}
+
+		if references {
+			referencing = append(referencing, container)
+		}
+	}
+
+	if len(referencing) == 0 {
+		return nil
+	}
+
+	// the task role is what limits the containers to the secrets of their environment
+	if dockerrun.TaskRoleARN != "" {
+		return fmt.Errorf("Deploys that reference secrets cannot set taskRoleArn, since they use the secrets role of environment '%s'", environmentID)
+	}
+
+	dockerrun.TaskRoleARN = secretsRoleARN(environmentID)
+
+	for _, container := range referencing {
+		// the api cannot see the image's ENTRYPOINT, and replacing it with l0-secrets would silently drop it
+		if len(container.EntryPoint) == 0 {
+			return fmt.Errorf("Container '%s' references secrets, so it must specify its entryPoint, since l0-secrets replaces the image's ENTRYPOINT", pstring(container.Name))
+		}
+
+		container.EntryPoint = append([]*string{stringp(SECRETS_ENTRYPOINT), stringp("--")}, container.EntryPoint...)
+		container.VolumesFrom = append(container.VolumesFrom, &awsecs.VolumeFrom{
+			SourceContainer: stringp(SECRETS_CONTAINER_NAME),
+			ReadOnly:        boolp(true),
+		})
+
+		container.Environment = append(container.Environment,
+			&awsecs.KeyValuePair{Name: stringp(config.AWS_S3_BUCKET), Value: stringp(config.AWSS3Bucket())},
+			&awsecs.KeyValuePair{Name: stringp(config.AWS_REGION), Value: stringp(config.AWSRegion())},
+			&awsecs.KeyValuePair{Name: stringp(config.PREFIX), Value: stringp(config.Prefix())})
+	}
+
+	secretsContainer := &ecs.ContainerDefinition{
+		&awsecs.ContainerDefinition{
+			Name:      stringp(SECRETS_CONTAINER_NAME),
+			Image:     stringp(fmt.Sprintf("quintilesims/l0-secrets:%s", config.RunnerVersionTag())),
+			Essential: boolp(false),
+			Memory:    int64p(8),
+		},
+	}
+
+	dockerrun.ContainerDefinitions = append(dockerrun.ContainerDefinitions, secretsContainer)
+	return nil
+}
@@ -0,0 +1,212 @@
+package ecsbackend
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/common/aws/iam/mock_iam"
+	"github.com/quintilesims/layer0/common/aws/s3/mock_s3"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+type MockECSSecretManager struct {
+	S3  *mock_s3.MockProvider
+	IAM *mock_iam.MockProvider
+}
+
+func NewMockECSSecretManager(ctrl *gomock.Controller) *MockECSSecretManager {
+	return &MockECSSecretManager{
+		S3:  mock_s3.NewMockProvider(ctrl),
+		IAM: mock_iam.NewMockProvider(ctrl),
+	}
+}
+
+func (this *MockECSSecretManager) Secret() *ECSSecretManager {
+	return &ECSSecretManager{
+		S3:     this.S3,
+		IAM:    this.IAM,
+		Bucket: "bucket",
+	}
+}
+
+func TestListSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSecret := NewMockECSSecretManager(ctrl)
+	mockSecret.S3.EXPECT().
+		ListObjects("bucket", "secrets/envid/").
+		Return([]string{"secrets/envid/db_password", "secrets/envid/api_key", "secrets/envid/"}, nil)
+
+	secrets, err := mockSecret.Secret().ListSecrets("envid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*models.Secret{
+		{EnvironmentID: "envid", SecretName: "db_password"},
+		{EnvironmentID: "envid", SecretName: "api_key"},
+	}
+
+	testutils.AssertEqual(t, secrets, expected)
+}
+
+func TestCreateSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleName := id.L0EnvironmentID("envid").ECSEnvironmentID().SecretsRoleName()
+	mockSecret := NewMockECSSecretManager(ctrl)
+	mockSecret.IAM.EXPECT().
+		CreateRole(roleName, "ecs-tasks.amazonaws.com").
+		Return(nil, nil)
+
+	// the role can only read the secrets of its environment
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::bucket/secrets/envid/*"]}]}`
+	mockSecret.IAM.EXPECT().
+		PutRolePolicy(roleName, policy).
+		Return(nil)
+
+	mockSecret.S3.EXPECT().
+		PutEncryptedObject("bucket", "secrets/envid/db_password", []byte("hunter2")).
+		Return(nil)
+
+	secret, err := mockSecret.Secret().CreateSecret("envid", "db_password", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, secret, &models.Secret{EnvironmentID: "envid", SecretName: "db_password"})
+}
+
+func TestDeleteSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSecret := NewMockECSSecretManager(ctrl)
+	mockSecret.S3.EXPECT().
+		DeleteObject("bucket", "secrets/envid/db_password").
+		Return(nil)
+
+	mockSecret.S3.EXPECT().
+		ListObjects("bucket", "secrets/envid/").
+		Return([]string{"secrets/envid/api_key"}, nil)
+
+	if err := mockSecret.Secret().DeleteSecret("envid", "db_password"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteSecret_last(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleName := id.L0EnvironmentID("envid").ECSEnvironmentID().SecretsRoleName()
+	mockSecret := NewMockECSSecretManager(ctrl)
+	mockSecret.S3.EXPECT().
+		DeleteObject("bucket", "secrets/envid/db_password").
+		Return(nil)
+
+	mockSecret.S3.EXPECT().
+		ListObjects("bucket", "secrets/envid/").
+		Return([]string{}, nil)
+
+	mockSecret.IAM.EXPECT().
+		ListRolePolicies(roleName).
+		Return([]*string{aws.String("policy")}, nil)
+
+	mockSecret.IAM.EXPECT().
+		DeleteRolePolicy(roleName, "policy").
+		Return(nil)
+
+	mockSecret.IAM.EXPECT().
+		DeleteRole(roleName).
+		Return(nil)
+
+	if err := mockSecret.Secret().DeleteSecret("envid", "db_password"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddSecretsContainer(t *testing.T) {
+	dockerrun, err := MarshalDockerrun([]byte(`{"containerDefinitions":[
+		{"name":"api","entryPoint":["/api"],"environment":[{"name":"DB_PASSWORD","value":"secret://envid/db_password"}]},
+		{"name":"worker","command":["work"]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := addSecretsContainer(dockerrun); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, dockerrun.TaskRoleARN, secretsRoleARN("envid"))
+	testutils.AssertEqual(t, len(dockerrun.ContainerDefinitions), 3)
+
+	api := dockerrun.ContainerDefinitions[0]
+	testutils.AssertEqual(t, aws.StringValueSlice(api.EntryPoint), []string{SECRETS_ENTRYPOINT, "--", "/api"})
+	testutils.AssertEqual(t, aws.StringValue(api.VolumesFrom[0].SourceContainer), SECRETS_CONTAINER_NAME)
+
+	// the secret reference is resolved by the container, never by the api
+	testutils.AssertEqual(t, aws.StringValue(api.Environment[0].Value), "secret://envid/db_password")
+
+	worker := dockerrun.ContainerDefinitions[1]
+	testutils.AssertEqual(t, len(worker.EntryPoint), 0)
+	testutils.AssertEqual(t, len(worker.VolumesFrom), 0)
+
+	secrets := dockerrun.ContainerDefinitions[2]
+	testutils.AssertEqual(t, aws.StringValue(secrets.Name), SECRETS_CONTAINER_NAME)
+	testutils.AssertEqual(t, aws.BoolValue(secrets.Essential), false)
+
+	// deploys created from an extracted dockerrun are not changed again
+	if err := addSecretsContainer(dockerrun); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(dockerrun.ContainerDefinitions), 3)
+	testutils.AssertEqual(t, len(api.EntryPoint), 3)
+}
+
+func TestAddSecretsContainerError_noEntryPoint(t *testing.T) {
+	// the image's ENTRYPOINT would be replaced, even though only the command is set
+	dockerrun, err := MarshalDockerrun([]byte(`{"containerDefinitions":[
+		{"name":"api","command":["postgres"],"environment":[{"name":"DB_PASSWORD","value":"secret://envid/db_password"}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := addSecretsContainer(dockerrun); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
+
+func TestAddSecretsContainerError_invalid(t *testing.T) {
+	cases := map[string]string{
+		"unqualified reference": `{"containerDefinitions":[
+			{"name":"api","entryPoint":["/api"],"environment":[{"name":"DB_PASSWORD","value":"secret://db_password"}]}
+		]}`,
+		"multiple environments": `{"containerDefinitions":[
+			{"name":"api","entryPoint":["/api"],"environment":[{"name":"DB_PASSWORD","value":"secret://envid/db_password"}]},
+			{"name":"worker","entryPoint":["/work"],"environment":[{"name":"DB_PASSWORD","value":"secret://other/db_password"}]}
+		]}`,
+		"task role": `{"taskRoleArn":"arn:aws:iam::123456789012:role/app","containerDefinitions":[
+			{"name":"api","entryPoint":["/api"],"environment":[{"name":"DB_PASSWORD","value":"secret://envid/db_password"}]}
+		]}`,
+	}
+
+	for name, body := range cases {
+		dockerrun, err := MarshalDockerrun([]byte(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := addSecretsContainer(dockerrun); err == nil {
+			t.Errorf("%s: error was nil!", name)
+		}
+	}
+}
@@ -2,15 +2,19 @@ package ecsbackend
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs"
 	"github.com/quintilesims/layer0/common/aws/ec2"
 	"github.com/quintilesims/layer0/common/aws/ecs"
+	"github.com/quintilesims/layer0/common/aws/elbv2"
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/waitutils"
@@ -21,6 +25,7 @@ const MAX_SERVICE_CREATE_RETRIES = 10
 type ECSServiceManager struct {
 	ECS            ecs.Provider
 	EC2            ec2.Provider
+	ELBV2          elbv2.Provider
 	CloudWatchLogs cloudwatchlogs.Provider
 	Backend        backend.Backend
 	Clock          waitutils.Clock
@@ -29,12 +34,14 @@ type ECSServiceManager struct {
 func NewECSServiceManager(
 	ecsProvider ecs.Provider,
 	ec2Provider ec2.Provider,
+	elbv2Provider elbv2.Provider,
 	cloudWatchLogsProvider cloudwatchlogs.Provider,
 	backend backend.Backend,
 ) *ECSServiceManager {
 	return &ECSServiceManager{
 		ECS:            ecsProvider,
 		EC2:            ec2Provider,
+		ELBV2:          elbv2Provider,
 		CloudWatchLogs: cloudWatchLogsProvider,
 		Backend:        backend,
 		Clock:          waitutils.RealClock{},
@@ -161,6 +168,10 @@ func (this *ECSServiceManager) DeleteService(environmentID, serviceID string) er
 		return err
 	}
 
+	if err := this.deleteTargetGroup(ecsServiceID); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -169,18 +180,23 @@ func (this *ECSServiceManager) CreateService(
 	environmentID,
 	deployID,
 	loadBalancerID string,
+	loadBalancerRule models.LoadBalancerRule,
 ) (*models.Service, error) {
 
 	// we generate a hashed id for services since aws does not enforce unique service names
 	serviceID := id.GenerateHashedEntityID(serviceName)
 
+	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
+	ecsServiceID := id.L0ServiceID(serviceID).ECSServiceID()
+	ecsDeployID := id.L0DeployID(deployID).ECSDeployID()
+	desiredCount := 1
+
 	var loadBalancerContainers []*ecs.LoadBalancer
 	var loadBalancerRole *string
 	if loadBalancerID != "" {
 		ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
-		ecsDeployID := id.L0DeployID(deployID).ECSDeployID()
 
-		loadBalancerContainer, err := this.getLoadBalancerContainer(ecsLoadBalancerID, ecsDeployID)
+		loadBalancerContainer, err := this.getLoadBalancerContainer(ecsLoadBalancerID, ecsDeployID, ecsServiceID, loadBalancerRule)
 		if err != nil {
 			return nil, err
 		}
@@ -189,11 +205,6 @@ func (this *ECSServiceManager) CreateService(
 		loadBalancerRole = stringp(ecsLoadBalancerID.RoleName())
 	}
 
-	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
-	ecsServiceID := id.L0ServiceID(serviceID).ECSServiceID()
-	ecsDeployID := id.L0DeployID(deployID).ECSDeployID()
-	desiredCount := 1
-
 	var service *ecs.Service
 	var attempts int
 	check := func() (bool, error) {
@@ -245,41 +256,228 @@ func (this *ECSServiceManager) CreateService(
 	}
 
 	if err := waiter.Wait(); err != nil {
+		if len(loadBalancerContainers) > 0 && loadBalancerContainers[0].TargetGroupArn != nil {
+			if err := this.deleteTargetGroup(ecsServiceID); err != nil {
+				log.Warnf("Failed to clean up target group for service '%s': %v", serviceID, err)
+			}
+		}
+
 		return nil, err
 	}
 
 	return this.populateModel(service), nil
 }
 
-func (this *ECSServiceManager) getLoadBalancerContainer(ecsLoadBalancerID id.ECSLoadBalancerID, ecsDeployID id.ECSDeployID) (*ecs.LoadBalancer, error) {
+func (this *ECSServiceManager) getLoadBalancerContainer(
+	ecsLoadBalancerID id.ECSLoadBalancerID,
+	ecsDeployID id.ECSDeployID,
+	ecsServiceID id.ECSServiceID,
+	loadBalancerRule models.LoadBalancerRule,
+) (*ecs.LoadBalancer, error) {
 	loadBalancer, err := this.Backend.GetLoadBalancer(ecsLoadBalancerID.L0LoadBalancerID())
 	if err != nil {
 		return nil, err
 	}
 
+	isApplication := loadBalancer.LoadBalancerType == "alb"
+	if !isApplication && loadBalancerRule != (models.LoadBalancerRule{}) {
+		return nil, errors.Newf(errors.InvalidLoadBalancerType, "Load balancer rules can only be used with application load balancers")
+	}
+
 	deploy, err := this.ECS.DescribeTaskDefinition(ecsDeployID.TaskDefinition())
 	if err != nil {
 		return nil, err
 	}
 
+	var hasDynamicPort bool
 	for _, container := range deploy.ContainerDefinitions {
 		for _, containerPortMap := range container.PortMappings {
 			for _, lbPort := range loadBalancer.Ports {
-				if *containerPortMap.HostPort == lbPort.ContainerPort {
-					loadBalancerContainer := ecs.NewLoadBalancer(
+				// application load balancers route to the container port so that
+				// containers can use dynamic host ports
+				if isApplication && *containerPortMap.ContainerPort == lbPort.ContainerPort {
+					targetGroupARN, err := this.createTargetGroup(ecsLoadBalancerID, ecsServiceID, loadBalancer, loadBalancerRule)
+					if err != nil {
+						return nil, err
+					}
+
+					loadBalancerContainer := ecs.NewTargetGroupLoadBalancer(
 						*container.Name,
 						*containerPortMap.ContainerPort,
-						ecsLoadBalancerID.String())
+						targetGroupARN)
 
 					return loadBalancerContainer, nil
 				}
+
+				if !isApplication {
+					hostPort := aws.Int64Value(containerPortMap.HostPort)
+
+					// classic load balancers forward to a fixed instance port, so they cannot
+					// reach containers that use dynamically assigned host ports
+					if hostPort == 0 && *containerPortMap.ContainerPort == lbPort.ContainerPort {
+						hasDynamicPort = true
+						continue
+					}
+
+					if hostPort == lbPort.ContainerPort {
+						loadBalancerContainer := ecs.NewLoadBalancer(
+							*container.Name,
+							*containerPortMap.ContainerPort,
+							ecsLoadBalancerID.String())
+
+						return loadBalancerContainer, nil
+					}
+				}
 			}
 		}
 	}
 
+	if hasDynamicPort {
+		return nil, errors.Newf(errors.InvalidLoadBalancerType, "Containers using dynamic host ports can only be used with application load balancers")
+	}
+
 	return nil, fmt.Errorf("No containers defined that listen on a port that is mapped by the load balancer")
 }
 
+// creates a target group for the service and adds a rule forwarding to it on each of the load balancer's listeners
+func (this *ECSServiceManager) createTargetGroup(
+	ecsLoadBalancerID id.ECSLoadBalancerID,
+	ecsServiceID id.ECSServiceID,
+	loadBalancer *models.LoadBalancer,
+	loadBalancerRule models.LoadBalancerRule,
+) (string, error) {
+	applicationLoadBalancer, err := this.ELBV2.DescribeLoadBalancer(ecsLoadBalancerID.String())
+	if err != nil {
+		return "", err
+	}
+
+	listeners, err := this.ELBV2.DescribeListeners(aws.StringValue(applicationLoadBalancer.LoadBalancerArn))
+	if err != nil {
+		return "", err
+	}
+
+	priority := loadBalancerRule.Priority
+	if priority == 0 {
+		for _, rule := range loadBalancer.Rules {
+			if rule.Priority >= priority {
+				priority = rule.Priority + 1
+			}
+		}
+
+		if priority == 0 {
+			priority = 1
+		}
+	}
+
+	for _, rule := range loadBalancer.Rules {
+		if rule.Priority == priority {
+			return "", errors.Newf(errors.InvalidLoadBalancerRule, "Priority %d is already used by another rule on load balancer '%s'", priority, loadBalancer.LoadBalancerID)
+		}
+	}
+
+	// rules without conditions match all requests
+	pathPattern := loadBalancerRule.PathPattern
+	if pathPattern == "" && loadBalancerRule.HostHeader == "" {
+		pathPattern = "*"
+	}
+
+	targetGroup, err := this.ELBV2.CreateTargetGroup(
+		ecsServiceID.String(),
+		"HTTP",
+		loadBalancer.Ports[0].ContainerPort,
+		config.AWSVPCID(),
+		toTargetGroupHealthCheck(loadBalancer.HealthCheck))
+	if err != nil {
+		return "", err
+	}
+
+	targetGroupARN := aws.StringValue(targetGroup.TargetGroupArn)
+
+	// match the load balancer's connection draining so every service drains the same way
+	if err := this.ELBV2.SetDeregistrationDelay(targetGroupARN, loadBalancer.ConnectionDraining.Timeout); err != nil {
+		if err := this.deleteTargetGroup(ecsServiceID); err != nil {
+			log.Warnf("Failed to clean up target group '%s': %v", ecsServiceID, err)
+		}
+
+		return "", err
+	}
+
+	rule := elbv2.NewRule(int64(priority), loadBalancerRule.HostHeader, pathPattern, targetGroupARN)
+	for _, listener := range listeners {
+		if err := this.ELBV2.CreateRule(aws.StringValue(listener.ListenerArn), rule); err != nil {
+			if err := this.deleteTargetGroup(ecsServiceID); err != nil {
+				log.Warnf("Failed to clean up target group '%s': %v", ecsServiceID, err)
+			}
+
+			if ContainsErrCode(err, "PriorityInUse") {
+				return "", errors.Newf(errors.InvalidLoadBalancerRule, "Priority %d is already used by another rule on load balancer '%s'", priority, loadBalancer.LoadBalancerID)
+			}
+
+			return "", err
+		}
+	}
+
+	return targetGroupARN, nil
+}
+
+// deletes the service's target group and any listener rules that forward to it
+func (this *ECSServiceManager) deleteTargetGroup(ecsServiceID id.ECSServiceID) error {
+	targetGroup, err := this.ELBV2.DescribeTargetGroup(ecsServiceID.String())
+	if err != nil {
+		if ContainsErrCode(err, "TargetGroupNotFound") {
+			return nil
+		}
+
+		return err
+	}
+
+	targetGroupARN := aws.StringValue(targetGroup.TargetGroupArn)
+	for _, loadBalancerARN := range targetGroup.LoadBalancerArns {
+		listeners, err := this.ELBV2.DescribeListeners(aws.StringValue(loadBalancerARN))
+		if err != nil {
+			return err
+		}
+
+		for _, listener := range listeners {
+			rules, err := this.ELBV2.DescribeRules(aws.StringValue(listener.ListenerArn))
+			if err != nil {
+				return err
+			}
+
+			for _, rule := range rules {
+				if rule.TargetGroupARN() == targetGroupARN && !aws.BoolValue(rule.IsDefault) {
+					if err := this.ELBV2.DeleteRule(aws.StringValue(rule.RuleArn)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	// the target group stays in use until ecs has finished draining the service
+	check := func() (bool, error) {
+		if err := this.ELBV2.DeleteTargetGroup(targetGroupARN); err != nil {
+			if ContainsErrCode(err, "ResourceInUse") {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	waiter := waitutils.Waiter{
+		Name:    fmt.Sprintf("TargetGroup delete for '%s'", ecsServiceID),
+		Retries: 50,
+		Delay:   time.Second * 5,
+		Clock:   this.Clock,
+		Check:   check,
+	}
+
+	return waiter.Wait()
+}
+
 func (this *ECSServiceManager) ScaleService(environmentID string, serviceID string, count int) (*models.Service, error) {
 	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
 	ecsServiceID := id.L0ServiceID(serviceID).ECSServiceID()
@@ -320,6 +518,60 @@ func (this *ECSServiceManager) GetServiceLogs(environmentID, serviceID, start, e
 	return GetLogs(this.CloudWatchLogs, taskARNs, start, end, tail)
 }
 
+// GetServiceInstanceTasks returns the ids of the service's running tasks, keyed by the ec2 instance they run on
+func (this *ECSServiceManager) GetServiceInstanceTasks(environmentID, serviceID string) (map[string][]string, error) {
+	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
+	ecsServiceID := id.L0ServiceID(serviceID).ECSServiceID()
+
+	taskARNs, err := this.ECS.ListTasks(ecsEnvironmentID.String(), stringp(ecsServiceID.String()), stringp("RUNNING"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceTasks := map[string][]string{}
+	if len(taskARNs) == 0 {
+		return instanceTasks, nil
+	}
+
+	tasks, err := this.ECS.DescribeTasks(ecsEnvironmentID.String(), taskARNs)
+	if err != nil {
+		return nil, err
+	}
+
+	containerInstanceTasks := map[string][]string{}
+	containerInstanceARNs := []*string{}
+	for _, task := range tasks {
+		containerInstanceARN := aws.StringValue(task.ContainerInstanceArn)
+		if containerInstanceARN == "" {
+			continue
+		}
+
+		if _, ok := containerInstanceTasks[containerInstanceARN]; !ok {
+			containerInstanceARNs = append(containerInstanceARNs, task.ContainerInstanceArn)
+		}
+
+		taskARN := aws.StringValue(task.TaskArn)
+		taskID := taskARN[strings.LastIndex(taskARN, "/")+1:]
+		containerInstanceTasks[containerInstanceARN] = append(containerInstanceTasks[containerInstanceARN], taskID)
+	}
+
+	if len(containerInstanceARNs) == 0 {
+		return instanceTasks, nil
+	}
+
+	containerInstances, err := this.ECS.DescribeContainerInstances(ecsEnvironmentID.String(), containerInstanceARNs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, containerInstance := range containerInstances {
+		instanceID := aws.StringValue(containerInstance.Ec2InstanceId)
+		instanceTasks[instanceID] = containerInstanceTasks[aws.StringValue(containerInstance.ContainerInstanceArn)]
+	}
+
+	return instanceTasks, nil
+}
+
 func (this *ECSServiceManager) populateModel(service *ecs.Service) *models.Service {
 	ecsEnvironmentID := id.ClusterARNToECSEnvironmentID(*service.ClusterArn)
 
@@ -341,10 +593,13 @@ func (this *ECSServiceManager) populateModel(service *ecs.Service) *models.Servi
 		deployments = append(deployments, model)
 	}
 
+	// services behind application load balancers reference a target group instead;
+	// their load balancer id is populated from tags
 	var loadBalancerID string
 	if len(service.LoadBalancers) > 0 {
-		ecsLoadBalancerName := *service.LoadBalancers[0].LoadBalancerName
-		loadBalancerID = id.ECSLoadBalancerID(ecsLoadBalancerName).L0LoadBalancerID()
+		if ecsLoadBalancerName := aws.StringValue(service.LoadBalancers[0].LoadBalancerName); ecsLoadBalancerName != "" {
+			loadBalancerID = id.ECSLoadBalancerID(ecsLoadBalancerName).L0LoadBalancerID()
+		}
 	}
 
 	return &models.Service{
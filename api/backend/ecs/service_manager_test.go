@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	aws_ecs "github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/golang/mock/gomock"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
@@ -13,6 +14,7 @@ import (
 	"github.com/quintilesims/layer0/common/aws/ec2/mock_ec2"
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/aws/ecs/mock_ecs"
+	"github.com/quintilesims/layer0/common/aws/elbv2/mock_elbv2"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +23,7 @@ import (
 type MockECSServiceManager struct {
 	ECS            *mock_ecs.MockProvider
 	EC2            *mock_ec2.MockProvider
+	ELBV2          *mock_elbv2.MockProvider
 	CloudWatchLogs *mock_cloudwatchlogs.MockProvider
 	Backend        *mock_backend.MockBackend
 }
@@ -29,13 +32,14 @@ func NewMockECSServiceManager(ctrl *gomock.Controller) *MockECSServiceManager {
 	return &MockECSServiceManager{
 		ECS:            mock_ecs.NewMockProvider(ctrl),
 		EC2:            mock_ec2.NewMockProvider(ctrl),
+		ELBV2:          mock_elbv2.NewMockProvider(ctrl),
 		CloudWatchLogs: mock_cloudwatchlogs.NewMockProvider(ctrl),
 		Backend:        mock_backend.NewMockBackend(ctrl),
 	}
 }
 
 func (this *MockECSServiceManager) Service() *ECSServiceManager {
-	return NewECSServiceManager(this.ECS, this.EC2, this.CloudWatchLogs, this.Backend)
+	return NewECSServiceManager(this.ECS, this.EC2, this.ELBV2, this.CloudWatchLogs, this.Backend)
 }
 
 func TestGetService(t *testing.T) {
@@ -169,6 +173,10 @@ func TestDeleteService(t *testing.T) {
 					DeleteService(environmentID.String(), serviceID.String()).
 					Return(nil)
 
+				mockService.ELBV2.EXPECT().
+					DescribeTargetGroup(serviceID.String()).
+					Return(nil, awserr.New("TargetGroupNotFound", "", nil))
+
 				return mockService.Service()
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
@@ -262,7 +270,7 @@ func TestCreateService(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSServiceManager)
-				manager.CreateService("svc_name", "envid", "dplyid.1", "")
+				manager.CreateService("svc_name", "envid", "dplyid.1", "", models.LoadBalancerRule{})
 			},
 		},
 		{
@@ -297,12 +305,81 @@ func TestCreateService(t *testing.T) {
 					g.Set(i+1, fmt.Errorf("some eror"))
 
 					manager := setup(g)
-					if _, err := manager.CreateService("svc_name", "envid", "dplyid.1", ""); err == nil {
+					if _, err := manager.CreateService("svc_name", "envid", "dplyid.1", "", models.LoadBalancerRule{}); err == nil {
 						reporter.Errorf("Error on variation %d, Error was nil!", i)
 					}
 				}
 			},
 		},
+		{
+			Name: "Should error when using a load balancer rule with a classic load balancer",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockService := NewMockECSServiceManager(ctrl)
+
+				loadBalancer := &models.LoadBalancer{
+					LoadBalancerID:   "lbid",
+					LoadBalancerType: "elb",
+				}
+
+				mockService.Backend.EXPECT().
+					GetLoadBalancer("lbid").
+					Return(loadBalancer, nil)
+
+				return mockService.Service()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSServiceManager)
+
+				rule := models.LoadBalancerRule{PathPattern: "/api/*"}
+				if _, err := manager.CreateService("svc_name", "envid", "dplyid.1", "lbid", rule); err == nil {
+					reporter.Fatalf("Error was nil!")
+				}
+			},
+		},
+		{
+			Name: "Should error when using dynamic host ports with a classic load balancer",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockService := NewMockECSServiceManager(ctrl)
+
+				deployID := id.L0DeployID("dplyid.1").ECSDeployID()
+
+				loadBalancer := &models.LoadBalancer{
+					LoadBalancerID:   "lbid",
+					LoadBalancerType: "elb",
+					Ports:            []models.Port{{HostPort: 80, ContainerPort: 80, Protocol: "http"}},
+				}
+
+				mockService.Backend.EXPECT().
+					GetLoadBalancer("lbid").
+					Return(loadBalancer, nil)
+
+				task := &ecs.TaskDefinition{
+					&aws_ecs.TaskDefinition{
+						ContainerDefinitions: []*aws_ecs.ContainerDefinition{
+							{
+								Name: stringp("web"),
+								PortMappings: []*aws_ecs.PortMapping{
+									{ContainerPort: int64p(80)},
+								},
+							},
+						},
+					},
+				}
+
+				mockService.ECS.EXPECT().
+					DescribeTaskDefinition(deployID.TaskDefinition()).
+					Return(task, nil)
+
+				return mockService.Service()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSServiceManager)
+
+				if _, err := manager.CreateService("svc_name", "envid", "dplyid.1", "lbid", models.LoadBalancerRule{}); err == nil {
+					reporter.Fatalf("Error was nil!")
+				}
+			},
+		},
 	}
 
 	testutils.RunTests(t, testCases)
@@ -532,3 +609,71 @@ func TestGetServiceLogs(t *testing.T) {
 
 	testutils.RunTests(t, testCases)
 }
+
+func TestGetServiceInstanceTasks(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should map running tasks to the ec2 instances they run on",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockService := NewMockECSServiceManager(ctrl)
+
+				environmentID := id.L0EnvironmentID("envid").ECSEnvironmentID()
+				clusterARN := fmt.Sprintf("arn:aws:ecs:region:aws_account_id:cluster/%s", environmentID.String())
+				serviceID := id.L0ServiceID("svcid").ECSServiceID()
+
+				taskARNs := []*string{
+					stringp("arn:aws:ecs:region:aws_account_id:task/t1"),
+					stringp("arn:aws:ecs:region:aws_account_id:task/t2"),
+					stringp("arn:aws:ecs:region:aws_account_id:task/t3"),
+				}
+
+				mockService.ECS.EXPECT().
+					ListTasks(environmentID.String(), stringp(serviceID.String()), stringp("RUNNING"), nil, nil).
+					Return(taskARNs, nil)
+
+				tasks := []*ecs.Task{}
+				for i, containerInstanceARN := range []string{"ci1", "ci1", "ci2"} {
+					task := ecs.NewTask(clusterARN, "", "")
+					task.TaskArn = taskARNs[i]
+					task.ContainerInstanceArn = stringp(containerInstanceARN)
+					tasks = append(tasks, task)
+				}
+
+				mockService.ECS.EXPECT().
+					DescribeTasks(environmentID.String(), taskARNs).
+					Return(tasks, nil)
+
+				containerInstances := []*ecs.ContainerInstance{}
+				for containerInstanceARN, instanceID := range map[string]string{"ci1": "i-1", "ci2": "i-2"} {
+					containerInstance := ecs.NewContainerInstance(true, 0, 0, nil, nil)
+					containerInstance.ContainerInstanceArn = stringp(containerInstanceARN)
+					containerInstance.Ec2InstanceId = stringp(instanceID)
+					containerInstances = append(containerInstances, containerInstance)
+				}
+
+				mockService.ECS.EXPECT().
+					DescribeContainerInstances(environmentID.String(), []*string{stringp("ci1"), stringp("ci2")}).
+					Return(containerInstances, nil)
+
+				return mockService.Service()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSServiceManager)
+
+				instanceTasks, err := manager.GetServiceInstanceTasks("envid", "svcid")
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				expected := map[string][]string{
+					"i-1": {"t1", "t2"},
+					"i-2": {"t3"},
+				}
+
+				reporter.AssertEqual(instanceTasks, expected)
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
@@ -1,11 +1,16 @@
 package ecsbackend
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs"
+	"github.com/quintilesims/layer0/common/aws/ec2"
 	"github.com/quintilesims/layer0/common/aws/ecs"
+	"github.com/quintilesims/layer0/common/docker"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 )
@@ -17,18 +22,24 @@ const (
 
 type ECSTaskManager struct {
 	ECS            ecs.Provider
+	EC2            ec2.Provider
 	CloudWatchLogs cloudwatchlogs.Provider
+	Docker         docker.Factory
 	Backend        backend.Backend
 }
 
 func NewECSTaskManager(
 	ecsProvider ecs.Provider,
+	ec2Provider ec2.Provider,
 	cloudWatchLogsProvider cloudwatchlogs.Provider,
+	dockerFactory docker.Factory,
 	backend backend.Backend,
 ) *ECSTaskManager {
 	return &ECSTaskManager{
 		ECS:            ecsProvider,
+		EC2:            ec2Provider,
 		CloudWatchLogs: cloudWatchLogsProvider,
+		Docker:         dockerFactory,
 		Backend:        backend,
 	}
 }
@@ -98,6 +109,22 @@ func (this *ECSTaskManager) CreateTask(
 	ecsOverrides := []*ecs.ContainerOverride{}
 	for _, override := range overrides {
 		o := ecs.NewContainerOverride(override.ContainerName, override.EnvironmentOverrides)
+		if len(override.Command) > 0 {
+			o.Command = aws.StringSlice(override.Command)
+		}
+
+		if override.CPU != 0 {
+			o.Cpu = aws.Int64(override.CPU)
+		}
+
+		if override.Memory != 0 {
+			o.Memory = aws.Int64(override.Memory)
+		}
+
+		if override.MemoryReservation != 0 {
+			o.MemoryReservation = aws.Int64(override.MemoryReservation)
+		}
+
 		ecsOverrides = append(ecsOverrides, o)
 	}
 
@@ -114,6 +141,118 @@ func (this *ECSTaskManager) GetTaskLogs(environmentID, taskARN, start, end strin
 	return GetLogs(this.CloudWatchLogs, []*string{stringp(taskARN)}, start, end, tail)
 }
 
+func (this *ECSTaskManager) ExecTask(
+	environmentID string,
+	taskARN string,
+	req models.ExecRequest,
+	streams models.ExecStreams,
+) (int, error) {
+	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
+	task, err := this.ECS.DescribeTask(ecsEnvironmentID.String(), taskARN)
+	if err != nil {
+		return 0, err
+	}
+
+	if status := aws.StringValue(task.LastStatus); status != "RUNNING" {
+		return 0, errors.Newf(errors.InvalidExecRequest, "Task is not running (status: %s)", status)
+	}
+
+	containerName, err := execContainerName(task, req.ContainerName)
+	if err != nil {
+		return 0, err
+	}
+
+	containerInstances, err := this.ECS.DescribeContainerInstances(ecsEnvironmentID.String(), []*string{task.ContainerInstanceArn})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(containerInstances) == 0 {
+		return 0, fmt.Errorf("Failed to find the container instance running task %s", taskARN)
+	}
+
+	instanceID := aws.StringValue(containerInstances[0].Ec2InstanceId)
+	instance, err := this.EC2.DescribeInstance(instanceID)
+	if err != nil {
+		return 0, err
+	}
+
+	if instance == nil {
+		return 0, fmt.Errorf("Instance %s does not exist", instanceID)
+	}
+
+	dockerProvider, err := this.Docker(aws.StringValue(instance.PrivateIpAddress))
+	if err != nil {
+		return 0, err
+	}
+
+	containerID, err := dockerProvider.GetTaskContainerID(aws.StringValue(task.TaskArn), containerName)
+	if err != nil {
+		return 0, err
+	}
+
+	return dockerProvider.Exec(containerID, req.Command, req.TTY, streams)
+}
+
+// execContainerName returns the container to exec into; it may only be omitted if the task runs a single container
+func execContainerName(task *ecs.Task, containerName string) (string, error) {
+	names := []string{}
+	for _, container := range task.Containers {
+		name := aws.StringValue(container.Name)
+		if name == containerName {
+			return name, nil
+		}
+
+		names = append(names, name)
+	}
+
+	if containerName == "" && len(names) == 1 {
+		return names[0], nil
+	}
+
+	if containerName == "" {
+		return "", errors.Newf(errors.InvalidExecRequest, "Task has multiple containers, please specify one of: %s", strings.Join(names, ", "))
+	}
+
+	return "", errors.Newf(errors.InvalidExecRequest, "Task has no container named '%s' (containers: %s)", containerName, strings.Join(names, ", "))
+}
+
+// ApplyContainerOverrides returns a copy of dockerrun with the command, cpu and memory of each
+// container replaced by the values in its override, as ECS does when the task is run
+func ApplyContainerOverrides(dockerrun *models.Dockerrun, overrides []models.ContainerOverride) *models.Dockerrun {
+	result := *dockerrun
+	result.ContainerDefinitions = make([]*ecs.ContainerDefinition, len(dockerrun.ContainerDefinitions))
+
+	for i, container := range dockerrun.ContainerDefinitions {
+		definition := *container.ContainerDefinition
+		for _, override := range overrides {
+			if override.ContainerName != aws.StringValue(definition.Name) {
+				continue
+			}
+
+			if len(override.Command) > 0 {
+				definition.Command = aws.StringSlice(override.Command)
+			}
+
+			if override.CPU != 0 {
+				definition.Cpu = aws.Int64(override.CPU)
+			}
+
+			if override.Memory != 0 {
+				definition.Memory = aws.Int64(override.Memory)
+			}
+
+			if override.MemoryReservation != 0 {
+				definition.MemoryReservation = aws.Int64(override.MemoryReservation)
+			}
+		}
+
+		result.ContainerDefinitions[i] = &ecs.ContainerDefinition{&definition}
+	}
+
+	return &result
+}
+
 // Assumes the tasks are all of the same type
 func modelFromTasks(tasks []*ecs.Task) (*models.Task, error) {
 	if len(tasks) == 0 {
@@ -144,7 +283,10 @@ func modelFromTasks(tasks []*ecs.Task) (*models.Task, error) {
 
 		copy := models.TaskCopy{
 			Details:    details,
+			LastStatus: aws.StringValue(task.LastStatus),
 			Reason:     stringOrEmpty(task.StoppedReason),
+			StartedAt:  aws.TimeValue(task.StartedAt),
+			StoppedAt:  aws.TimeValue(task.StoppedAt),
 			TaskCopyID: stringOrEmpty(task.TaskArn),
 		}
 
@@ -3,6 +3,7 @@ package ecsbackend
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	aws_ecs "github.com/aws/aws-sdk-go/service/ecs"
@@ -11,8 +12,12 @@ import (
 	"github.com/quintilesims/layer0/api/backend/mock_backend"
 	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs"
 	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs/mock_cloudwatchlogs"
+	"github.com/quintilesims/layer0/common/aws/ec2"
+	"github.com/quintilesims/layer0/common/aws/ec2/mock_ec2"
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/aws/ecs/mock_ecs"
+	"github.com/quintilesims/layer0/common/docker"
+	"github.com/quintilesims/layer0/common/docker/mock_docker"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 	"github.com/stretchr/testify/assert"
@@ -20,20 +25,30 @@ import (
 
 type MockECSTaskManager struct {
 	ECS            *mock_ecs.MockProvider
+	EC2            *mock_ec2.MockProvider
 	CloudWatchLogs *mock_cloudwatchlogs.MockProvider
+	Docker         *mock_docker.MockProvider
+	DockerHosts    []string
 	Backend        *mock_backend.MockBackend
 }
 
 func NewMockECSTaskManager(ctrl *gomock.Controller) *MockECSTaskManager {
 	return &MockECSTaskManager{
 		ECS:            mock_ecs.NewMockProvider(ctrl),
+		EC2:            mock_ec2.NewMockProvider(ctrl),
 		CloudWatchLogs: mock_cloudwatchlogs.NewMockProvider(ctrl),
+		Docker:         mock_docker.NewMockProvider(ctrl),
 		Backend:        mock_backend.NewMockBackend(ctrl),
 	}
 }
 
 func (this *MockECSTaskManager) Task() *ECSTaskManager {
-	taskManager := NewECSTaskManager(this.ECS, this.CloudWatchLogs, this.Backend)
+	dockerFactory := func(host string) (docker.Provider, error) {
+		this.DockerHosts = append(this.DockerHosts, host)
+		return this.Docker, nil
+	}
+
+	taskManager := NewECSTaskManager(this.ECS, this.EC2, this.CloudWatchLogs, dockerFactory, this.Backend)
 	return taskManager
 }
 
@@ -63,6 +78,65 @@ func TestGetTask(t *testing.T) {
 	assert.Len(t, result.Copies, 1)
 }
 
+func TestGetTask_stopped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	startedAt := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	stoppedAt := startedAt.Add(time.Minute)
+
+	environmentID := id.L0EnvironmentID("env_id")
+	task := &ecs.Task{
+		&aws_ecs.Task{
+			LastStatus:    aws.String("STOPPED"),
+			StartedAt:     aws.Time(startedAt),
+			StoppedAt:     aws.Time(stoppedAt),
+			StoppedReason: aws.String("Essential container in task exited"),
+			TaskArn:       aws.String("task_arn"),
+			Containers: []*aws_ecs.Container{
+				{
+					Name:       aws.String("migrate"),
+					LastStatus: aws.String("STOPPED"),
+					ExitCode:   aws.Int64(1),
+					Reason:     aws.String("OutOfMemoryError"),
+				},
+			},
+		},
+	}
+
+	mockTask := NewMockECSTaskManager(ctrl)
+	mockTask.ECS.EXPECT().
+		DescribeTask(environmentID.ECSEnvironmentID().String(), "task_arn").
+		Return(task, nil)
+
+	result, err := mockTask.Task().GetTask("env_id", "task_arn")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []models.TaskCopy{
+		{
+			Details: []models.TaskDetail{
+				{
+					ContainerName: "migrate",
+					ExitCode:      1,
+					LastStatus:    "STOPPED",
+					Reason:        "OutOfMemoryError",
+				},
+			},
+			LastStatus: "STOPPED",
+			Reason:     "Essential container in task exited",
+			StartedAt:  startedAt,
+			StoppedAt:  stoppedAt,
+			TaskCopyID: "task_arn",
+		},
+	}
+
+	assert.Equal(t, int64(0), result.RunningCount)
+	assert.Equal(t, int64(0), result.PendingCount)
+	assert.Equal(t, expected, result.Copies)
+}
+
 func TestListTasks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -138,6 +212,70 @@ func TestCreateTask(t *testing.T) {
 	assert.Equal(t, "tsk_arn", result)
 }
 
+func TestCreateTask_overrides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	task := &ecs.Task{&aws_ecs.Task{
+		TaskArn: aws.String("tsk_arn"),
+	}}
+
+	ecsEnvironmentID := id.L0EnvironmentID("env_id").ECSEnvironmentID()
+	ecsDeployID := id.L0DeployID("dpl_id.1").ECSDeployID()
+
+	expected := []*ecs.ContainerOverride{
+		{&aws_ecs.ContainerOverride{
+			Name:              aws.String("api"),
+			Environment:       []*aws_ecs.KeyValuePair{},
+			Command:           aws.StringSlice([]string{"python", "manage.py", "migrate"}),
+			Cpu:               aws.Int64(256),
+			Memory:            aws.Int64(1024),
+			MemoryReservation: aws.Int64(512),
+		}},
+	}
+
+	mockTask := NewMockECSTaskManager(ctrl)
+	mockTask.ECS.EXPECT().
+		RunTask(ecsEnvironmentID.String(), ecsDeployID.TaskDefinition(), id.PREFIX, expected).
+		Return(task, nil)
+
+	overrides := []models.ContainerOverride{
+		{
+			ContainerName:     "api",
+			Command:           []string{"python", "manage.py", "migrate"},
+			CPU:               256,
+			Memory:            1024,
+			MemoryReservation: 512,
+		},
+	}
+
+	if _, err := mockTask.Task().CreateTask("env_id", "dpl_id.1", overrides); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyContainerOverrides(t *testing.T) {
+	dockerrun := &models.Dockerrun{
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{&aws_ecs.ContainerDefinition{Name: aws.String("api"), Memory: aws.Int64(128)}},
+			{&aws_ecs.ContainerDefinition{Name: aws.String("worker"), Memory: aws.Int64(256)}},
+		},
+	}
+
+	overrides := []models.ContainerOverride{
+		{ContainerName: "api", Memory: 1024, Command: []string{"migrate"}},
+	}
+
+	result := ApplyContainerOverrides(dockerrun, overrides)
+
+	assert.Equal(t, int64(1024), aws.Int64Value(result.ContainerDefinitions[0].Memory))
+	assert.Equal(t, []string{"migrate"}, aws.StringValueSlice(result.ContainerDefinitions[0].Command))
+	assert.Equal(t, int64(256), aws.Int64Value(result.ContainerDefinitions[1].Memory))
+
+	// the original dockerrun should not be modified
+	assert.Equal(t, int64(128), aws.Int64Value(dockerrun.ContainerDefinitions[0].Memory))
+}
+
 func TestGetTaskLogs(t *testing.T) {
 	tmp := GetLogs
 	defer func() { GetLogs = tmp }()
@@ -207,3 +345,120 @@ func TestGetTaskLogs(t *testing.T) {
 
 	testutils.RunTests(t, testCases)
 }
+
+func TestExecTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	environmentID := id.L0EnvironmentID("env_id").ECSEnvironmentID()
+	task := &ecs.Task{
+		&aws_ecs.Task{
+			TaskArn:              aws.String("task_arn"),
+			LastStatus:           aws.String("RUNNING"),
+			ContainerInstanceArn: aws.String("container_instance_arn"),
+			Containers: []*aws_ecs.Container{
+				{Name: aws.String("api")},
+				{Name: aws.String("worker")},
+			},
+		},
+	}
+
+	containerInstance := &ecs.ContainerInstance{
+		&aws_ecs.ContainerInstance{
+			Ec2InstanceId: aws.String("instance_id"),
+		},
+	}
+
+	instance := ec2.NewInstance()
+	instance.PrivateIpAddress = aws.String("10.0.0.1")
+
+	mockTask := NewMockECSTaskManager(ctrl)
+	mockTask.ECS.EXPECT().
+		DescribeTask(environmentID.String(), "task_arn").
+		Return(task, nil)
+
+	mockTask.ECS.EXPECT().
+		DescribeContainerInstances(environmentID.String(), []*string{aws.String("container_instance_arn")}).
+		Return([]*ecs.ContainerInstance{containerInstance}, nil)
+
+	mockTask.EC2.EXPECT().
+		DescribeInstance("instance_id").
+		Return(instance, nil)
+
+	mockTask.Docker.EXPECT().
+		GetTaskContainerID("task_arn", "worker").
+		Return("container_id", nil)
+
+	streams := models.ExecStreams{}
+	mockTask.Docker.EXPECT().
+		Exec("container_id", []string{"sh"}, true, streams).
+		Return(3, nil)
+
+	req := models.ExecRequest{
+		Command:       []string{"sh"},
+		ContainerName: "worker",
+		TTY:           true,
+	}
+
+	exitCode, err := mockTask.Task().ExecTask("env_id", "task_arn", req, streams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 3, exitCode)
+	assert.Equal(t, []string{"10.0.0.1"}, mockTask.DockerHosts)
+}
+
+func TestExecTask_notRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	task := &ecs.Task{
+		&aws_ecs.Task{
+			LastStatus: aws.String("STOPPED"),
+		},
+	}
+
+	mockTask := NewMockECSTaskManager(ctrl)
+	mockTask.ECS.EXPECT().
+		DescribeTask(gomock.Any(), "task_arn").
+		Return(task, nil)
+
+	req := models.ExecRequest{Command: []string{"sh"}}
+	if _, err := mockTask.Task().ExecTask("env_id", "task_arn", req, models.ExecStreams{}); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
+
+func TestExecContainerName(t *testing.T) {
+	newTask := func(names ...string) *ecs.Task {
+		task := &ecs.Task{&aws_ecs.Task{}}
+		for _, name := range names {
+			task.Containers = append(task.Containers, &aws_ecs.Container{Name: aws.String(name)})
+		}
+
+		return task
+	}
+
+	name, err := execContainerName(newTask("api"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "api", name)
+
+	name, err = execContainerName(newTask("api", "worker"), "worker")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "worker", name)
+
+	if _, err := execContainerName(newTask("api", "worker"), ""); err == nil {
+		t.Fatal("Error was nil for ambiguous container")
+	}
+
+	if _, err := execContainerName(newTask("api"), "worker"); err == nil {
+		t.Fatal("Error was nil for missing container")
+	}
+}
@@ -124,12 +124,25 @@ func IteratePages(fn paginatedf) error {
 	return nil
 }
 
-var CreateRenderedDockerrun = func(body []byte) (*models.Dockerrun, error) {
+var CreateRenderedDockerrun = func(body []byte, variables map[string]string) (*models.Dockerrun, error) {
+	if variables != nil {
+		rendered, err := RenderDockerrunTemplate(body, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		body = rendered
+	}
+
 	dockerrun, err := MarshalDockerrun(body)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := addSecretsContainer(dockerrun); err != nil {
+		return nil, err
+	}
+
 	for _, container := range dockerrun.ContainerDefinitions {
 		if container.LogConfiguration == nil {
 			container.LogConfiguration = &awsecs.LogConfiguration{
@@ -223,3 +236,13 @@ func generateTaskIDCatalog(taskARNs []*string) map[string]bool {
 
 	return catalog
 }
+
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -11,22 +11,24 @@ type Backend interface {
 	DeleteEnvironment(environmentID string) error
 	GetEnvironment(environmentID string) (*models.Environment, error)
 	ListEnvironments() ([]id.ECSEnvironmentID, error)
-	CreateEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error
-	DeleteEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error
+	CreateEnvironmentLink(environmentID string, link models.EnvironmentLink) error
+	DeleteEnvironmentLink(environmentID string, link models.EnvironmentLink) error
 
 	ListDeploys() ([]*models.Deploy, error)
 	GetDeploy(deployID string) (*models.Deploy, error)
-	CreateDeploy(name string, body []byte) (*models.Deploy, error)
+	CreateDeploy(name string, body []byte, variables map[string]string) (*models.Deploy, error)
 	DeleteDeploy(deployID string) error
+	ValidateDeploy(deployName string, body []byte, environmentID string) (*models.DeployValidation, error)
 
 	ListServices() ([]id.ECSServiceID, error)
 	GetService(environmentID, serviceID string) (*models.Service, error)
 	GetEnvironmentServices(environmentID string) ([]*models.Service, error)
-	CreateService(serviceName, environmentID, deployID, loadBalancerID string) (*models.Service, error)
+	CreateService(serviceName, environmentID, deployID, loadBalancerID string, loadBalancerRule models.LoadBalancerRule) (*models.Service, error)
 	DeleteService(environmentID, serviceID string) error
 	ScaleService(environmentID, serviceID string, count int) (*models.Service, error)
 	UpdateService(environmentID, serviceID, deployID string) (*models.Service, error)
 	GetServiceLogs(environmentID, serviceID, start, end string, tail int) ([]*models.LogFile, error)
+	GetServiceInstanceTasks(environmentID, serviceID string) (map[string][]string, error)
 
 	CreateTask(environmentID, deployID string, overrides []models.ContainerOverride) (string, error)
 	ListTasks() ([]string, error)
@@ -34,13 +36,21 @@ type Backend interface {
 	GetEnvironmentTasks(environmentID string) (map[string]*models.Task, error)
 	DeleteTask(environmentID, taskARN string) error
 	GetTaskLogs(environmentID, taskARN, start, end string, tail int) ([]*models.LogFile, error)
+	ExecTask(environmentID, taskARN string, req models.ExecRequest, streams models.ExecStreams) (int, error)
 
 	ListLoadBalancers() ([]*models.LoadBalancer, error)
 	GetLoadBalancer(id string) (*models.LoadBalancer, error)
 	DeleteLoadBalancer(id string) error
-	CreateLoadBalancer(loadBalancerName, environmentID string, isPublic bool, ports []models.Port, healthCheck models.HealthCheck, idleTimeout int, crossZone bool) (*models.LoadBalancer, error)
+	CreateLoadBalancer(loadBalancerName, environmentID, loadBalancerType string, isPublic bool, ports []models.Port, healthCheck models.HealthCheck, idleTimeout int, crossZone bool) (*models.LoadBalancer, error)
 	UpdateLoadBalancerPorts(loadBalancerID string, ports []models.Port) (*models.LoadBalancer, error)
 	UpdateLoadBalancerHealthCheck(loadBalancerID string, healthCheck models.HealthCheck) (*models.LoadBalancer, error)
 	UpdateLoadBalancerIdleTimeout(loadBalancerID string, idleTimeout int) (*models.LoadBalancer, error)
 	UpdateLoadBalancerCrossZone(loadBalancerID string, crossZone bool) (*models.LoadBalancer, error)
+	UpdateLoadBalancerAccessLogs(loadBalancerID string, accessLogs models.AccessLogs) (*models.LoadBalancer, error)
+	UpdateLoadBalancerConnectionDraining(loadBalancerID string, connectionDraining models.ConnectionDraining) (*models.LoadBalancer, error)
+	GetLoadBalancerHealth(loadBalancerID string) (*models.LoadBalancerHealth, error)
+
+	ListSecrets(environmentID string) ([]*models.Secret, error)
+	CreateSecret(environmentID, secretName, value string) (*models.Secret, error)
+	DeleteSecret(environmentID, secretName string) error
 }
@@ -35,16 +35,16 @@ func (m *MockBackend) EXPECT() *MockBackendMockRecorder {
 }
 
 // CreateDeploy mocks base method
-func (m *MockBackend) CreateDeploy(arg0 string, arg1 []byte) (*models.Deploy, error) {
-	ret := m.ctrl.Call(m, "CreateDeploy", arg0, arg1)
+func (m *MockBackend) CreateDeploy(arg0 string, arg1 []byte, arg2 map[string]string) (*models.Deploy, error) {
+	ret := m.ctrl.Call(m, "CreateDeploy", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*models.Deploy)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateDeploy indicates an expected call of CreateDeploy
-func (mr *MockBackendMockRecorder) CreateDeploy(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeploy", reflect.TypeOf((*MockBackend)(nil).CreateDeploy), arg0, arg1)
+func (mr *MockBackendMockRecorder) CreateDeploy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeploy", reflect.TypeOf((*MockBackend)(nil).CreateDeploy), arg0, arg1, arg2)
 }
 
 // CreateEnvironment mocks base method
@@ -61,7 +61,7 @@ func (mr *MockBackendMockRecorder) CreateEnvironment(arg0, arg1, arg2, arg3, arg
 }
 
 // CreateEnvironmentLink mocks base method
-func (m *MockBackend) CreateEnvironmentLink(arg0, arg1 string) error {
+func (m *MockBackend) CreateEnvironmentLink(arg0 string, arg1 models.EnvironmentLink) error {
 	ret := m.ctrl.Call(m, "CreateEnvironmentLink", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -73,29 +73,42 @@ func (mr *MockBackendMockRecorder) CreateEnvironmentLink(arg0, arg1 interface{})
 }
 
 // CreateLoadBalancer mocks base method
-func (m *MockBackend) CreateLoadBalancer(arg0, arg1 string, arg2 bool, arg3 []models.Port, arg4 models.HealthCheck, arg5 int, arg6 bool) (*models.LoadBalancer, error) {
-	ret := m.ctrl.Call(m, "CreateLoadBalancer", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+func (m *MockBackend) CreateLoadBalancer(arg0, arg1, arg2 string, arg3 bool, arg4 []models.Port, arg5 models.HealthCheck, arg6 int, arg7 bool) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "CreateLoadBalancer", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	ret0, _ := ret[0].(*models.LoadBalancer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateLoadBalancer indicates an expected call of CreateLoadBalancer
-func (mr *MockBackendMockRecorder) CreateLoadBalancer(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancer", reflect.TypeOf((*MockBackend)(nil).CreateLoadBalancer), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+func (mr *MockBackendMockRecorder) CreateLoadBalancer(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancer", reflect.TypeOf((*MockBackend)(nil).CreateLoadBalancer), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// CreateSecret mocks base method
+func (m *MockBackend) CreateSecret(arg0, arg1, arg2 string) (*models.Secret, error) {
+	ret := m.ctrl.Call(m, "CreateSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecret indicates an expected call of CreateSecret
+func (mr *MockBackendMockRecorder) CreateSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockBackend)(nil).CreateSecret), arg0, arg1, arg2)
 }
 
 // CreateService mocks base method
-func (m *MockBackend) CreateService(arg0, arg1, arg2, arg3 string) (*models.Service, error) {
-	ret := m.ctrl.Call(m, "CreateService", arg0, arg1, arg2, arg3)
+func (m *MockBackend) CreateService(arg0, arg1, arg2, arg3 string, arg4 models.LoadBalancerRule) (*models.Service, error) {
+	ret := m.ctrl.Call(m, "CreateService", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(*models.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateService indicates an expected call of CreateService
-func (mr *MockBackendMockRecorder) CreateService(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockBackend)(nil).CreateService), arg0, arg1, arg2, arg3)
+func (mr *MockBackendMockRecorder) CreateService(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockBackend)(nil).CreateService), arg0, arg1, arg2, arg3, arg4)
 }
 
 // CreateTask mocks base method
@@ -136,7 +149,7 @@ func (mr *MockBackendMockRecorder) DeleteEnvironment(arg0 interface{}) *gomock.C
 }
 
 // DeleteEnvironmentLink mocks base method
-func (m *MockBackend) DeleteEnvironmentLink(arg0, arg1 string) error {
+func (m *MockBackend) DeleteEnvironmentLink(arg0 string, arg1 models.EnvironmentLink) error {
 	ret := m.ctrl.Call(m, "DeleteEnvironmentLink", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -159,6 +172,18 @@ func (mr *MockBackendMockRecorder) DeleteLoadBalancer(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancer", reflect.TypeOf((*MockBackend)(nil).DeleteLoadBalancer), arg0)
 }
 
+// DeleteSecret mocks base method
+func (m *MockBackend) DeleteSecret(arg0, arg1 string) error {
+	ret := m.ctrl.Call(m, "DeleteSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret
+func (mr *MockBackendMockRecorder) DeleteSecret(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockBackend)(nil).DeleteSecret), arg0, arg1)
+}
+
 // DeleteService mocks base method
 func (m *MockBackend) DeleteService(arg0, arg1 string) error {
 	ret := m.ctrl.Call(m, "DeleteService", arg0, arg1)
@@ -183,6 +208,19 @@ func (mr *MockBackendMockRecorder) DeleteTask(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockBackend)(nil).DeleteTask), arg0, arg1)
 }
 
+// ExecTask mocks base method
+func (m *MockBackend) ExecTask(arg0, arg1 string, arg2 models.ExecRequest, arg3 models.ExecStreams) (int, error) {
+	ret := m.ctrl.Call(m, "ExecTask", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecTask indicates an expected call of ExecTask
+func (mr *MockBackendMockRecorder) ExecTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTask", reflect.TypeOf((*MockBackend)(nil).ExecTask), arg0, arg1, arg2, arg3)
+}
+
 // GetDeploy mocks base method
 func (m *MockBackend) GetDeploy(arg0 string) (*models.Deploy, error) {
 	ret := m.ctrl.Call(m, "GetDeploy", arg0)
@@ -248,6 +286,19 @@ func (mr *MockBackendMockRecorder) GetLoadBalancer(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancer", reflect.TypeOf((*MockBackend)(nil).GetLoadBalancer), arg0)
 }
 
+// GetLoadBalancerHealth mocks base method
+func (m *MockBackend) GetLoadBalancerHealth(arg0 string) (*models.LoadBalancerHealth, error) {
+	ret := m.ctrl.Call(m, "GetLoadBalancerHealth", arg0)
+	ret0, _ := ret[0].(*models.LoadBalancerHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancerHealth indicates an expected call of GetLoadBalancerHealth
+func (mr *MockBackendMockRecorder) GetLoadBalancerHealth(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerHealth", reflect.TypeOf((*MockBackend)(nil).GetLoadBalancerHealth), arg0)
+}
+
 // GetService mocks base method
 func (m *MockBackend) GetService(arg0, arg1 string) (*models.Service, error) {
 	ret := m.ctrl.Call(m, "GetService", arg0, arg1)
@@ -261,6 +312,19 @@ func (mr *MockBackendMockRecorder) GetService(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*MockBackend)(nil).GetService), arg0, arg1)
 }
 
+// GetServiceInstanceTasks mocks base method
+func (m *MockBackend) GetServiceInstanceTasks(arg0, arg1 string) (map[string][]string, error) {
+	ret := m.ctrl.Call(m, "GetServiceInstanceTasks", arg0, arg1)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceInstanceTasks indicates an expected call of GetServiceInstanceTasks
+func (mr *MockBackendMockRecorder) GetServiceInstanceTasks(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceInstanceTasks", reflect.TypeOf((*MockBackend)(nil).GetServiceInstanceTasks), arg0, arg1)
+}
+
 // GetServiceLogs mocks base method
 func (m *MockBackend) GetServiceLogs(arg0, arg1, arg2, arg3 string, arg4 int) ([]*models.LogFile, error) {
 	ret := m.ctrl.Call(m, "GetServiceLogs", arg0, arg1, arg2, arg3, arg4)
@@ -339,6 +403,19 @@ func (mr *MockBackendMockRecorder) ListLoadBalancers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancers", reflect.TypeOf((*MockBackend)(nil).ListLoadBalancers))
 }
 
+// ListSecrets mocks base method
+func (m *MockBackend) ListSecrets(arg0 string) ([]*models.Secret, error) {
+	ret := m.ctrl.Call(m, "ListSecrets", arg0)
+	ret0, _ := ret[0].([]*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets
+func (mr *MockBackendMockRecorder) ListSecrets(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockBackend)(nil).ListSecrets), arg0)
+}
+
 // ListServices mocks base method
 func (m *MockBackend) ListServices() ([]id.ECSServiceID, error) {
 	ret := m.ctrl.Call(m, "ListServices")
@@ -391,6 +468,32 @@ func (mr *MockBackendMockRecorder) UpdateEnvironment(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvironment", reflect.TypeOf((*MockBackend)(nil).UpdateEnvironment), arg0, arg1)
 }
 
+// UpdateLoadBalancerAccessLogs mocks base method
+func (m *MockBackend) UpdateLoadBalancerAccessLogs(arg0 string, arg1 models.AccessLogs) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "UpdateLoadBalancerAccessLogs", arg0, arg1)
+	ret0, _ := ret[0].(*models.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoadBalancerAccessLogs indicates an expected call of UpdateLoadBalancerAccessLogs
+func (mr *MockBackendMockRecorder) UpdateLoadBalancerAccessLogs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoadBalancerAccessLogs", reflect.TypeOf((*MockBackend)(nil).UpdateLoadBalancerAccessLogs), arg0, arg1)
+}
+
+// UpdateLoadBalancerConnectionDraining mocks base method
+func (m *MockBackend) UpdateLoadBalancerConnectionDraining(arg0 string, arg1 models.ConnectionDraining) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "UpdateLoadBalancerConnectionDraining", arg0, arg1)
+	ret0, _ := ret[0].(*models.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoadBalancerConnectionDraining indicates an expected call of UpdateLoadBalancerConnectionDraining
+func (mr *MockBackendMockRecorder) UpdateLoadBalancerConnectionDraining(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoadBalancerConnectionDraining", reflect.TypeOf((*MockBackend)(nil).UpdateLoadBalancerConnectionDraining), arg0, arg1)
+}
+
 // UpdateLoadBalancerCrossZone mocks base method
 func (m *MockBackend) UpdateLoadBalancerCrossZone(arg0 string, arg1 bool) (*models.LoadBalancer, error) {
 	ret := m.ctrl.Call(m, "UpdateLoadBalancerCrossZone", arg0, arg1)
@@ -455,3 +558,16 @@ func (m *MockBackend) UpdateService(arg0, arg1, arg2 string) (*models.Service, e
 func (mr *MockBackendMockRecorder) UpdateService(arg0, arg1, arg2 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockBackend)(nil).UpdateService), arg0, arg1, arg2)
 }
+
+// ValidateDeploy mocks base method
+func (m *MockBackend) ValidateDeploy(arg0 string, arg1 []byte, arg2 string) (*models.DeployValidation, error) {
+	ret := m.ctrl.Call(m, "ValidateDeploy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.DeployValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateDeploy indicates an expected call of ValidateDeploy
+func (mr *MockBackendMockRecorder) ValidateDeploy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDeploy", reflect.TypeOf((*MockBackend)(nil).ValidateDeploy), arg0, arg1, arg2)
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/emicklei/go-restful"
+	"github.com/quintilesims/layer0/api/logic"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/db/tag_store"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
+)
+
+// the entity types that can be deleted in bulk
+var bulkDeleteEntityTypes = map[string]bool{
+	"deploy":        true,
+	"load_balancer": true,
+	"service":       true,
+	"task":          true,
+}
+
+type BulkHandler struct {
+	JobLogic logic.JobLogic
+	TagStore tag_store.TagStore
+}
+
+func NewBulkHandler(jobLogic logic.JobLogic, tagStore tag_store.TagStore) *BulkHandler {
+	return &BulkHandler{
+		JobLogic: jobLogic,
+		TagStore: tagStore,
+	}
+}
+
+func (b *BulkHandler) Routes() *restful.WebService {
+	service := new(restful.WebService)
+	service.Path("/bulk").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Param(service.HeaderParameter("Authorization", "Basic realm authentication token"))
+
+	service.Route(service.POST("/").
+		Filter(basicAuthenticate).
+		To(b.Bulk).
+		Doc("Run an action on each entity whose tags match the selector. "+
+			"The action runs as a single job that records the result for each entity in its meta").
+		Reads(models.BulkRequest{}).
+		Returns(http.StatusOK, "The matched entities (dry run)", models.BulkResponse{}).
+		Returns(http.StatusAccepted, "Accepted", models.BulkResponse{}).
+		Returns(400, "Invalid request", models.ServerError{}))
+
+	return service
+}
+
+func (b *BulkHandler) Bulk(request *restful.Request, response *restful.Response) {
+	var req models.BulkRequest
+	if err := request.ReadEntity(&req); err != nil {
+		BadRequest(response, errors.InvalidJSON, err)
+		return
+	}
+
+	if req.Action != "delete" {
+		err := fmt.Errorf("Unsupported action '%s'; only 'delete' is supported", req.Action)
+		BadRequest(response, errors.InvalidBulkRequest, err)
+		return
+	}
+
+	if !bulkDeleteEntityTypes[req.EntityType] {
+		err := fmt.Errorf("Entities of type '%s' cannot be deleted in bulk", req.EntityType)
+		BadRequest(response, errors.InvalidEntityType, err)
+		return
+	}
+
+	// require 'all' to act on every entity of a type so an empty selector isn't a mistake
+	if len(req.Selector) == 0 && !req.All {
+		err := fmt.Errorf("A selector is required unless 'all' is set")
+		BadRequest(response, errors.InvalidBulkRequest, err)
+		return
+	}
+
+	entityIDs, err := b.selectEntityIDs(req.EntityType, req.Selector)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	// only act on the entities the caller confirmed that still match
+	if req.EntityIDs != nil {
+		entityIDs = intersectEntityIDs(entityIDs, req.EntityIDs)
+	}
+
+	resp := models.BulkResponse{
+		Action:     req.Action,
+		EntityIDs:  entityIDs,
+		EntityType: req.EntityType,
+	}
+
+	if req.DryRun || len(entityIDs) == 0 {
+		response.WriteAsJson(resp)
+		return
+	}
+
+	jobRequest := models.BulkJobRequest{
+		Action:     req.Action,
+		EntityIDs:  entityIDs,
+		EntityType: req.EntityType,
+	}
+
+	job, err := b.JobLogic.CreateJob(types.BulkDeleteJob, jobRequest)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	resp.JobID = job.JobID
+	response.AddHeader("Location", fmt.Sprintf("/job/%s", job.JobID))
+	response.AddHeader("X-JobID", job.JobID)
+	response.WriteHeader(http.StatusAccepted)
+	response.WriteAsJson(resp)
+}
+
+// selectEntityIDs returns the sorted ids of the entities with every tag in selector,
+// excluding the entities of the api
+func (b *BulkHandler) selectEntityIDs(entityType string, selector map[string]string) ([]string, error) {
+	tags, err := b.TagStore.SelectByType(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	ewts := tags.GroupByEntity()
+	for key, val := range selector {
+		ewts = ewts.WithTag(key, val)
+	}
+
+	// the api's own entities are never deleted in bulk
+	ewts = ewts.RemoveIf(func(ewt models.EntityWithTags) bool {
+		if ewt.EntityID == config.API_SERVICE_ID || ewt.EntityID == config.API_LOAD_BALANCER_ID {
+			return true
+		}
+
+		return ewt.Tags.Any(func(t models.Tag) bool {
+			return t.Key == "environment_id" && t.Value == config.API_ENVIRONMENT_ID
+		})
+	})
+
+	entityIDs := []string{}
+	for _, ewt := range ewts {
+		entityIDs = append(entityIDs, ewt.EntityID)
+	}
+
+	sort.Strings(entityIDs)
+	return entityIDs, nil
+}
+
+// intersectEntityIDs returns the ids in entityIDs that are also in confirmed
+func intersectEntityIDs(entityIDs, confirmed []string) []string {
+	isConfirmed := map[string]bool{}
+	for _, entityID := range confirmed {
+		isConfirmed[entityID] = true
+	}
+
+	intersection := []string{}
+	for _, entityID := range entityIDs {
+		if isConfirmed[entityID] {
+			intersection = append(intersection, entityID)
+		}
+	}
+
+	return intersection
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/api/logic/mock_logic"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/quintilesims/layer0/common/types"
+)
+
+func TestBulk(t *testing.T) {
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call CreateJob with the entities that match the selector",
+			Request: &TestRequest{
+				Body: models.BulkRequest{
+					Action:     "delete",
+					EntityType: "service",
+					Selector:   map[string]string{"environment_id": "e1"},
+				},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				request := models.BulkJobRequest{
+					Action:     "delete",
+					EntityIDs:  []string{"s1"},
+					EntityType: "service",
+				}
+
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				jobLogicMock.EXPECT().
+					CreateJob(types.BulkDeleteJob, request).
+					Return(&models.Job{JobID: "job_id"}, nil)
+
+				return NewBulkHandler(jobLogicMock, getTestTagStore(t, TestTags))
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*BulkHandler)
+				handler.Bulk(req, resp)
+
+				header := resp.Header()
+				reporter.AssertInSlice("/job/job_id", header["Location"])
+				reporter.AssertInSlice("job_id", header["X-Jobid"])
+
+				var response models.BulkResponse
+				read(&response)
+
+				reporter.AssertEqual(response.EntityIDs, []string{"s1"})
+				reporter.AssertEqual(response.JobID, "job_id")
+			},
+		},
+		{
+			Name: "Should not call CreateJob on a dry run",
+			Request: &TestRequest{
+				Body: models.BulkRequest{
+					Action:     "delete",
+					All:        true,
+					DryRun:     true,
+					EntityType: "task",
+				},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				return NewBulkHandler(jobLogicMock, getTestTagStore(t, TestTags))
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*BulkHandler)
+				handler.Bulk(req, resp)
+
+				var response models.BulkResponse
+				read(&response)
+
+				reporter.AssertEqual(response.EntityIDs, []string{"t1", "t2"})
+				reporter.AssertEqual(response.JobID, "")
+			},
+		},
+		{
+			Name: "Should return InvalidBulkRequest error without a selector",
+			Request: &TestRequest{
+				Body: models.BulkRequest{
+					Action:     "delete",
+					EntityType: "service",
+				},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				return NewBulkHandler(jobLogicMock, getTestTagStore(t, TestTags))
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*BulkHandler)
+				handler.Bulk(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.InvalidBulkRequest))
+			},
+		},
+		{
+			Name: "Should return InvalidEntityType error for environments",
+			Request: &TestRequest{
+				Body: models.BulkRequest{
+					Action:     "delete",
+					All:        true,
+					EntityType: "environment",
+				},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				return NewBulkHandler(jobLogicMock, getTestTagStore(t, TestTags))
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*BulkHandler)
+				handler.Bulk(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.InvalidEntityType))
+			},
+		},
+		{
+			Name: "Should exclude the entities of the api",
+			Request: &TestRequest{
+				Body: models.BulkRequest{
+					Action:     "delete",
+					All:        true,
+					DryRun:     true,
+					EntityType: "service",
+				},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				tags := append(models.Tags{
+					{EntityID: "api", EntityType: "service", Key: "name", Value: "api"},
+					{EntityID: "s3", EntityType: "service", Key: "environment_id", Value: "api"},
+				}, TestTags...)
+
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				return NewBulkHandler(jobLogicMock, getTestTagStore(t, tags))
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*BulkHandler)
+				handler.Bulk(req, resp)
+
+				var response models.BulkResponse
+				read(&response)
+
+				reporter.AssertEqual(response.EntityIDs, []string{"s1", "s2"})
+			},
+		},
+		{
+			Name: "Should only delete the confirmed entities that still match",
+			Request: &TestRequest{
+				Body: models.BulkRequest{
+					Action:     "delete",
+					All:        true,
+					EntityIDs:  []string{"t2", "t3"},
+					EntityType: "task",
+				},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				request := models.BulkJobRequest{
+					Action:     "delete",
+					EntityIDs:  []string{"t2"},
+					EntityType: "task",
+				}
+
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				jobLogicMock.EXPECT().
+					CreateJob(types.BulkDeleteJob, request).
+					Return(&models.Job{JobID: "job_id"}, nil)
+
+				return NewBulkHandler(jobLogicMock, getTestTagStore(t, TestTags))
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*BulkHandler)
+				handler.Bulk(req, resp)
+
+				var response models.BulkResponse
+				read(&response)
+
+				reporter.AssertEqual(response.EntityIDs, []string{"t2"})
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
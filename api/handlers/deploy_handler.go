@@ -56,6 +56,30 @@ func (this *DeployHandler) Routes() *restful.WebService {
 		Returns(http.StatusCreated, "Created", models.Deploy{}).
 		Reads(models.CreateDeployRequest{}))
 
+	service.Route(service.GET("{id}/diff/{other_id}").
+		Filter(basicAuthenticate).
+		To(this.DiffDeploys).
+		Doc("Return the changes between two Deploys").
+		Param(id).
+		Param(service.PathParameter("other_id", "identifier of the deploy to compare against").DataType("string")).
+		Writes(models.DeployDiff{}))
+
+	service.Route(service.POST("/prune").
+		Filter(basicAuthenticate).
+		To(this.PruneDeploys).
+		Doc("Delete old versions of each Deploy family, keeping the latest versions and any versions in use").
+		Reads(models.PruneDeploysRequest{}).
+		Returns(400, "Invalid request", models.ServerError{}).
+		Writes(models.PruneDeploysReport{}))
+
+	service.Route(service.POST("/validate").
+		Filter(basicAuthenticate).
+		To(this.ValidateDeploy).
+		Doc("Check a Dockerrun for problems without creating a Deploy").
+		Reads(models.ValidateDeployRequest{}).
+		Returns(400, "Invalid request", models.ServerError{}).
+		Writes(models.DeployValidation{}))
+
 	return service
 }
 
@@ -117,3 +141,59 @@ func (this *DeployHandler) CreateDeploy(request *restful.Request, response *rest
 
 	response.WriteAsJson(deploy)
 }
+
+func (this *DeployHandler) ValidateDeploy(request *restful.Request, response *restful.Response) {
+	var req models.ValidateDeployRequest
+	if err := request.ReadEntity(&req); err != nil {
+		BadRequest(response, errors.InvalidJSON, err)
+		return
+	}
+
+	validation, err := this.DeployLogic.ValidateDeploy(req)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(validation)
+}
+
+func (this *DeployHandler) PruneDeploys(request *restful.Request, response *restful.Response) {
+	var req models.PruneDeploysRequest
+	if err := request.ReadEntity(&req); err != nil {
+		BadRequest(response, errors.InvalidJSON, err)
+		return
+	}
+
+	report, err := this.DeployLogic.PruneDeploys(req)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(report)
+}
+
+func (this *DeployHandler) DiffDeploys(request *restful.Request, response *restful.Response) {
+	deployID := request.PathParameter("id")
+	if deployID == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	otherDeployID := request.PathParameter("other_id")
+	if otherDeployID == "" {
+		err := fmt.Errorf("Parameter 'other_id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	diff, err := this.DeployLogic.DiffDeploys(deployID, otherDeployID)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(diff)
+}
@@ -276,3 +276,168 @@ func TestCreateDeploy(t *testing.T) {
 
 	RunHandlerTestCases(t, testCases)
 }
+
+func TestValidateDeploy(t *testing.T) {
+	request := models.ValidateDeployRequest{
+		DeployName:    "dply_name",
+		Dockerrun:     []byte("some dockerrun"),
+		EnvironmentID: "env_id",
+	}
+
+	validation := &models.DeployValidation{
+		Errors:   []string{"some error"},
+		Warnings: []string{},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should return validation from logic layer",
+			Request: &TestRequest{
+				Body: request,
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockDeploy := mock_logic.NewMockDeployLogic(ctrl)
+
+				mockDeploy.EXPECT().
+					ValidateDeploy(request).
+					Return(validation, nil)
+
+				return NewDeployHandler(mockDeploy)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*DeployHandler)
+				handler.ValidateDeploy(req, resp)
+
+				var response *models.DeployValidation
+				read(&response)
+
+				reporter.AssertEqual(response, validation)
+			},
+		},
+		{
+			Name: "Should propagate ValidateDeploy error",
+			Request: &TestRequest{
+				Body: request,
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockDeploy := mock_logic.NewMockDeployLogic(ctrl)
+
+				mockDeploy.EXPECT().
+					ValidateDeploy(gomock.Any()).
+					Return(nil, errors.Newf(errors.UnexpectedError, "some error"))
+
+				return NewDeployHandler(mockDeploy)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*DeployHandler)
+				handler.ValidateDeploy(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.UnexpectedError))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
+func TestDiffDeploys(t *testing.T) {
+	diff := &models.DeployDiff{
+		Containers:    []models.ContainerDiff{},
+		DeployID:      "d.1",
+		OtherDeployID: "d.2",
+		Volumes:       []models.DeployChange{},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call DiffDeploys with proper params",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "d.1", "other_id": "d.2"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockDeploy := mock_logic.NewMockDeployLogic(ctrl)
+
+				mockDeploy.EXPECT().
+					DiffDeploys("d.1", "d.2").
+					Return(diff, nil)
+
+				return NewDeployHandler(mockDeploy)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*DeployHandler)
+				handler.DiffDeploys(req, resp)
+
+				var response *models.DeployDiff
+				read(&response)
+
+				reporter.AssertEqual(response, diff)
+			},
+		},
+		{
+			Name: "Should return MissingParameter error with no other_id",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "d.1"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				return NewDeployHandler(mock_logic.NewMockDeployLogic(ctrl))
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*DeployHandler)
+				handler.DiffDeploys(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.MissingParameter))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
+func TestPruneDeploys(t *testing.T) {
+	request := models.PruneDeploysRequest{
+		DryRun: true,
+		Keep:   5,
+	}
+
+	report := &models.PruneDeploysReport{
+		DryRun: true,
+		Pruned: []models.DeploySummary{
+			{DeployID: "d.1", DeployName: "dpl", Version: "1"},
+		},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call PruneDeploys with proper params",
+			Request: &TestRequest{
+				Body: request,
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockDeploy := mock_logic.NewMockDeployLogic(ctrl)
+
+				mockDeploy.EXPECT().
+					PruneDeploys(request).
+					Return(report, nil)
+
+				return NewDeployHandler(mockDeploy)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*DeployHandler)
+				handler.PruneDeploys(req, resp)
+
+				var response *models.PruneDeploysReport
+				read(&response)
+
+				reporter.AssertEqual(response, report)
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
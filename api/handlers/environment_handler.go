@@ -79,10 +79,10 @@ func (e *EnvironmentHandler) Routes() *restful.WebService {
 	sourceID := service.PathParameter("source_id", "identifier of the source environment").
 		DataType("string")
 
-	destID := service.PathParameter("dest_id", "identifier of the destination environment").
+	destID := service.PathParameter("dest_id", "identifier of the destination environment, security group, or cidr").
 		DataType("string")
 
-	service.Route(service.DELETE("{source_id}/link/{dest_id}").
+	service.Route(service.DELETE("{source_id}/link/{dest_id:*}").
 		Filter(basicAuthenticate).
 		To(e.DeleteEnvironmentLink).
 		Doc("Delete an Environment Link").
@@ -202,7 +202,15 @@ func (e *EnvironmentHandler) CreateEnvironmentLink(request *restful.Request, res
 		return
 	}
 
-	if err := e.EnvironmentLogic.CreateEnvironmentLink(id, req.EnvironmentID); err != nil {
+	link := models.EnvironmentLink{
+		EnvironmentID:   req.EnvironmentID,
+		CIDR:            req.CIDR,
+		SecurityGroupID: req.SecurityGroupID,
+		Direction:       req.Direction,
+		Rules:           req.Rules,
+	}
+
+	if err := e.EnvironmentLogic.CreateEnvironmentLink(id, link); err != nil {
 		ReturnError(response, err)
 		return
 	}
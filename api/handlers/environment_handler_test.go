@@ -440,6 +440,18 @@ func TestUpdateEnvironment(t *testing.T) {
 func TestCreateEnvironmentLink(t *testing.T) {
 	request := models.CreateEnvironmentLinkRequest{
 		EnvironmentID: "eid2",
+		Direction:     "outbound",
+		Rules: []models.EnvironmentLinkRule{
+			{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+		},
+	}
+
+	link := models.EnvironmentLink{
+		EnvironmentID: "eid2",
+		Direction:     "outbound",
+		Rules: []models.EnvironmentLinkRule{
+			{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+		},
 	}
 
 	testCases := []HandlerTestCase{
@@ -453,7 +465,7 @@ func TestCreateEnvironmentLink(t *testing.T) {
 				mockEnvironment := mock_logic.NewMockEnvironmentLogic(ctrl)
 
 				mockEnvironment.EXPECT().
-					CreateEnvironmentLink("eid1", "eid2").
+					CreateEnvironmentLink("eid1", link).
 					Return(nil)
 
 				mockJob := mock_logic.NewMockJobLogic(ctrl)
@@ -17,12 +17,16 @@ func ToHttpError(code errors.ErrorCode) int {
 	switch code {
 	case errors.InvalidJSON, errors.MissingParameter, errors.InvalidEntityType,
 		errors.InvalidEnvironmentID, errors.InvalidServiceID, errors.InvalidDeployID,
-		errors.InvalidTagKey, errors.InvalidTagValue, errors.InvalidCertificateID:
+		errors.InvalidTagKey, errors.InvalidTagValue, errors.InvalidCertificateID,
+		errors.InvalidEnvironmentLink, errors.InvalidLoadBalancerType, errors.InvalidLoadBalancerRule,
+		errors.InvalidLoadBalancerAttribute, errors.InvalidDeployTemplate, errors.InvalidSecretName,
+		errors.InvalidCompose, errors.InvalidExecRequest:
 		ret = http.StatusBadRequest
 	case errors.Throttled:
 		ret = http.StatusServiceUnavailable
 	case errors.DeployDoesNotExist, errors.EnvironmentDoesNotExist, errors.JobDoesNotExist,
-		errors.LoadBalancerDoesNotExist, errors.ServiceDoesNotExist, errors.TaskDoesNotExist:
+		errors.LoadBalancerDoesNotExist, errors.ServiceDoesNotExist, errors.TaskDoesNotExist,
+		errors.SecretDoesNotExist:
 		ret = http.StatusNotFound
 	default:
 		ret = http.StatusInternalServerError
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/emicklei/go-restful"
+	"github.com/gorilla/websocket"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
+)
+
+var execUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+}
+
+func parseExecRequest(request *restful.Request) (models.ExecRequest, error) {
+	query := request.Request.URL.Query()
+	req := models.ExecRequest{
+		Command:       query["command"],
+		ContainerName: query.Get("container"),
+	}
+
+	if param := query.Get("tty"); param != "" {
+		tty, err := strconv.ParseBool(param)
+		if err != nil {
+			return req, fmt.Errorf("Parameter 'tty' must be a boolean")
+		}
+
+		req.TTY = tty
+	}
+
+	if len(req.Command) == 0 {
+		return req, fmt.Errorf("Parameter 'command' is required")
+	}
+
+	return req, nil
+}
+
+// serveExec upgrades the request to a websocket and relays the exec session over it.
+// Stream data is sent as binary messages prefixed with the stream's byte;
+// resize, exit and error notifications are sent as json-encoded text messages.
+// Each session is recorded in the api log.
+func serveExec(
+	request *restful.Request,
+	response *restful.Response,
+	entityType string,
+	entityID string,
+	req models.ExecRequest,
+	exec func(models.ExecStreams) (int, error),
+) {
+	conn, err := execUpgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+	if err != nil {
+		// the upgrader has already replied with an http error
+		logrus.Errorf("Failed to upgrade exec request: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	audit := logrus.WithFields(logrus.Fields{
+		"audit":       "exec",
+		"entity_type": entityType,
+		"entity_id":   entityID,
+		"container":   req.ContainerName,
+		"command":     strings.Join(req.Command, " "),
+		"tty":         req.TTY,
+		"remote_addr": request.Request.RemoteAddr,
+		"caller":      execCaller(request),
+	})
+
+	audit.Infof("Exec session started")
+	start := time.Now()
+
+	session := newExecSession(conn)
+	go session.readInput()
+
+	exitCode, err := exec(session.Streams())
+	audit = audit.WithField("duration", time.Since(start).String())
+
+	if err != nil {
+		audit.Errorf("Exec session failed: %v", err)
+
+		message := err.Error()
+		if serverError, ok := err.(*errors.ServerError); ok {
+			message = serverError.Model().Message
+		}
+
+		session.writeJSON(models.ExecMessage{Type: types.ExecErrorMessage, Error: message})
+	} else {
+		audit.WithField("exit_code", exitCode).Infof("Exec session ended")
+		session.writeJSON(models.ExecMessage{Type: types.ExecExitMessage, ExitCode: exitCode})
+	}
+
+	session.write(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+// execCaller identifies who started an exec session by the username and a fingerprint
+// of the credentials they authenticated with, e.g. 'layer0 (sha256:1a2b3c4d5e6f7a8b)'
+func execCaller(request *restful.Request) string {
+	authorization := request.Request.Header.Get("Authorization")
+	if authorization == "" {
+		return "unknown"
+	}
+
+	username, _, _ := request.Request.BasicAuth()
+	sum := sha256.Sum256([]byte(authorization))
+	return fmt.Sprintf("%s (sha256:%s)", username, hex.EncodeToString(sum[:8]))
+}
+
+type execSession struct {
+	conn   *websocket.Conn
+	stdin  *io.PipeReader
+	input  *io.PipeWriter
+	resize chan models.TerminalSize
+	mutex  sync.Mutex
+}
+
+func newExecSession(conn *websocket.Conn) *execSession {
+	stdin, input := io.Pipe()
+
+	return &execSession{
+		conn:   conn,
+		stdin:  stdin,
+		input:  input,
+		resize: make(chan models.TerminalSize, 1),
+	}
+}
+
+func (s *execSession) Streams() models.ExecStreams {
+	return models.ExecStreams{
+		Resize: s.resize,
+		Stderr: &execWriter{session: s, stream: types.ExecStderr},
+		Stdin:  s.stdin,
+		Stdout: &execWriter{session: s, stream: types.ExecStdout},
+	}
+}
+
+// readInput relays stdin and resize messages from the client until the connection closes
+func (s *execSession) readInput() {
+	defer close(s.resize)
+	defer s.input.Close()
+
+	for {
+		messageType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		switch messageType {
+		case websocket.BinaryMessage:
+			if len(data) == 0 || data[0] != types.ExecStdin {
+				continue
+			}
+
+			// a stdin message without data marks the end of the client's input
+			if len(data) == 1 {
+				s.input.Close()
+				continue
+			}
+
+			// writes fail once the command has exited or stdin was closed, which is not an error
+			s.input.Write(data[1:])
+		case websocket.TextMessage:
+			var message models.ExecMessage
+			if err := json.Unmarshal(data, &message); err != nil || message.Type != types.ExecResizeMessage {
+				continue
+			}
+
+			// only the latest size matters, so replace any resize that has not been applied yet
+			select {
+			case <-s.resize:
+			default:
+			}
+
+			s.resize <- models.TerminalSize{Height: message.Height, Width: message.Width}
+		}
+	}
+}
+
+func (s *execSession) write(messageType int, data []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.conn.WriteMessage(messageType, data)
+}
+
+func (s *execSession) writeJSON(message models.ExecMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return s.write(websocket.TextMessage, data)
+}
+
+type execWriter struct {
+	session *execSession
+	stream  byte
+}
+
+func (w *execWriter) Write(p []byte) (int, error) {
+	message := append([]byte{w.stream}, p...)
+	if err := w.session.write(websocket.BinaryMessage, message); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
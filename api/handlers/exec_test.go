@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/quintilesims/layer0/api/logic/mock_logic"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/quintilesims/layer0/common/types"
+)
+
+func TestParseExecRequest(t *testing.T) {
+	request, err := (&TestRequest{Query: "container=api&command=ls&command=-la&tty=true"}).RestfulRequest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := parseExecRequest(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := models.ExecRequest{
+		Command:       []string{"ls", "-la"},
+		ContainerName: "api",
+		TTY:           true,
+	}
+
+	testutils.AssertEqual(t, req, expected)
+}
+
+func TestParseExecRequestErrors(t *testing.T) {
+	cases := map[string]string{
+		"Missing command": "container=api",
+		"Invalid tty":     "command=sh&tty=maybe",
+	}
+
+	for name, query := range cases {
+		request, err := (&TestRequest{Query: query}).RestfulRequest()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := parseExecRequest(request); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestExecCaller(t *testing.T) {
+	request := restful.NewRequest(httptest.NewRequest("GET", "/", nil))
+	testutils.AssertEqual(t, execCaller(request), "unknown")
+
+	// layer0:nohaxplz
+	request.Request.Header.Set("Authorization", fmt.Sprintf("Basic %s", config.DEFAULT_AUTH_TOKEN))
+	caller := execCaller(request)
+	if !strings.HasPrefix(caller, "layer0 (sha256:") {
+		t.Fatalf("Unexpected caller '%s'", caller)
+	}
+
+	// the fingerprint must not include the credentials themselves
+	if strings.Contains(caller, config.DEFAULT_AUTH_TOKEN) || strings.Contains(caller, "nohaxplz") {
+		t.Fatalf("Caller '%s' includes credentials", caller)
+	}
+}
+
+func dialExec(t *testing.T, handler *restful.WebService, path string) (*websocket.Conn, func()) {
+	container := restful.NewContainer()
+	container.Add(handler)
+	server := httptest.NewServer(container)
+
+	url := fmt.Sprintf("ws%s%s", strings.TrimPrefix(server.URL, "http"), path)
+	header := http.Header{"Authorization": {fmt.Sprintf("Basic %s", config.AuthToken())}}
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+
+	// fail instead of hanging if the session never ends
+	conn.SetReadDeadline(time.Now().Add(time.Second * 10))
+
+	return conn, func() {
+		conn.Close()
+		server.Close()
+	}
+}
+
+// readExec returns the stdout written during the session along with its final message
+func readExec(t *testing.T, conn *websocket.Conn) (string, models.ExecMessage) {
+	var stdout string
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if messageType == websocket.TextMessage {
+			var message models.ExecMessage
+			if err := json.Unmarshal(data, &message); err != nil {
+				t.Fatal(err)
+			}
+
+			return stdout, message
+		}
+
+		if data[0] == types.ExecStdout {
+			stdout += string(data[1:])
+		}
+	}
+}
+
+func TestExecTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := models.ExecRequest{
+		Command:       []string{"cat"},
+		ContainerName: "api",
+	}
+
+	logicMock := mock_logic.NewMockTaskLogic(ctrl)
+	logicMock.EXPECT().
+		ExecTask("some_id", req, gomock.Any()).
+		Do(func(taskID string, req models.ExecRequest, streams models.ExecStreams) {
+			io.Copy(streams.Stdout, streams.Stdin)
+		}).
+		Return(7, nil)
+
+	conn, close := dialExec(t, NewTaskHandler(logicMock, nil).Routes(), "/task/some_id/exec?container=api&command=cat")
+	defer close()
+
+	if err := conn.WriteMessage(websocket.BinaryMessage, append([]byte{types.ExecStdin}, "hello"...)); err != nil {
+		t.Fatal(err)
+	}
+
+	// an empty stdin message closes the command's input
+	if err := conn.WriteMessage(websocket.BinaryMessage, []byte{types.ExecStdin}); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, message := readExec(t, conn)
+	testutils.AssertEqual(t, stdout, "hello")
+	testutils.AssertEqual(t, message.Type, types.ExecExitMessage)
+	testutils.AssertEqual(t, message.ExitCode, 7)
+}
+
+func TestExecService_error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logicMock := mock_logic.NewMockServiceLogic(ctrl)
+	logicMock.EXPECT().
+		ExecService("some_id", gomock.Any(), gomock.Any()).
+		Return(0, errors.Newf(errors.InvalidExecRequest, "Service has no running tasks"))
+
+	conn, close := dialExec(t, NewServiceHandler(logicMock, nil).Routes(), "/service/some_id/exec?command=sh")
+	defer close()
+
+	_, message := readExec(t, conn)
+	testutils.AssertEqual(t, message.Type, types.ExecErrorMessage)
+	testutils.AssertEqual(t, message.Error, "Service has no running tasks")
+}
@@ -45,6 +45,13 @@ func (l *LoadBalancerHandler) Routes() *restful.WebService {
 		Param(id).
 		Writes(models.LoadBalancer{}))
 
+	service.Route(service.GET("{id}/health").
+		Filter(basicAuthenticate).
+		To(l.GetLoadBalancerHealth).
+		Doc("Return the health of each instance registered with a LoadBalancer").
+		Param(id).
+		Writes(models.LoadBalancerHealth{}))
+
 	service.Route(service.POST("/").
 		Filter(basicAuthenticate).
 		To(l.CreateLoadBalancer).
@@ -92,6 +99,22 @@ func (l *LoadBalancerHandler) Routes() *restful.WebService {
 		Doc("Update load balancer cross-zone load balancing").
 		Writes(models.LoadBalancer{}))
 
+	service.Route(service.PUT("{id}/accesslogs").
+		Filter(basicAuthenticate).
+		To(l.UpdateLoadBalancerAccessLogs).
+		Reads(models.UpdateLoadBalancerAccessLogsRequest{}).
+		Param(id).
+		Doc("Update load balancer access logs").
+		Writes(models.LoadBalancer{}))
+
+	service.Route(service.PUT("{id}/connectiondraining").
+		Filter(basicAuthenticate).
+		To(l.UpdateLoadBalancerConnectionDraining).
+		Reads(models.UpdateLoadBalancerConnectionDrainingRequest{}).
+		Param(id).
+		Doc("Update load balancer connection draining").
+		Writes(models.LoadBalancer{}))
+
 	return service
 }
 
@@ -122,6 +145,23 @@ func (l *LoadBalancerHandler) GetLoadBalancer(request *restful.Request, response
 	response.WriteAsJson(loadbalancer)
 }
 
+func (l *LoadBalancerHandler) GetLoadBalancerHealth(request *restful.Request, response *restful.Response) {
+	id := request.PathParameter("id")
+	if id == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	health, err := l.LoadBalancerLogic.GetLoadBalancerHealth(id)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(health)
+}
+
 func (l *LoadBalancerHandler) DeleteLoadBalancer(request *restful.Request, response *restful.Response) {
 	id := request.PathParameter("id")
 	if id == "" {
@@ -246,3 +286,49 @@ func (l *LoadBalancerHandler) UpdateLoadBalancerCrossZone(request *restful.Reque
 
 	response.WriteAsJson(loadBalancer)
 }
+
+func (l *LoadBalancerHandler) UpdateLoadBalancerAccessLogs(request *restful.Request, response *restful.Response) {
+	id := request.PathParameter("id")
+	if id == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	var req models.UpdateLoadBalancerAccessLogsRequest
+	if err := request.ReadEntity(&req); err != nil {
+		BadRequest(response, errors.InvalidJSON, err)
+		return
+	}
+
+	loadBalancer, err := l.LoadBalancerLogic.UpdateLoadBalancerAccessLogs(id, req.AccessLogs)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(loadBalancer)
+}
+
+func (l *LoadBalancerHandler) UpdateLoadBalancerConnectionDraining(request *restful.Request, response *restful.Response) {
+	id := request.PathParameter("id")
+	if id == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	var req models.UpdateLoadBalancerConnectionDrainingRequest
+	if err := request.ReadEntity(&req); err != nil {
+		BadRequest(response, errors.InvalidJSON, err)
+		return
+	}
+
+	loadBalancer, err := l.LoadBalancerLogic.UpdateLoadBalancerConnectionDraining(id, req.ConnectionDraining)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(loadBalancer)
+}
@@ -168,6 +168,62 @@ func TestGetLoadBalancer(t *testing.T) {
 	RunHandlerTestCases(t, testCases)
 }
 
+func TestGetLoadBalancerHealth(t *testing.T) {
+	health := &models.LoadBalancerHealth{
+		LoadBalancerID: "some_id",
+		Instances: []models.InstanceHealth{
+			{InstanceID: "i-123", State: "InService"},
+		},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should return health from logic layer",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockLoadBalancerLogic(ctrl)
+				logicMock.EXPECT().
+					GetLoadBalancerHealth("some_id").
+					Return(health, nil)
+
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+				return NewLoadBalancerHandler(logicMock, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*LoadBalancerHandler)
+				handler.GetLoadBalancerHealth(req, resp)
+
+				var response *models.LoadBalancerHealth
+				read(&response)
+
+				reporter.AssertEqual(response, health)
+			},
+		},
+		{
+			Name:    "Should return MissingParameter error with no id",
+			Request: &TestRequest{},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockLoadBalancerLogic(ctrl)
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+				return NewLoadBalancerHandler(logicMock, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*LoadBalancerHandler)
+				handler.GetLoadBalancerHealth(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.MissingParameter))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
 func TestCreateLoadBalancer(t *testing.T) {
 	request := models.CreateLoadBalancerRequest{
 		LoadBalancerName: "lb_name",
@@ -414,3 +470,61 @@ func TestUpdateLoadBalancerCrossZone(t *testing.T) {
 
 	RunHandlerTestCases(t, testCases)
 }
+
+func TestUpdateLoadBalancerAccessLogs(t *testing.T) {
+	request := models.UpdateLoadBalancerAccessLogsRequest{AccessLogs: models.AccessLogs{Enabled: true, S3BucketName: "bucket"}}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call UpdateLoadBalancerAccessLogs with correct params",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Body:       request,
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockLogic := mock_logic.NewMockLoadBalancerLogic(ctrl)
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+
+				mockLogic.EXPECT().
+					UpdateLoadBalancerAccessLogs("some_id", request.AccessLogs)
+
+				return NewLoadBalancerHandler(mockLogic, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*LoadBalancerHandler)
+				handler.UpdateLoadBalancerAccessLogs(req, resp)
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
+func TestUpdateLoadBalancerConnectionDraining(t *testing.T) {
+	request := models.UpdateLoadBalancerConnectionDrainingRequest{ConnectionDraining: models.ConnectionDraining{Enabled: true, Timeout: 120}}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call UpdateLoadBalancerConnectionDraining with correct params",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Body:       request,
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockLogic := mock_logic.NewMockLoadBalancerLogic(ctrl)
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+
+				mockLogic.EXPECT().
+					UpdateLoadBalancerConnectionDraining("some_id", request.ConnectionDraining)
+
+				return NewLoadBalancerHandler(mockLogic, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*LoadBalancerHandler)
+				handler.UpdateLoadBalancerConnectionDraining(req, resp)
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
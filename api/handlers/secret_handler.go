@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/emicklei/go-restful"
+	"github.com/quintilesims/layer0/api/logic"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+type SecretHandler struct {
+	SecretLogic logic.SecretLogic
+}
+
+func NewSecretHandler(secretLogic logic.SecretLogic) *SecretHandler {
+	return &SecretHandler{
+		SecretLogic: secretLogic,
+	}
+}
+
+func (this *SecretHandler) Routes() *restful.WebService {
+	service := new(restful.WebService)
+	service.Path("/secret").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON)
+
+	environmentID := service.PathParameter("environment_id", "identifier of the environment").
+		DataType("string")
+
+	name := service.PathParameter("name", "name of the secret").
+		DataType("string")
+
+	service.Route(service.GET("{environment_id}").
+		Filter(basicAuthenticate).
+		To(this.ListSecrets).
+		Doc("List the secrets in an environment").
+		Param(environmentID).
+		Returns(200, "OK", []models.Secret{}))
+
+	service.Route(service.POST("/").
+		Filter(basicAuthenticate).
+		To(this.CreateSecret).
+		Doc("Create or replace a secret").
+		Reads(models.CreateSecretRequest{}).
+		Returns(http.StatusCreated, "Created", models.Secret{}).
+		Returns(400, "Invalid request", models.ServerError{}).
+		Writes(models.Secret{}))
+
+	service.Route(service.DELETE("{environment_id}/{name}").
+		Filter(basicAuthenticate).
+		To(this.DeleteSecret).
+		Doc("Delete a secret").
+		Param(environmentID).
+		Param(name).
+		Returns(http.StatusNoContent, "Deleted", nil))
+
+	return service
+}
+
+func (this *SecretHandler) ListSecrets(request *restful.Request, response *restful.Response) {
+	environmentID := request.PathParameter("environment_id")
+	if environmentID == "" {
+		err := fmt.Errorf("Parameter 'environment_id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	secrets, err := this.SecretLogic.ListSecrets(environmentID)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(secrets)
+}
+
+func (this *SecretHandler) CreateSecret(request *restful.Request, response *restful.Response) {
+	var req models.CreateSecretRequest
+	if err := request.ReadEntity(&req); err != nil {
+		BadRequest(response, errors.InvalidJSON, err)
+		return
+	}
+
+	secret, err := this.SecretLogic.CreateSecret(req)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(secret)
+}
+
+func (this *SecretHandler) DeleteSecret(request *restful.Request, response *restful.Response) {
+	environmentID := request.PathParameter("environment_id")
+	if environmentID == "" {
+		err := fmt.Errorf("Parameter 'environment_id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	if name == "" {
+		err := fmt.Errorf("Parameter 'name' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	if err := this.SecretLogic.DeleteSecret(environmentID, name); err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson("")
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/api/logic/mock_logic"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestListSecrets(t *testing.T) {
+	secrets := []*models.Secret{
+		{EnvironmentID: "e1", SecretName: "s1"},
+		{EnvironmentID: "e1", SecretName: "s2"},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should return secrets from logic layer",
+			Request: &TestRequest{
+				Parameters: map[string]string{"environment_id": "e1"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockSecretLogic(ctrl)
+				logicMock.EXPECT().
+					ListSecrets("e1").
+					Return(secrets, nil)
+
+				return NewSecretHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*SecretHandler)
+				handler.ListSecrets(req, resp)
+
+				var response []*models.Secret
+				read(&response)
+
+				reporter.AssertEqual(response, secrets)
+			},
+		},
+		{
+			Name:    "Should return MissingParameter error with no environment_id",
+			Request: &TestRequest{},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				return NewSecretHandler(mock_logic.NewMockSecretLogic(ctrl))
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*SecretHandler)
+				handler.ListSecrets(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.MissingParameter))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
+func TestCreateSecret(t *testing.T) {
+	request := models.CreateSecretRequest{
+		EnvironmentID: "e1",
+		SecretName:    "s1",
+		Value:         "value",
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call CreateSecret with correct params",
+			Request: &TestRequest{
+				Body: request,
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockSecretLogic(ctrl)
+				logicMock.EXPECT().
+					CreateSecret(request).
+					Return(&models.Secret{}, nil)
+
+				return NewSecretHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*SecretHandler)
+				handler.CreateSecret(req, resp)
+			},
+		},
+		{
+			Name: "Should propagate CreateSecret error",
+			Request: &TestRequest{
+				Body: request,
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockSecretLogic(ctrl)
+				logicMock.EXPECT().
+					CreateSecret(gomock.Any()).
+					Return(nil, errors.Newf(errors.InvalidSecretName, "some error"))
+
+				return NewSecretHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*SecretHandler)
+				handler.CreateSecret(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.InvalidSecretName))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
+func TestDeleteSecret(t *testing.T) {
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call DeleteSecret with correct params",
+			Request: &TestRequest{
+				Parameters: map[string]string{"environment_id": "e1", "name": "s1"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockSecretLogic(ctrl)
+				logicMock.EXPECT().
+					DeleteSecret("e1", "s1").
+					Return(nil)
+
+				return NewSecretHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*SecretHandler)
+				handler.DeleteSecret(req, resp)
+			},
+		},
+		{
+			Name: "Should return MissingParameter error with no name",
+			Request: &TestRequest{
+				Parameters: map[string]string{"environment_id": "e1"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				return NewSecretHandler(mock_logic.NewMockSecretLogic(ctrl))
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*SecretHandler)
+				handler.DeleteSecret(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.MissingParameter))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
@@ -92,6 +92,15 @@ func (this *ServiceHandler) Routes() *restful.WebService {
 		Param(service.QueryParameter("end", "The end of the time range to fetch logs (format YYYY-MM-DD HH:MM)").DataType("string")).
 		Writes([]models.LogFile{}))
 
+	service.Route(service.GET("/{id}/exec").
+		Filter(basicAuthenticate).
+		To(this.ExecService).
+		Doc("Run a command in a service container; the connection is upgraded to a websocket").
+		Param(service.PathParameter("id", "identifier of the service").DataType("string")).
+		Param(service.QueryParameter("container", "name of the container, optional if the service has a single container").DataType("string")).
+		Param(service.QueryParameter("command", "command to run, specified once per argument").DataType("string")).
+		Param(service.QueryParameter("tty", "allocate a tty for the command").DataType("boolean")))
+
 	return service
 }
 
@@ -228,3 +237,22 @@ func (this *ServiceHandler) GetServiceLogs(request *restful.Request, response *r
 
 	response.WriteAsJson(logs)
 }
+
+func (this *ServiceHandler) ExecService(request *restful.Request, response *restful.Response) {
+	serviceID := request.PathParameter("id")
+	if serviceID == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.InvalidServiceID, err)
+		return
+	}
+
+	req, err := parseExecRequest(request)
+	if err != nil {
+		BadRequest(response, errors.InvalidExecRequest, err)
+		return
+	}
+
+	serveExec(request, response, "service", serviceID, req, func(streams models.ExecStreams) (int, error) {
+		return this.ServiceLogic.ExecService(serviceID, req, streams)
+	})
+}
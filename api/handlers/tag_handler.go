@@ -43,7 +43,8 @@ func (t *TagHandler) Routes() *restful.WebService {
 	service.Route(service.POST("/").
 		Filter(basicAuthenticate).
 		To(t.CreateTag).
-		Doc("Create a tag for a service, deploy, or environment").
+		Doc("Create a tag for a service, deploy, or environment. "+
+			"User-defined labels are stored as tags with keys prefixed by '"+models.LabelPrefix+"'").
 		Reads(models.Tag{}).
 		Returns(http.StatusCreated, "Created", models.Tag{}).
 		Returns(400, "Invalid request", models.ServerError{}).
@@ -115,10 +116,10 @@ func (t *TagHandler) FindTags(request *restful.Request, response *restful.Respon
 		return
 	}
 
-	// filter the non-special params the by tag.Name and tag.Value (e.g. environment_id, version)
+	// filter the non-special params the by tag.Name and tag.Value (e.g. environment_id, version, label:team)
 	ewts := tags.GroupByEntity()
 	for key, val := range params {
-		ewts = ewts.WithKey(key).WithValue(val)
+		ewts = ewts.WithTag(key, val)
 	}
 
 	if fuzz != "" {
@@ -188,6 +189,12 @@ func (t *TagHandler) CreateTag(request *restful.Request, response *restful.Respo
 		return
 	}
 
+	if tag.Key == "" || tag.Key == models.LabelPrefix {
+		err := fmt.Errorf("Tag key cannot be empty")
+		BadRequest(response, errors.InvalidTagKey, err)
+		return
+	}
+
 	if err := t.TagStore.Insert(tag); err != nil {
 		ReturnError(response, err)
 		return
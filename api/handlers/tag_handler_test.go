@@ -5,6 +5,7 @@ import (
 
 	"github.com/emicklei/go-restful"
 	"github.com/quintilesims/layer0/common/db/tag_store"
+	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 )
@@ -27,6 +28,7 @@ var TestTags = models.Tags{
 	{EntityID: "s1", EntityType: "service", Key: "environment_id", Value: "e1"},
 	{EntityID: "s2", EntityType: "service", Key: "name", Value: "svc2"},
 	{EntityID: "s2", EntityType: "service", Key: "environment_id", Value: "e2"},
+	{EntityID: "s2", EntityType: "service", Key: "label:team", Value: "payments"},
 
 	{EntityID: "t1", EntityType: "task", Key: "name", Value: "tsk1"},
 	{EntityID: "t1", EntityType: "task", Key: "environment_id", Value: "e1"},
@@ -151,6 +153,62 @@ func TestFindTags(t *testing.T) {
 				r.AssertEqual(tags[0].EntityID, "d2")
 			},
 		},
+		{
+			Name: "type=service&label:team=payments",
+			Request: &TestRequest{
+				Query: "type=service&label:team=payments",
+			},
+			Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler.FindTags(req, resp)
+
+				var tags []models.EntityWithTags
+				read(&tags)
+
+				r.AssertEqual(len(tags), 1)
+				r.AssertEqual(tags[0].EntityID, "s2")
+				r.AssertEqual(tags[0].Tags.Labels(), map[string]string{"team": "payments"})
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, cases)
+}
+
+func TestCreateTag(t *testing.T) {
+	store := getTestTagStore(t, nil)
+	handler := NewTagHandler(store)
+
+	cases := []HandlerTestCase{
+		{
+			Name: "Should insert tag",
+			Request: &TestRequest{
+				Body: models.Tag{EntityID: "s1", EntityType: "service", Key: "label:team", Value: "payments"},
+			},
+			Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler.CreateTag(req, resp)
+
+				tags, err := store.SelectByTypeAndID("service", "s1")
+				if err != nil {
+					r.Fatal(err)
+				}
+
+				r.AssertEqual(tags.Labels(), map[string]string{"team": "payments"})
+			},
+		},
+		{
+			Name: "Should return InvalidTagKey error for an empty label key",
+			Request: &TestRequest{
+				Body: models.Tag{EntityID: "s1", EntityType: "service", Key: "label:", Value: "payments"},
+			},
+			Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler.CreateTag(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				r.AssertEqual(response.ErrorCode, int64(errors.InvalidTagKey))
+			},
+		},
 	}
 
 	RunHandlerTestCases(t, cases)
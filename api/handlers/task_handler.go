@@ -72,6 +72,15 @@ func (this *TaskHandler) Routes() *restful.WebService {
 		Param(service.QueryParameter("end", "The end of the time range to fetch logs (format YYYY-MM-DD HH:MM)").DataType("string")).
 		Writes([]models.LogFile{}))
 
+	service.Route(service.GET("/{id}/exec").
+		Filter(basicAuthenticate).
+		To(this.ExecTask).
+		Doc("Run a command in a task container; the connection is upgraded to a websocket").
+		Param(service.PathParameter("id", "identifier of the task").DataType("string")).
+		Param(service.QueryParameter("container", "name of the container, optional if the task has a single container").DataType("string")).
+		Param(service.QueryParameter("command", "command to run, specified once per argument").DataType("string")).
+		Param(service.QueryParameter("tty", "allocate a tty for the command").DataType("boolean")))
+
 	return service
 }
 
@@ -161,3 +170,22 @@ func (this *TaskHandler) GetTaskLogs(request *restful.Request, response *restful
 
 	response.WriteAsJson(logs)
 }
+
+func (this *TaskHandler) ExecTask(request *restful.Request, response *restful.Response) {
+	taskID := request.PathParameter("id")
+	if taskID == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.InvalidTaskID, err)
+		return
+	}
+
+	req, err := parseExecRequest(request)
+	if err != nil {
+		BadRequest(response, errors.InvalidExecRequest, err)
+		return
+	}
+
+	serveExec(request, response, "task", taskID, req, func(streams models.ExecStreams) (int, error) {
+		return this.TaskLogic.ExecTask(taskID, req, streams)
+	})
+}
@@ -0,0 +1,162 @@
+package logic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/quintilesims/layer0/common/aws/ecs"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+const (
+	ContainerAdded   = "added"
+	ContainerRemoved = "removed"
+	ContainerChanged = "changed"
+)
+
+// diffDockerruns compares the containers and volumes of two dockerruns; containers and volumes
+// are matched by name, and only those that differ are included in the result
+func diffDockerruns(from, to *models.Dockerrun) ([]models.ContainerDiff, []models.DeployChange) {
+	fromContainers := map[string]*ecs.ContainerDefinition{}
+	for _, container := range from.ContainerDefinitions {
+		fromContainers[aws.StringValue(container.Name)] = container
+	}
+
+	toContainers := map[string]*ecs.ContainerDefinition{}
+	for _, container := range to.ContainerDefinitions {
+		toContainers[aws.StringValue(container.Name)] = container
+	}
+
+	names := map[string]bool{}
+	for name := range fromContainers {
+		names[name] = true
+	}
+
+	for name := range toContainers {
+		names[name] = true
+	}
+
+	sortedNames := []string{}
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+
+	sort.Strings(sortedNames)
+
+	containerDiffs := []models.ContainerDiff{}
+	for _, name := range sortedNames {
+		fromContainer, inFrom := fromContainers[name]
+		toContainer, inTo := toContainers[name]
+
+		diff := models.ContainerDiff{ContainerName: name}
+		switch {
+		case !inFrom:
+			diff.Status = ContainerAdded
+			diff.Changes = diffFields(map[string]string{}, containerFields(toContainer))
+		case !inTo:
+			diff.Status = ContainerRemoved
+			diff.Changes = diffFields(containerFields(fromContainer), map[string]string{})
+		default:
+			diff.Status = ContainerChanged
+			diff.Changes = diffFields(containerFields(fromContainer), containerFields(toContainer))
+		}
+
+		if len(diff.Changes) > 0 {
+			containerDiffs = append(containerDiffs, diff)
+		}
+	}
+
+	volumeChanges := diffFields(volumeFields(from.Volumes), volumeFields(to.Volumes))
+	return containerDiffs, volumeChanges
+}
+
+// containerFields flattens the parts of a container definition we diff into 'field: value' pairs
+func containerFields(container *ecs.ContainerDefinition) map[string]string {
+	fields := map[string]string{
+		"image": aws.StringValue(container.Image),
+	}
+
+	if container.Memory != nil {
+		fields["memory"] = strconv.FormatInt(*container.Memory, 10)
+	}
+
+	if container.MemoryReservation != nil {
+		fields["memoryReservation"] = strconv.FormatInt(*container.MemoryReservation, 10)
+	}
+
+	for _, variable := range container.Environment {
+		fields["environment."+aws.StringValue(variable.Name)] = aws.StringValue(variable.Value)
+	}
+
+	for _, portMapping := range container.PortMappings {
+		protocol := aws.StringValue(portMapping.Protocol)
+		if protocol == "" {
+			protocol = "tcp"
+		}
+
+		hostPort := "dynamic"
+		if port := aws.Int64Value(portMapping.HostPort); port != 0 {
+			hostPort = strconv.FormatInt(port, 10)
+		}
+
+		fields[fmt.Sprintf("portMapping.%d/%s", aws.Int64Value(portMapping.ContainerPort), protocol)] = hostPort
+	}
+
+	for _, mountPoint := range container.MountPoints {
+		source := aws.StringValue(mountPoint.SourceVolume)
+		if aws.BoolValue(mountPoint.ReadOnly) {
+			source += " (read only)"
+		}
+
+		fields["mountPoint."+aws.StringValue(mountPoint.ContainerPath)] = source
+	}
+
+	return fields
+}
+
+func volumeFields(volumes []*ecs.Volume) map[string]string {
+	fields := map[string]string{}
+	for _, volume := range volumes {
+		source := "docker managed"
+		if volume.Host != nil && volume.Host.SourcePath != nil {
+			source = aws.StringValue(volume.Host.SourcePath)
+		}
+
+		fields["volume."+aws.StringValue(volume.Name)] = source
+	}
+
+	return fields
+}
+
+func diffFields(from, to map[string]string) []models.DeployChange {
+	keys := map[string]bool{}
+	for key := range from {
+		keys[key] = true
+	}
+
+	for key := range to {
+		keys[key] = true
+	}
+
+	sortedKeys := []string{}
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+
+	sort.Strings(sortedKeys)
+
+	changes := []models.DeployChange{}
+	for _, key := range sortedKeys {
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		if inFrom == inTo && fromValue == toValue {
+			continue
+		}
+
+		changes = append(changes, models.DeployChange{Field: key, From: fromValue, To: toValue})
+	}
+
+	return changes
+}
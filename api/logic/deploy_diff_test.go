@@ -0,0 +1,101 @@
+package logic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func unmarshalDockerrun(t *testing.T, body string) *models.Dockerrun {
+	var dockerrun models.Dockerrun
+	if err := json.Unmarshal([]byte(body), &dockerrun); err != nil {
+		t.Fatal(err)
+	}
+
+	return &dockerrun
+}
+
+func TestDiffDockerruns(t *testing.T) {
+	from := unmarshalDockerrun(t, `{
+		"containerDefinitions": [
+			{
+				"name": "api",
+				"image": "api:1",
+				"memory": 512,
+				"environment": [{"name": "MODE", "value": "prod"}, {"name": "DEBUG", "value": "false"}],
+				"portMappings": [{"hostPort": 80, "containerPort": 80}],
+				"mountPoints": [{"sourceVolume": "data", "containerPath": "/data"}]
+			},
+			{"name": "worker", "image": "worker:1", "memory": 128},
+			{"name": "old", "image": "old:1", "memory": 128}
+		],
+		"volumes": [{"name": "data", "host": {"sourcePath": "/var/data"}}]
+	}`)
+
+	to := unmarshalDockerrun(t, `{
+		"containerDefinitions": [
+			{
+				"name": "api",
+				"image": "api:2",
+				"memory": 1024,
+				"environment": [{"name": "MODE", "value": "prod"}, {"name": "VERBOSE", "value": "true"}],
+				"portMappings": [{"containerPort": 80}],
+				"mountPoints": [{"sourceVolume": "data", "containerPath": "/data", "readOnly": true}]
+			},
+			{"name": "worker", "image": "worker:1", "memory": 128},
+			{"name": "new", "image": "new:1", "memoryReservation": 64}
+		],
+		"volumes": [{"name": "data"}]
+	}`)
+
+	containers, volumes := diffDockerruns(from, to)
+
+	expectedContainers := []models.ContainerDiff{
+		{
+			ContainerName: "api",
+			Status:        ContainerChanged,
+			Changes: []models.DeployChange{
+				{Field: "environment.DEBUG", From: "false", To: ""},
+				{Field: "environment.VERBOSE", From: "", To: "true"},
+				{Field: "image", From: "api:1", To: "api:2"},
+				{Field: "memory", From: "512", To: "1024"},
+				{Field: "mountPoint./data", From: "data", To: "data (read only)"},
+				{Field: "portMapping.80/tcp", From: "80", To: "dynamic"},
+			},
+		},
+		{
+			ContainerName: "new",
+			Status:        ContainerAdded,
+			Changes: []models.DeployChange{
+				{Field: "image", From: "", To: "new:1"},
+				{Field: "memoryReservation", From: "", To: "64"},
+			},
+		},
+		{
+			ContainerName: "old",
+			Status:        ContainerRemoved,
+			Changes: []models.DeployChange{
+				{Field: "image", From: "old:1", To: ""},
+				{Field: "memory", From: "128", To: ""},
+			},
+		},
+	}
+
+	expectedVolumes := []models.DeployChange{
+		{Field: "volume.data", From: "/var/data", To: "docker managed"},
+	}
+
+	testutils.AssertEqual(t, containers, expectedContainers)
+	testutils.AssertEqual(t, volumes, expectedVolumes)
+}
+
+func TestDiffDockerruns_noChanges(t *testing.T) {
+	body := `{"containerDefinitions": [{"name": "api", "image": "api:1", "memory": 512}]}`
+
+	containers, volumes := diffDockerruns(unmarshalDockerrun(t, body), unmarshalDockerrun(t, body))
+
+	testutils.AssertEqual(t, containers, []models.ContainerDiff{})
+	testutils.AssertEqual(t, volumes, []models.DeployChange{})
+}
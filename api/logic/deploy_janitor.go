@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"time"
+
+	"github.com/quintilesims/layer0/common/logutils"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/waitutils"
+)
+
+const (
+	deployJanitorSleepDuration = time.Hour * 1
+)
+
+var deployLogger = logutils.NewStackTraceLogger("Deploy Janitor")
+
+type DeployJanitor struct {
+	DeployLogic DeployLogic
+	Keep        int
+	Clock       waitutils.Clock
+}
+
+func NewDeployJanitor(deployLogic DeployLogic, keep int) *DeployJanitor {
+	return &DeployJanitor{
+		DeployLogic: deployLogic,
+		Keep:        keep,
+		Clock:       waitutils.RealClock{},
+	}
+}
+
+func (d *DeployJanitor) Run() {
+	go func() {
+		for {
+			deployLogger.Info("Starting cleanup")
+			d.pulse()
+			deployLogger.Infof("Finished cleanup")
+			d.Clock.Sleep(deployJanitorSleepDuration)
+		}
+	}()
+}
+
+func (d *DeployJanitor) pulse() error {
+	report, err := d.DeployLogic.PruneDeploys(models.PruneDeploysRequest{Keep: d.Keep})
+	if err != nil {
+		deployLogger.Errorf("Failed to prune deploys: %v", err)
+		return err
+	}
+
+	for _, deploy := range report.Pruned {
+		deployLogger.Infof("Deleted deploy '%s'", deploy.DeployID)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/api/logic/mock_logic"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+func TestDeployJanitorPulse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deployLogicMock := mock_logic.NewMockDeployLogic(ctrl)
+
+	deployLogicMock.EXPECT().
+		PruneDeploys(models.PruneDeploysRequest{Keep: 5}).
+		Return(&models.PruneDeploysReport{}, nil)
+
+	janitor := NewDeployJanitor(deployLogicMock, 5)
+	if err := janitor.pulse(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,11 @@
 package logic
 
 import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/quintilesims/layer0/api/backend/ecs"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 )
@@ -10,6 +15,9 @@ type DeployLogic interface {
 	GetDeploy(deployID string) (*models.Deploy, error)
 	DeleteDeploy(deployID string) error
 	CreateDeploy(model models.CreateDeployRequest) (*models.Deploy, error)
+	ValidateDeploy(model models.ValidateDeployRequest) (*models.DeployValidation, error)
+	DiffDeploys(deployID, otherDeployID string) (*models.DeployDiff, error)
+	PruneDeploys(req models.PruneDeploysRequest) (*models.PruneDeploysReport, error)
 }
 
 type L0DeployLogic struct {
@@ -72,7 +80,36 @@ func (d *L0DeployLogic) CreateDeploy(req models.CreateDeployRequest) (*models.De
 		return nil, errors.Newf(errors.MissingParameter, "DeployName is required")
 	}
 
-	deploy, err := d.Backend.CreateDeploy(req.DeployName, req.Dockerrun)
+	body := req.Dockerrun
+	var variables map[string]string
+	if len(req.Template) > 0 {
+		if len(req.Dockerrun) > 0 {
+			return nil, errors.Newf(errors.InvalidDeployTemplate, "Dockerrun and Template cannot both be specified")
+		}
+
+		body = req.Template
+		variables = req.Variables
+		if variables == nil {
+			variables = map[string]string{}
+		}
+	} else if len(req.Variables) > 0 {
+		return nil, errors.Newf(errors.MissingParameter, "Variables can only be used with a Template")
+	}
+
+	if len(req.Compose) > 0 {
+		if len(body) > 0 {
+			return nil, errors.Newf(errors.InvalidCompose, "Compose cannot be specified with Dockerrun or Template")
+		}
+
+		dockerrun, err := ecsbackend.ConvertCompose(req.Compose)
+		if err != nil {
+			return nil, err
+		}
+
+		body = dockerrun
+	}
+
+	deploy, err := d.Backend.CreateDeploy(req.DeployName, body, variables)
 	if err != nil {
 		return deploy, err
 	}
@@ -85,6 +122,16 @@ func (d *L0DeployLogic) CreateDeploy(req models.CreateDeployRequest) (*models.De
 		return deploy, err
 	}
 
+	if len(req.Template) > 0 {
+		if err := d.insertTemplateTags(deploy.DeployID, req.Template, variables); err != nil {
+			return deploy, err
+		}
+	}
+
+	if err := d.insertSecretsTag(deploy.DeployID, deploy.Dockerrun); err != nil {
+		return deploy, err
+	}
+
 	if err := d.populateModel(deploy); err != nil {
 		return deploy, err
 	}
@@ -92,6 +139,141 @@ func (d *L0DeployLogic) CreateDeploy(req models.CreateDeployRequest) (*models.De
 	return deploy, nil
 }
 
+func (d *L0DeployLogic) ValidateDeploy(req models.ValidateDeployRequest) (*models.DeployValidation, error) {
+	if len(req.Dockerrun) == 0 {
+		return nil, errors.Newf(errors.MissingParameter, "Dockerrun is required")
+	}
+
+	return d.Backend.ValidateDeploy(req.DeployName, req.Dockerrun, req.EnvironmentID)
+}
+
+func (d *L0DeployLogic) DiffDeploys(deployID, otherDeployID string) (*models.DeployDiff, error) {
+	dockerruns := []*models.Dockerrun{}
+	for _, id := range []string{deployID, otherDeployID} {
+		deploy, err := d.Backend.GetDeploy(id)
+		if err != nil {
+			return nil, err
+		}
+
+		dockerrun, err := ecsbackend.MarshalDockerrun(deploy.Dockerrun)
+		if err != nil {
+			return nil, err
+		}
+
+		dockerruns = append(dockerruns, dockerrun)
+	}
+
+	containers, volumes := diffDockerruns(dockerruns[0], dockerruns[1])
+	diff := &models.DeployDiff{
+		Containers:    containers,
+		DeployID:      deployID,
+		OtherDeployID: otherDeployID,
+		Volumes:       volumes,
+	}
+
+	return diff, nil
+}
+
+// PruneDeploys deletes all but the latest req.Keep versions of each deploy family.
+// Versions used by a service or task are never deleted, and do not count towards req.Keep.
+// If req.DryRun is set, the deploys that would have been deleted are reported, but not deleted.
+func (d *L0DeployLogic) PruneDeploys(req models.PruneDeploysRequest) (*models.PruneDeploysReport, error) {
+	if req.Keep < 1 {
+		return nil, errors.Newf(errors.MissingParameter, "Keep must be at least 1")
+	}
+
+	deploys, err := d.ListDeploys()
+	if err != nil {
+		return nil, err
+	}
+
+	inUse, err := d.getDeploysInUse()
+	if err != nil {
+		return nil, err
+	}
+
+	families := map[string][]*models.DeploySummary{}
+	for _, deploy := range deploys {
+		// deploys without a name were not created by layer0, e.g. the api's own deploy
+		if deploy.DeployName == "" || inUse[deploy.DeployID] {
+			continue
+		}
+
+		if _, err := strconv.Atoi(deploy.Version); err != nil {
+			continue
+		}
+
+		families[deploy.DeployName] = append(families[deploy.DeployName], deploy)
+	}
+
+	report := &models.PruneDeploysReport{
+		DryRun: req.DryRun,
+		Pruned: []models.DeploySummary{},
+	}
+
+	for _, family := range families {
+		if len(family) <= req.Keep {
+			continue
+		}
+
+		sort.Slice(family, func(i, j int) bool {
+			vi, _ := strconv.Atoi(family[i].Version)
+			vj, _ := strconv.Atoi(family[j].Version)
+			return vi > vj
+		})
+
+		for _, deploy := range family[req.Keep:] {
+			if !req.DryRun {
+				if err := d.DeleteDeploy(deploy.DeployID); err != nil {
+					return nil, err
+				}
+			}
+
+			report.Pruned = append(report.Pruned, *deploy)
+		}
+	}
+
+	sort.Slice(report.Pruned, func(i, j int) bool {
+		return report.Pruned[i].DeployID < report.Pruned[j].DeployID
+	})
+
+	return report, nil
+}
+
+// getDeploysInUse returns the ids of the deploys currently used by a service deployment or a task
+func (d *L0DeployLogic) getDeploysInUse() (map[string]bool, error) {
+	inUse := map[string]bool{}
+
+	environmentIDs, err := d.Backend.ListEnvironments()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, environmentID := range environmentIDs {
+		services, err := d.Backend.GetEnvironmentServices(environmentID.L0EnvironmentID())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, service := range services {
+			for _, deployment := range service.Deployments {
+				inUse[deployment.DeployID] = true
+			}
+		}
+	}
+
+	tags, err := d.TagStore.SelectByType("task")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags.WithKey("deploy_id") {
+		inUse[tag.Value] = true
+	}
+
+	return inUse, nil
+}
+
 func (d *L0DeployLogic) populateModel(model *models.Deploy) error {
 	tags, err := d.TagStore.SelectByTypeAndID("deploy", model.DeployID)
 	if err != nil {
@@ -106,5 +288,52 @@ func (d *L0DeployLogic) populateModel(model *models.Deploy) error {
 		model.Version = tag.Value
 	}
 
+	if tag, ok := tags.WithKey("template").First(); ok {
+		model.Template = []byte(tag.Value)
+	}
+
+	if tag, ok := tags.WithKey("variables").First(); ok {
+		if err := json.Unmarshal([]byte(tag.Value), &model.Variables); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// the template and variables a deploy was rendered from are kept so they can be shown alongside the rendered dockerrun
+func (d *L0DeployLogic) insertTemplateTags(deployID string, template []byte, variables map[string]string) error {
+	if err := d.TagStore.Insert(models.Tag{EntityID: deployID, EntityType: "deploy", Key: "template", Value: string(template)}); err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(variables)
+	if err != nil {
+		return err
+	}
+
+	return d.TagStore.Insert(models.Tag{EntityID: deployID, EntityType: "deploy", Key: "variables", Value: string(value)})
+}
+
+// the secrets a deploy references are kept so they can be checked when the deploy is run as a service or task
+func (d *L0DeployLogic) insertSecretsTag(deployID string, dockerrun []byte) error {
+	if len(dockerrun) == 0 {
+		return nil
+	}
+
+	references, err := secretReferencesFromDockerrun(dockerrun)
+	if err != nil {
+		return err
+	}
+
+	if len(references) == 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(references)
+	if err != nil {
+		return err
+	}
+
+	return d.TagStore.Insert(models.Tag{EntityID: deployID, EntityType: "deploy", Key: "secrets", Value: string(value)})
+}
@@ -3,6 +3,8 @@ package logic
 import (
 	"testing"
 
+	"github.com/quintilesims/layer0/api/backend/ecs"
+	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 )
@@ -103,7 +105,7 @@ func TestCreateDeploy(t *testing.T) {
 	retDeploy := &models.Deploy{DeployID: "d1", Version: "1"}
 
 	testLogic.Backend.EXPECT().
-		CreateDeploy("name", []byte("dockerrun")).
+		CreateDeploy("name", []byte("dockerrun"), nil).
 		Return(retDeploy, nil)
 
 	request := models.CreateDeployRequest{
@@ -128,6 +130,107 @@ func TestCreateDeploy(t *testing.T) {
 	testLogic.AssertTagExists(t, models.Tag{EntityID: "d1", EntityType: "deploy", Key: "version", Value: "1"})
 }
 
+func TestCreateDeploy_secrets(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	dockerrun := []byte(`{"containerDefinitions":[{"name":"api","environment":[{"name":"DB_PASSWORD","value":"secret://e1/db_password"},{"name":"MODE","value":"prod"}]}]}`)
+	retDeploy := &models.Deploy{DeployID: "d1", Version: "1", Dockerrun: dockerrun}
+
+	testLogic.Backend.EXPECT().
+		CreateDeploy("name", dockerrun, nil).
+		Return(retDeploy, nil)
+
+	request := models.CreateDeployRequest{
+		DeployName: "name",
+		Dockerrun:  dockerrun,
+	}
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	if _, err := deployLogic.CreateDeploy(request); err != nil {
+		t.Fatal(err)
+	}
+
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "d1", EntityType: "deploy", Key: "secrets", Value: `{"api":{"DB_PASSWORD":"e1/db_password"}}`})
+}
+
+func TestCreateDeploy_compose(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	compose := []byte("version: '2'\nservices:\n  api:\n    image: api\n    mem_limit: 128m\n")
+	dockerrun, err := ecsbackend.ConvertCompose(compose)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testLogic.Backend.EXPECT().
+		CreateDeploy("name", dockerrun, nil).
+		Return(&models.Deploy{DeployID: "d1", Version: "1"}, nil)
+
+	request := models.CreateDeployRequest{
+		Compose:    compose,
+		DeployName: "name",
+	}
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	if _, err := deployLogic.CreateDeploy(request); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateDeployError_composeWithDockerrun(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	request := models.CreateDeployRequest{
+		Compose:    []byte("compose"),
+		DeployName: "name",
+		Dockerrun:  []byte("dockerrun"),
+	}
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	if _, err := deployLogic.CreateDeploy(request); err == nil {
+		t.Errorf("Error was nil!")
+	}
+}
+
+func TestCreateDeploy_template(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	retDeploy := &models.Deploy{DeployID: "d1", Version: "1"}
+	variables := map[string]string{"tag": "v2"}
+
+	testLogic.Backend.EXPECT().
+		CreateDeploy("name", []byte("template"), variables).
+		Return(retDeploy, nil)
+
+	request := models.CreateDeployRequest{
+		DeployName: "name",
+		Template:   []byte("template"),
+		Variables:  variables,
+	}
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	received, err := deployLogic.CreateDeploy(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &models.Deploy{
+		DeployID:   "d1",
+		DeployName: "name",
+		Template:   []byte("template"),
+		Variables:  variables,
+		Version:    "1",
+	}
+
+	testutils.AssertEqual(t, received, expected)
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "d1", EntityType: "deploy", Key: "template", Value: "template"})
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "d1", EntityType: "deploy", Key: "variables", Value: `{"tag":"v2"}`})
+}
+
 func TestCreateDeployError_missingRequiredParams(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -136,6 +239,16 @@ func TestCreateDeployError_missingRequiredParams(t *testing.T) {
 
 	cases := map[string]models.CreateDeployRequest{
 		"Missing DeployName": {},
+		"Dockerrun and Template": {
+			DeployName: "name",
+			Dockerrun:  []byte("dockerrun"),
+			Template:   []byte("template"),
+		},
+		"Variables without Template": {
+			DeployName: "name",
+			Dockerrun:  []byte("dockerrun"),
+			Variables:  map[string]string{"tag": "v2"},
+		},
 	}
 
 	for name, request := range cases {
@@ -144,3 +257,178 @@ func TestCreateDeployError_missingRequiredParams(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDeploy(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	expected := &models.DeployValidation{Valid: true}
+
+	testLogic.Backend.EXPECT().
+		ValidateDeploy("name", []byte("dockerrun"), "eid").
+		Return(expected, nil)
+
+	request := models.ValidateDeployRequest{
+		DeployName:    "name",
+		Dockerrun:     []byte("dockerrun"),
+		EnvironmentID: "eid",
+	}
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	received, err := deployLogic.ValidateDeploy(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, received, expected)
+}
+
+func TestValidateDeployError_missingDockerrun(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	if _, err := deployLogic.ValidateDeploy(models.ValidateDeployRequest{}); err == nil {
+		t.Errorf("Error was nil!")
+	}
+}
+
+func TestDiffDeploys(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		GetDeploy("d.1").
+		Return(&models.Deploy{DeployID: "d.1", Dockerrun: []byte(`{"containerDefinitions": [{"name": "api", "image": "api:1"}]}`)}, nil)
+
+	testLogic.Backend.EXPECT().
+		GetDeploy("d.2").
+		Return(&models.Deploy{DeployID: "d.2", Dockerrun: []byte(`{"containerDefinitions": [{"name": "api", "image": "api:2"}]}`)}, nil)
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	received, err := deployLogic.DiffDeploys("d.1", "d.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &models.DeployDiff{
+		Containers: []models.ContainerDiff{
+			{
+				ContainerName: "api",
+				Status:        "changed",
+				Changes:       []models.DeployChange{{Field: "image", From: "api:1", To: "api:2"}},
+			},
+		},
+		DeployID:      "d.1",
+		OtherDeployID: "d.2",
+		Volumes:       []models.DeployChange{},
+	}
+
+	testutils.AssertEqual(t, received, expected)
+}
+
+func TestPruneDeploys(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "d.1", EntityType: "deploy", Key: "name", Value: "dpl"},
+		{EntityID: "d.1", EntityType: "deploy", Key: "version", Value: "1"},
+		{EntityID: "d.2", EntityType: "deploy", Key: "name", Value: "dpl"},
+		{EntityID: "d.2", EntityType: "deploy", Key: "version", Value: "2"},
+		{EntityID: "d.3", EntityType: "deploy", Key: "name", Value: "dpl"},
+		{EntityID: "d.3", EntityType: "deploy", Key: "version", Value: "3"},
+		{EntityID: "d.4", EntityType: "deploy", Key: "name", Value: "dpl"},
+		{EntityID: "d.4", EntityType: "deploy", Key: "version", Value: "4"},
+		{EntityID: "d.5", EntityType: "deploy", Key: "name", Value: "dpl"},
+		{EntityID: "d.5", EntityType: "deploy", Key: "version", Value: "5"},
+		{EntityID: "o.1", EntityType: "deploy", Key: "name", Value: "other"},
+		{EntityID: "o.1", EntityType: "deploy", Key: "version", Value: "1"},
+		{EntityID: "t1", EntityType: "task", Key: "deploy_id", Value: "d.2"},
+	})
+
+	testLogic.Backend.EXPECT().
+		ListDeploys().
+		Return([]*models.Deploy{
+			{DeployID: "d.1"},
+			{DeployID: "d.2"},
+			{DeployID: "d.3"},
+			{DeployID: "d.4"},
+			{DeployID: "d.5"},
+			{DeployID: "o.1"},
+			{DeployID: "api.1"},
+		}, nil)
+
+	testLogic.Backend.EXPECT().
+		ListEnvironments().
+		Return([]id.ECSEnvironmentID{id.L0EnvironmentID("e1").ECSEnvironmentID()}, nil)
+
+	testLogic.Backend.EXPECT().
+		GetEnvironmentServices("e1").
+		Return([]*models.Service{
+			{Deployments: []models.Deployment{{DeployID: "d.1"}}},
+		}, nil)
+
+	testLogic.Backend.EXPECT().
+		DeleteDeploy("d.3").
+		Return(nil)
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	report, err := deployLogic.PruneDeploys(models.PruneDeploysRequest{Keep: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []models.DeploySummary{
+		{DeployID: "d.3", DeployName: "dpl", Version: "3"},
+	}
+
+	testutils.AssertEqual(t, report.Pruned, expected)
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "d.4", EntityType: "deploy", Key: "name", Value: "dpl"})
+
+	tags, err := testLogic.TagStore.SelectByTypeAndID("deploy", "d.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(tags), 0)
+}
+
+func TestPruneDeploys_dryRun(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "d.1", EntityType: "deploy", Key: "name", Value: "dpl"},
+		{EntityID: "d.1", EntityType: "deploy", Key: "version", Value: "1"},
+		{EntityID: "d.2", EntityType: "deploy", Key: "name", Value: "dpl"},
+		{EntityID: "d.2", EntityType: "deploy", Key: "version", Value: "2"},
+	})
+
+	testLogic.Backend.EXPECT().
+		ListDeploys().
+		Return([]*models.Deploy{{DeployID: "d.1"}, {DeployID: "d.2"}}, nil)
+
+	testLogic.Backend.EXPECT().
+		ListEnvironments().
+		Return([]id.ECSEnvironmentID{}, nil)
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	report, err := deployLogic.PruneDeploys(models.PruneDeploysRequest{DryRun: true, Keep: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, report.DryRun, true)
+	testutils.AssertEqual(t, report.Pruned, []models.DeploySummary{{DeployID: "d.1", DeployName: "dpl", Version: "1"}})
+}
+
+func TestPruneDeploysError_invalidKeep(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	if _, err := deployLogic.PruneDeploys(models.PruneDeploysRequest{Keep: 0}); err == nil {
+		t.Errorf("Error was nil!")
+	}
+}
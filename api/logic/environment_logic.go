@@ -1,6 +1,13 @@
 package logic
 
 import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
@@ -13,8 +20,8 @@ type EnvironmentLogic interface {
 	CanCreateEnvironment(req models.CreateEnvironmentRequest) (bool, error)
 	CreateEnvironment(req models.CreateEnvironmentRequest) (*models.Environment, error)
 	UpdateEnvironment(id string, minClusterCount int) (*models.Environment, error)
-	CreateEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error
-	DeleteEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error
+	CreateEnvironmentLink(environmentID string, link models.EnvironmentLink) error
+	DeleteEnvironmentLink(environmentID, peer string) error
 }
 
 type L0EnvironmentLogic struct {
@@ -55,8 +62,24 @@ func (e *L0EnvironmentLogic) DeleteEnvironment(environmentID string) error {
 		return err
 	}
 
-	for _, tag := range tags.WithKey("link") {
-		if err := e.DeleteEnvironmentLink(environmentID, tag.Value); err != nil {
+	links, err := environmentLinksFromTags(tags)
+	if err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		if err := e.DeleteEnvironmentLink(environmentID, link.Peer()); err != nil {
+			return err
+		}
+	}
+
+	secrets, err := e.Backend.ListSecrets(environmentID)
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		if err := e.Backend.DeleteSecret(environmentID, secret.SecretName); err != nil {
 			return err
 		}
 	}
@@ -130,46 +153,116 @@ func (e *L0EnvironmentLogic) UpdateEnvironment(environmentID string, minClusterC
 	return environment, nil
 }
 
-func (e *L0EnvironmentLogic) CreateEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error {
-	if err := e.Backend.CreateEnvironmentLink(sourceEnvironmentID, destEnvironmentID); err != nil {
+func (e *L0EnvironmentLogic) CreateEnvironmentLink(environmentID string, link models.EnvironmentLink) error {
+	link, err := normalizeEnvironmentLink(environmentID, link)
+	if err != nil {
 		return err
 	}
 
-	if err := e.TagStore.Insert(models.Tag{EntityID: sourceEnvironmentID, EntityType: "environment", Key: "link", Value: destEnvironmentID}); err != nil {
+	tags, err := e.TagStore.SelectByTypeAndID("environment", environmentID)
+	if err != nil {
+		return err
+	}
+
+	links, err := environmentLinksFromTags(tags)
+	if err != nil {
 		return err
 	}
 
-	if err := e.TagStore.Insert(models.Tag{EntityID: destEnvironmentID, EntityType: "environment", Key: "link", Value: sourceEnvironmentID}); err != nil {
+	for _, existing := range links {
+		if existing.Peer() != link.Peer() {
+			continue
+		}
+
+		if !reflect.DeepEqual(existing, link) {
+			return errors.Newf(errors.InvalidEnvironmentLink, "Environment '%s' is already linked to '%s' with different rules", environmentID, link.Peer())
+		}
+
+		return nil
+	}
+
+	if err := e.Backend.CreateEnvironmentLink(environmentID, link); err != nil {
 		return err
 	}
 
+	if err := e.insertEnvironmentLinkTag(environmentID, link); err != nil {
+		return err
+	}
+
+	// environment links are recorded on both environments from their own point of view
+	if link.EnvironmentID != "" {
+		mirror := link
+		mirror.EnvironmentID = environmentID
+		mirror.Direction = reverseLinkDirection(link.Direction)
+
+		if err := e.insertEnvironmentLinkTag(link.EnvironmentID, mirror); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (e *L0EnvironmentLogic) DeleteEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error {
-	if err := e.Backend.DeleteEnvironmentLink(sourceEnvironmentID, destEnvironmentID); err != nil {
+func (e *L0EnvironmentLogic) DeleteEnvironmentLink(environmentID, peer string) error {
+	tags, err := e.TagStore.SelectByTypeAndID("environment", environmentID)
+	if err != nil {
+		return err
+	}
+
+	links, err := environmentLinksFromTags(tags)
+	if err != nil {
 		return err
 	}
 
-	sourceTags, err := e.TagStore.SelectByTypeAndID("environment", sourceEnvironmentID)
+	// links that aren't tracked are assumed to be legacy links that allow all traffic
+	link, err := normalizeEnvironmentLink(environmentID, environmentLinkForPeer(peer))
 	if err != nil {
 		return err
 	}
 
-	for _, tag := range sourceTags.WithKey("link").WithValue(destEnvironmentID) {
-		if err := e.TagStore.Delete(tag.EntityType, tag.EntityID, tag.Key); err != nil {
+	for _, existing := range links {
+		if existing.Peer() == peer {
+			link = existing
+		}
+	}
+
+	if err := e.Backend.DeleteEnvironmentLink(environmentID, link); err != nil {
+		return err
+	}
+
+	if err := e.deleteEnvironmentLinkTags(environmentID, peer); err != nil {
+		return err
+	}
+
+	if link.EnvironmentID != "" {
+		if err := e.deleteEnvironmentLinkTags(link.EnvironmentID, environmentID); err != nil {
 			return err
 		}
 	}
 
-	destTags, err := e.TagStore.SelectByTypeAndID("environment", destEnvironmentID)
+	return nil
+}
+
+func (e *L0EnvironmentLogic) insertEnvironmentLinkTag(environmentID string, link models.EnvironmentLink) error {
+	value, err := json.Marshal(link)
+	if err != nil {
+		return err
+	}
+
+	return e.TagStore.Insert(models.Tag{EntityID: environmentID, EntityType: "environment", Key: "link:" + link.Peer(), Value: string(value)})
+}
+
+func (e *L0EnvironmentLogic) deleteEnvironmentLinkTags(environmentID, peer string) error {
+	tags, err := e.TagStore.SelectByTypeAndID("environment", environmentID)
 	if err != nil {
 		return err
 	}
 
-	for _, tag := range destTags.WithKey("link").WithValue(sourceEnvironmentID) {
-		if err := e.TagStore.Delete(tag.EntityType, tag.EntityID, tag.Key); err != nil {
-			return err
+	for _, tag := range tags {
+		if tag.Key == "link:"+peer || (tag.Key == "link" && tag.Value == peer) {
+			if err := e.TagStore.Delete(tag.EntityType, tag.EntityID, tag.Key); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -190,11 +283,12 @@ func (e *L0EnvironmentLogic) populateModel(model *models.Environment) error {
 		model.OperatingSystem = tag.Value
 	}
 
-	model.Links = []string{}
-	for _, tag := range tags.WithKey("link") {
-		model.Links = append(model.Links, tag.Value)
+	links, err := environmentLinksFromTags(tags)
+	if err != nil {
+		return err
 	}
 
+	model.Links = links
 	return nil
 }
 
@@ -220,3 +314,136 @@ func (e *L0EnvironmentLogic) makeEnvironmentSummaryModels(environmentIDs []id.EC
 
 	return summaries, nil
 }
+
+// environmentLinksFromTags reads the 'link:<peer>' tags of an environment.
+// Links created before link rules existed are stored as 'link' tags and allow all traffic in both directions.
+func environmentLinksFromTags(tags models.Tags) ([]models.EnvironmentLink, error) {
+	links := []models.EnvironmentLink{}
+	peers := map[string]bool{}
+
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag.Key, "link:") {
+			continue
+		}
+
+		var link models.EnvironmentLink
+		if err := json.Unmarshal([]byte(tag.Value), &link); err != nil {
+			return nil, fmt.Errorf("Failed to parse environment link '%s': %v", tag.Key, err)
+		}
+
+		links = append(links, link)
+		peers[link.Peer()] = true
+	}
+
+	for _, tag := range tags.WithKey("link") {
+		if !peers[tag.Value] {
+			link := models.EnvironmentLink{
+				EnvironmentID: tag.Value,
+				Direction:     "both",
+				Rules:         []models.EnvironmentLinkRule{},
+			}
+
+			links = append(links, link)
+			peers[tag.Value] = true
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Peer() < links[j].Peer()
+	})
+
+	return links, nil
+}
+
+func environmentLinkForPeer(peer string) models.EnvironmentLink {
+	switch {
+	case strings.Contains(peer, "/"):
+		return models.EnvironmentLink{CIDR: peer}
+	case strings.HasPrefix(peer, "sg-"):
+		return models.EnvironmentLink{SecurityGroupID: peer}
+	default:
+		return models.EnvironmentLink{EnvironmentID: peer}
+	}
+}
+
+func normalizeEnvironmentLink(environmentID string, link models.EnvironmentLink) (models.EnvironmentLink, error) {
+	var peers int
+	for _, peer := range []string{link.EnvironmentID, link.CIDR, link.SecurityGroupID} {
+		if peer != "" {
+			peers++
+		}
+	}
+
+	if peers != 1 {
+		return link, errors.Newf(errors.InvalidEnvironmentLink, "Exactly one of EnvironmentID, CIDR, or SecurityGroupID is required")
+	}
+
+	if link.EnvironmentID == environmentID {
+		return link, errors.Newf(errors.InvalidEnvironmentLink, "Cannot link an environment to itself")
+	}
+
+	if link.CIDR != "" {
+		if _, _, err := net.ParseCIDR(link.CIDR); err != nil {
+			return link, errors.Newf(errors.InvalidEnvironmentLink, "'%s' is not a valid CIDR", link.CIDR)
+		}
+	}
+
+	if link.Direction == "" {
+		link.Direction = "inbound"
+		if link.EnvironmentID != "" {
+			link.Direction = "both"
+		}
+	}
+
+	switch link.Direction {
+	case "both", "outbound":
+		if link.CIDR != "" {
+			return link, errors.Newf(errors.InvalidEnvironmentLink, "CIDR links only support the 'inbound' direction")
+		}
+	case "inbound":
+	default:
+		return link, errors.Newf(errors.InvalidEnvironmentLink, "Direction '%s' is not one of 'both', 'inbound', or 'outbound'", link.Direction)
+	}
+
+	rules := make([]models.EnvironmentLinkRule, len(link.Rules))
+	for i, rule := range link.Rules {
+		rule.Protocol = strings.ToLower(rule.Protocol)
+
+		switch rule.Protocol {
+		case "all", "-1":
+			rule = models.EnvironmentLinkRule{Protocol: "all"}
+		case "tcp", "udp":
+			if rule.ToPort == 0 {
+				rule.ToPort = rule.FromPort
+			}
+
+			if rule.FromPort < 1 || rule.ToPort > 65535 || rule.FromPort > rule.ToPort {
+				return link, errors.Newf(errors.InvalidEnvironmentLink, "Port range '%d-%d' is not valid", rule.FromPort, rule.ToPort)
+			}
+		case "icmp":
+			// for icmp, the ports are the icmp type and code
+			if rule.FromPort == 0 && rule.ToPort == 0 {
+				rule.FromPort = -1
+				rule.ToPort = -1
+			}
+		default:
+			return link, errors.Newf(errors.InvalidEnvironmentLink, "Protocol '%s' is not one of 'tcp', 'udp', 'icmp', or 'all'", rule.Protocol)
+		}
+
+		rules[i] = rule
+	}
+
+	link.Rules = rules
+	return link, nil
+}
+
+func reverseLinkDirection(direction string) string {
+	switch direction {
+	case "inbound":
+		return "outbound"
+	case "outbound":
+		return "inbound"
+	default:
+		return direction
+	}
+}
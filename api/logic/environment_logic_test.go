@@ -38,7 +38,9 @@ func TestGetEnvironment(t *testing.T) {
 		EnvironmentID:   "e1",
 		EnvironmentName: "env",
 		OperatingSystem: "linux",
-		Links:           []string{"e2"},
+		Links: []models.EnvironmentLink{
+			{EnvironmentID: "e2", Direction: "both", Rules: []models.EnvironmentLinkRule{}},
+		},
 	}
 
 	testutils.AssertEqual(t, received, expected)
@@ -96,7 +98,15 @@ func TestDeleteEnvironment(t *testing.T) {
 		Return(nil)
 
 	testLogic.Backend.EXPECT().
-		DeleteEnvironmentLink("eid1", "eid2").
+		DeleteEnvironmentLink("eid1", models.EnvironmentLink{EnvironmentID: "eid2", Direction: "both", Rules: []models.EnvironmentLinkRule{}}).
+		Return(nil)
+
+	testLogic.Backend.EXPECT().
+		ListSecrets("eid1").
+		Return([]*models.Secret{{EnvironmentID: "eid1", SecretName: "db_password"}}, nil)
+
+	testLogic.Backend.EXPECT().
+		DeleteSecret("eid1", "db_password").
 		Return(nil)
 
 	testLogic.AddTags(t, []*models.Tag{
@@ -186,7 +196,7 @@ func TestCreateEnvironment(t *testing.T) {
 		EnvironmentID:   "e1",
 		EnvironmentName: "name",
 		OperatingSystem: "linux",
-		Links:           []string{},
+		Links:           []models.EnvironmentLink{},
 	}
 
 	testutils.AssertEqual(t, received, expected)
@@ -242,39 +252,159 @@ func TestUpdateEnvironment(t *testing.T) {
 	expected := &models.Environment{
 		EnvironmentID:   "e1",
 		EnvironmentName: "env",
-		Links:           []string{},
+		Links:           []models.EnvironmentLink{},
 	}
 
 	testutils.AssertEqual(t, received, expected)
 }
 
+func TestGetEnvironment_links(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		GetEnvironment("e1").
+		Return(&models.Environment{EnvironmentID: "e1"}, nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "e1", EntityType: "environment", Key: "link", Value: "e2"},
+		{EntityID: "e1", EntityType: "environment", Key: "link:e3", Value: `{"environment_id":"e3","direction":"outbound","rules":[{"protocol":"tcp","from_port":5432,"to_port":5432}]}`},
+		{EntityID: "e1", EntityType: "environment", Key: "link:10.0.0.0/16", Value: `{"cidr":"10.0.0.0/16","direction":"inbound"}`},
+	})
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	received, err := environmentLogic.GetEnvironment("e1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []models.EnvironmentLink{
+		{CIDR: "10.0.0.0/16", Direction: "inbound"},
+		{EnvironmentID: "e2", Direction: "both", Rules: []models.EnvironmentLinkRule{}},
+		{EnvironmentID: "e3", Direction: "outbound", Rules: []models.EnvironmentLinkRule{
+			{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+		}},
+	}
+
+	testutils.AssertEqual(t, received.Links, expected)
+}
+
 func TestCreateEnvironmentLink(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
 
+	link := models.EnvironmentLink{
+		EnvironmentID: "eid2",
+		Direction:     "both",
+		Rules:         []models.EnvironmentLinkRule{},
+	}
+
 	testLogic.Backend.EXPECT().
-		CreateEnvironmentLink("eid1", "eid2").
+		CreateEnvironmentLink("eid1", link).
 		Return(nil)
 
 	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
-	if err := environmentLogic.CreateEnvironmentLink("eid1", "eid2"); err != nil {
+	if err := environmentLogic.CreateEnvironmentLink("eid1", models.EnvironmentLink{EnvironmentID: "eid2"}); err != nil {
 		t.Fatal(err)
 	}
 
-	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid1", EntityType: "environment", Key: "link", Value: "eid2"})
-	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid2", EntityType: "environment", Key: "link", Value: "eid1"})
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid1", EntityType: "environment", Key: "link:eid2", Value: `{"environment_id":"eid2","cidr":"","security_group_id":"","direction":"both","rules":[]}`})
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid2", EntityType: "environment", Key: "link:eid1", Value: `{"environment_id":"eid1","cidr":"","security_group_id":"","direction":"both","rules":[]}`})
 }
 
+func TestCreateEnvironmentLink_oneWayRules(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	link := models.EnvironmentLink{
+		EnvironmentID: "eid2",
+		Direction:     "outbound",
+		Rules: []models.EnvironmentLinkRule{
+			{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+		},
+	}
+
+	testLogic.Backend.EXPECT().
+		CreateEnvironmentLink("eid1", link).
+		Return(nil)
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	req := models.EnvironmentLink{
+		EnvironmentID: "eid2",
+		Direction:     "outbound",
+		Rules: []models.EnvironmentLinkRule{
+			{Protocol: "TCP", FromPort: 5432},
+		},
+	}
+
+	if err := environmentLogic.CreateEnvironmentLink("eid1", req); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := testLogic.TagStore.SelectByTypeAndID("environment", "eid2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	links, err := environmentLinksFromTags(tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []models.EnvironmentLink{
+		{
+			EnvironmentID: "eid1",
+			Direction:     "inbound",
+			Rules: []models.EnvironmentLinkRule{
+				{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+			},
+		},
+	}
+
+	testutils.AssertEqual(t, links, expected)
+}
+
+func TestCreateEnvironmentLink_userInputErrors(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	links := map[string]models.EnvironmentLink{
+		"No peer":           {},
+		"Multiple peers":    {EnvironmentID: "eid2", CIDR: "10.0.0.0/16"},
+		"Self link":         {EnvironmentID: "eid1"},
+		"Invalid cidr":      {CIDR: "10.0.0.0"},
+		"Outbound cidr":     {CIDR: "10.0.0.0/16", Direction: "outbound"},
+		"Invalid direction": {EnvironmentID: "eid2", Direction: "sideways"},
+		"Invalid protocol":  {EnvironmentID: "eid2", Rules: []models.EnvironmentLinkRule{{Protocol: "http", FromPort: 80}}},
+		"Invalid port":      {EnvironmentID: "eid2", Rules: []models.EnvironmentLinkRule{{Protocol: "tcp", FromPort: 70000}}},
+	}
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	for name, link := range links {
+		if err := environmentLogic.CreateEnvironmentLink("eid1", link); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
 func TestDeleteEnvironmentLink(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
 
+	link := models.EnvironmentLink{
+		EnvironmentID: "eid2",
+		Direction:     "outbound",
+		Rules: []models.EnvironmentLinkRule{
+			{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+		},
+	}
+
 	testLogic.Backend.EXPECT().
-		DeleteEnvironmentLink("eid1", "eid2").
+		DeleteEnvironmentLink("eid1", link).
 		Return(nil)
 
 	testLogic.AddTags(t, []*models.Tag{
-		{EntityID: "eid1", EntityType: "environment", Key: "link", Value: "eid2"},
+		{EntityID: "eid1", EntityType: "environment", Key: "link:eid2", Value: `{"environment_id":"eid2","direction":"outbound","rules":[{"protocol":"tcp","from_port":5432,"to_port":5432}]}`},
+		{EntityID: "eid2", EntityType: "environment", Key: "link:eid1", Value: `{"environment_id":"eid1","direction":"inbound","rules":[{"protocol":"tcp","from_port":5432,"to_port":5432}]}`},
 		{EntityID: "extra", EntityType: "environment", Key: "name", Value: "extra"},
 	})
 
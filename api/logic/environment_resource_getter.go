@@ -8,7 +8,6 @@ import (
 	"github.com/quintilesims/layer0/api/scheduler/resource"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/types"
-	"github.com/zpatrick/go-bytesize"
 )
 
 type EnvironmentResourceGetter struct {
@@ -16,7 +15,7 @@ type EnvironmentResourceGetter struct {
 	TaskLogic    TaskLogic
 	DeployLogic  DeployLogic
 	JobLogic     JobLogic
-	deployCache  map[string][]resource.ResourceConsumer
+	deployCache  map[string]*models.Dockerrun
 }
 
 func NewEnvironmentResourceGetter(s ServiceLogic, t TaskLogic, d DeployLogic, j JobLogic) *EnvironmentResourceGetter {
@@ -25,7 +24,7 @@ func NewEnvironmentResourceGetter(s ServiceLogic, t TaskLogic, d DeployLogic, j
 		TaskLogic:    t,
 		DeployLogic:  d,
 		JobLogic:     j,
-		deployCache:  map[string][]resource.ResourceConsumer{},
+		deployCache:  map[string]*models.Dockerrun{},
 	}
 }
 
@@ -78,7 +77,7 @@ func (c *EnvironmentResourceGetter) getPendingServiceResources(environmentID str
 			return fmt.Sprintf("Service: %s, Deploy: %s, Container: %s, Copy: %d", service.ServiceID, deployID, containerName, copy)
 		}
 
-		serviceResourceConsumers, err := c.getResourcesHelper(deployIDCopies, generateID)
+		serviceResourceConsumers, err := c.getResourcesHelper(deployIDCopies, nil, generateID)
 		if err != nil {
 			return nil, err
 		}
@@ -110,7 +109,7 @@ func (c *EnvironmentResourceGetter) getPendingTaskResourcesInECS(environmentID s
 			return fmt.Sprintf("Task: %s, Deploy: %s, Container: %s, Copy: %d", task.TaskID, deployID, containerName, copy)
 		}
 
-		taskResourceConsumers, err := c.getResourcesHelper(deployIDCopies, generateID)
+		taskResourceConsumers, err := c.getResourcesHelper(deployIDCopies, nil, generateID)
 		if err != nil {
 			return nil, err
 		}
@@ -147,7 +146,7 @@ func (c *EnvironmentResourceGetter) getPendingTaskResourcesInJobs(environmentID
 						return fmt.Sprintf("Task: %s, Deploy: %s, Container: %s, Copy: %d", req.TaskName, deployID, containerName, copy)
 					}
 
-					taskResourceConsumers, err := c.getResourcesHelper(deployIDCopies, generateID)
+					taskResourceConsumers, err := c.getResourcesHelper(deployIDCopies, req.ContainerOverrides, generateID)
 					if err != nil {
 						return nil, err
 					}
@@ -161,10 +160,10 @@ func (c *EnvironmentResourceGetter) getPendingTaskResourcesInJobs(environmentID
 	return resourceConsumers, nil
 }
 
-func (c *EnvironmentResourceGetter) getResourcesHelper(deployIDCopies map[string]int, generateID func(string, string, int) string) ([]resource.ResourceConsumer, error) {
+func (c *EnvironmentResourceGetter) getResourcesHelper(deployIDCopies map[string]int, overrides []models.ContainerOverride, generateID func(string, string, int) string) ([]resource.ResourceConsumer, error) {
 	resourceConsumers := []resource.ResourceConsumer{}
 	for deployID, copies := range deployIDCopies {
-		containerResources, err := c.getContainerResourcesFromDeploy(deployID)
+		containerResources, err := c.getContainerResourcesFromDeploy(deployID, overrides)
 		if err != nil {
 			return nil, err
 		}
@@ -181,43 +180,27 @@ func (c *EnvironmentResourceGetter) getResourcesHelper(deployIDCopies map[string
 	return resourceConsumers, nil
 }
 
-func (c *EnvironmentResourceGetter) getContainerResourcesFromDeploy(deployID string) ([]resource.ResourceConsumer, error) {
-	if consumers, ok := c.deployCache[deployID]; ok {
-		return consumers, nil
-	}
-
-	d, err := c.DeployLogic.GetDeploy(deployID)
-	if err != nil {
-		return nil, err
-	}
-
-	deploy, err := ecsbackend.MarshalDockerrun(d.Dockerrun)
-	if err != nil {
-		return nil, err
-	}
-
-	consumers := make([]resource.ResourceConsumer, len(deploy.ContainerDefinitions))
-	for i, container := range deploy.ContainerDefinitions {
-		var memory bytesize.Bytesize
-
-		if container.MemoryReservation != nil && *container.MemoryReservation != 0 {
-			memory = bytesize.MiB * bytesize.Bytesize(*container.MemoryReservation)
+// getContainerResourcesFromDeploy returns the resources of each container in the deploy.
+// The memory of any container with an override is taken from the override.
+func (c *EnvironmentResourceGetter) getContainerResourcesFromDeploy(deployID string, overrides []models.ContainerOverride) ([]resource.ResourceConsumer, error) {
+	deploy, ok := c.deployCache[deployID]
+	if !ok {
+		d, err := c.DeployLogic.GetDeploy(deployID)
+		if err != nil {
+			return nil, err
 		}
 
-		if container.Memory != nil && *container.Memory != 0 {
-			memory = bytesize.MiB * bytesize.Bytesize(*container.Memory)
+		deploy, err = ecsbackend.MarshalDockerrun(d.Dockerrun)
+		if err != nil {
+			return nil, err
 		}
 
-		ports := []int{}
-		for _, p := range container.PortMappings {
-			if p.HostPort != nil && *p.HostPort != 0 {
-				ports = append(ports, int(*p.HostPort))
-			}
-		}
+		c.deployCache[deployID] = deploy
+	}
 
-		consumers[i] = resource.NewResourceConsumer(*container.Name, memory, ports)
+	if len(overrides) > 0 {
+		deploy = ecsbackend.ApplyContainerOverrides(deploy, overrides)
 	}
 
-	c.deployCache[deployID] = consumers
-	return consumers, nil
+	return ecsbackend.DockerrunResourceConsumers(deploy), nil
 }
@@ -160,6 +160,54 @@ func TestGetPendingTaskResourcesInJobs(t *testing.T) {
 	testutils.AssertEqual(t, resources[0].Memory, bytesize.MiB*500)
 }
 
+func TestGetPendingTaskResourcesInJobs_overrides(t *testing.T) {
+	crg, ctrl := newTestEnvironmentResourceGetter(t)
+	defer ctrl.Finish()
+
+	jobs := []*models.Job{
+		{
+			JobID:     "j1",
+			JobType:   int64(types.CreateTaskJob),
+			JobStatus: int64(types.Pending),
+			Request: requestToString(t, models.CreateTaskRequest{
+				TaskName:      "t1",
+				DeployID:      "d1",
+				EnvironmentID: "e1",
+				ContainerOverrides: []models.ContainerOverride{
+					{ContainerName: "one", Memory: 2048},
+				},
+			}),
+		},
+		{
+			JobID:     "j2",
+			JobType:   int64(types.CreateTaskJob),
+			JobStatus: int64(types.Pending),
+			Request: requestToString(t, models.CreateTaskRequest{
+				TaskName:      "t2",
+				DeployID:      "d1",
+				EnvironmentID: "e1",
+			}),
+		},
+	}
+
+	crg.JobLogic.EXPECT().
+		ListJobs().
+		Return(jobs, nil)
+
+	crg.DeployLogic.EXPECT().
+		GetDeploy("d1").
+		Return(&models.Deploy{Dockerrun: deployWithOneContainer}, nil)
+
+	resources, err := crg.EnvironmentResourceGetter().getPendingTaskResourcesInJobs("e1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(resources), 2)
+	testutils.AssertEqual(t, resources[0].Memory, bytesize.MiB*2048)
+	testutils.AssertEqual(t, resources[1].Memory, bytesize.MiB*500)
+}
+
 func TestGetPendingTaskResourcesInECS(t *testing.T) {
 	crg, ctrl := newTestEnvironmentResourceGetter(t)
 	defer ctrl.Finish()
@@ -296,3 +344,75 @@ func TestGetPendingServiceResources(t *testing.T) {
 	testutils.AssertEqual(t, resources[3].Ports, []int{8000})
 	testutils.AssertEqual(t, resources[3].Memory, bytesize.MiB*1000)
 }
+
+func TestGetContainerResourcesFromDeploy_dynamicPorts(t *testing.T) {
+	crg, ctrl := newTestEnvironmentResourceGetter(t)
+	defer ctrl.Finish()
+
+	dynamicDeploy := []byte(`
+{
+  "containerDefinitions": [
+    {
+      "name": "one",
+      "memory": 500,
+      "portMappings": [
+        {
+          "hostPort": 0,
+          "containerPort": 80
+        },
+        {
+          "containerPort": 8080
+        },
+        {
+          "hostPort": 9000,
+          "containerPort": 9000
+        }
+      ]
+    }
+  ]
+}
+`)
+
+	hostDeploy := []byte(`
+{
+  "networkMode": "host",
+  "containerDefinitions": [
+    {
+      "name": "one",
+      "memory": 500,
+      "portMappings": [
+        {
+          "containerPort": 80
+        }
+      ]
+    }
+  ]
+}
+`)
+
+	crg.DeployLogic.EXPECT().
+		GetDeploy("d1").
+		Return(&models.Deploy{Dockerrun: dynamicDeploy}, nil)
+
+	crg.DeployLogic.EXPECT().
+		GetDeploy("d2").
+		Return(&models.Deploy{Dockerrun: hostDeploy}, nil)
+
+	getter := crg.EnvironmentResourceGetter()
+
+	resources, err := getter.getContainerResourcesFromDeploy("d1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(resources), 1)
+	testutils.AssertEqual(t, resources[0].Ports, []int{9000})
+
+	resources, err = getter.getContainerResourcesFromDeploy("d2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(resources), 1)
+	testutils.AssertEqual(t, resources[0].Ports, []int{80})
+}
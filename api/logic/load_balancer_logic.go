@@ -2,6 +2,7 @@ package logic
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
@@ -16,6 +17,9 @@ type LoadBalancerLogic interface {
 	UpdateLoadBalancerHealthCheck(loadBalancerID string, healthCheck models.HealthCheck) (*models.LoadBalancer, error)
 	UpdateLoadBalancerIdleTimeout(loadBalancerID string, idleTimeout int) (*models.LoadBalancer, error)
 	UpdateLoadBalancerCrossZone(loadBalancerID string, crossZone bool) (*models.LoadBalancer, error)
+	UpdateLoadBalancerAccessLogs(loadBalancerID string, accessLogs models.AccessLogs) (*models.LoadBalancer, error)
+	UpdateLoadBalancerConnectionDraining(loadBalancerID string, connectionDraining models.ConnectionDraining) (*models.LoadBalancer, error)
+	GetLoadBalancerHealth(loadBalancerID string) (*models.LoadBalancerHealth, error)
 }
 
 type L0LoadBalancerLogic struct {
@@ -43,6 +47,7 @@ func (l *L0LoadBalancerLogic) ListLoadBalancers() ([]*models.LoadBalancerSummary
 		summaries[i] = &models.LoadBalancerSummary{
 			LoadBalancerID:   loadBalancer.LoadBalancerID,
 			LoadBalancerName: loadBalancer.LoadBalancerName,
+			LoadBalancerType: loadBalancer.LoadBalancerType,
 			EnvironmentID:    loadBalancer.EnvironmentID,
 			EnvironmentName:  loadBalancer.EnvironmentName,
 		}
@@ -85,6 +90,15 @@ func (l *L0LoadBalancerLogic) CreateLoadBalancer(req models.CreateLoadBalancerRe
 		return nil, errors.Newf(errors.MissingParameter, "LoadBalancerName not specified")
 	}
 
+	loadBalancerType := strings.ToLower(req.LoadBalancerType)
+	switch loadBalancerType {
+	case "":
+		loadBalancerType = "elb"
+	case "elb", "alb":
+	default:
+		return nil, errors.Newf(errors.InvalidLoadBalancerType, "LoadBalancerType '%s' is not valid, must be 'elb' or 'alb'", req.LoadBalancerType)
+	}
+
 	exists, err := l.doesLoadBalancerTagExist(req.EnvironmentID, req.LoadBalancerName)
 	if err != nil {
 		return nil, err
@@ -98,6 +112,7 @@ func (l *L0LoadBalancerLogic) CreateLoadBalancer(req models.CreateLoadBalancerRe
 	loadBalancer, err := l.Backend.CreateLoadBalancer(
 		req.LoadBalancerName,
 		req.EnvironmentID,
+		loadBalancerType,
 		req.IsPublic,
 		req.Ports,
 		req.HealthCheck,
@@ -178,6 +193,69 @@ func (l *L0LoadBalancerLogic) UpdateLoadBalancerCrossZone(loadBalancerID string,
 	return loadBalancer, nil
 }
 
+func (l *L0LoadBalancerLogic) UpdateLoadBalancerAccessLogs(loadBalancerID string, accessLogs models.AccessLogs) (*models.LoadBalancer, error) {
+	if accessLogs.Enabled {
+		if accessLogs.S3BucketName == "" {
+			return nil, errors.Newf(errors.MissingParameter, "S3BucketName not specified")
+		}
+
+		if accessLogs.EmitInterval == 0 {
+			accessLogs.EmitInterval = 60
+		}
+
+		if accessLogs.EmitInterval != 5 && accessLogs.EmitInterval != 60 {
+			return nil, errors.Newf(errors.InvalidLoadBalancerAttribute, "EmitInterval '%d' is not valid, must be 5 or 60", accessLogs.EmitInterval)
+		}
+	}
+
+	loadBalancer, err := l.Backend.UpdateLoadBalancerAccessLogs(loadBalancerID, accessLogs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.populateModel(loadBalancer); err != nil {
+		return nil, err
+	}
+
+	return loadBalancer, nil
+}
+
+func (l *L0LoadBalancerLogic) UpdateLoadBalancerConnectionDraining(loadBalancerID string, connectionDraining models.ConnectionDraining) (*models.LoadBalancer, error) {
+	if connectionDraining.Enabled {
+		if connectionDraining.Timeout == 0 {
+			connectionDraining.Timeout = 300
+		}
+
+		if connectionDraining.Timeout < 1 || connectionDraining.Timeout > 3600 {
+			return nil, errors.Newf(errors.InvalidLoadBalancerAttribute, "Timeout '%d' is not valid, must be between 1 and 3600", connectionDraining.Timeout)
+		}
+	}
+
+	loadBalancer, err := l.Backend.UpdateLoadBalancerConnectionDraining(loadBalancerID, connectionDraining)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.populateModel(loadBalancer); err != nil {
+		return nil, err
+	}
+
+	return loadBalancer, nil
+}
+
+func (l *L0LoadBalancerLogic) GetLoadBalancerHealth(loadBalancerID string) (*models.LoadBalancerHealth, error) {
+	health, err := l.Backend.GetLoadBalancerHealth(loadBalancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.populateHealthModel(health); err != nil {
+		return nil, err
+	}
+
+	return health, nil
+}
+
 func (l *L0LoadBalancerLogic) doesLoadBalancerTagExist(environmentID, name string) (bool, error) {
 	tags, err := l.TagStore.SelectByType("load_balancer")
 	if err != nil {
@@ -236,5 +314,71 @@ func (l *L0LoadBalancerLogic) populateModel(model *models.LoadBalancer) error {
 		}
 	}
 
+	for i, rule := range model.Rules {
+		if tag, ok := tags.WithID(rule.ServiceID).WithKey("name").First(); ok {
+			model.Rules[i].ServiceName = tag.Value
+		}
+	}
+
+	return nil
+}
+
+func (l *L0LoadBalancerLogic) populateHealthModel(model *models.LoadBalancerHealth) error {
+	tags, err := l.TagStore.SelectByTypeAndID("load_balancer", model.LoadBalancerID)
+	if err != nil {
+		return err
+	}
+
+	if tag, ok := tags.WithKey("environment_id").First(); ok {
+		model.EnvironmentID = tag.Value
+	}
+
+	if tag, ok := tags.WithKey("name").First(); ok {
+		model.LoadBalancerName = tag.Value
+	}
+
+	if model.EnvironmentID != "" {
+		tags, err := l.TagStore.SelectByTypeAndID("environment", model.EnvironmentID)
+		if err != nil {
+			return err
+		}
+
+		if tag, ok := tags.WithKey("name").First(); ok {
+			model.EnvironmentName = tag.Value
+		}
+	}
+
+	serviceTags, err := l.TagStore.SelectByType("service")
+	if err != nil {
+		return err
+	}
+
+	// map each instance back to the service using this load balancer and the tasks it runs there
+	for _, tag := range serviceTags.WithKey("load_balancer_id").WithValue(model.LoadBalancerID) {
+		serviceID := tag.EntityID
+
+		instanceTasks, err := l.Backend.GetServiceInstanceTasks(model.EnvironmentID, serviceID)
+		if err != nil {
+			return err
+		}
+
+		for i, instance := range model.Instances {
+			if instance.ServiceID != "" && instance.ServiceID != serviceID {
+				continue
+			}
+
+			if taskIDs, ok := instanceTasks[instance.InstanceID]; ok {
+				model.Instances[i].ServiceID = serviceID
+				model.Instances[i].TaskIDs = taskIDs
+			}
+		}
+	}
+
+	for i, instance := range model.Instances {
+		if tag, ok := serviceTags.WithID(instance.ServiceID).WithKey("name").First(); ok {
+			model.Instances[i].ServiceName = tag.Value
+		}
+	}
+
 	return nil
 }
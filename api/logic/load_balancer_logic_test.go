@@ -38,6 +38,57 @@ func TestGetLoadBalancer(t *testing.T) {
 	testutils.AssertEqual(t, received, expected)
 }
 
+func TestGetLoadBalancerHealth(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	retHealth := &models.LoadBalancerHealth{
+		LoadBalancerID: "l1",
+		Instances: []models.InstanceHealth{
+			{InstanceID: "i1", State: "InService"},
+			{InstanceID: "i2", State: "OutOfService", ReasonCode: "Instance"},
+			{InstanceID: "i3", State: "InService", ServiceID: "s2"},
+		},
+	}
+
+	testLogic.Backend.EXPECT().
+		GetLoadBalancerHealth("l1").
+		Return(retHealth, nil)
+
+	testLogic.Backend.EXPECT().
+		GetServiceInstanceTasks("e1", "s1").
+		Return(map[string][]string{"i1": {"t1", "t2"}, "i2": {"t3"}, "i3": {"t4"}}, nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "l1", EntityType: "load_balancer", Key: "name", Value: "lb"},
+		{EntityID: "l1", EntityType: "load_balancer", Key: "environment_id", Value: "e1"},
+		{EntityID: "e1", EntityType: "environment", Key: "name", Value: "env"},
+		{EntityID: "s1", EntityType: "service", Key: "name", Value: "svc"},
+		{EntityID: "s1", EntityType: "service", Key: "load_balancer_id", Value: "l1"},
+		{EntityID: "s2", EntityType: "service", Key: "name", Value: "other"},
+	})
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	received, err := loadBalancerLogic.GetLoadBalancerHealth("l1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &models.LoadBalancerHealth{
+		LoadBalancerID:   "l1",
+		LoadBalancerName: "lb",
+		EnvironmentID:    "e1",
+		EnvironmentName:  "env",
+		Instances: []models.InstanceHealth{
+			{InstanceID: "i1", State: "InService", ServiceID: "s1", ServiceName: "svc", TaskIDs: []string{"t1", "t2"}},
+			{InstanceID: "i2", State: "OutOfService", ReasonCode: "Instance", ServiceID: "s1", ServiceName: "svc", TaskIDs: []string{"t3"}},
+			{InstanceID: "i3", State: "InService", ServiceID: "s2", ServiceName: "other"},
+		},
+	}
+
+	testutils.AssertEqual(t, received, expected)
+}
+
 func TestListLoadBalancers(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -131,7 +182,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 	}
 
 	testLogic.Backend.EXPECT().
-		CreateLoadBalancer("name", "e1", true, []models.Port{}, healthCheck, 60, false).
+		CreateLoadBalancer("name", "e1", "elb", true, []models.Port{}, healthCheck, 60, false).
 		Return(retLoadBalancer, nil)
 
 	request := models.CreateLoadBalancerRequest{
@@ -207,6 +258,22 @@ func TestCreateLoadBalancerError_duplicateName(t *testing.T) {
 	}
 }
 
+func TestCreateLoadBalancerError_invalidType(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	request := models.CreateLoadBalancerRequest{
+		EnvironmentID:    "e1",
+		LoadBalancerName: "name",
+		LoadBalancerType: "nlb",
+	}
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	if _, err := loadBalancerLogic.CreateLoadBalancer(request); err == nil {
+		t.Errorf("Error was nil!")
+	}
+}
+
 func TestUpdateLoadBalancerPorts(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -305,3 +372,93 @@ func TestUpdateLoadBalancerCrossZone(t *testing.T) {
 
 	testutils.AssertEqual(t, received.CrossZone, crossZone)
 }
+
+func TestUpdateLoadBalancerAccessLogs(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	request := models.AccessLogs{
+		Enabled:      true,
+		S3BucketName: "bucket",
+	}
+
+	// the emit interval should default to 60 minutes
+	expected := models.AccessLogs{
+		Enabled:      true,
+		S3BucketName: "bucket",
+		EmitInterval: 60,
+	}
+
+	testLogic.Backend.EXPECT().
+		UpdateLoadBalancerAccessLogs("lb_id", expected).
+		Return(&models.LoadBalancer{AccessLogs: expected}, nil)
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	received, err := loadBalancerLogic.UpdateLoadBalancerAccessLogs("lb_id", request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, received.AccessLogs, expected)
+}
+
+func TestUpdateLoadBalancerAccessLogsError_invalidParams(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+
+	cases := map[string]models.AccessLogs{
+		"Missing S3BucketName": {
+			Enabled: true,
+		},
+		"Invalid EmitInterval": {
+			Enabled:      true,
+			S3BucketName: "bucket",
+			EmitInterval: 30,
+		},
+	}
+
+	for name, accessLogs := range cases {
+		if _, err := loadBalancerLogic.UpdateLoadBalancerAccessLogs("lb_id", accessLogs); err == nil {
+			t.Errorf("Case %s: error was nil!", name)
+		}
+	}
+}
+
+func TestUpdateLoadBalancerConnectionDraining(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	connectionDraining := models.ConnectionDraining{
+		Enabled: true,
+		Timeout: 120,
+	}
+
+	testLogic.Backend.EXPECT().
+		UpdateLoadBalancerConnectionDraining("lb_id", connectionDraining).
+		Return(&models.LoadBalancer{ConnectionDraining: connectionDraining}, nil)
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	received, err := loadBalancerLogic.UpdateLoadBalancerConnectionDraining("lb_id", connectionDraining)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, received.ConnectionDraining, connectionDraining)
+}
+
+func TestUpdateLoadBalancerConnectionDrainingError_invalidTimeout(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	connectionDraining := models.ConnectionDraining{
+		Enabled: true,
+		Timeout: 3601,
+	}
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	if _, err := loadBalancerLogic.UpdateLoadBalancerConnectionDraining("lb_id", connectionDraining); err == nil {
+		t.Errorf("Error was nil!")
+	}
+}
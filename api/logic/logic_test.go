@@ -19,6 +19,7 @@ func TestMain(m *testing.M) {
 	log.SetLevel(log.FatalLevel)
 	jobLogger.Level = log.FatalLevel
 	tagLogger.Level = log.FatalLevel
+	deployLogger.Level = log.FatalLevel
 	retCode := m.Run()
 	os.Exit(retCode)
 }
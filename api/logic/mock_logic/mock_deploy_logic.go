@@ -58,6 +58,19 @@ func (mr *MockDeployLogicMockRecorder) DeleteDeploy(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeploy", reflect.TypeOf((*MockDeployLogic)(nil).DeleteDeploy), arg0)
 }
 
+// DiffDeploys mocks base method
+func (m *MockDeployLogic) DiffDeploys(arg0, arg1 string) (*models.DeployDiff, error) {
+	ret := m.ctrl.Call(m, "DiffDeploys", arg0, arg1)
+	ret0, _ := ret[0].(*models.DeployDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffDeploys indicates an expected call of DiffDeploys
+func (mr *MockDeployLogicMockRecorder) DiffDeploys(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffDeploys", reflect.TypeOf((*MockDeployLogic)(nil).DiffDeploys), arg0, arg1)
+}
+
 // GetDeploy mocks base method
 func (m *MockDeployLogic) GetDeploy(arg0 string) (*models.Deploy, error) {
 	ret := m.ctrl.Call(m, "GetDeploy", arg0)
@@ -83,3 +96,29 @@ func (m *MockDeployLogic) ListDeploys() ([]*models.DeploySummary, error) {
 func (mr *MockDeployLogicMockRecorder) ListDeploys() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploys", reflect.TypeOf((*MockDeployLogic)(nil).ListDeploys))
 }
+
+// PruneDeploys mocks base method
+func (m *MockDeployLogic) PruneDeploys(arg0 models.PruneDeploysRequest) (*models.PruneDeploysReport, error) {
+	ret := m.ctrl.Call(m, "PruneDeploys", arg0)
+	ret0, _ := ret[0].(*models.PruneDeploysReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneDeploys indicates an expected call of PruneDeploys
+func (mr *MockDeployLogicMockRecorder) PruneDeploys(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneDeploys", reflect.TypeOf((*MockDeployLogic)(nil).PruneDeploys), arg0)
+}
+
+// ValidateDeploy mocks base method
+func (m *MockDeployLogic) ValidateDeploy(arg0 models.ValidateDeployRequest) (*models.DeployValidation, error) {
+	ret := m.ctrl.Call(m, "ValidateDeploy", arg0)
+	ret0, _ := ret[0].(*models.DeployValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateDeploy indicates an expected call of ValidateDeploy
+func (mr *MockDeployLogicMockRecorder) ValidateDeploy(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDeploy", reflect.TypeOf((*MockDeployLogic)(nil).ValidateDeploy), arg0)
+}
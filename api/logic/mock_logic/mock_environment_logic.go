@@ -60,7 +60,7 @@ func (mr *MockEnvironmentLogicMockRecorder) CreateEnvironment(arg0 interface{})
 }
 
 // CreateEnvironmentLink mocks base method
-func (m *MockEnvironmentLogic) CreateEnvironmentLink(arg0, arg1 string) error {
+func (m *MockEnvironmentLogic) CreateEnvironmentLink(arg0 string, arg1 models.EnvironmentLink) error {
 	ret := m.ctrl.Call(m, "CreateEnvironmentLink", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -71,6 +71,19 @@ func (mr *MockLoadBalancerLogicMockRecorder) GetLoadBalancer(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancer", reflect.TypeOf((*MockLoadBalancerLogic)(nil).GetLoadBalancer), arg0)
 }
 
+// GetLoadBalancerHealth mocks base method
+func (m *MockLoadBalancerLogic) GetLoadBalancerHealth(arg0 string) (*models.LoadBalancerHealth, error) {
+	ret := m.ctrl.Call(m, "GetLoadBalancerHealth", arg0)
+	ret0, _ := ret[0].(*models.LoadBalancerHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancerHealth indicates an expected call of GetLoadBalancerHealth
+func (mr *MockLoadBalancerLogicMockRecorder) GetLoadBalancerHealth(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerHealth", reflect.TypeOf((*MockLoadBalancerLogic)(nil).GetLoadBalancerHealth), arg0)
+}
+
 // ListLoadBalancers mocks base method
 func (m *MockLoadBalancerLogic) ListLoadBalancers() ([]*models.LoadBalancerSummary, error) {
 	ret := m.ctrl.Call(m, "ListLoadBalancers")
@@ -84,6 +97,32 @@ func (mr *MockLoadBalancerLogicMockRecorder) ListLoadBalancers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancers", reflect.TypeOf((*MockLoadBalancerLogic)(nil).ListLoadBalancers))
 }
 
+// UpdateLoadBalancerAccessLogs mocks base method
+func (m *MockLoadBalancerLogic) UpdateLoadBalancerAccessLogs(arg0 string, arg1 models.AccessLogs) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "UpdateLoadBalancerAccessLogs", arg0, arg1)
+	ret0, _ := ret[0].(*models.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoadBalancerAccessLogs indicates an expected call of UpdateLoadBalancerAccessLogs
+func (mr *MockLoadBalancerLogicMockRecorder) UpdateLoadBalancerAccessLogs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoadBalancerAccessLogs", reflect.TypeOf((*MockLoadBalancerLogic)(nil).UpdateLoadBalancerAccessLogs), arg0, arg1)
+}
+
+// UpdateLoadBalancerConnectionDraining mocks base method
+func (m *MockLoadBalancerLogic) UpdateLoadBalancerConnectionDraining(arg0 string, arg1 models.ConnectionDraining) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "UpdateLoadBalancerConnectionDraining", arg0, arg1)
+	ret0, _ := ret[0].(*models.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoadBalancerConnectionDraining indicates an expected call of UpdateLoadBalancerConnectionDraining
+func (mr *MockLoadBalancerLogicMockRecorder) UpdateLoadBalancerConnectionDraining(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoadBalancerConnectionDraining", reflect.TypeOf((*MockLoadBalancerLogic)(nil).UpdateLoadBalancerConnectionDraining), arg0, arg1)
+}
+
 // UpdateLoadBalancerCrossZone mocks base method
 func (m *MockLoadBalancerLogic) UpdateLoadBalancerCrossZone(arg0 string, arg1 bool) (*models.LoadBalancer, error) {
 	ret := m.ctrl.Call(m, "UpdateLoadBalancerCrossZone", arg0, arg1)
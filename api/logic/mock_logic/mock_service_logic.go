@@ -58,6 +58,19 @@ func (mr *MockServiceLogicMockRecorder) DeleteService(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockServiceLogic)(nil).DeleteService), arg0)
 }
 
+// ExecService mocks base method
+func (m *MockServiceLogic) ExecService(arg0 string, arg1 models.ExecRequest, arg2 models.ExecStreams) (int, error) {
+	ret := m.ctrl.Call(m, "ExecService", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecService indicates an expected call of ExecService
+func (mr *MockServiceLogicMockRecorder) ExecService(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecService", reflect.TypeOf((*MockServiceLogic)(nil).ExecService), arg0, arg1, arg2)
+}
+
 // GetEnvironmentServices mocks base method
 func (m *MockServiceLogic) GetEnvironmentServices(arg0 string) ([]*models.Service, error) {
 	ret := m.ctrl.Call(m, "GetEnvironmentServices", arg0)
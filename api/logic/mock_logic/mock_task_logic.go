@@ -58,6 +58,19 @@ func (mr *MockTaskLogicMockRecorder) DeleteTask(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskLogic)(nil).DeleteTask), arg0)
 }
 
+// ExecTask mocks base method
+func (m *MockTaskLogic) ExecTask(arg0 string, arg1 models.ExecRequest, arg2 models.ExecStreams) (int, error) {
+	ret := m.ctrl.Call(m, "ExecTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecTask indicates an expected call of ExecTask
+func (mr *MockTaskLogicMockRecorder) ExecTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTask", reflect.TypeOf((*MockTaskLogic)(nil).ExecTask), arg0, arg1, arg2)
+}
+
 // GetEnvironmentTasks mocks base method
 func (m *MockTaskLogic) GetEnvironmentTasks(arg0 string) ([]*models.Task, error) {
 	ret := m.ctrl.Call(m, "GetEnvironmentTasks", arg0)
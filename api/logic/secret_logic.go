@@ -0,0 +1,188 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+var secretNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.\-]+$`)
+
+type SecretLogic interface {
+	ListSecrets(environmentID string) ([]*models.Secret, error)
+	CreateSecret(req models.CreateSecretRequest) (*models.Secret, error)
+	DeleteSecret(environmentID, secretName string) error
+}
+
+type L0SecretLogic struct {
+	Logic
+}
+
+func NewL0SecretLogic(logic Logic) *L0SecretLogic {
+	return &L0SecretLogic{
+		Logic: logic,
+	}
+}
+
+func (this *L0SecretLogic) ListSecrets(environmentID string) ([]*models.Secret, error) {
+	secrets, err := this.Backend.ListSecrets(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, secret := range secrets {
+		if err := this.populateModel(secret); err != nil {
+			return nil, err
+		}
+	}
+
+	return secrets, nil
+}
+
+func (this *L0SecretLogic) CreateSecret(req models.CreateSecretRequest) (*models.Secret, error) {
+	if req.EnvironmentID == "" {
+		return nil, errors.Newf(errors.MissingParameter, "EnvironmentID not specified")
+	}
+
+	if req.SecretName == "" {
+		return nil, errors.Newf(errors.MissingParameter, "SecretName not specified")
+	}
+
+	if !secretNameRegex.MatchString(req.SecretName) {
+		return nil, errors.Newf(errors.InvalidSecretName, "Secret names may only contain letters, numbers, '.', '-', and '_'")
+	}
+
+	if req.Value == "" {
+		return nil, errors.Newf(errors.MissingParameter, "Value not specified")
+	}
+
+	secret, err := this.Backend.CreateSecret(req.EnvironmentID, req.SecretName, req.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := this.populateModel(secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (this *L0SecretLogic) DeleteSecret(environmentID, secretName string) error {
+	return this.Backend.DeleteSecret(environmentID, secretName)
+}
+
+func (this *L0SecretLogic) populateModel(model *models.Secret) error {
+	tags, err := this.TagStore.SelectByTypeAndID("environment", model.EnvironmentID)
+	if err != nil {
+		return err
+	}
+
+	if tag, ok := tags.WithKey("name").First(); ok {
+		model.EnvironmentName = tag.Value
+	}
+
+	return nil
+}
+
+// secretReferencesFromDockerrun returns the secrets referenced by each container's environment variables
+// as '<environment id>/<secret name>', keyed by container name and then by environment variable name
+func secretReferencesFromDockerrun(body []byte) (map[string]map[string]string, error) {
+	var dockerrun models.Dockerrun
+	if err := json.Unmarshal(body, &dockerrun); err != nil {
+		return nil, err
+	}
+
+	references := map[string]map[string]string{}
+	for _, container := range dockerrun.ContainerDefinitions {
+		for _, variable := range container.Environment {
+			value := aws.StringValue(variable.Value)
+			if !models.IsSecretReference(value) {
+				continue
+			}
+
+			containerName := aws.StringValue(container.Name)
+			if _, ok := references[containerName]; !ok {
+				references[containerName] = map[string]string{}
+			}
+
+			references[containerName][aws.StringValue(variable.Name)] = strings.TrimPrefix(value, models.SECRET_REFERENCE_PREFIX)
+		}
+	}
+
+	return references, nil
+}
+
+func (this *Logic) getDeploySecretReferences(deployID string) (map[string]map[string]string, error) {
+	tags, err := this.TagStore.SelectByTypeAndID("deploy", deployID)
+	if err != nil {
+		return nil, err
+	}
+
+	references := map[string]map[string]string{}
+	if tag, ok := tags.WithKey("secrets").First(); ok {
+		if err := json.Unmarshal([]byte(tag.Value), &references); err != nil {
+			return nil, err
+		}
+	}
+
+	return references, nil
+}
+
+// validateDeploySecrets checks that every secret referenced by the deploy belongs to, and exists in, the environment.
+// The secrets are resolved by the containers when they start, so a missing secret would otherwise
+// only be noticed once the containers fail to start.
+func (this *Logic) validateDeploySecrets(environmentID, deployID string) error {
+	references, err := this.getDeploySecretReferences(deployID)
+	if err != nil {
+		return err
+	}
+
+	if len(references) == 0 {
+		return nil
+	}
+
+	secrets, err := this.Backend.ListSecrets(environmentID)
+	if err != nil {
+		return err
+	}
+
+	exists := map[string]bool{}
+	for _, secret := range secrets {
+		exists[secret.SecretName] = true
+	}
+
+	containerNames := []string{}
+	for containerName := range references {
+		containerNames = append(containerNames, containerName)
+	}
+
+	sort.Strings(containerNames)
+
+	for _, containerName := range containerNames {
+		for _, reference := range references[containerName] {
+			referenceEnvironmentID, secretName, err := models.ParseSecretReference(models.SECRET_REFERENCE_PREFIX + reference)
+			if err != nil {
+				return errors.New(errors.InvalidSecretName, err)
+			}
+
+			if referenceEnvironmentID != environmentID {
+				err := fmt.Errorf("Deploy '%s' references the secrets of environment '%s', so it cannot run in environment '%s'", deployID, referenceEnvironmentID, environmentID)
+				return errors.New(errors.InvalidEnvironmentID, err)
+			}
+
+			if !exists[secretName] {
+				err := fmt.Errorf("Deploy '%s' references secret '%s', which does not exist in environment '%s'", deployID, secretName, environmentID)
+				return errors.New(errors.SecretDoesNotExist, err)
+			}
+		}
+	}
+
+	return nil
+}
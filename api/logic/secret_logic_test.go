@@ -0,0 +1,113 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestListSecrets(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		ListSecrets("e1").
+		Return([]*models.Secret{{EnvironmentID: "e1", SecretName: "db_password"}}, nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "e1", EntityType: "environment", Key: "name", Value: "env"},
+	})
+
+	secretLogic := NewL0SecretLogic(testLogic.Logic())
+	received, err := secretLogic.ListSecrets("e1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*models.Secret{
+		{EnvironmentID: "e1", EnvironmentName: "env", SecretName: "db_password"},
+	}
+
+	testutils.AssertEqual(t, received, expected)
+}
+
+func TestCreateSecret(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		CreateSecret("e1", "db_password", "hunter2").
+		Return(&models.Secret{EnvironmentID: "e1", SecretName: "db_password"}, nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "e1", EntityType: "environment", Key: "name", Value: "env"},
+	})
+
+	request := models.CreateSecretRequest{
+		EnvironmentID: "e1",
+		SecretName:    "db_password",
+		Value:         "hunter2",
+	}
+
+	secretLogic := NewL0SecretLogic(testLogic.Logic())
+	received, err := secretLogic.CreateSecret(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &models.Secret{
+		EnvironmentID:   "e1",
+		EnvironmentName: "env",
+		SecretName:      "db_password",
+	}
+
+	testutils.AssertEqual(t, received, expected)
+}
+
+func TestCreateSecretError_invalidRequest(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	secretLogic := NewL0SecretLogic(testLogic.Logic())
+
+	cases := map[string]models.CreateSecretRequest{
+		"Missing EnvironmentID": {
+			SecretName: "name",
+			Value:      "value",
+		},
+		"Missing SecretName": {
+			EnvironmentID: "e1",
+			Value:         "value",
+		},
+		"Missing Value": {
+			EnvironmentID: "e1",
+			SecretName:    "name",
+		},
+		"Invalid SecretName": {
+			EnvironmentID: "e1",
+			SecretName:    "../name",
+			Value:         "value",
+		},
+	}
+
+	for name, request := range cases {
+		if _, err := secretLogic.CreateSecret(request); err == nil {
+			t.Errorf("Case %s: error was nil!", name)
+		}
+	}
+}
+
+func TestDeleteSecret(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		DeleteSecret("e1", "db_password").
+		Return(nil)
+
+	secretLogic := NewL0SecretLogic(testLogic.Logic())
+	if err := secretLogic.DeleteSecret("e1", "db_password"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,6 +2,7 @@ package logic
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
@@ -18,6 +19,7 @@ type ServiceLogic interface {
 	UpdateService(serviceID string, req models.UpdateServiceRequest) (*models.Service, error)
 	ScaleService(serviceID string, size int) (*models.Service, error)
 	GetServiceLogs(serviceID, start, end string, tail int) ([]*models.LogFile, error)
+	ExecService(serviceID string, req models.ExecRequest, streams models.ExecStreams) (int, error)
 }
 
 type L0ServiceLogic struct {
@@ -114,6 +116,10 @@ func (this *L0ServiceLogic) UpdateService(serviceID string, req models.UpdateSer
 		return nil, err
 	}
 
+	if err := this.validateDeploySecrets(environmentID, req.DeployID); err != nil {
+		return nil, err
+	}
+
 	service, err := this.Backend.UpdateService(environmentID, serviceID, req.DeployID)
 	if err != nil {
 		return nil, err
@@ -141,6 +147,18 @@ func (this *L0ServiceLogic) CreateService(req models.CreateServiceRequest) (*mod
 		return nil, errors.Newf(errors.MissingParameter, "DeployID not specified")
 	}
 
+	if req.LoadBalancerID == "" && req.LoadBalancerRule != (models.LoadBalancerRule{}) {
+		return nil, errors.Newf(errors.MissingParameter, "LoadBalancerID must be specified when using a LoadBalancerRule")
+	}
+
+	if req.LoadBalancerRule.Priority < 0 || req.LoadBalancerRule.Priority > 50000 {
+		return nil, errors.Newf(errors.InvalidLoadBalancerRule, "LoadBalancerRule priority must be between 1 and 50000")
+	}
+
+	if err := this.validateDeploySecrets(req.EnvironmentID, req.DeployID); err != nil {
+		return nil, err
+	}
+
 	exists, err := this.doesServiceTagExist(req.EnvironmentID, req.ServiceName)
 	if err != nil {
 		return nil, err
@@ -155,7 +173,8 @@ func (this *L0ServiceLogic) CreateService(req models.CreateServiceRequest) (*mod
 		req.ServiceName,
 		req.EnvironmentID,
 		req.DeployID,
-		req.LoadBalancerID)
+		req.LoadBalancerID,
+		req.LoadBalancerRule)
 	if err != nil {
 		return service, err
 	}
@@ -199,6 +218,35 @@ func (this *L0ServiceLogic) GetServiceLogs(serviceID, start, end string, tail in
 	return logs, nil
 }
 
+// ExecService runs the command in one of the service's running tasks
+func (this *L0ServiceLogic) ExecService(serviceID string, req models.ExecRequest, streams models.ExecStreams) (int, error) {
+	if len(req.Command) == 0 {
+		return 0, errors.Newf(errors.MissingParameter, "Command not specified")
+	}
+
+	environmentID, err := this.getEnvironmentID(serviceID)
+	if err != nil {
+		return 0, err
+	}
+
+	instanceTasks, err := this.Backend.GetServiceInstanceTasks(environmentID, serviceID)
+	if err != nil {
+		return 0, err
+	}
+
+	taskIDs := []string{}
+	for _, ids := range instanceTasks {
+		taskIDs = append(taskIDs, ids...)
+	}
+
+	if len(taskIDs) == 0 {
+		return 0, errors.Newf(errors.InvalidExecRequest, "Service %s has no running tasks", serviceID)
+	}
+
+	sort.Strings(taskIDs)
+	return this.Backend.ExecTask(environmentID, taskIDs[0], req, streams)
+}
+
 func (this *L0ServiceLogic) getEnvironmentID(serviceID string) (string, error) {
 	tags, err := this.TagStore.SelectByTypeAndID("service", serviceID)
 	if err != nil {
@@ -150,7 +150,7 @@ func TestCreateService(t *testing.T) {
 	defer ctrl.Finish()
 
 	testLogic.Backend.EXPECT().
-		CreateService("name", "e1", "d1", "l1").
+		CreateService("name", "e1", "d1", "l1", models.LoadBalancerRule{}).
 		Return(&models.Service{ServiceID: "s1"}, nil)
 
 	testLogic.Scaler.EXPECT().
@@ -226,6 +226,59 @@ func TestCreateServiceError_duplicateName(t *testing.T) {
 	}
 }
 
+func TestCreateServiceError_missingSecret(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "d1", EntityType: "deploy", Key: "secrets", Value: `{"api":{"DB_PASSWORD":"e1/db_password"}}`},
+	})
+
+	testLogic.Backend.EXPECT().
+		ListSecrets("e1").
+		Return([]*models.Secret{{SecretName: "api_key"}}, nil)
+
+	request := models.CreateServiceRequest{
+		EnvironmentID: "e1",
+		ServiceName:   "svc",
+		DeployID:      "d1",
+	}
+
+	serviceLogic := NewL0ServiceLogic(testLogic.Logic())
+	if _, err := serviceLogic.CreateService(request); err == nil {
+		t.Errorf("Error was nil!")
+	}
+}
+
+func TestCreateServiceError_invalidLoadBalancerRule(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	serviceLogic := NewL0ServiceLogic(testLogic.Logic())
+
+	cases := map[string]models.CreateServiceRequest{
+		"Missing LoadBalancerID": {
+			ServiceName:      "name",
+			EnvironmentID:    "e1",
+			DeployID:         "d1",
+			LoadBalancerRule: models.LoadBalancerRule{PathPattern: "/api/*"},
+		},
+		"Priority out of range": {
+			ServiceName:      "name",
+			EnvironmentID:    "e1",
+			DeployID:         "d1",
+			LoadBalancerID:   "l1",
+			LoadBalancerRule: models.LoadBalancerRule{Priority: 50001},
+		},
+	}
+
+	for name, request := range cases {
+		if _, err := serviceLogic.CreateService(request); err == nil {
+			t.Errorf("Case %s: error was nil!", name)
+		}
+	}
+}
+
 func TestUpdateService(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -305,3 +358,51 @@ func TestGetServiceLogs(t *testing.T) {
 
 	testutils.AssertEqual(t, received, logs)
 }
+
+func TestExecService(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	instanceTasks := map[string][]string{
+		"i-2": {"task3"},
+		"i-1": {"task2", "task1"},
+	}
+
+	testLogic.Backend.EXPECT().
+		GetServiceInstanceTasks("e1", "s1").
+		Return(instanceTasks, nil)
+
+	req := models.ExecRequest{Command: []string{"env"}}
+	streams := models.ExecStreams{}
+	testLogic.Backend.EXPECT().
+		ExecTask("e1", "task1", req, streams).
+		Return(0, nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "s1", EntityType: "service", Key: "environment_id", Value: "e1"},
+	})
+
+	serviceLogic := NewL0ServiceLogic(testLogic.Logic())
+	if _, err := serviceLogic.ExecService("s1", req, streams); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExecServiceError_noRunningTasks(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		GetServiceInstanceTasks("e1", "s1").
+		Return(map[string][]string{}, nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "s1", EntityType: "service", Key: "environment_id", Value: "e1"},
+	})
+
+	serviceLogic := NewL0ServiceLogic(testLogic.Logic())
+	req := models.ExecRequest{Command: []string{"env"}}
+	if _, err := serviceLogic.ExecService("s1", req, models.ExecStreams{}); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
@@ -15,6 +15,7 @@ type TaskLogic interface {
 	GetEnvironmentTasks(environmentID string) ([]*models.Task, error)
 	DeleteTask(string) error
 	GetTaskLogs(string, string, string, int) ([]*models.LogFile, error)
+	ExecTask(string, models.ExecRequest, models.ExecStreams) (int, error)
 }
 
 type L0TaskLogic struct {
@@ -121,6 +122,10 @@ func (this *L0TaskLogic) CreateTask(req models.CreateTaskRequest) (string, error
 		return "", errors.Newf(errors.MissingParameter, "TaskName not specified")
 	}
 
+	if err := this.validateDeploySecrets(req.EnvironmentID, req.DeployID); err != nil {
+		return "", err
+	}
+
 	taskARN, err := this.Backend.CreateTask(req.EnvironmentID, req.DeployID, req.ContainerOverrides)
 	if err != nil {
 		return "", err
@@ -162,6 +167,24 @@ func (this *L0TaskLogic) GetTaskLogs(taskID, start, end string, tail int) ([]*mo
 	return logs, nil
 }
 
+func (this *L0TaskLogic) ExecTask(taskID string, req models.ExecRequest, streams models.ExecStreams) (int, error) {
+	if len(req.Command) == 0 {
+		return 0, errors.Newf(errors.MissingParameter, "Command not specified")
+	}
+
+	environmentID, err := this.lookupTaskEnvironmentID(taskID)
+	if err != nil {
+		return 0, err
+	}
+
+	taskARN, err := this.lookupTaskARN(taskID)
+	if err != nil {
+		return 0, err
+	}
+
+	return this.Backend.ExecTask(environmentID, taskARN, req, streams)
+}
+
 func (t *L0TaskLogic) getTaskARNFromID(taskARN string) (string, error) {
 	tags, err := t.TagStore.SelectByType("task")
 	if err != nil {
@@ -141,6 +141,64 @@ func TestCreateTask(t *testing.T) {
 	}
 }
 
+func TestCreateTask_secrets(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "dpl_id", EntityType: "deploy", Key: "secrets", Value: `{"api":{"DB_PASSWORD":"env_id/db_password","API_KEY":"env_id/api_key"},"worker":{"DB_PASSWORD":"env_id/db_password"}}`},
+	})
+
+	overrides := []models.ContainerOverride{
+		{ContainerName: "api", EnvironmentOverrides: map[string]string{"MODE": "test"}},
+	}
+
+	req := models.CreateTaskRequest{
+		TaskName:           "tsk_name",
+		EnvironmentID:      "env_id",
+		DeployID:           "dpl_id",
+		ContainerOverrides: overrides,
+	}
+
+	testLogic.Backend.EXPECT().
+		ListSecrets("env_id").
+		Return([]*models.Secret{{SecretName: "db_password"}, {SecretName: "api_key"}}, nil)
+
+	// secrets are resolved by the containers, so they are never passed as overrides
+	testLogic.Backend.EXPECT().
+		CreateTask("env_id", "dpl_id", overrides).
+		Return("tsk_arn", nil)
+
+	taskLogic := NewL0TaskLogic(testLogic.Logic())
+	if _, err := taskLogic.CreateTask(req); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateTaskError_missingSecret(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "dpl_id", EntityType: "deploy", Key: "secrets", Value: `{"api":{"DB_PASSWORD":"env_id/db_password"}}`},
+	})
+
+	testLogic.Backend.EXPECT().
+		ListSecrets("env_id").
+		Return([]*models.Secret{}, nil)
+
+	req := models.CreateTaskRequest{
+		TaskName:      "tsk_name",
+		EnvironmentID: "env_id",
+		DeployID:      "dpl_id",
+	}
+
+	taskLogic := NewL0TaskLogic(testLogic.Logic())
+	if _, err := taskLogic.CreateTask(req); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
+
 func TestCreateTaskError_missingRequiredParams(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -195,3 +253,66 @@ func TestGetTaskLogs(t *testing.T) {
 
 	testutils.AssertEqual(t, expected, result)
 }
+
+func TestExecTask(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	req := models.ExecRequest{
+		Command:       []string{"sh"},
+		ContainerName: "api",
+		TTY:           true,
+	}
+
+	streams := models.ExecStreams{}
+	testLogic.Backend.EXPECT().
+		ExecTask("env_id", "tsk_arn", req, streams).
+		Return(1, nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "tsk_id", EntityType: "task", Key: "environment_id", Value: "env_id"},
+		{EntityID: "tsk_id", EntityType: "task", Key: "arn", Value: "tsk_arn"},
+	})
+
+	taskLogic := NewL0TaskLogic(testLogic.Logic())
+	exitCode, err := taskLogic.ExecTask("tsk_id", req, streams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, exitCode, 1)
+}
+
+func TestExecTaskError_missingCommand(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	taskLogic := NewL0TaskLogic(testLogic.Logic())
+	if _, err := taskLogic.ExecTask("tsk_id", models.ExecRequest{}, models.ExecStreams{}); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
+
+func TestCreateTaskError_secretsOfOtherEnvironment(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "dpl_id", EntityType: "deploy", Key: "secrets", Value: `{"api":{"DB_PASSWORD":"other_env_id/db_password"}}`},
+	})
+
+	testLogic.Backend.EXPECT().
+		ListSecrets("env_id").
+		Return([]*models.Secret{{SecretName: "db_password"}}, nil)
+
+	req := models.CreateTaskRequest{
+		TaskName:      "tsk_name",
+		EnvironmentID: "env_id",
+		DeployID:      "dpl_id",
+	}
+
+	taskLogic := NewL0TaskLogic(testLogic.Logic())
+	if _, err := taskLogic.CreateTask(req); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
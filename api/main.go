@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -27,16 +28,19 @@ func setupRestful(lgc logic.Logic) {
 	environmentLogic := logic.NewL0EnvironmentLogic(lgc)
 	healthLogic := logic.NewL0HealthLogic(lgc)
 	loadBalancerLogic := logic.NewL0LoadBalancerLogic(lgc)
+	secretLogic := logic.NewL0SecretLogic(lgc)
 	serviceLogic := logic.NewL0ServiceLogic(lgc)
 	taskLogic := logic.NewL0TaskLogic(lgc)
 	jobLogic := logic.NewL0JobLogic(lgc, taskLogic, deployLogic)
 
 	adminHandler := handlers.NewAdminHandler(adminLogic)
+	bulkHandler := handlers.NewBulkHandler(jobLogic, lgc.TagStore)
 	deployHandler := handlers.NewDeployHandler(deployLogic)
 	environmentHandler := handlers.NewEnvironmentHandler(environmentLogic, jobLogic)
 	healthHandler := handlers.NewHealthHandler(healthLogic)
 	jobHandler := handlers.NewJobHandler(jobLogic)
 	loadBalancerHandler := handlers.NewLoadBalancerHandler(loadBalancerLogic, jobLogic)
+	secretHandler := handlers.NewSecretHandler(secretLogic)
 	serviceHandler := handlers.NewServiceHandler(serviceLogic, jobLogic)
 	tagHandler := handlers.NewTagHandler(lgc.TagStore)
 	taskHandler := handlers.NewTaskHandler(taskLogic, jobLogic)
@@ -50,7 +54,9 @@ func setupRestful(lgc logic.Logic) {
 	restful.Add(adminHandler.Routes())
 	restful.Add(loadBalancerHandler.Routes())
 	restful.Add(taskHandler.Routes())
+	restful.Add(secretHandler.Routes())
 	restful.Add(jobHandler.Routes())
+	restful.Add(bulkHandler.Routes())
 
 	restful.Filter(handlers.LogRequest)
 	restful.Filter(handlers.AddVersionHeader)
@@ -162,6 +168,7 @@ func main() {
 
 	jobJanitor := logic.NewJobJanitor(jobLogic)
 	tagJanitor := logic.NewTagJanitor(taskLogic, lgc.TagStore)
+	deployJanitor := logic.NewDeployJanitor(deployLogic, config.DeployRetentionCount())
 	go runEnvironmentScaler(environmentLogic)
 
 	logrus.Infof("Starting Job Janitor")
@@ -170,8 +177,23 @@ func main() {
 	logrus.Infof("Starting Tag Janitor")
 	tagJanitor.Run()
 
+	if deployJanitor.Keep > 0 {
+		logrus.Infof("Starting Deploy Janitor")
+		deployJanitor.Run()
+	}
+
+	trusted, err := trustedProxyNetworks(backend.ECSEnvironmentManager.EC2, strings.Split(config.AWSPublicSubnets(), ","))
+	if err != nil {
+		logrus.Fatalf("Failed to get the networks of the api load balancer: %v", err)
+	}
+
 	logrus.Print("Service on localhost" + port)
-	logrus.Fatal(http.ListenAndServe(port, nil))
+	listener, err := net.Listen("tcp", port)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Fatal(http.Serve(&proxyProtocolListener{Listener: listener, trusted: trusted}, nil))
 }
 
 func runEnvironmentScaler(environmentLogic *logic.L0EnvironmentLogic) {
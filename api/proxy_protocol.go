@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/quintilesims/layer0/common/aws/ec2"
+)
+
+// The api load balancer forwards tcp so exec sessions can upgrade to websockets, which means it
+// cannot set X-Forwarded-For. Instead, it sends a proxy protocol (v1) header with the client's
+// address at the start of each connection. Headers are only trusted from the networks the load
+// balancer's nodes run in, so other hosts that can reach the api cannot forge their address.
+const (
+	proxyProtocolPrefix        = "PROXY "
+	proxyProtocolHeaderTimeout = time.Second * 10
+)
+
+// proxyProtocolListener reports the address in the proxy protocol header of each connection from a
+// trusted network as its remote address. Connections without a header, such as health checks, are
+// served as usual. Headers from other networks are not parsed, so their requests are rejected as invalid.
+type proxyProtocolListener struct {
+	net.Listener
+	trusted []*net.IPNet
+}
+
+func (l *proxyProtocolListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return newProxyProtocolConn(conn, l.trusted), nil
+}
+
+type proxyProtocolConn struct {
+	net.Conn
+	reader     *bufio.Reader
+	trusted    []*net.IPNet
+	once       sync.Once
+	remoteAddr net.Addr
+}
+
+func newProxyProtocolConn(conn net.Conn, trusted []*net.IPNet) *proxyProtocolConn {
+	return &proxyProtocolConn{Conn: conn, reader: bufio.NewReader(conn), trusted: trusted}
+}
+
+func (c *proxyProtocolConn) Read(b []byte) (int, error) {
+	c.once.Do(c.readHeader)
+	return c.reader.Read(b)
+}
+
+func (c *proxyProtocolConn) RemoteAddr() net.Addr {
+	c.once.Do(c.readHeader)
+	return c.remoteAddr
+}
+
+func (c *proxyProtocolConn) readHeader() {
+	c.remoteAddr = c.Conn.RemoteAddr()
+	if !isTrustedProxy(c.remoteAddr, c.trusted) {
+		return
+	}
+
+	c.Conn.SetReadDeadline(time.Now().Add(proxyProtocolHeaderTimeout))
+	defer c.Conn.SetReadDeadline(time.Time{})
+
+	prefix, err := c.reader.Peek(len(proxyProtocolPrefix))
+	if err != nil || string(prefix) != proxyProtocolPrefix {
+		return
+	}
+
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return
+	}
+
+	if addr := parseProxyProtocolHeader(line); addr != nil {
+		c.remoteAddr = addr
+	}
+}
+
+func isTrustedProxy(addr net.Addr, trusted []*net.IPNet) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+
+	for _, network := range trusted {
+		if network.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trustedProxyNetworks returns the networks of the public subnets, which hold the nodes of the api load balancer
+func trustedProxyNetworks(ec2Provider ec2.Provider, subnetIDs []string) ([]*net.IPNet, error) {
+	networks := []*net.IPNet{}
+	for _, subnetID := range subnetIDs {
+		subnet, err := ec2Provider.DescribeSubnet(subnetID)
+		if err != nil {
+			return nil, err
+		}
+
+		if subnet == nil {
+			return nil, fmt.Errorf("Subnet '%s' does not exist", subnetID)
+		}
+
+		_, network, err := net.ParseCIDR(aws.StringValue(subnet.CidrBlock))
+		if err != nil {
+			return nil, err
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// parseProxyProtocolHeader returns nil if the header does not include the client's address, e.g.
+// 'PROXY UNKNOWN\r\n'. Otherwise, the header has the format
+// 'PROXY <TCP4|TCP6> <source ip> <destination ip> <source port> <destination port>\r\n'.
+func parseProxyProtocolHeader(line string) net.Addr {
+	fields := strings.Fields(line)
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil
+	}
+
+	ip := net.ParseIP(fields[2])
+	port, err := strconv.Atoi(fields[4])
+	if ip == nil || err != nil {
+		return nil
+	}
+
+	return &net.TCPAddr{IP: ip, Port: port}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestParseProxyProtocolHeader(t *testing.T) {
+	cases := map[string]string{
+		"PROXY TCP4 203.0.113.7 10.0.0.1 56324 80\r\n":    "203.0.113.7:56324",
+		"PROXY TCP6 2001:db8::1 2001:db8::2 56324 80\r\n": "[2001:db8::1]:56324",
+		"PROXY UNKNOWN\r\n":                          "",
+		"PROXY TCP4 not-an-ip 10.0.0.1 56324 80\r\n": "",
+	}
+
+	for header, expected := range cases {
+		addr := parseProxyProtocolHeader(header)
+		if expected == "" {
+			if addr != nil {
+				t.Errorf("%q: expected nil, got %v", header, addr)
+			}
+
+			continue
+		}
+
+		if addr == nil {
+			t.Errorf("%q: address was nil", header)
+			continue
+		}
+
+		testutils.AssertEqual(t, addr.String(), expected)
+	}
+}
+
+func TestProxyProtocolConn(t *testing.T) {
+	_, loopback, err := net.ParseCIDR("127.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, other, err := net.ParseCIDR("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := "PROXY TCP4 203.0.113.7 10.0.0.1 56324 80\r\n"
+	request := "GET /health HTTP/1.1\r\n\r\n"
+
+	cases := []struct {
+		Input        string
+		Trusted      []*net.IPNet
+		ExpectedAddr string
+		ExpectedBody string
+	}{
+		{header + request, []*net.IPNet{loopback}, "203.0.113.7:56324", request},
+		{request, []*net.IPNet{loopback}, "", request},
+		// headers from untrusted networks are passed through, so the request is invalid
+		{header + request, []*net.IPNet{other}, "", header + request},
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	for _, c := range cases {
+		go func(input string) {
+			client, err := net.Dial("tcp", listener.Addr().String())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			client.Write([]byte(input))
+			client.Close()
+		}(c.Input)
+
+		server, err := listener.Accept()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		conn := newProxyProtocolConn(server, c.Trusted)
+		if c.ExpectedAddr == "" {
+			c.ExpectedAddr = server.RemoteAddr().String()
+		}
+
+		testutils.AssertEqual(t, conn.RemoteAddr().String(), c.ExpectedAddr)
+
+		body, err := ioutil.ReadAll(conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertEqual(t, string(body), c.ExpectedBody)
+		server.Close()
+	}
+}
@@ -346,3 +346,23 @@ func TestResourceManagerScaledown_complex(t *testing.T) {
 
 	test.Run(t)
 }
+
+func TestResourceManagerNoScale_dynamicPorts(t *testing.T) {
+	// there is 1 provider in the cluster that has port 80 being used
+	// there are 3 consumers that use dynamic host ports, so they don't reserve any ports
+	// we should stay at size 1
+	test := EnvironmentScalerUnitTest{
+		ExpectedScale:     1,
+		MemoryPerProvider: bytesize.MB * 3,
+		ResourceProviders: []*resource.ResourceProvider{
+			resource.NewResourceProvider("", true, bytesize.MB*3, []int{80}),
+		},
+		ResourceConsumers: []resource.ResourceConsumer{
+			{Memory: bytesize.MB},
+			{Memory: bytesize.MB},
+			{Memory: bytesize.MB},
+		},
+	}
+
+	test.Run(t)
+}
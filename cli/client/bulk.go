@@ -0,0 +1,26 @@
+package client
+
+import (
+	"github.com/quintilesims/layer0/common/models"
+)
+
+// BulkDelete deletes each entity of entityType whose tags match selector in a single job.
+// If entityIDs is not nil, only those entities are deleted, and only if they still match selector.
+// If dryRun is set, the matching entities are returned without being deleted.
+func (c *APIClient) BulkDelete(entityType string, selector map[string]string, all bool, entityIDs []string, dryRun bool) (*models.BulkResponse, error) {
+	req := models.BulkRequest{
+		Action:     "delete",
+		All:        all,
+		DryRun:     dryRun,
+		EntityIDs:  entityIDs,
+		EntityType: entityType,
+		Selector:   selector,
+	}
+
+	var response *models.BulkResponse
+	if err := c.Execute(c.Sling("bulk/").Post("").BodyJSON(req), &response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestBulkDelete(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "POST")
+		testutils.AssertEqual(t, r.URL.Path, "/bulk/")
+
+		var req models.BulkRequest
+		Unmarshal(t, r, &req)
+
+		expected := models.BulkRequest{
+			Action:     "delete",
+			EntityIDs:  []string{"s1", "s2"},
+			EntityType: "service",
+			Selector:   map[string]string{"team": "payments"},
+		}
+
+		testutils.AssertEqual(t, req, expected)
+
+		response := models.BulkResponse{
+			Action:     "delete",
+			EntityIDs:  []string{"s1", "s2"},
+			EntityType: "service",
+			JobID:      "jid",
+		}
+
+		MarshalAndWrite(t, w, response, 202)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	response, err := client.BulkDelete("service", map[string]string{"team": "payments"}, false, []string{"s1", "s2"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, response.EntityIDs, []string{"s1", "s2"})
+	testutils.AssertEqual(t, response.JobID, "jid")
+}
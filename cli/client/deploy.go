@@ -18,6 +18,35 @@ func (c *APIClient) CreateDeploy(name string, content []byte) (*models.Deploy, e
 	return deploy, nil
 }
 
+func (c *APIClient) CreateDeployFromCompose(name string, compose []byte) (*models.Deploy, error) {
+	req := models.CreateDeployRequest{
+		Compose:    compose,
+		DeployName: name,
+	}
+
+	var deploy *models.Deploy
+	if err := c.Execute(c.Sling("deploy").Post("").BodyJSON(req), &deploy); err != nil {
+		return nil, err
+	}
+
+	return deploy, nil
+}
+
+func (c *APIClient) CreateDeployFromTemplate(name string, template []byte, variables map[string]string) (*models.Deploy, error) {
+	req := models.CreateDeployRequest{
+		DeployName: name,
+		Template:   template,
+		Variables:  variables,
+	}
+
+	var deploy *models.Deploy
+	if err := c.Execute(c.Sling("deploy").Post("").BodyJSON(req), &deploy); err != nil {
+		return nil, err
+	}
+
+	return deploy, nil
+}
+
 func (c *APIClient) DeleteDeploy(id string) error {
 	var response *string
 	if err := c.Execute(c.Sling("deploy/").Delete(id), &response); err != nil {
@@ -27,6 +56,15 @@ func (c *APIClient) DeleteDeploy(id string) error {
 	return nil
 }
 
+func (c *APIClient) DiffDeploys(id, otherID string) (*models.DeployDiff, error) {
+	var diff *models.DeployDiff
+	if err := c.Execute(c.Sling("deploy/").Get(id+"/diff/"+otherID), &diff); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
 func (c *APIClient) GetDeploy(id string) (*models.Deploy, error) {
 	var deploy *models.Deploy
 	if err := c.Execute(c.Sling("deploy/").Get(id), &deploy); err != nil {
@@ -44,3 +82,32 @@ func (c *APIClient) ListDeploys() ([]*models.DeploySummary, error) {
 
 	return deploys, nil
 }
+
+func (c *APIClient) ValidateDeploy(name string, content []byte, environmentID string) (*models.DeployValidation, error) {
+	req := models.ValidateDeployRequest{
+		DeployName:    name,
+		Dockerrun:     content,
+		EnvironmentID: environmentID,
+	}
+
+	var validation *models.DeployValidation
+	if err := c.Execute(c.Sling("deploy/").Post("validate").BodyJSON(req), &validation); err != nil {
+		return nil, err
+	}
+
+	return validation, nil
+}
+
+func (c *APIClient) PruneDeploys(keep int, dryRun bool) (*models.PruneDeploysReport, error) {
+	req := models.PruneDeploysRequest{
+		DryRun: dryRun,
+		Keep:   keep,
+	}
+
+	var report *models.PruneDeploysReport
+	if err := c.Execute(c.Sling("deploy/").Post("prune").BodyJSON(req), &report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -33,6 +33,57 @@ func TestCreateDeploy(t *testing.T) {
 	testutils.AssertEqual(t, deploy.DeployID, "id")
 }
 
+func TestCreateDeployFromCompose(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "POST")
+		testutils.AssertEqual(t, r.URL.Path, "/deploy")
+
+		var req models.CreateDeployRequest
+		Unmarshal(t, r, &req)
+
+		testutils.AssertEqual(t, req.DeployName, "name")
+		testutils.AssertEqual(t, req.Compose, []byte("compose"))
+
+		MarshalAndWrite(t, w, models.Deploy{DeployID: "id"}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	deploy, err := client.CreateDeployFromCompose("name", []byte("compose"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, deploy.DeployID, "id")
+}
+
+func TestCreateDeployFromTemplate(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "POST")
+		testutils.AssertEqual(t, r.URL.Path, "/deploy")
+
+		var req models.CreateDeployRequest
+		Unmarshal(t, r, &req)
+
+		testutils.AssertEqual(t, req.DeployName, "name")
+		testutils.AssertEqual(t, req.Template, []byte("template"))
+		testutils.AssertEqual(t, req.Variables, map[string]string{"key": "val"})
+
+		MarshalAndWrite(t, w, models.Deploy{DeployID: "id"}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	deploy, err := client.CreateDeployFromTemplate("name", []byte("template"), map[string]string{"key": "val"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, deploy.DeployID, "id")
+}
+
 func TestDeleteDeploy(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		testutils.AssertEqual(t, r.Method, "DELETE")
@@ -49,6 +100,26 @@ func TestDeleteDeploy(t *testing.T) {
 	}
 }
 
+func TestDiffDeploys(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+		testutils.AssertEqual(t, r.URL.Path, "/deploy/id.1/diff/id.2")
+
+		MarshalAndWrite(t, w, models.DeployDiff{DeployID: "id.1", OtherDeployID: "id.2"}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	diff, err := client.DiffDeploys("id.1", "id.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, diff.DeployID, "id.1")
+	testutils.AssertEqual(t, diff.OtherDeployID, "id.2")
+}
+
 func TestGetDeploy(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		testutils.AssertEqual(t, r.Method, "GET")
@@ -93,3 +164,60 @@ func TestListDeploys(t *testing.T) {
 	testutils.AssertEqual(t, deploys[0].DeployID, "id1")
 	testutils.AssertEqual(t, deploys[1].DeployID, "id2")
 }
+
+func TestValidateDeploy(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "POST")
+		testutils.AssertEqual(t, r.URL.Path, "/deploy/validate")
+
+		var req models.ValidateDeployRequest
+		Unmarshal(t, r, &req)
+
+		testutils.AssertEqual(t, req.DeployName, "name")
+		testutils.AssertEqual(t, req.Dockerrun, []byte("content"))
+		testutils.AssertEqual(t, req.EnvironmentID, "eid")
+
+		validation := models.DeployValidation{
+			Errors: []string{"some error"},
+			Valid:  false,
+		}
+
+		MarshalAndWrite(t, w, validation, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	validation, err := client.ValidateDeploy("name", []byte("content"), "eid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, validation.Valid, false)
+	testutils.AssertEqual(t, validation.Errors, []string{"some error"})
+}
+
+func TestPruneDeploys(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "POST")
+		testutils.AssertEqual(t, r.URL.Path, "/deploy/prune")
+
+		var req models.PruneDeploysRequest
+		Unmarshal(t, r, &req)
+
+		testutils.AssertEqual(t, req.Keep, 5)
+		testutils.AssertEqual(t, req.DryRun, true)
+
+		MarshalAndWrite(t, w, models.PruneDeploysReport{DryRun: true}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	report, err := client.PruneDeploys(5, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, report.DryRun, true)
+}
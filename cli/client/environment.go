@@ -62,9 +62,13 @@ func (c *APIClient) UpdateEnvironment(id string, minCount int) (*models.Environm
 	return environment, nil
 }
 
-func (c *APIClient) CreateLink(sourceID string, destinationID string) error {
+func (c *APIClient) CreateLink(sourceID string, link models.EnvironmentLink) error {
 	req := models.CreateEnvironmentLinkRequest{
-		EnvironmentID: destinationID,
+		EnvironmentID:   link.EnvironmentID,
+		CIDR:            link.CIDR,
+		SecurityGroupID: link.SecurityGroupID,
+		Direction:       link.Direction,
+		Rules:           link.Rules,
 	}
 
 	var resp string
@@ -139,6 +139,8 @@ func TestCreateLink(t *testing.T) {
 		Unmarshal(t, r, &req)
 
 		testutils.AssertEqual(t, req.EnvironmentID, "id2")
+		testutils.AssertEqual(t, req.Direction, "outbound")
+		testutils.AssertEqual(t, req.Rules, []models.EnvironmentLinkRule{{Protocol: "tcp", FromPort: 80, ToPort: 80}})
 
 		MarshalAndWrite(t, w, "", 200)
 	}
@@ -146,7 +148,13 @@ func TestCreateLink(t *testing.T) {
 	client, server := newClientAndServer(handler)
 	defer server.Close()
 
-	if err := client.CreateLink("id1", "id2"); err != nil {
+	link := models.EnvironmentLink{
+		EnvironmentID: "id2",
+		Direction:     "outbound",
+		Rules:         []models.EnvironmentLinkRule{{Protocol: "tcp", FromPort: 80, ToPort: 80}},
+	}
+
+	if err := client.CreateLink("id1", link); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -166,3 +174,19 @@ func TestCreateUnlink(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestCreateUnlink_cidr(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "DELETE")
+		testutils.AssertEqual(t, r.URL.Path, "/environment/id1/link/10.0.0.0/16")
+
+		MarshalAndWrite(t, w, "", 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if err := client.DeleteLink("id1", "10.0.0.0/16"); err != nil {
+		t.Fatal(err)
+	}
+}
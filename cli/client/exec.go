@@ -0,0 +1,198 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
+)
+
+// keep exec sessions from being closed by idle timeouts between the cli and the api
+const execPingInterval = time.Second * 30
+
+func (c *APIClient) ExecTask(id string, req models.ExecRequest, streams models.ExecStreams) (int, error) {
+	return c.exec(fmt.Sprintf("task/%s/exec", id), req, streams)
+}
+
+func (c *APIClient) ExecService(id string, req models.ExecRequest, streams models.ExecStreams) (int, error) {
+	return c.exec(fmt.Sprintf("service/%s/exec", id), req, streams)
+}
+
+// exec relays an exec session between streams and the api until the command exits, returning its exit code
+func (c *APIClient) exec(path string, req models.ExecRequest, streams models.ExecStreams) (int, error) {
+	conn, err := c.dialExec(path, req)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var mutex sync.Mutex
+	write := func(messageType int, data []byte) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return conn.WriteMessage(messageType, data)
+	}
+
+	if streams.Stdin != nil {
+		go func() {
+			buffer := make([]byte, 4096)
+			for {
+				n, err := streams.Stdin.Read(buffer)
+				if n > 0 {
+					message := append([]byte{types.ExecStdin}, buffer[:n]...)
+					if err := write(websocket.BinaryMessage, message); err != nil {
+						return
+					}
+				}
+
+				if err != nil {
+					// a stdin message without data marks the end of our input
+					write(websocket.BinaryMessage, []byte{types.ExecStdin})
+					return
+				}
+			}
+		}()
+	}
+
+	if streams.Resize != nil {
+		go func() {
+			for size := range streams.Resize {
+				message, err := json.Marshal(models.ExecMessage{
+					Type:   types.ExecResizeMessage,
+					Height: size.Height,
+					Width:  size.Width,
+				})
+				if err != nil {
+					continue
+				}
+
+				if err := write(websocket.TextMessage, message); err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		ticker := time.NewTicker(execPingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(execPingInterval))
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return 0, fmt.Errorf("Exec session ended unexpectedly: %v", err)
+		}
+
+		switch messageType {
+		case websocket.BinaryMessage:
+			if len(data) == 0 {
+				continue
+			}
+
+			var w io.Writer
+			switch data[0] {
+			case types.ExecStdout:
+				w = streams.Stdout
+			case types.ExecStderr:
+				w = streams.Stderr
+			}
+
+			if w != nil {
+				w.Write(data[1:])
+			}
+		case websocket.TextMessage:
+			var message models.ExecMessage
+			if err := json.Unmarshal(data, &message); err != nil {
+				return 0, err
+			}
+
+			switch message.Type {
+			case types.ExecExitMessage:
+				return message.ExitCode, nil
+			case types.ExecErrorMessage:
+				return 0, fmt.Errorf("%s", message.Error)
+			}
+		}
+	}
+}
+
+func (c *APIClient) dialExec(path string, req models.ExecRequest) (*websocket.Conn, error) {
+	endpoint, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Scheme == "https" {
+		endpoint.Scheme = "wss"
+	} else {
+		endpoint.Scheme = "ws"
+	}
+
+	query := url.Values{}
+	query.Set("tty", strconv.FormatBool(req.TTY))
+	if req.ContainerName != "" {
+		query.Set("container", req.ContainerName)
+	}
+
+	for _, arg := range req.Command {
+		query.Add("command", arg)
+	}
+
+	endpoint.Path = fmt.Sprintf("%s/%s", strings.TrimSuffix(endpoint.Path, "/"), path)
+	endpoint.RawQuery = query.Encode()
+
+	dialer := &websocket.Dialer{}
+	if transport, ok := c.httpClient.Transport.(*http.Transport); ok {
+		dialer.TLSClientConfig = transport.TLSClientConfig
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", fmt.Sprintf("Basic %s", c.Token))
+
+	conn, resp, err := dialer.Dial(endpoint.String(), header)
+	if err != nil {
+		if strings.Contains(err.Error(), "x509: certificate is valid for") {
+			return nil, sslError(err)
+		}
+
+		if resp == nil {
+			return nil, fmt.Errorf("Unable to connect to API with error: %v", err)
+		}
+
+		if resp.StatusCode == 401 {
+			return nil, fmt.Errorf("Invalid Auth Token. Have you tried running `l0-setup endpoint <prefix>`?")
+		}
+
+		var serverError *ServerError
+		if err := json.NewDecoder(resp.Body).Decode(&serverError); err == nil && serverError != nil {
+			return nil, serverError.ToCommonError()
+		}
+
+		return nil, fmt.Errorf("Layer0 API returned invalid status code: %s", resp.Status)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/quintilesims/layer0/common/types"
+)
+
+func TestExecTask(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.URL.Path, "/task/id/exec")
+		testutils.AssertEqual(t, r.URL.Query()["command"], []string{"cat", "-"})
+		testutils.AssertEqual(t, r.URL.Query().Get("container"), "api")
+		testutils.AssertEqual(t, r.URL.Query().Get("tty"), "false")
+
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		// echo stdin back on stdout until the client closes its input
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(data) == 1 {
+				break
+			}
+
+			conn.WriteMessage(websocket.BinaryMessage, append([]byte{types.ExecStdout}, data[1:]...))
+		}
+
+		message, err := json.Marshal(models.ExecMessage{Type: types.ExecExitMessage, ExitCode: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		conn.WriteMessage(websocket.TextMessage, message)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	req := models.ExecRequest{
+		Command:       []string{"cat", "-"},
+		ContainerName: "api",
+	}
+
+	streams := models.ExecStreams{
+		Stdin:  strings.NewReader("hello"),
+		Stdout: &stdout,
+	}
+
+	exitCode, err := client.ExecTask("id", req, streams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, exitCode, 3)
+	testutils.AssertEqual(t, stdout.String(), "hello")
+}
+
+func TestExecService_serverError(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.URL.Path, "/service/id/exec")
+
+		serverError := errors.Newf(errors.InvalidExecRequest, "Parameter 'command' is required")
+		MarshalAndWrite(t, w, serverError.Model(), 400)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if _, err := client.ExecService("id", models.ExecRequest{Command: []string{"sh"}}, models.ExecStreams{}); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
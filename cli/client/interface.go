@@ -7,17 +7,24 @@ import (
 )
 
 type Client interface {
+	BulkDelete(entityType string, selector map[string]string, all bool, entityIDs []string, dryRun bool) (*models.BulkResponse, error)
+
 	CreateDeploy(name string, content []byte) (*models.Deploy, error)
+	CreateDeployFromCompose(name string, compose []byte) (*models.Deploy, error)
+	CreateDeployFromTemplate(name string, template []byte, variables map[string]string) (*models.Deploy, error)
 	DeleteDeploy(id string) error
+	DiffDeploys(id, otherID string) (*models.DeployDiff, error)
 	GetDeploy(id string) (*models.Deploy, error)
 	ListDeploys() ([]*models.DeploySummary, error)
+	PruneDeploys(keep int, dryRun bool) (*models.PruneDeploysReport, error)
+	ValidateDeploy(name string, content []byte, environmentID string) (*models.DeployValidation, error)
 
 	CreateEnvironment(name, instanceSize string, minCount int, userData []byte, os, amiID string) (*models.Environment, error)
 	DeleteEnvironment(id string) (string, error)
 	GetEnvironment(id string) (*models.Environment, error)
 	ListEnvironments() ([]*models.EnvironmentSummary, error)
 	UpdateEnvironment(id string, minCount int) (*models.Environment, error)
-	CreateLink(sourceID string, destinationID string) error
+	CreateLink(sourceID string, link models.EnvironmentLink) error
 	DeleteLink(sourceID string, destinationID string) error
 
 	Delete(id string) error
@@ -25,31 +32,45 @@ type Client interface {
 	ListJobs() ([]*models.Job, error)
 	WaitForJob(jobID string, timeout time.Duration) error
 
-	CreateLoadBalancer(name, environmentID string, healthCheck models.HealthCheck, ports []models.Port, isPublic bool, idleTimeout int, crossZone bool) (*models.LoadBalancer, error)
+	CreateLoadBalancer(name, environmentID string, healthCheck models.HealthCheck, ports []models.Port, isPublic bool, idleTimeout int, crossZone bool, loadBalancerType string) (*models.LoadBalancer, error)
 	DeleteLoadBalancer(id string) (string, error)
 	GetLoadBalancer(id string) (*models.LoadBalancer, error)
+	GetLoadBalancerHealth(id string) (*models.LoadBalancerHealth, error)
 	ListLoadBalancers() ([]*models.LoadBalancerSummary, error)
 	UpdateLoadBalancerHealthCheck(id string, healthCheck models.HealthCheck) (*models.LoadBalancer, error)
 	UpdateLoadBalancerPorts(id string, ports []models.Port) (*models.LoadBalancer, error)
 	UpdateLoadBalancerIdleTimeout(id string, idleTimeout int) (*models.LoadBalancer, error)
 	UpdateLoadBalancerCrossZone(id string, crossZone bool) (*models.LoadBalancer, error)
+	UpdateLoadBalancerAccessLogs(id string, accessLogs models.AccessLogs) (*models.LoadBalancer, error)
+	UpdateLoadBalancerConnectionDraining(id string, connectionDraining models.ConnectionDraining) (*models.LoadBalancer, error)
+
+	CreateSecret(environmentID, name, value string) (*models.Secret, error)
+	DeleteSecret(environmentID, name string) error
+	ListSecrets(environmentID string) ([]*models.Secret, error)
 
-	CreateService(name, environmentID, deployID, loadBalancerID string) (*models.Service, error)
+	CreateService(name, environmentID, deployID, loadBalancerID string, loadBalancerRule models.LoadBalancerRule) (*models.Service, error)
 	DeleteService(id string) (string, error)
+	ExecService(id string, req models.ExecRequest, streams models.ExecStreams) (int, error)
 	UpdateService(serviceID, deployID string) (*models.Service, error)
 	GetService(id string) (*models.Service, error)
 	GetServiceLogs(id, start, end string, tail int) ([]*models.LogFile, error)
 	ListServices() ([]*models.ServiceSummary, error)
 	ScaleService(id string, scale int) (*models.Service, error)
-	WaitForDeployment(serviceID string, timeout time.Duration) (*models.Service, error)
+	WaitForDeployment(serviceID string, timeout time.Duration, waitForHealthy bool) (*models.Service, error)
 
 	CreateTask(name, environmentID, deployID string, overrides []models.ContainerOverride) (string, error)
 	DeleteTask(id string) error
+	ExecTask(id string, req models.ExecRequest, streams models.ExecStreams) (int, error)
 	GetTask(id string) (*models.Task, error)
 	GetTaskLogs(id, start, end string, tail int) ([]*models.LogFile, error)
 	ListTasks() ([]*models.TaskSummary, error)
+	WaitForTask(id string, timeout time.Duration) (*models.Task, error)
 
+	DeleteLabel(entityType, entityID, key string) error
+	ListLabels(entityType, entityID string) (map[string]string, error)
+	SetLabel(entityType, entityID, key, value string) error
 	SelectByQuery(params map[string]string) ([]*models.EntityWithTags, error)
+
 	GetVersion() (string, error)
 	GetConfig() (*models.APIConfig, error)
 	UpdateSQL() error
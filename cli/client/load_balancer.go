@@ -4,9 +4,10 @@ import (
 	"github.com/quintilesims/layer0/common/models"
 )
 
-func (c *APIClient) CreateLoadBalancer(name, environmentID string, healthCheck models.HealthCheck, ports []models.Port, isPublic bool, idleTimeout int, crossZone bool) (*models.LoadBalancer, error) {
+func (c *APIClient) CreateLoadBalancer(name, environmentID string, healthCheck models.HealthCheck, ports []models.Port, isPublic bool, idleTimeout int, crossZone bool, loadBalancerType string) (*models.LoadBalancer, error) {
 	req := models.CreateLoadBalancerRequest{
 		LoadBalancerName: name,
+		LoadBalancerType: loadBalancerType,
 		EnvironmentID:    environmentID,
 		HealthCheck:      healthCheck,
 		Ports:            ports,
@@ -41,6 +42,15 @@ func (c *APIClient) GetLoadBalancer(id string) (*models.LoadBalancer, error) {
 	return loadBalancer, nil
 }
 
+func (c *APIClient) GetLoadBalancerHealth(id string) (*models.LoadBalancerHealth, error) {
+	var health *models.LoadBalancerHealth
+	if err := c.Execute(c.Sling("loadbalancer/").Get(id+"/health"), &health); err != nil {
+		return nil, err
+	}
+
+	return health, nil
+}
+
 func (c *APIClient) ListLoadBalancers() ([]*models.LoadBalancerSummary, error) {
 	var loadBalancers []*models.LoadBalancerSummary
 	if err := c.Execute(c.Sling("loadbalancer/").Get(""), &loadBalancers); err != nil {
@@ -101,3 +111,29 @@ func (c *APIClient) UpdateLoadBalancerCrossZone(id string, crossZone bool) (*mod
 
 	return loadBalancer, nil
 }
+
+func (c *APIClient) UpdateLoadBalancerAccessLogs(id string, accessLogs models.AccessLogs) (*models.LoadBalancer, error) {
+	req := models.UpdateLoadBalancerAccessLogsRequest{
+		AccessLogs: accessLogs,
+	}
+
+	var loadBalancer *models.LoadBalancer
+	if err := c.Execute(c.Sling("loadbalancer/").Put(id+"/accesslogs").BodyJSON(req), &loadBalancer); err != nil {
+		return nil, err
+	}
+
+	return loadBalancer, nil
+}
+
+func (c *APIClient) UpdateLoadBalancerConnectionDraining(id string, connectionDraining models.ConnectionDraining) (*models.LoadBalancer, error) {
+	req := models.UpdateLoadBalancerConnectionDrainingRequest{
+		ConnectionDraining: connectionDraining,
+	}
+
+	var loadBalancer *models.LoadBalancer
+	if err := c.Execute(c.Sling("loadbalancer/").Put(id+"/connectiondraining").BodyJSON(req), &loadBalancer); err != nil {
+		return nil, err
+	}
+
+	return loadBalancer, nil
+}
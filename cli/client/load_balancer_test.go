@@ -45,6 +45,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 		testutils.AssertEqual(t, req.HealthCheck, healthCheck)
 		testutils.AssertEqual(t, req.Ports, ports)
 		testutils.AssertEqual(t, req.IdleTimeout, 60)
+		testutils.AssertEqual(t, req.LoadBalancerType, "alb")
 
 		MarshalAndWrite(t, w, models.LoadBalancer{LoadBalancerID: "id"}, 200)
 	}
@@ -52,7 +53,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 	client, server := newClientAndServer(handler)
 	defer server.Close()
 
-	loadBalancer, err := client.CreateLoadBalancer("name", "environmentID", healthCheck, ports, true, 60, true)
+	loadBalancer, err := client.CreateLoadBalancer("name", "environmentID", healthCheck, ports, true, 60, true, "alb")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -103,6 +104,33 @@ func TestGetLoadBalancer(t *testing.T) {
 	testutils.AssertEqual(t, loadBalancer.LoadBalancerID, "id")
 }
 
+func TestGetLoadBalancerHealth(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+		testutils.AssertEqual(t, r.URL.Path, "/loadbalancer/id/health")
+
+		health := models.LoadBalancerHealth{
+			LoadBalancerID: "id",
+			Instances: []models.InstanceHealth{
+				{InstanceID: "i-123", State: "InService"},
+			},
+		}
+
+		MarshalAndWrite(t, w, health, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	health, err := client.GetLoadBalancerHealth("id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, health.LoadBalancerID, "id")
+	testutils.AssertEqual(t, health.Instances[0].InstanceID, "i-123")
+}
+
 func TestListLoadBalancers(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		testutils.AssertEqual(t, r.Method, "GET")
@@ -251,3 +279,55 @@ func TestUpdateLoadBalancerCrossZone(t *testing.T) {
 
 	testutils.AssertEqual(t, loadBalancer.LoadBalancerID, "id")
 }
+
+func TestUpdateLoadBalancerAccessLogs(t *testing.T) {
+	accessLogs := models.AccessLogs{Enabled: true, S3BucketName: "bucket", EmitInterval: 5}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "PUT")
+		testutils.AssertEqual(t, r.URL.Path, "/loadbalancer/id/accesslogs")
+
+		var req models.UpdateLoadBalancerAccessLogsRequest
+		Unmarshal(t, r, &req)
+
+		testutils.AssertEqual(t, req.AccessLogs, accessLogs)
+
+		MarshalAndWrite(t, w, models.LoadBalancer{LoadBalancerID: "id"}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	loadBalancer, err := client.UpdateLoadBalancerAccessLogs("id", accessLogs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, loadBalancer.LoadBalancerID, "id")
+}
+
+func TestUpdateLoadBalancerConnectionDraining(t *testing.T) {
+	connectionDraining := models.ConnectionDraining{Enabled: true, Timeout: 120}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "PUT")
+		testutils.AssertEqual(t, r.URL.Path, "/loadbalancer/id/connectiondraining")
+
+		var req models.UpdateLoadBalancerConnectionDrainingRequest
+		Unmarshal(t, r, &req)
+
+		testutils.AssertEqual(t, req.ConnectionDraining, connectionDraining)
+
+		MarshalAndWrite(t, w, models.LoadBalancer{LoadBalancerID: "id"}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	loadBalancer, err := client.UpdateLoadBalancerConnectionDraining("id", connectionDraining)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, loadBalancer.LoadBalancerID, "id")
+}
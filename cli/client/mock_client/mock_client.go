@@ -34,6 +34,19 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// BulkDelete mocks base method
+func (m *MockClient) BulkDelete(arg0 string, arg1 map[string]string, arg2 bool, arg3 []string, arg4 bool) (*models.BulkResponse, error) {
+	ret := m.ctrl.Call(m, "BulkDelete", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*models.BulkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkDelete indicates an expected call of BulkDelete
+func (mr *MockClientMockRecorder) BulkDelete(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockClient)(nil).BulkDelete), arg0, arg1, arg2, arg3, arg4)
+}
+
 // CreateDeploy mocks base method
 func (m *MockClient) CreateDeploy(arg0 string, arg1 []byte) (*models.Deploy, error) {
 	ret := m.ctrl.Call(m, "CreateDeploy", arg0, arg1)
@@ -47,6 +60,32 @@ func (mr *MockClientMockRecorder) CreateDeploy(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeploy", reflect.TypeOf((*MockClient)(nil).CreateDeploy), arg0, arg1)
 }
 
+// CreateDeployFromCompose mocks base method
+func (m *MockClient) CreateDeployFromCompose(arg0 string, arg1 []byte) (*models.Deploy, error) {
+	ret := m.ctrl.Call(m, "CreateDeployFromCompose", arg0, arg1)
+	ret0, _ := ret[0].(*models.Deploy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDeployFromCompose indicates an expected call of CreateDeployFromCompose
+func (mr *MockClientMockRecorder) CreateDeployFromCompose(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployFromCompose", reflect.TypeOf((*MockClient)(nil).CreateDeployFromCompose), arg0, arg1)
+}
+
+// CreateDeployFromTemplate mocks base method
+func (m *MockClient) CreateDeployFromTemplate(arg0 string, arg1 []byte, arg2 map[string]string) (*models.Deploy, error) {
+	ret := m.ctrl.Call(m, "CreateDeployFromTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Deploy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDeployFromTemplate indicates an expected call of CreateDeployFromTemplate
+func (mr *MockClientMockRecorder) CreateDeployFromTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployFromTemplate", reflect.TypeOf((*MockClient)(nil).CreateDeployFromTemplate), arg0, arg1, arg2)
+}
+
 // CreateEnvironment mocks base method
 func (m *MockClient) CreateEnvironment(arg0, arg1 string, arg2 int, arg3 []byte, arg4, arg5 string) (*models.Environment, error) {
 	ret := m.ctrl.Call(m, "CreateEnvironment", arg0, arg1, arg2, arg3, arg4, arg5)
@@ -61,7 +100,7 @@ func (mr *MockClientMockRecorder) CreateEnvironment(arg0, arg1, arg2, arg3, arg4
 }
 
 // CreateLink mocks base method
-func (m *MockClient) CreateLink(arg0, arg1 string) error {
+func (m *MockClient) CreateLink(arg0 string, arg1 models.EnvironmentLink) error {
 	ret := m.ctrl.Call(m, "CreateLink", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -73,29 +112,42 @@ func (mr *MockClientMockRecorder) CreateLink(arg0, arg1 interface{}) *gomock.Cal
 }
 
 // CreateLoadBalancer mocks base method
-func (m *MockClient) CreateLoadBalancer(arg0, arg1 string, arg2 models.HealthCheck, arg3 []models.Port, arg4 bool, arg5 int, arg6 bool) (*models.LoadBalancer, error) {
-	ret := m.ctrl.Call(m, "CreateLoadBalancer", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+func (m *MockClient) CreateLoadBalancer(arg0, arg1 string, arg2 models.HealthCheck, arg3 []models.Port, arg4 bool, arg5 int, arg6 bool, arg7 string) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "CreateLoadBalancer", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	ret0, _ := ret[0].(*models.LoadBalancer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateLoadBalancer indicates an expected call of CreateLoadBalancer
-func (mr *MockClientMockRecorder) CreateLoadBalancer(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancer", reflect.TypeOf((*MockClient)(nil).CreateLoadBalancer), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+func (mr *MockClientMockRecorder) CreateLoadBalancer(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancer", reflect.TypeOf((*MockClient)(nil).CreateLoadBalancer), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// CreateSecret mocks base method
+func (m *MockClient) CreateSecret(arg0, arg1, arg2 string) (*models.Secret, error) {
+	ret := m.ctrl.Call(m, "CreateSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecret indicates an expected call of CreateSecret
+func (mr *MockClientMockRecorder) CreateSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockClient)(nil).CreateSecret), arg0, arg1, arg2)
 }
 
 // CreateService mocks base method
-func (m *MockClient) CreateService(arg0, arg1, arg2, arg3 string) (*models.Service, error) {
-	ret := m.ctrl.Call(m, "CreateService", arg0, arg1, arg2, arg3)
+func (m *MockClient) CreateService(arg0, arg1, arg2, arg3 string, arg4 models.LoadBalancerRule) (*models.Service, error) {
+	ret := m.ctrl.Call(m, "CreateService", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(*models.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateService indicates an expected call of CreateService
-func (mr *MockClientMockRecorder) CreateService(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockClient)(nil).CreateService), arg0, arg1, arg2, arg3)
+func (mr *MockClientMockRecorder) CreateService(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockClient)(nil).CreateService), arg0, arg1, arg2, arg3, arg4)
 }
 
 // CreateTask mocks base method
@@ -148,6 +200,18 @@ func (mr *MockClientMockRecorder) DeleteEnvironment(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnvironment", reflect.TypeOf((*MockClient)(nil).DeleteEnvironment), arg0)
 }
 
+// DeleteLabel mocks base method
+func (m *MockClient) DeleteLabel(arg0, arg1, arg2 string) error {
+	ret := m.ctrl.Call(m, "DeleteLabel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel
+func (mr *MockClientMockRecorder) DeleteLabel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockClient)(nil).DeleteLabel), arg0, arg1, arg2)
+}
+
 // DeleteLink mocks base method
 func (m *MockClient) DeleteLink(arg0, arg1 string) error {
 	ret := m.ctrl.Call(m, "DeleteLink", arg0, arg1)
@@ -173,6 +237,18 @@ func (mr *MockClientMockRecorder) DeleteLoadBalancer(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancer", reflect.TypeOf((*MockClient)(nil).DeleteLoadBalancer), arg0)
 }
 
+// DeleteSecret mocks base method
+func (m *MockClient) DeleteSecret(arg0, arg1 string) error {
+	ret := m.ctrl.Call(m, "DeleteSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret
+func (mr *MockClientMockRecorder) DeleteSecret(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockClient)(nil).DeleteSecret), arg0, arg1)
+}
+
 // DeleteService mocks base method
 func (m *MockClient) DeleteService(arg0 string) (string, error) {
 	ret := m.ctrl.Call(m, "DeleteService", arg0)
@@ -198,6 +274,45 @@ func (mr *MockClientMockRecorder) DeleteTask(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockClient)(nil).DeleteTask), arg0)
 }
 
+// DiffDeploys mocks base method
+func (m *MockClient) DiffDeploys(arg0, arg1 string) (*models.DeployDiff, error) {
+	ret := m.ctrl.Call(m, "DiffDeploys", arg0, arg1)
+	ret0, _ := ret[0].(*models.DeployDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffDeploys indicates an expected call of DiffDeploys
+func (mr *MockClientMockRecorder) DiffDeploys(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffDeploys", reflect.TypeOf((*MockClient)(nil).DiffDeploys), arg0, arg1)
+}
+
+// ExecService mocks base method
+func (m *MockClient) ExecService(arg0 string, arg1 models.ExecRequest, arg2 models.ExecStreams) (int, error) {
+	ret := m.ctrl.Call(m, "ExecService", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecService indicates an expected call of ExecService
+func (mr *MockClientMockRecorder) ExecService(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecService", reflect.TypeOf((*MockClient)(nil).ExecService), arg0, arg1, arg2)
+}
+
+// ExecTask mocks base method
+func (m *MockClient) ExecTask(arg0 string, arg1 models.ExecRequest, arg2 models.ExecStreams) (int, error) {
+	ret := m.ctrl.Call(m, "ExecTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecTask indicates an expected call of ExecTask
+func (mr *MockClientMockRecorder) ExecTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTask", reflect.TypeOf((*MockClient)(nil).ExecTask), arg0, arg1, arg2)
+}
+
 // GetConfig mocks base method
 func (m *MockClient) GetConfig() (*models.APIConfig, error) {
 	ret := m.ctrl.Call(m, "GetConfig")
@@ -263,6 +378,19 @@ func (mr *MockClientMockRecorder) GetLoadBalancer(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancer", reflect.TypeOf((*MockClient)(nil).GetLoadBalancer), arg0)
 }
 
+// GetLoadBalancerHealth mocks base method
+func (m *MockClient) GetLoadBalancerHealth(arg0 string) (*models.LoadBalancerHealth, error) {
+	ret := m.ctrl.Call(m, "GetLoadBalancerHealth", arg0)
+	ret0, _ := ret[0].(*models.LoadBalancerHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancerHealth indicates an expected call of GetLoadBalancerHealth
+func (mr *MockClientMockRecorder) GetLoadBalancerHealth(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerHealth", reflect.TypeOf((*MockClient)(nil).GetLoadBalancerHealth), arg0)
+}
+
 // GetService mocks base method
 func (m *MockClient) GetService(arg0 string) (*models.Service, error) {
 	ret := m.ctrl.Call(m, "GetService", arg0)
@@ -367,6 +495,19 @@ func (mr *MockClientMockRecorder) ListJobs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockClient)(nil).ListJobs))
 }
 
+// ListLabels mocks base method
+func (m *MockClient) ListLabels(arg0, arg1 string) (map[string]string, error) {
+	ret := m.ctrl.Call(m, "ListLabels", arg0, arg1)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLabels indicates an expected call of ListLabels
+func (mr *MockClientMockRecorder) ListLabels(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLabels", reflect.TypeOf((*MockClient)(nil).ListLabels), arg0, arg1)
+}
+
 // ListLoadBalancers mocks base method
 func (m *MockClient) ListLoadBalancers() ([]*models.LoadBalancerSummary, error) {
 	ret := m.ctrl.Call(m, "ListLoadBalancers")
@@ -380,6 +521,19 @@ func (mr *MockClientMockRecorder) ListLoadBalancers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancers", reflect.TypeOf((*MockClient)(nil).ListLoadBalancers))
 }
 
+// ListSecrets mocks base method
+func (m *MockClient) ListSecrets(arg0 string) ([]*models.Secret, error) {
+	ret := m.ctrl.Call(m, "ListSecrets", arg0)
+	ret0, _ := ret[0].([]*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets
+func (mr *MockClientMockRecorder) ListSecrets(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockClient)(nil).ListSecrets), arg0)
+}
+
 // ListServices mocks base method
 func (m *MockClient) ListServices() ([]*models.ServiceSummary, error) {
 	ret := m.ctrl.Call(m, "ListServices")
@@ -406,6 +560,19 @@ func (mr *MockClientMockRecorder) ListTasks() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockClient)(nil).ListTasks))
 }
 
+// PruneDeploys mocks base method
+func (m *MockClient) PruneDeploys(arg0 int, arg1 bool) (*models.PruneDeploysReport, error) {
+	ret := m.ctrl.Call(m, "PruneDeploys", arg0, arg1)
+	ret0, _ := ret[0].(*models.PruneDeploysReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneDeploys indicates an expected call of PruneDeploys
+func (mr *MockClientMockRecorder) PruneDeploys(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneDeploys", reflect.TypeOf((*MockClient)(nil).PruneDeploys), arg0, arg1)
+}
+
 // RunScaler mocks base method
 func (m *MockClient) RunScaler(arg0 string) (*models.ScalerRunInfo, error) {
 	ret := m.ctrl.Call(m, "RunScaler", arg0)
@@ -445,6 +612,18 @@ func (mr *MockClientMockRecorder) SelectByQuery(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByQuery", reflect.TypeOf((*MockClient)(nil).SelectByQuery), arg0)
 }
 
+// SetLabel mocks base method
+func (m *MockClient) SetLabel(arg0, arg1, arg2, arg3 string) error {
+	ret := m.ctrl.Call(m, "SetLabel", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLabel indicates an expected call of SetLabel
+func (mr *MockClientMockRecorder) SetLabel(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLabel", reflect.TypeOf((*MockClient)(nil).SetLabel), arg0, arg1, arg2, arg3)
+}
+
 // UpdateEnvironment mocks base method
 func (m *MockClient) UpdateEnvironment(arg0 string, arg1 int) (*models.Environment, error) {
 	ret := m.ctrl.Call(m, "UpdateEnvironment", arg0, arg1)
@@ -458,6 +637,32 @@ func (mr *MockClientMockRecorder) UpdateEnvironment(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvironment", reflect.TypeOf((*MockClient)(nil).UpdateEnvironment), arg0, arg1)
 }
 
+// UpdateLoadBalancerAccessLogs mocks base method
+func (m *MockClient) UpdateLoadBalancerAccessLogs(arg0 string, arg1 models.AccessLogs) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "UpdateLoadBalancerAccessLogs", arg0, arg1)
+	ret0, _ := ret[0].(*models.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoadBalancerAccessLogs indicates an expected call of UpdateLoadBalancerAccessLogs
+func (mr *MockClientMockRecorder) UpdateLoadBalancerAccessLogs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoadBalancerAccessLogs", reflect.TypeOf((*MockClient)(nil).UpdateLoadBalancerAccessLogs), arg0, arg1)
+}
+
+// UpdateLoadBalancerConnectionDraining mocks base method
+func (m *MockClient) UpdateLoadBalancerConnectionDraining(arg0 string, arg1 models.ConnectionDraining) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "UpdateLoadBalancerConnectionDraining", arg0, arg1)
+	ret0, _ := ret[0].(*models.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoadBalancerConnectionDraining indicates an expected call of UpdateLoadBalancerConnectionDraining
+func (mr *MockClientMockRecorder) UpdateLoadBalancerConnectionDraining(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoadBalancerConnectionDraining", reflect.TypeOf((*MockClient)(nil).UpdateLoadBalancerConnectionDraining), arg0, arg1)
+}
+
 // UpdateLoadBalancerCrossZone mocks base method
 func (m *MockClient) UpdateLoadBalancerCrossZone(arg0 string, arg1 bool) (*models.LoadBalancer, error) {
 	ret := m.ctrl.Call(m, "UpdateLoadBalancerCrossZone", arg0, arg1)
@@ -535,17 +740,30 @@ func (mr *MockClientMockRecorder) UpdateService(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockClient)(nil).UpdateService), arg0, arg1)
 }
 
+// ValidateDeploy mocks base method
+func (m *MockClient) ValidateDeploy(arg0 string, arg1 []byte, arg2 string) (*models.DeployValidation, error) {
+	ret := m.ctrl.Call(m, "ValidateDeploy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.DeployValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateDeploy indicates an expected call of ValidateDeploy
+func (mr *MockClientMockRecorder) ValidateDeploy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDeploy", reflect.TypeOf((*MockClient)(nil).ValidateDeploy), arg0, arg1, arg2)
+}
+
 // WaitForDeployment mocks base method
-func (m *MockClient) WaitForDeployment(arg0 string, arg1 time.Duration) (*models.Service, error) {
-	ret := m.ctrl.Call(m, "WaitForDeployment", arg0, arg1)
+func (m *MockClient) WaitForDeployment(arg0 string, arg1 time.Duration, arg2 bool) (*models.Service, error) {
+	ret := m.ctrl.Call(m, "WaitForDeployment", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*models.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // WaitForDeployment indicates an expected call of WaitForDeployment
-func (mr *MockClientMockRecorder) WaitForDeployment(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForDeployment", reflect.TypeOf((*MockClient)(nil).WaitForDeployment), arg0, arg1)
+func (mr *MockClientMockRecorder) WaitForDeployment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForDeployment", reflect.TypeOf((*MockClient)(nil).WaitForDeployment), arg0, arg1, arg2)
 }
 
 // WaitForJob mocks base method
@@ -559,3 +777,16 @@ func (m *MockClient) WaitForJob(arg0 string, arg1 time.Duration) error {
 func (mr *MockClientMockRecorder) WaitForJob(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForJob", reflect.TypeOf((*MockClient)(nil).WaitForJob), arg0, arg1)
 }
+
+// WaitForTask mocks base method
+func (m *MockClient) WaitForTask(arg0 string, arg1 time.Duration) (*models.Task, error) {
+	ret := m.ctrl.Call(m, "WaitForTask", arg0, arg1)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForTask indicates an expected call of WaitForTask
+func (mr *MockClientMockRecorder) WaitForTask(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForTask", reflect.TypeOf((*MockClient)(nil).WaitForTask), arg0, arg1)
+}
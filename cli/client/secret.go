@@ -0,0 +1,38 @@
+package client
+
+import (
+	"github.com/quintilesims/layer0/common/models"
+)
+
+func (c *APIClient) CreateSecret(environmentID, name, value string) (*models.Secret, error) {
+	req := models.CreateSecretRequest{
+		EnvironmentID: environmentID,
+		SecretName:    name,
+		Value:         value,
+	}
+
+	var secret *models.Secret
+	if err := c.Execute(c.Sling("secret/").Post("").BodyJSON(req), &secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (c *APIClient) DeleteSecret(environmentID, name string) error {
+	var resp string
+	if err := c.Execute(c.Sling("secret/").Delete(environmentID+"/"+name), &resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *APIClient) ListSecrets(environmentID string) ([]*models.Secret, error) {
+	var secrets []*models.Secret
+	if err := c.Execute(c.Sling("secret/").Get(environmentID), &secrets); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
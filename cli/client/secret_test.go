@@ -0,0 +1,77 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestCreateSecret(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "POST")
+		testutils.AssertEqual(t, r.URL.Path, "/secret/")
+
+		var req models.CreateSecretRequest
+		Unmarshal(t, r, &req)
+
+		testutils.AssertEqual(t, req.EnvironmentID, "eid")
+		testutils.AssertEqual(t, req.SecretName, "name")
+		testutils.AssertEqual(t, req.Value, "value")
+
+		MarshalAndWrite(t, w, models.Secret{EnvironmentID: "eid", SecretName: "name"}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	secret, err := client.CreateSecret("eid", "name", "value")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, secret.SecretName, "name")
+}
+
+func TestDeleteSecret(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "DELETE")
+		testutils.AssertEqual(t, r.URL.Path, "/secret/eid/name")
+
+		MarshalAndWrite(t, w, "", 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if err := client.DeleteSecret("eid", "name"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListSecrets(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+		testutils.AssertEqual(t, r.URL.Path, "/secret/eid")
+
+		secrets := []models.Secret{
+			{EnvironmentID: "eid", SecretName: "name1"},
+			{EnvironmentID: "eid", SecretName: "name2"},
+		}
+
+		MarshalAndWrite(t, w, secrets, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	secrets, err := client.ListSecrets("eid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(secrets), 2)
+	testutils.AssertEqual(t, secrets[0].SecretName, "name1")
+	testutils.AssertEqual(t, secrets[1].SecretName, "name2")
+}
@@ -12,12 +12,13 @@ import (
 
 const REQUIRED_SUCCESS_WAIT_COUNT = 3
 
-func (c *APIClient) CreateService(name, environmentID, deployID, serviceID string) (*models.Service, error) {
+func (c *APIClient) CreateService(name, environmentID, deployID, loadBalancerID string, loadBalancerRule models.LoadBalancerRule) (*models.Service, error) {
 	req := models.CreateServiceRequest{
-		ServiceName:    name,
-		EnvironmentID:  environmentID,
-		DeployID:       deployID,
-		LoadBalancerID: serviceID,
+		ServiceName:      name,
+		EnvironmentID:    environmentID,
+		DeployID:         deployID,
+		LoadBalancerID:   loadBalancerID,
+		LoadBalancerRule: loadBalancerRule,
 	}
 
 	var service *models.Service
@@ -105,7 +106,10 @@ func (c *APIClient) ScaleService(id string, count int) (*models.Service, error)
 	return service, nil
 }
 
-func (c *APIClient) WaitForDeployment(serviceID string, timeout time.Duration) (*models.Service, error) {
+// WaitForDeployment waits until each of the service's deployments is running its desired count.
+// If waitForHealthy is set, it also waits until the service's load balancer reports
+// each of the service's instances as InService, and at least one instance is registered.
+func (c *APIClient) WaitForDeployment(serviceID string, timeout time.Duration, waitForHealthy bool) (*models.Service, error) {
 	var successCount int
 
 	waiter := waitutils.Waiter{
@@ -119,10 +123,38 @@ func (c *APIClient) WaitForDeployment(serviceID string, timeout time.Duration) (
 				return false, err
 			}
 
+			var desiredCount int64
 			for _, deploy := range service.Deployments {
 				if deploy.DesiredCount != deploy.RunningCount {
 					return false, nil
 				}
+
+				desiredCount += deploy.DesiredCount
+			}
+
+			if waitForHealthy && service.LoadBalancerID != "" {
+				health, err := c.GetLoadBalancerHealth(service.LoadBalancerID)
+				if err != nil {
+					return false, err
+				}
+
+				var inService int
+				for _, instance := range health.Instances {
+					if instance.ServiceID != "" && instance.ServiceID != serviceID {
+						continue
+					}
+
+					if instance.State != "InService" {
+						return false, nil
+					}
+
+					inService++
+				}
+
+				// a load balancer with no registered instances is not healthy yet
+				if desiredCount > 0 && inService == 0 {
+					return false, nil
+				}
 			}
 
 			successCount++
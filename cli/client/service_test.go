@@ -10,6 +10,12 @@ import (
 )
 
 func TestCreateService(t *testing.T) {
+	rule := models.LoadBalancerRule{
+		HostHeader:  "api.example.com",
+		PathPattern: "/v1/*",
+		Priority:    10,
+	}
+
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		testutils.AssertEqual(t, r.Method, "POST")
 		testutils.AssertEqual(t, r.URL.Path, "/service/")
@@ -21,6 +27,7 @@ func TestCreateService(t *testing.T) {
 		testutils.AssertEqual(t, req.EnvironmentID, "environmentID")
 		testutils.AssertEqual(t, req.DeployID, "deployID")
 		testutils.AssertEqual(t, req.LoadBalancerID, "loadBalancerID")
+		testutils.AssertEqual(t, req.LoadBalancerRule, rule)
 
 		MarshalAndWrite(t, w, models.Service{ServiceID: "id"}, 200)
 	}
@@ -28,7 +35,7 @@ func TestCreateService(t *testing.T) {
 	client, server := newClientAndServer(handler)
 	defer server.Close()
 
-	service, err := client.CreateService("name", "environmentID", "deployID", "loadBalancerID")
+	service, err := client.CreateService("name", "environmentID", "deployID", "loadBalancerID", rule)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -212,7 +219,7 @@ func TestWaitForDeployment(t *testing.T) {
 	client, server := newClientAndServer(handler)
 	defer server.Close()
 
-	service, err := client.WaitForDeployment("id", time.Minute*15)
+	service, err := client.WaitForDeployment("id", time.Minute*15, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -223,6 +230,59 @@ func TestWaitForDeployment(t *testing.T) {
 	}
 }
 
+func TestWaitForDeployment_waitForHealthy(t *testing.T) {
+	var healthCount int
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+
+		switch r.URL.Path {
+		case "/service/id":
+			service := models.Service{
+				ServiceID:      "id",
+				LoadBalancerID: "lbid",
+				Deployments: []models.Deployment{
+					{DesiredCount: 1, RunningCount: 1},
+				},
+			}
+
+			MarshalAndWrite(t, w, service, 200)
+		case "/loadbalancer/lbid/health":
+			healthCount++
+
+			health := models.LoadBalancerHealth{
+				LoadBalancerID: "lbid",
+				Instances: []models.InstanceHealth{
+					{InstanceID: "i-2", ServiceID: "other", State: "OutOfService"},
+				},
+			}
+
+			// the first check has no registered instances, which must not count as healthy
+			switch {
+			case healthCount == 2:
+				health.Instances = append(health.Instances, models.InstanceHealth{InstanceID: "i-1", ServiceID: "id", State: "OutOfService"})
+			case healthCount > 2:
+				health.Instances = append(health.Instances, models.InstanceHealth{InstanceID: "i-1", ServiceID: "id", State: "InService"})
+			}
+
+			MarshalAndWrite(t, w, health, 200)
+		default:
+			t.Fatalf("Unexpected path: %s", r.URL.Path)
+		}
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if _, err := client.WaitForDeployment("id", time.Minute*15, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if healthCount < 3 {
+		t.Fatalf("Health was only checked %d times", healthCount)
+	}
+}
+
 func TestWaitForDeployment_timeout(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		service := models.Service{
@@ -237,7 +297,7 @@ func TestWaitForDeployment_timeout(t *testing.T) {
 	client, server := newClientAndServer(handler)
 	defer server.Close()
 
-	if _, err := client.WaitForDeployment("id", time.Millisecond); err == nil {
+	if _, err := client.WaitForDeployment("id", time.Millisecond, false); err == nil {
 		t.Fatal("Error was nil!")
 	}
 }
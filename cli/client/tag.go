@@ -1,21 +1,70 @@
 package client
 
 import (
-	"fmt"
+	"net/url"
 
 	"github.com/quintilesims/layer0/common/models"
 )
 
 func (c *APIClient) SelectByQuery(params map[string]string) ([]*models.EntityWithTags, error) {
-	query := "?"
+	query := url.Values{}
 	for k, v := range params {
-		query += fmt.Sprintf("&%s=%s", k, v)
+		query.Set(k, v)
 	}
 
 	var response []*models.EntityWithTags
-	if err := c.Execute(c.Sling("/tag").Get(query), &response); err != nil {
+	if err := c.Execute(c.Sling("/tag").Get("?"+query.Encode()), &response); err != nil {
 		return nil, err
 	}
 
 	return response, nil
 }
+
+// ListLabels returns the user-defined labels of the specified entity
+func (c *APIClient) ListLabels(entityType, entityID string) (map[string]string, error) {
+	params := map[string]string{
+		"type": entityType,
+		"id":   entityID,
+	}
+
+	ewts, err := c.SelectByQuery(params)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := models.Tags{}
+	for _, ewt := range ewts {
+		tags = append(tags, ewt.Tags...)
+	}
+
+	return tags.Labels(), nil
+}
+
+func (c *APIClient) SetLabel(entityType, entityID, key, value string) error {
+	tag := models.Tag{
+		EntityID:   entityID,
+		EntityType: entityType,
+		Key:        models.LabelKey(key),
+		Value:      value,
+	}
+
+	if err := c.Execute(c.Sling("tag/").Post("").BodyJSON(tag), nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *APIClient) DeleteLabel(entityType, entityID, key string) error {
+	tag := models.Tag{
+		EntityID:   entityID,
+		EntityType: entityType,
+		Key:        models.LabelKey(key),
+	}
+
+	if err := c.Execute(c.Sling("tag/").Delete("").BodyJSON(tag), nil); err != nil {
+		return err
+	}
+
+	return nil
+}
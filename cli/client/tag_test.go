@@ -46,3 +46,86 @@ func TestSelectByQuery(t *testing.T) {
 	testutils.AssertEqual(t, tags[0].EntityID, "id1")
 	testutils.AssertEqual(t, tags[1].EntityID, "id2")
 }
+
+func TestListLabels(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+		testutils.AssertEqual(t, r.URL.Path, "/tag")
+
+		query := r.URL.Query()
+		testutils.AssertEqual(t, query.Get("type"), "service")
+		testutils.AssertEqual(t, query.Get("id"), "id")
+
+		tags := []models.EntityWithTags{
+			{
+				EntityID:   "id",
+				EntityType: "service",
+				Tags: models.Tags{
+					{EntityID: "id", EntityType: "service", Key: "name", Value: "svc"},
+					{EntityID: "id", EntityType: "service", Key: "label:team", Value: "payments"},
+				},
+			},
+		}
+
+		MarshalAndWrite(t, w, tags, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	labels, err := client.ListLabels("service", "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, labels, map[string]string{"team": "payments"})
+}
+
+func TestSetLabel(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "POST")
+		testutils.AssertEqual(t, r.URL.Path, "/tag/")
+
+		var tag models.Tag
+		Unmarshal(t, r, &tag)
+
+		expected := models.Tag{
+			EntityID:   "id",
+			EntityType: "service",
+			Key:        "label:team",
+			Value:      "payments",
+		}
+
+		testutils.AssertEqual(t, tag, expected)
+
+		MarshalAndWrite(t, w, "", 201)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if err := client.SetLabel("service", "id", "team", "payments"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteLabel(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "DELETE")
+		testutils.AssertEqual(t, r.URL.Path, "/tag/")
+
+		var tag models.Tag
+		Unmarshal(t, r, &tag)
+
+		testutils.AssertEqual(t, tag.Key, "label:team")
+
+		MarshalAndWrite(t, w, "", 204)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if err := client.DeleteLabel("service", "id", "team"); err != nil {
+		t.Fatal(err)
+	}
+}
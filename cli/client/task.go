@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
+	"github.com/quintilesims/layer0/common/waitutils"
 )
 
 func (c *APIClient) CreateTask(
@@ -79,3 +82,77 @@ func (c *APIClient) ListTasks() ([]*models.TaskSummary, error) {
 
 	return tasks, nil
 }
+
+// WaitForTask waits until every copy of the task has stopped and the job creating the task, if any, has finished.
+// An error is returned if the job failed, or if the task has no copies once the job has finished.
+func (c *APIClient) WaitForTask(id string, timeout time.Duration) (*models.Task, error) {
+	var task *models.Task
+
+	waiter := waitutils.Waiter{
+		Name:    "WaitForTask",
+		Timeout: timeout,
+		Delay:   time.Second * 5,
+		Clock:   c.Clock,
+		Check: func() (bool, error) {
+			t, err := c.GetTask(id)
+			if err != nil {
+				return false, err
+			}
+
+			if t.PendingCount > 0 || t.RunningCount > 0 {
+				return false, nil
+			}
+
+			for _, copy := range t.Copies {
+				if copy.LastStatus != "STOPPED" {
+					return false, nil
+				}
+			}
+
+			creating, err := c.isCreatingTask(id)
+			if err != nil {
+				return false, err
+			}
+
+			if creating {
+				return false, nil
+			}
+
+			if len(t.Copies) == 0 {
+				return false, fmt.Errorf("Task '%s' does not have any copies", id)
+			}
+
+			task = t
+			return true, nil
+		},
+	}
+
+	if err := waiter.Wait(); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// isCreatingTask returns true if a create task job for the task has not finished
+func (c *APIClient) isCreatingTask(id string) (bool, error) {
+	jobs, err := c.ListJobs()
+	if err != nil {
+		return false, err
+	}
+
+	for _, job := range jobs {
+		if types.JobType(job.JobType) != types.CreateTaskJob || job.Meta["task_id"] != id {
+			continue
+		}
+
+		switch types.JobStatus(job.JobStatus) {
+		case types.Pending, types.InProgress:
+			return true, nil
+		case types.Error:
+			return false, fmt.Errorf("Job '%s' failed to create the task. Use 'l0 job logs %s' for more information", job.JobID, job.JobID)
+		}
+	}
+
+	return false, nil
+}
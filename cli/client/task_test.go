@@ -3,9 +3,11 @@ package client
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/quintilesims/layer0/common/types"
 )
 
 func TestCreateTask(t *testing.T) {
@@ -134,3 +136,77 @@ func TestListTasks(t *testing.T) {
 	testutils.AssertEqual(t, tasks[0].TaskID, "id1")
 	testutils.AssertEqual(t, tasks[1].TaskID, "id2")
 }
+
+func TestWaitForTask(t *testing.T) {
+	var count int
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+
+		switch r.URL.Path {
+		case "/task/id":
+			count++
+
+			status := "RUNNING"
+			if count > 2 {
+				status = "STOPPED"
+			}
+
+			task := models.Task{
+				TaskID: "id",
+				Copies: []models.TaskCopy{
+					{LastStatus: "STOPPED"},
+					{LastStatus: status},
+				},
+			}
+
+			MarshalAndWrite(t, w, task, 200)
+		case "/job/":
+			// the job is still running on the first check after the copies stopped
+			status := types.Completed
+			if count == 3 {
+				status = types.InProgress
+			}
+
+			jobs := []*models.Job{
+				{JobID: "j1", JobType: int64(types.CreateTaskJob), JobStatus: int64(status), Meta: map[string]string{"task_id": "id"}},
+				{JobID: "j2", JobType: int64(types.CreateTaskJob), JobStatus: int64(types.InProgress), Meta: map[string]string{"task_id": "other"}},
+			}
+
+			MarshalAndWrite(t, w, jobs, 200)
+		default:
+			t.Fatalf("Unexpected path: %s", r.URL.Path)
+		}
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	task, err := client.WaitForTask("id", time.Minute*15)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, task.TaskID, "id")
+	testutils.AssertEqual(t, count, 4)
+}
+
+func TestWaitForTaskError_noCopies(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/task/id":
+			MarshalAndWrite(t, w, models.Task{TaskID: "id"}, 200)
+		case "/job/":
+			MarshalAndWrite(t, w, []*models.Job{}, 200)
+		default:
+			t.Fatalf("Unexpected path: %s", r.URL.Path)
+		}
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if _, err := client.WaitForTask("id", time.Minute*15); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
@@ -0,0 +1,140 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/urfave/cli"
+)
+
+var (
+	bulkSelectorFlag = cli.StringFlag{
+		Name:  "selector",
+		Usage: "delete each entity with all of the specified labels, e.g. 'team=payments,branch=feature-x'",
+	}
+	bulkEnvironmentFlag = cli.StringFlag{
+		Name:  "environment",
+		Usage: "only delete entities in the specified environment",
+	}
+	bulkAllFlag = cli.BoolFlag{
+		Name:  "all",
+		Usage: "delete every entity that matches the other flags, or every entity if none are specified",
+	}
+)
+
+// bulkDeleteFlags are added to the delete commands of entities that can be deleted in bulk
+var bulkDeleteFlags = []cli.Flag{bulkSelectorFlag, bulkEnvironmentFlag, bulkAllFlag}
+
+// deploys do not belong to an environment, so they cannot be selected by one
+var deployBulkDeleteFlags = []cli.Flag{bulkSelectorFlag, bulkAllFlag}
+
+func isBulkDelete(c *cli.Context) bool {
+	return c.String("selector") != "" || c.String("environment") != "" || c.Bool("all")
+}
+
+// bulkDelete deletes each entity of the specified type that matches the selector flags in a single job
+func (cm *Command) bulkDelete(c *cli.Context, entityType string) error {
+	if len(c.Args()) > 0 {
+		return NewUsageError("NAME cannot be specified with --selector, --environment, or --all")
+	}
+
+	labels, err := parseSelector(c.String("selector"))
+	if err != nil {
+		return NewUsageError("Invalid --selector: %v", err)
+	}
+
+	selector := map[string]string{}
+	for key, value := range labels {
+		selector[models.LabelKey(key)] = value
+	}
+
+	if environment := c.String("environment"); environment != "" {
+		if entityType == "deploy" {
+			return NewUsageError("Deploys do not belong to an environment; --environment cannot be specified")
+		}
+
+		environmentID, err := cm.resolveSingleID("environment", environment)
+		if err != nil {
+			return err
+		}
+
+		selector["environment_id"] = environmentID
+	}
+
+	if c.String("selector") == "" && !c.Bool("all") {
+		return NewUsageError("Either --selector or --all must be specified")
+	}
+
+	all := c.Bool("all")
+	matched, err := cm.Client.BulkDelete(entityType, selector, all, nil, true)
+	if err != nil {
+		return err
+	}
+
+	entityName := strings.Replace(entityType, "_", " ", -1)
+	if len(matched.EntityIDs) == 0 {
+		cm.Printer.Printf("No %ss match\n", entityName)
+		return nil
+	}
+
+	// bulk deletes always list what matched and ask first, since a loose selector or --all
+	// can reach far more than intended
+	cm.Printer.Printf("The following %ss match:\n", entityName)
+	for _, id := range matched.EntityIDs {
+		cm.Printer.Printf("  %s\n", id)
+	}
+
+	if cm.BulkConfirm != nil {
+		ok, err := cm.BulkConfirm(fmt.Sprintf("Delete %d %s(s) (%s)?", len(matched.EntityIDs), entityName, strings.Join(matched.EntityIDs, ", ")))
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("Operation cancelled")
+		}
+	}
+
+	// only the confirmed entities are deleted, even if others have matched since
+	resp, err := cm.Client.BulkDelete(entityType, selector, all, matched.EntityIDs, false)
+	if err != nil {
+		return err
+	}
+
+	if !c.Bool("wait") {
+		cm.Printer.Printf("This operation is running as a job. Run `l0 job get %s` to see progress\n", resp.JobID)
+		return nil
+	}
+
+	timeout, err := getTimeout(c)
+	if err != nil {
+		return err
+	}
+
+	cm.Printer.StartSpinner("Deleting")
+	if err := cm.Client.WaitForJob(resp.JobID, timeout); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parseSelector parses a selector in the format 'key=value,key2=value2'
+func parseSelector(s string) (map[string]string, error) {
+	selector := map[string]string{}
+	if s == "" {
+		return selector, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		key, value, err := parseLabel(pair)
+		if err != nil {
+			return nil, err
+		}
+
+		selector[key] = value
+	}
+
+	return selector, nil
+}
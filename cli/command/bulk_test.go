@@ -0,0 +1,164 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/urfave/cli"
+)
+
+func TestBulkDelete_selector(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+
+	cmd := tc.Command()
+	cmd.BulkConfirm = func(message string) (bool, error) {
+		testutils.AssertEqual(t, message, "Delete 2 service(s) (id1, id2)?")
+		return true, nil
+	}
+
+	command := NewServiceCommand(cmd)
+	selector := map[string]string{"label:team": "payments", "label:branch": "feature-x"}
+
+	tc.Client.EXPECT().
+		BulkDelete("service", selector, false, nil, true).
+		Return(&models.BulkResponse{EntityIDs: []string{"id1", "id2"}}, nil)
+
+	tc.Client.EXPECT().
+		BulkDelete("service", selector, false, []string{"id1", "id2"}, false).
+		Return(&models.BulkResponse{EntityIDs: []string{"id1", "id2"}, JobID: "jobid"}, nil)
+
+	tc.Client.EXPECT().
+		WaitForJob("jobid", testutils.TEST_TIMEOUT).
+		Return(nil)
+
+	flags := map[string]interface{}{
+		"selector": "team=payments,branch=feature-x",
+		"wait":     true,
+	}
+
+	c := testutils.GetCLIContext(t, nil, flags)
+	if err := command.Delete(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBulkDelete_environmentAll(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+
+	// BulkConfirm is nil when --yes is passed
+	command := NewTaskCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "env").
+		Return([]string{"envid"}, nil)
+
+	selector := map[string]string{"environment_id": "envid"}
+
+	tc.Client.EXPECT().
+		BulkDelete("task", selector, true, nil, true).
+		Return(&models.BulkResponse{EntityIDs: []string{"id"}}, nil)
+
+	tc.Client.EXPECT().
+		BulkDelete("task", selector, true, []string{"id"}, false).
+		Return(&models.BulkResponse{EntityIDs: []string{"id"}, JobID: "jobid"}, nil)
+
+	flags := map[string]interface{}{
+		"environment": "env",
+		"all":         true,
+	}
+
+	c := testutils.GetCLIContext(t, nil, flags)
+	if err := command.Delete(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBulkDelete_promptsWithoutProfileConfirm(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+
+	var prompted bool
+	cmd := tc.Command()
+	cmd.BulkConfirm = func(message string) (bool, error) {
+		prompted = true
+		return false, nil
+	}
+
+	command := NewLoadBalancerCommand(cmd)
+
+	tc.Client.EXPECT().
+		BulkDelete("load_balancer", map[string]string{}, true, nil, true).
+		Return(&models.BulkResponse{EntityIDs: []string{"id"}}, nil)
+
+	c := testutils.GetCLIContext(t, nil, map[string]interface{}{"all": true})
+	if err := command.Delete(c); err == nil {
+		t.Fatal("Error was nil!")
+	}
+
+	testutils.AssertEqual(t, prompted, true)
+}
+
+func TestBulkDelete_noMatches(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	tc.Client.EXPECT().
+		BulkDelete("deploy", map[string]string{}, true, nil, true).
+		Return(&models.BulkResponse{EntityIDs: []string{}}, nil)
+
+	c := testutils.GetCLIContext(t, nil, map[string]interface{}{"all": true})
+	if err := command.Delete(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBulkDelete_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"NAME with selector": testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"selector": "a=b"}),
+		"Invalid selector":   testutils.GetCLIContext(t, nil, map[string]interface{}{"selector": "a"}),
+		"Missing selector":   testutils.GetCLIContext(t, nil, map[string]interface{}{"environment": "env"}),
+	}
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "env").
+		Return([]string{"envid"}, nil)
+
+	for name, c := range contexts {
+		if err := command.Delete(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestBulkDeleteError_deployEnvironment(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	flags := map[string]interface{}{
+		"environment": "env",
+		"all":         true,
+	}
+
+	c := testutils.GetCLIContext(t, nil, flags)
+	if err := command.Delete(c); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
+
+func TestParseSelector(t *testing.T) {
+	selector, err := parseSelector("team=payments, branch=feature-x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, selector, map[string]string{"team": "payments", "branch": "feature-x"})
+}
@@ -1,6 +1,9 @@
 package command
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/quintilesims/layer0/cli/client"
 	"github.com/quintilesims/layer0/cli/printer"
 	"github.com/urfave/cli"
@@ -11,10 +14,17 @@ type CommandGroup interface {
 	GetCommand() cli.Command
 }
 
+// ConfirmFunc asks the user to confirm the action described by message
+type ConfirmFunc func(message string) (bool, error)
+
 type Command struct {
 	Client   client.Client
 	Printer  printer.Printer
 	Resolver Resolver
+	// Confirm is called before destructive actions; it is nil if the current profile does not require confirmation
+	Confirm ConfirmFunc
+	// BulkConfirm is called before bulk deletes regardless of the profile; it is nil only if --yes was passed
+	BulkConfirm ConfirmFunc
 }
 
 func (cm *Command) SetPrinter(printer printer.Printer) {
@@ -30,6 +40,24 @@ func (cm *Command) resolveSingleID(entityType, target string) (string, error) {
 	return assertSingleID(entityType, target, ids)
 }
 
+// confirm returns an error unless the user confirms the action, or confirmation is not required
+func (cm *Command) confirm(format string, tokens ...interface{}) error {
+	if cm.Confirm == nil {
+		return nil
+	}
+
+	ok, err := cm.Confirm(fmt.Sprintf(format, tokens...))
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("Operation cancelled")
+	}
+
+	return nil
+}
+
 func (cm *Command) handleError(c *cli.Context, err error) {
 	if _, ok := err.(*UsageError); ok {
 		handleUsageError(c, err)
@@ -77,9 +105,14 @@ func (cm *Command) deleteWithJob(c *cli.Context, entityType string, deleteEntity
 	})
 }
 
-// delete will fetch the NAME arg and use it to resolve the entity id of the specified type
+// delete will fetch the NAME arg and use it to resolve the entity id of the specified type,
+// or delete each matching entity in bulk if any of the bulkDeleteFlags are specified
 // the deleteEntity function should wrap a Client Delete<Entity> call
 func (cm *Command) delete(c *cli.Context, entityType string, deleteEntity func(string) error) error {
+	if isBulkDelete(c) {
+		return cm.bulkDelete(c, entityType)
+	}
+
 	args, err := extractArgs(c.Args(), "NAME")
 	if err != nil {
 		return err
@@ -90,6 +123,10 @@ func (cm *Command) delete(c *cli.Context, entityType string, deleteEntity func(s
 		return err
 	}
 
+	if err := cm.confirm("Delete %s '%s' (%s)?", strings.Replace(entityType, "_", " ", -1), args["NAME"], id); err != nil {
+		return err
+	}
+
 	if err := deleteEntity(id); err != nil {
 		return err
 	}
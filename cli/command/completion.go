@@ -0,0 +1,465 @@
+package command
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/urfave/cli"
+)
+
+// how long entity names fetched for completion are reused before they are fetched again
+const completionCacheTTL = time.Second * 30
+
+// the entity types whose names complete each command group's NAME and OTHER_NAME arguments
+var groupEntityTypes = map[string]string{
+	"deploy":       "deploy",
+	"environment":  "environment",
+	"job":          "job",
+	"loadbalancer": "load_balancer",
+	"service":      "service",
+	"task":         "task",
+}
+
+// the entity types whose names complete other arguments, regardless of the command group
+var argEntityTypes = map[string]string{
+	"DEPLOY":      "deploy",
+	"DESTINATION": "environment",
+	"ENVIRONMENT": "environment",
+	"SOURCE":      "environment",
+}
+
+// the entity types whose names complete the values of flags
+var flagEntityTypes = map[string]string{
+	"environment":  "environment",
+	"loadbalancer": "load_balancer",
+}
+
+var completionScripts = map[string]string{
+	"bash": `_l0_completion() {
+	local cur words cword
+	if declare -F _get_comp_words_by_ref >/dev/null; then
+		_get_comp_words_by_ref -n : cur words cword
+	else
+		cur="${COMP_WORDS[COMP_CWORD]}"
+		words=("${COMP_WORDS[@]}")
+		cword=$COMP_CWORD
+	fi
+
+	# pass the global flags before __complete too, so l0 uses the profile they select
+	local -a globals=()
+	local i=1
+	while [[ $i -lt $cword && ${words[i]} == -* ]]; do
+		case "${words[i]}" in
+			{{ join .ValueFlags "|" }})
+				[[ $((i+1)) -lt $cword ]] || break
+				globals+=("${words[i]}" "${words[i+1]}")
+				i=$((i+2))
+				;;
+			*)
+				globals+=("${words[i]}")
+				i=$((i+1))
+				;;
+		esac
+	done
+
+	local IFS=$'\n'
+	COMPREPLY=($(l0 "${globals[@]}" __complete "${words[@]:1:$cword}" 2>/dev/null))
+
+	if declare -F __ltrim_colon_completions >/dev/null; then
+		__ltrim_colon_completions "$cur"
+	fi
+}
+
+complete -F _l0_completion l0
+`,
+	"zsh": `#compdef l0
+
+_l0() {
+	# pass the global flags before __complete too, so l0 uses the profile they select
+	local -a candidates globals
+	local i=2
+	while (( i < CURRENT )) && [[ $words[i] == -* ]]; do
+		case "$words[i]" in
+			{{ join .ValueFlags "|" }})
+				(( i + 1 < CURRENT )) || break
+				globals+=("$words[i]" "$words[i+1]")
+				(( i += 2 ))
+				;;
+			*)
+				globals+=("$words[i]")
+				(( i++ ))
+				;;
+		esac
+	done
+
+	candidates=("${(@f)$(l0 "${globals[@]}" __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+	compadd -Q -- "${candidates[@]}"
+}
+
+compdef _l0 l0
+`,
+	"fish": `function __l0_complete
+	set -l tokens (commandline -opc)
+	set -e tokens[1]
+
+	# pass the global flags before __complete too, so l0 uses the profile they select
+	set -l globals
+	set -l i 1
+	while test $i -le (count $tokens); and string match -q -- '-*' $tokens[$i]
+		switch $tokens[$i]
+			case {{ join .ValueFlags " " }}
+				test $i -lt (count $tokens); or break
+				set globals $globals $tokens[$i] $tokens[(math $i + 1)]
+				set i (math $i + 2)
+			case '*'
+				set globals $globals $tokens[$i]
+				set i (math $i + 1)
+		end
+	end
+
+	l0 $globals __complete $tokens (commandline -ct) 2>/dev/null
+end
+
+complete -c l0 -f -a '(__l0_complete)'
+`,
+}
+
+type CompletionCommand struct {
+	*Command
+	App *cli.App
+	// CacheDir is where entity names are cached between completions; they are not cached if it is empty
+	CacheDir string
+}
+
+func NewCompletionCommand(command *Command, app *cli.App) *CompletionCommand {
+	return &CompletionCommand{
+		Command: command,
+		App:     app,
+	}
+}
+
+func (cc *CompletionCommand) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "completion",
+		Usage:     "print the script that enables shell completion for bash, zsh, or fish",
+		ArgsUsage: "SHELL",
+		Description: "Load the script in your shell's profile, e.g. for bash:\n" +
+			"   source <(l0 completion bash)",
+		Action: wrapAction(cc.Command, cc.Script),
+	}
+}
+
+// GetCompleteCommand returns the hidden command the completion scripts run to get the candidates for the last word
+func (cc *CompletionCommand) GetCompleteCommand() cli.Command {
+	return cli.Command{
+		Name:            "__complete",
+		Hidden:          true,
+		SkipFlagParsing: true,
+		Action: func(c *cli.Context) {
+			for _, candidate := range cc.Complete(c.Args()) {
+				fmt.Fprintln(cc.App.Writer, candidate)
+			}
+		},
+	}
+}
+
+func (cc *CompletionCommand) Script(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "SHELL")
+	if err != nil {
+		return err
+	}
+
+	script, ok := completionScripts[args["SHELL"]]
+	if !ok {
+		return NewUsageError("Unsupported shell '%s' (expected bash, zsh, or fish)", args["SHELL"])
+	}
+
+	tmpl, err := template.New(args["SHELL"]).Funcs(template.FuncMap{"join": strings.Join}).Parse(script)
+	if err != nil {
+		return err
+	}
+
+	// the scripts need to know which global flags take a value to find where the command starts
+	valueFlags := []string{}
+	for _, name := range flagNames(cc.App.Flags) {
+		if flagTakesValue(cc.App.Flags, strings.TrimLeft(name, "-")) {
+			valueFlags = append(valueFlags, name)
+		}
+	}
+
+	return tmpl.Execute(cc.App.Writer, struct{ ValueFlags []string }{valueFlags})
+}
+
+// CompletionCacheDir returns the directory in dir where entity names are cached for the api at endpoint.
+// It is keyed by the endpoint, so profiles and environment variables that select different apis don't mix.
+// The directory is hidden, since l0-setup treats the other directories in ~/.layer0 as instances.
+func CompletionCacheDir(dir, endpoint string) string {
+	sum := sha256.Sum256([]byte(endpoint))
+	return filepath.Join(dir, ".cache", hex.EncodeToString(sum[:8]))
+}
+
+// Complete returns the candidates for the last of words, which is the word being completed.
+// The other words are the arguments typed so far, without the 'l0' program name.
+func (cc *CompletionCommand) Complete(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	current := words[len(words)-1]
+	commands := cc.App.Commands
+	flags := cc.App.Flags
+	path := []cli.Command{}
+	args := []string{}
+	var valueFlag string
+
+	for _, word := range words[:len(words)-1] {
+		if valueFlag != "" {
+			valueFlag = ""
+			continue
+		}
+
+		if strings.HasPrefix(word, "-") {
+			name := strings.TrimLeft(word, "-")
+			if !strings.Contains(name, "=") && flagTakesValue(flags, name) {
+				valueFlag = name
+			}
+
+			continue
+		}
+
+		if len(commands) > 0 {
+			command := findCommand(commands, word)
+			if command == nil {
+				return []string{}
+			}
+
+			path = append(path, *command)
+			commands = command.Subcommands
+			flags = command.Flags
+			continue
+		}
+
+		args = append(args, word)
+	}
+
+	var candidates []string
+	switch {
+	case valueFlag != "":
+		if entityType, ok := flagEntityTypes[valueFlag]; ok {
+			candidates = cc.entityNames(entityType, current)
+		}
+	case strings.HasPrefix(current, "-"):
+		candidates = flagNames(flags)
+	case len(commands) > 0:
+		candidates = commandNames(commands)
+	case len(path) > 0:
+		if entityType := argEntityType(path, len(args)); entityType != "" {
+			candidates = cc.entityNames(entityType, current)
+		}
+	}
+
+	matches := []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, current) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	return matches
+}
+
+// entityNames returns the names of the entities of the specified type.
+// Services, load balancers and tasks are scoped as ENVIRONMENT:NAME once current contains a ':',
+// and deploys as NAME:VERSION.
+func (cc *CompletionCommand) entityNames(entityType, current string) []string {
+	ewts, err := cc.selectByType(entityType)
+	if err != nil {
+		log.Debugf("Failed to complete %s names: %v", entityType, err)
+		return nil
+	}
+
+	environmentNames := map[string]string{}
+	scoped := strings.Contains(current, ":")
+	if scoped && entityType != "deploy" {
+		environments, err := cc.selectByType("environment")
+		if err != nil {
+			log.Debugf("Failed to complete environment names: %v", err)
+			return nil
+		}
+
+		for _, ewt := range environments {
+			environmentNames[ewt.EntityID] = tagValue(ewt, "name")
+		}
+	}
+
+	names := map[string]bool{}
+	for _, ewt := range ewts {
+		name := tagValue(ewt, "name")
+		if name == "" {
+			continue
+		}
+
+		switch {
+		case !scoped:
+			names[name] = true
+		case entityType == "deploy":
+			names[fmt.Sprintf("%s:%s", name, tagValue(ewt, "version"))] = true
+		default:
+			if environmentName := environmentNames[tagValue(ewt, "environment_id")]; environmentName != "" {
+				names[fmt.Sprintf("%s:%s", environmentName, name)] = true
+			}
+		}
+	}
+
+	sorted := []string{}
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+
+	sort.Strings(sorted)
+	return sorted
+}
+
+// selectByType returns the tags of each entity of the specified type, using the cache if it was written recently
+func (cc *CompletionCommand) selectByType(entityType string) ([]*models.EntityWithTags, error) {
+	var path string
+	if cc.CacheDir != "" {
+		path = filepath.Join(cc.CacheDir, fmt.Sprintf("%s.json", entityType))
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < completionCacheTTL {
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+
+			var ewts []*models.EntityWithTags
+			if err := json.Unmarshal(data, &ewts); err == nil {
+				return ewts, nil
+			}
+		}
+	}
+
+	ewts, err := cc.Client.SelectByQuery(map[string]string{"type": entityType})
+	if err != nil {
+		return nil, err
+	}
+
+	if path != "" {
+		if err := writeCompletionCache(path, ewts); err != nil {
+			log.Debugf("Failed to cache %s names: %v", entityType, err)
+		}
+	}
+
+	return ewts, nil
+}
+
+func writeCompletionCache(path string, ewts []*models.EntityWithTags) error {
+	data, err := json.Marshal(ewts)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// argEntityType returns the type of entity whose names complete the argument at index of the last command in path
+func argEntityType(path []cli.Command, index int) string {
+	command := path[len(path)-1]
+	names := strings.Fields(command.ArgsUsage)
+	if len(names) == 0 {
+		return ""
+	}
+
+	// variadic arguments such as 'KEY...' repeat the last name
+	if index >= len(names) {
+		if !strings.HasSuffix(names[len(names)-1], "...") {
+			return ""
+		}
+
+		index = len(names) - 1
+	}
+
+	name := strings.Trim(names[index], "[].")
+	if entityType, ok := argEntityTypes[name]; ok {
+		return entityType
+	}
+
+	// the NAME of a create command is the name of the new entity
+	if (name == "NAME" || name == "OTHER_NAME") && command.Name != "create" {
+		return groupEntityTypes[path[0].Name]
+	}
+
+	return ""
+}
+
+func findCommand(commands []cli.Command, name string) *cli.Command {
+	for i := range commands {
+		if commands[i].HasName(name) {
+			return &commands[i]
+		}
+	}
+
+	return nil
+}
+
+func commandNames(commands []cli.Command) []string {
+	names := []string{}
+	for _, command := range commands {
+		if !command.Hidden {
+			names = append(names, command.Name)
+		}
+	}
+
+	return names
+}
+
+func flagNames(flags []cli.Flag) []string {
+	names := []string{}
+	for _, flag := range flags {
+		for _, name := range strings.Split(flag.GetName(), ",") {
+			name = strings.TrimSpace(name)
+			if len(name) == 1 {
+				names = append(names, "-"+name)
+			} else {
+				names = append(names, "--"+name)
+			}
+		}
+	}
+
+	return names
+}
+
+func flagTakesValue(flags []cli.Flag, name string) bool {
+	for _, flag := range flags {
+		for _, flagName := range strings.Split(flag.GetName(), ",") {
+			if strings.TrimSpace(flagName) == name {
+				_, isBool := flag.(cli.BoolFlag)
+				return !isBool
+			}
+		}
+	}
+
+	return false
+}
+
+func tagValue(ewt *models.EntityWithTags, key string) string {
+	if tag, ok := ewt.Tags.WithKey(key).First(); ok {
+		return tag.Value
+	}
+
+	return ""
+}
@@ -0,0 +1,186 @@
+package command
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/urfave/cli"
+)
+
+func newTestCompletionCommand(tc *TestCommand) *CompletionCommand {
+	cmd := tc.Command()
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "o, output"},
+		cli.BoolFlag{Name: "d, debug"},
+	}
+
+	for _, group := range []CommandGroup{NewEnvironmentCommand(cmd), NewServiceCommand(cmd), NewDeployCommand(cmd)} {
+		app.Commands = append(app.Commands, group.GetCommand())
+	}
+
+	completion := NewCompletionCommand(cmd, app)
+	app.Commands = append(app.Commands, completion.GetCommand(), completion.GetCompleteCommand())
+	return completion
+}
+
+func serviceTags() []*models.EntityWithTags {
+	return []*models.EntityWithTags{
+		{
+			EntityID: "svc1",
+			Tags:     models.Tags{{Key: "name", Value: "api"}, {Key: "environment_id", Value: "env1"}},
+		},
+		{
+			EntityID: "svc2",
+			Tags:     models.Tags{{Key: "name", Value: "web"}, {Key: "environment_id", Value: "env2"}},
+		},
+	}
+}
+
+func environmentTags() []*models.EntityWithTags {
+	return []*models.EntityWithTags{
+		{EntityID: "env1", Tags: models.Tags{{Key: "name", Value: "dev"}}},
+		{EntityID: "env2", Tags: models.Tags{{Key: "name", Value: "prod"}}},
+	}
+}
+
+func TestComplete_commands(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	completion := newTestCompletionCommand(tc)
+
+	testutils.AssertEqual(t, completion.Complete([]string{"se"}), []string{"service"})
+	testutils.AssertEqual(t, completion.Complete([]string{"-d", "comp"}), []string{"completion"})
+	testutils.AssertEqual(t, completion.Complete([]string{"service", "d"}), []string{"delete"})
+	testutils.AssertEqual(t, completion.Complete([]string{"unknown", ""}), []string{})
+}
+
+func TestComplete_flags(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	completion := newTestCompletionCommand(tc)
+
+	testutils.AssertEqual(t, completion.Complete([]string{"--o"}), []string{"--output"})
+	testutils.AssertEqual(t, completion.Complete([]string{"service", "delete", "--w"}), []string{"--wait"})
+}
+
+func TestComplete_entityNames(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	completion := newTestCompletionCommand(tc)
+
+	tc.Client.EXPECT().
+		SelectByQuery(map[string]string{"type": "service"}).
+		Return(serviceTags(), nil)
+
+	tc.Client.EXPECT().
+		SelectByQuery(map[string]string{"type": "environment"}).
+		Return(environmentTags(), nil).
+		Times(2)
+
+	testutils.AssertEqual(t, completion.Complete([]string{"service", "get", "w"}), []string{"web"})
+	testutils.AssertEqual(t, completion.Complete([]string{"service", "create", "--loadbalancer", "lb", ""}), []string{"dev", "prod"})
+	testutils.AssertEqual(t, completion.Complete([]string{"service", "create", "env", ""}), []string{})
+	testutils.AssertEqual(t, completion.Complete([]string{"service", "delete", "--environment", "p"}), []string{"prod"})
+}
+
+func TestComplete_environmentScope(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	completion := newTestCompletionCommand(tc)
+
+	tc.Client.EXPECT().
+		SelectByQuery(map[string]string{"type": "service"}).
+		Return(serviceTags(), nil)
+
+	tc.Client.EXPECT().
+		SelectByQuery(map[string]string{"type": "environment"}).
+		Return(environmentTags(), nil)
+
+	testutils.AssertEqual(t, completion.Complete([]string{"service", "logs", "prod:"}), []string{"prod:web"})
+}
+
+func TestComplete_deployVersions(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	completion := newTestCompletionCommand(tc)
+
+	deployTags := []*models.EntityWithTags{
+		{EntityID: "dpl1", Tags: models.Tags{{Key: "name", Value: "api"}, {Key: "version", Value: "1"}}},
+		{EntityID: "dpl2", Tags: models.Tags{{Key: "name", Value: "api"}, {Key: "version", Value: "2"}}},
+	}
+
+	tc.Client.EXPECT().
+		SelectByQuery(map[string]string{"type": "deploy"}).
+		Return(deployTags, nil).
+		Times(2)
+
+	testutils.AssertEqual(t, completion.Complete([]string{"deploy", "delete", "a"}), []string{"api"})
+	testutils.AssertEqual(t, completion.Complete([]string{"deploy", "delete", "api:"}), []string{"api:1", "api:2"})
+}
+
+func TestComplete_cache(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	completion := newTestCompletionCommand(tc)
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	completion.CacheDir = dir
+
+	// the second completion must use the cache
+	tc.Client.EXPECT().
+		SelectByQuery(map[string]string{"type": "environment"}).
+		Return(environmentTags(), nil).
+		Times(1)
+
+	for i := 0; i < 2; i++ {
+		testutils.AssertEqual(t, completion.Complete([]string{"environment", "get", ""}), []string{"dev", "prod"})
+	}
+}
+
+func TestCompletionCacheDir(t *testing.T) {
+	dir := CompletionCacheDir("/home/user/.layer0", "https://l0-prod.example.com")
+	testutils.AssertEqual(t, filepath.Dir(dir), "/home/user/.layer0/.cache")
+	testutils.AssertEqual(t, dir, CompletionCacheDir("/home/user/.layer0", "https://l0-prod.example.com"))
+
+	if dir == CompletionCacheDir("/home/user/.layer0", "https://l0-dev.example.com") {
+		t.Fatal("Endpoints share a cache directory")
+	}
+}
+
+func TestCompletionScript(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	completion := newTestCompletionCommand(tc)
+
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		var script bytes.Buffer
+		completion.App.Writer = &script
+
+		c := testutils.GetCLIContext(t, []string{shell}, nil)
+		if err := completion.Script(c); err != nil {
+			t.Fatalf("%s: %v", shell, err)
+		}
+
+		// only the global flags that take a value consume the next word
+		if !strings.Contains(script.String(), "-o") || strings.Contains(script.String(), "--debug") {
+			t.Fatalf("%s: unexpected global flags in script:\n%s", shell, script.String())
+		}
+	}
+
+	c := testutils.GetCLIContext(t, []string{"powershell"}, nil)
+	if err := completion.Script(c); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
@@ -1,8 +1,13 @@
 package command
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
 	"io/ioutil"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/urfave/cli"
@@ -26,19 +31,47 @@ func (d *DeployCommand) GetCommand() cli.Command {
 				Usage:     "create a new deploy",
 				Action:    wrapAction(d.Command, d.Create),
 				ArgsUsage: "PATH NAME",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "compose",
+						Usage: "treat PATH as a docker compose file (the default for files ending in '.yml' or '.yaml')",
+					},
+					cli.StringSliceFlag{
+						Name:  "var",
+						Usage: "render PATH as a template with the variable in format 'KEY=VAL' (can be specified multiple times)",
+					},
+					cli.StringSliceFlag{
+						Name:  "var-file",
+						Usage: "render PATH as a template with the variables in the file, one 'KEY=VAL' per line (can be specified multiple times)",
+					},
+				},
 			},
 			{
 				Name:      "delete",
 				Usage:     "delete a deploy",
 				ArgsUsage: "NAME",
 				Action:    wrapAction(d.Command, d.Delete),
+				Flags:     deployBulkDeleteFlags,
+			},
+			{
+				Name:      "diff",
+				Usage:     "show the changes between two deploys",
+				Action:    wrapAction(d.Command, d.Diff),
+				ArgsUsage: "NAME OTHER_NAME",
 			},
 			{
 				Name:      "get",
 				Usage:     "describe a deploy",
 				Action:    wrapAction(d.Command, d.Get),
 				ArgsUsage: "NAME",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "details",
+						Usage: "show the rendered dockerrun, and the template and variables it was rendered from",
+					},
+				},
 			},
+			d.labelCommand("deploy"),
 			{
 				Name:      "list",
 				Usage:     "list all deploys (only the latest versions of each family will be shown)",
@@ -49,6 +82,40 @@ func (d *DeployCommand) GetCommand() cli.Command {
 						Name:  "all",
 						Usage: "list all versions of all deploys",
 					},
+					labelFlag,
+				},
+			},
+			{
+				Name:      "prune",
+				Usage:     "delete old versions of each deploy, keeping the latest versions and any versions in use",
+				Action:    wrapAction(d.Command, d.Prune),
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					cli.IntFlag{
+						Name:  "keep",
+						Value: 10,
+						Usage: "number of versions of each deploy to keep",
+					},
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "show the deploys that would be deleted without deleting them",
+					},
+				},
+			},
+			{
+				Name:      "validate",
+				Usage:     "check a dockerrun for problems without creating a deploy",
+				Action:    wrapAction(d.Command, d.Validate),
+				ArgsUsage: "PATH",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "environment",
+						Usage: "also check that the deploy fits on the instances of the specified environment",
+					},
+					cli.StringFlag{
+						Name:  "name",
+						Usage: "name the deploy will be created with",
+					},
 				},
 			},
 		},
@@ -66,7 +133,58 @@ func (d *DeployCommand) Create(c *cli.Context) error {
 		return err
 	}
 
-	deploy, err := d.Client.CreateDeploy(args["NAME"], content)
+	hasVariables := len(c.StringSlice("var")) > 0 || len(c.StringSlice("var-file")) > 0
+
+	if c.Bool("compose") || isComposeFile(args["PATH"]) {
+		if hasVariables {
+			return fmt.Errorf("Variables cannot be used with docker compose files")
+		}
+
+		deploy, err := d.Client.CreateDeployFromCompose(args["NAME"], content)
+		if err != nil {
+			return err
+		}
+
+		return d.Printer.PrintDeploys(deploy)
+	}
+
+	if !hasVariables {
+		deploy, err := d.Client.CreateDeploy(args["NAME"], content)
+		if err != nil {
+			return err
+		}
+
+		return d.Printer.PrintDeploys(deploy)
+	}
+
+	variables := map[string]string{}
+	for _, path := range c.StringSlice("var-file") {
+		file, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(file))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			if err := parseTemplateVariable(line, variables); err != nil {
+				return err
+			}
+		}
+	}
+
+	// variables specified with --var take precedence over those in variable files
+	for _, v := range c.StringSlice("var") {
+		if err := parseTemplateVariable(v, variables); err != nil {
+			return err
+		}
+	}
+
+	deploy, err := d.Client.CreateDeployFromTemplate(args["NAME"], content, variables)
 	if err != nil {
 		return err
 	}
@@ -74,10 +192,44 @@ func (d *DeployCommand) Create(c *cli.Context) error {
 	return d.Printer.PrintDeploys(deploy)
 }
 
+func parseTemplateVariable(v string, variables map[string]string) error {
+	split := strings.SplitN(v, "=", 2)
+	if len(split) != 2 || split[0] == "" {
+		return NewUsageError("Template variable format is: KEY=VAL")
+	}
+
+	variables[split[0]] = split[1]
+	return nil
+}
+
 func (d *DeployCommand) Delete(c *cli.Context) error {
 	return d.delete(c, "deploy", d.Client.DeleteDeploy)
 }
 
+func (d *DeployCommand) Diff(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME", "OTHER_NAME")
+	if err != nil {
+		return err
+	}
+
+	id, err := d.resolveSingleID("deploy", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	otherID, err := d.resolveSingleID("deploy", args["OTHER_NAME"])
+	if err != nil {
+		return err
+	}
+
+	diff, err := d.Client.DiffDeploys(id, otherID)
+	if err != nil {
+		return err
+	}
+
+	return d.Printer.PrintDeployDiff(diff)
+}
+
 func (d *DeployCommand) Get(c *cli.Context) error {
 	deploys := []*models.Deploy{}
 	getDeployf := func(id string) error {
@@ -94,6 +246,10 @@ func (d *DeployCommand) Get(c *cli.Context) error {
 		return err
 	}
 
+	if c.Bool("details") {
+		return d.Printer.PrintDeployDetails(deploys...)
+	}
+
 	return d.Printer.PrintDeploys(deploys...)
 }
 
@@ -110,9 +266,87 @@ func (d *DeployCommand) List(c *cli.Context) error {
 		}
 	}
 
+	ids, err := d.selectByLabels(c, "deploy")
+	if err != nil {
+		return err
+	}
+
+	if ids != nil {
+		filtered := []*models.DeploySummary{}
+		for _, summary := range deploySummaries {
+			if ids[summary.DeployID] {
+				filtered = append(filtered, summary)
+			}
+		}
+
+		deploySummaries = filtered
+	}
+
 	return d.Printer.PrintDeploySummaries(deploySummaries...)
 }
 
+func (d *DeployCommand) Prune(c *cli.Context) error {
+	keep := c.Int("keep")
+	if keep < 1 {
+		return fmt.Errorf("--keep must be at least 1")
+	}
+
+	if !c.Bool("dry-run") {
+		if err := d.confirm("Prune all but the latest %d version(s) of each deploy?", keep); err != nil {
+			return err
+		}
+	}
+
+	report, err := d.Client.PruneDeploys(keep, c.Bool("dry-run"))
+	if err != nil {
+		return err
+	}
+
+	return d.Printer.PrintDeployPruneReport(report)
+}
+
+func (d *DeployCommand) Validate(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "PATH")
+	if err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(args["PATH"])
+	if err != nil {
+		return err
+	}
+
+	var environmentID string
+	if environment := c.String("environment"); environment != "" {
+		id, err := d.resolveSingleID("environment", environment)
+		if err != nil {
+			return err
+		}
+
+		environmentID = id
+	}
+
+	validation, err := d.Client.ValidateDeploy(c.String("name"), content, environmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Printer.PrintDeployValidation(validation); err != nil {
+		return err
+	}
+
+	if !validation.Valid {
+		return fmt.Errorf("Deploy at '%s' is not valid", args["PATH"])
+	}
+
+	return nil
+}
+
+func isComposeFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yml" || ext == ".yaml"
+}
+
 func filterDeploySummaries(deploys []*models.DeploySummary) ([]*models.DeploySummary, error) {
 	catalog := map[string]*models.DeploySummary{}
 
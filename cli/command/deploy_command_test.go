@@ -26,14 +26,73 @@ func TestCreateDeploy(t *testing.T) {
 	}
 }
 
+func TestCreateDeploy_compose(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	file, close := tempFile(t, "compose")
+	defer close()
+
+	tc.Client.EXPECT().
+		CreateDeployFromCompose("name", []byte("compose")).
+		Return(&models.Deploy{}, nil)
+
+	flags := map[string]interface{}{
+		"compose": true,
+	}
+
+	c := testutils.GetCLIContext(t, []string{file.Name(), "name"}, flags)
+	if err := command.Create(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateDeploy_template(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	file, close := tempFile(t, "template")
+	defer close()
+
+	varFile, closeVarFile := tempFile(t, "# comment\ntag=v1\n\nmemory=512\n")
+	defer closeVarFile()
+
+	variables := map[string]string{
+		"tag":    "v2",
+		"memory": "512",
+		"cmd":    "a=b",
+	}
+
+	tc.Client.EXPECT().
+		CreateDeployFromTemplate("name", []byte("template"), variables).
+		Return(&models.Deploy{}, nil)
+
+	flags := map[string]interface{}{
+		"var":      []string{"tag=v2", "cmd=a=b"},
+		"var-file": []string{varFile.Name()},
+	}
+
+	c := testutils.GetCLIContext(t, []string{file.Name(), "name"}, flags)
+	if err := command.Create(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestCreateDeploy_userInputErrors(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
 	command := NewDeployCommand(tc.Command())
 
+	file, close := tempFile(t, "template")
+	defer close()
+
 	contexts := map[string]*cli.Context{
 		"Missing PATH arg": testutils.GetCLIContext(t, nil, nil),
 		"Missing NAME arg": testutils.GetCLIContext(t, []string{"path"}, nil),
+		"Malformed var":    testutils.GetCLIContext(t, []string{file.Name(), "name"}, map[string]interface{}{"var": []string{"tag"}}),
+		"Compose with var": testutils.GetCLIContext(t, []string{file.Name(), "name"}, map[string]interface{}{"compose": true, "var": []string{"tag=v1"}}),
 	}
 
 	for name, c := range contexts {
@@ -78,6 +137,46 @@ func TestDeleteDeploy_userInputErrors(t *testing.T) {
 	}
 }
 
+func TestDiffDeploys(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("deploy", "name:1").
+		Return([]string{"id.1"}, nil)
+
+	tc.Resolver.EXPECT().
+		Resolve("deploy", "name:2").
+		Return([]string{"id.2"}, nil)
+
+	tc.Client.EXPECT().
+		DiffDeploys("id.1", "id.2").
+		Return(&models.DeployDiff{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name:1", "name:2"}, nil)
+	if err := command.Diff(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiffDeploys_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg":       testutils.GetCLIContext(t, nil, nil),
+		"Missing OTHER_NAME arg": testutils.GetCLIContext(t, []string{"name:1"}, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.Diff(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
 func TestGetDeploy(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
@@ -128,6 +227,103 @@ func TestListDeploys(t *testing.T) {
 	}
 }
 
+func TestPruneDeploys(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	tc.Client.EXPECT().
+		PruneDeploys(5, true).
+		Return(&models.PruneDeploysReport{}, nil)
+
+	flags := map[string]interface{}{
+		"keep":    5,
+		"dry-run": true,
+	}
+
+	c := testutils.GetCLIContext(t, nil, flags)
+	if err := command.Prune(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPruneDeploys_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Invalid keep": testutils.GetCLIContext(t, nil, map[string]interface{}{"keep": 0}),
+	}
+
+	for name, c := range contexts {
+		if err := command.Prune(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestValidateDeploy(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	file, close := tempFile(t, "content")
+	defer close()
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "env").
+		Return([]string{"envid"}, nil)
+
+	tc.Client.EXPECT().
+		ValidateDeploy("name", []byte("content"), "envid").
+		Return(&models.DeployValidation{Valid: true}, nil)
+
+	flags := map[string]interface{}{
+		"environment": "env",
+		"name":        "name",
+	}
+
+	c := testutils.GetCLIContext(t, []string{file.Name()}, flags)
+	if err := command.Validate(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateDeploy_invalid(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	file, close := tempFile(t, "content")
+	defer close()
+
+	tc.Client.EXPECT().
+		ValidateDeploy("", []byte("content"), "").
+		Return(&models.DeployValidation{Errors: []string{"some error"}}, nil)
+
+	c := testutils.GetCLIContext(t, []string{file.Name()}, nil)
+	if err := command.Validate(c); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
+
+func TestValidateDeploy_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing PATH arg": testutils.GetCLIContext(t, nil, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.Validate(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
 func TestFilterDeploySummaries(t *testing.T) {
 	input := []*models.DeploySummary{
 		{DeployName: "a", DeployID: "a.1", Version: "1"},
@@ -3,6 +3,7 @@ package command
 import (
 	"io/ioutil"
 	"strconv"
+	"strings"
 
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/urfave/cli"
@@ -69,12 +70,15 @@ func (e *EnvironmentCommand) GetCommand() cli.Command {
 				Usage:     "describe an environment",
 				Action:    wrapAction(e.Command, e.Get),
 				ArgsUsage: "NAME",
+				Flags:     watchFlags,
 			},
+			e.labelCommand("environment"),
 			{
 				Name:      "list",
 				Usage:     "list all environments",
 				Action:    wrapAction(e.Command, e.List),
 				ArgsUsage: " ",
+				Flags:     append([]cli.Flag{labelFlag}, watchFlags...),
 			},
 			{
 				Name:      "setmincount",
@@ -86,13 +90,41 @@ func (e *EnvironmentCommand) GetCommand() cli.Command {
 				Name:      "link",
 				Usage:     "links two environments together",
 				Action:    wrapAction(e.Command, e.Link),
-				ArgsUsage: "SOURCE DESTINATION",
+				ArgsUsage: "SOURCE [DESTINATION]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "one-way",
+						Usage: "only allow traffic from SOURCE to DESTINATION",
+					},
+					cli.StringSliceFlag{
+						Name:  "port",
+						Usage: "only allow traffic on the specified port(s) (format: PORT[-PORT]/PROTOCOL, icmp, or all)",
+					},
+					cli.StringFlag{
+						Name:  "cidr",
+						Usage: "allow traffic from a cidr range into SOURCE instead of linking to DESTINATION",
+					},
+					cli.StringFlag{
+						Name:  "security-group",
+						Usage: "allow traffic from a security group id into SOURCE instead of linking to DESTINATION",
+					},
+				},
 			},
 			{
 				Name:      "unlink",
 				Usage:     "unlinks two previously linked environments",
 				Action:    wrapAction(e.Command, e.Unlink),
-				ArgsUsage: "SOURCE DESTINATION",
+				ArgsUsage: "SOURCE [DESTINATION]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "cidr",
+						Usage: "remove a link to a cidr range instead of DESTINATION",
+					},
+					cli.StringFlag{
+						Name:  "security-group",
+						Usage: "remove a link to a security group id instead of DESTINATION",
+					},
+				},
 			},
 		},
 	}
@@ -127,31 +159,51 @@ func (e *EnvironmentCommand) Delete(c *cli.Context) error {
 }
 
 func (e *EnvironmentCommand) Get(c *cli.Context) error {
-	environments := []*models.Environment{}
-	getEnvironmentf := func(id string) error {
-		environment, err := e.Client.GetEnvironment(id)
-		if err != nil {
-			return err
+	return e.watch(c, "environment", func() (interface{}, error) {
+		environments := []*models.Environment{}
+		getEnvironmentf := func(id string) error {
+			environment, err := e.Client.GetEnvironment(id)
+			if err != nil {
+				return err
+			}
+
+			environments = append(environments, environment)
+			return nil
 		}
 
-		environments = append(environments, environment)
-		return nil
-	}
-
-	if err := e.get(c, "environment", getEnvironmentf); err != nil {
-		return err
-	}
+		if err := e.get(c, "environment", getEnvironmentf); err != nil {
+			return nil, err
+		}
 
-	return e.Printer.PrintEnvironments(environments...)
+		return environments, e.Printer.PrintEnvironments(environments...)
+	})
 }
 
 func (e *EnvironmentCommand) List(c *cli.Context) error {
-	environmentSummaries, err := e.Client.ListEnvironments()
-	if err != nil {
-		return err
-	}
+	return e.watch(c, "environment", func() (interface{}, error) {
+		environmentSummaries, err := e.Client.ListEnvironments()
+		if err != nil {
+			return nil, err
+		}
 
-	return e.Printer.PrintEnvironmentSummaries(environmentSummaries...)
+		ids, err := e.selectByLabels(c, "environment")
+		if err != nil {
+			return nil, err
+		}
+
+		if ids != nil {
+			filtered := []*models.EnvironmentSummary{}
+			for _, summary := range environmentSummaries {
+				if ids[summary.EnvironmentID] {
+					filtered = append(filtered, summary)
+				}
+			}
+
+			environmentSummaries = filtered
+		}
+
+		return environmentSummaries, e.Printer.PrintEnvironmentSummaries(environmentSummaries...)
+	})
 }
 
 func (e *EnvironmentCommand) SetMinCount(c *cli.Context) error {
@@ -179,57 +231,150 @@ func (e *EnvironmentCommand) SetMinCount(c *cli.Context) error {
 }
 
 func (e *EnvironmentCommand) Link(c *cli.Context) error {
-	args, err := extractArgs(c.Args(), "SOURCE", "DESTINATION")
+	rules := []models.EnvironmentLinkRule{}
+	for _, port := range c.StringSlice("port") {
+		rule, err := parseLinkRule(port)
+		if err != nil {
+			return err
+		}
+
+		rules = append(rules, *rule)
+	}
+
+	link := models.EnvironmentLink{
+		CIDR:            c.String("cidr"),
+		SecurityGroupID: c.String("security-group"),
+		Rules:           rules,
+	}
+
+	sourceID, destID, err := e.resolveLinkArgs(c, "link")
 	if err != nil {
 		return err
 	}
 
-	id1, err := e.resolveSingleID("environment", args["SOURCE"])
-	if err != nil {
+	if destID != "" {
+		link.EnvironmentID = destID
+		link.Direction = "both"
+		if c.Bool("one-way") {
+			link.Direction = "outbound"
+		}
+	}
+
+	if err := e.Client.CreateLink(sourceID, link); err != nil {
 		return err
 	}
 
-	id2, err := e.resolveSingleID("environment", args["DESTINATION"])
+	e.Printer.Printf("Environment successfully linked\n")
+	return nil
+}
+
+func (e *EnvironmentCommand) Unlink(c *cli.Context) error {
+	sourceID, destID, err := e.resolveLinkArgs(c, "unlink")
 	if err != nil {
 		return err
 	}
 
-	if id1 == id2 {
-		return NewUsageError("Cannot link an environment to itself")
+	peer := destID
+	switch {
+	case c.String("cidr") != "":
+		peer = c.String("cidr")
+	case c.String("security-group") != "":
+		peer = c.String("security-group")
 	}
 
-	if err := e.Client.CreateLink(id1, id2); err != nil {
+	if err := e.confirm("Unlink environment '%s' from '%s'?", sourceID, peer); err != nil {
 		return err
 	}
 
-	e.Printer.Printf("Environment successfully linked\n")
+	if err := e.Client.DeleteLink(sourceID, peer); err != nil {
+		return err
+	}
+
+	e.Printer.Printf("Environment successfully unlinked\n")
 	return nil
 }
 
-func (e *EnvironmentCommand) Unlink(c *cli.Context) error {
+// resolveLinkArgs returns the SOURCE environment id and, unless a cidr or
+// security group was specified, the DESTINATION environment id
+func (e *EnvironmentCommand) resolveLinkArgs(c *cli.Context, action string) (string, string, error) {
+	if c.String("cidr") != "" && c.String("security-group") != "" {
+		return "", "", NewUsageError("Only one of --cidr or --security-group may be specified")
+	}
+
+	if c.String("cidr") != "" || c.String("security-group") != "" {
+		args, err := extractArgs(c.Args(), "SOURCE")
+		if err != nil {
+			return "", "", err
+		}
+
+		sourceID, err := e.resolveSingleID("environment", args["SOURCE"])
+		if err != nil {
+			return "", "", err
+		}
+
+		return sourceID, "", nil
+	}
+
 	args, err := extractArgs(c.Args(), "SOURCE", "DESTINATION")
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
-	id1, err := e.resolveSingleID("environment", args["SOURCE"])
+	sourceID, err := e.resolveSingleID("environment", args["SOURCE"])
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
-	id2, err := e.resolveSingleID("environment", args["DESTINATION"])
+	destID, err := e.resolveSingleID("environment", args["DESTINATION"])
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
-	if id1 == id2 {
-		return NewUsageError("Cannot unlink an environment from itself")
+	if sourceID == destID {
+		if action == "link" {
+			return "", "", NewUsageError("Cannot link an environment to itself")
+		}
+
+		return "", "", NewUsageError("Cannot unlink an environment from itself")
 	}
 
-	if err := e.Client.DeleteLink(id1, id2); err != nil {
-		return err
+	return sourceID, destID, nil
+}
+
+func parseLinkRule(port string) (*models.EnvironmentLinkRule, error) {
+	switch protocol := strings.ToLower(port); protocol {
+	case "all", "icmp":
+		return &models.EnvironmentLinkRule{Protocol: protocol}, nil
 	}
 
-	e.Printer.Printf("Environment successfully unlinked\n")
-	return nil
+	split := strings.Split(port, "/")
+	if len(split) != 2 {
+		return nil, NewUsageError("Port format is: PORT[-PORT]/PROTOCOL, icmp, or all")
+	}
+
+	ports := strings.Split(split[0], "-")
+	if len(ports) > 2 {
+		return nil, NewUsageError("Port format is: PORT[-PORT]/PROTOCOL, icmp, or all")
+	}
+
+	fromPort, err := strconv.Atoi(ports[0])
+	if err != nil {
+		return nil, NewUsageError("'%s' is not a valid integer", ports[0])
+	}
+
+	toPort := fromPort
+	if len(ports) == 2 {
+		toPort, err = strconv.Atoi(ports[1])
+		if err != nil {
+			return nil, NewUsageError("'%s' is not a valid integer", ports[1])
+		}
+	}
+
+	rule := &models.EnvironmentLinkRule{
+		Protocol: strings.ToLower(split[1]),
+		FromPort: fromPort,
+		ToPort:   toPort,
+	}
+
+	return rule, nil
 }
@@ -209,8 +209,14 @@ func TestEnvironmentLink(t *testing.T) {
 		Resolve("environment", "name2").
 		Return([]string{"id2"}, nil)
 
+	link := models.EnvironmentLink{
+		EnvironmentID: "id2",
+		Direction:     "both",
+		Rules:         []models.EnvironmentLinkRule{},
+	}
+
 	tc.Client.EXPECT().
-		CreateLink("id1", "id2").
+		CreateLink("id1", link).
 		Return(nil)
 
 	c := testutils.GetCLIContext(t, []string{"name1", "name2"}, nil)
@@ -219,6 +225,72 @@ func TestEnvironmentLink(t *testing.T) {
 	}
 }
 
+func TestEnvironmentLink_oneWayPorts(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "name1").
+		Return([]string{"id1"}, nil)
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "name2").
+		Return([]string{"id2"}, nil)
+
+	link := models.EnvironmentLink{
+		EnvironmentID: "id2",
+		Direction:     "outbound",
+		Rules: []models.EnvironmentLinkRule{
+			{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+			{Protocol: "udp", FromPort: 8000, ToPort: 8100},
+		},
+	}
+
+	tc.Client.EXPECT().
+		CreateLink("id1", link).
+		Return(nil)
+
+	flags := map[string]interface{}{
+		"one-way": true,
+		"port":    []string{"5432/tcp", "8000-8100/UDP"},
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name1", "name2"}, flags)
+	if err := command.Link(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnvironmentLink_cidr(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "name1").
+		Return([]string{"id1"}, nil)
+
+	link := models.EnvironmentLink{
+		CIDR:  "10.0.0.0/16",
+		Rules: []models.EnvironmentLinkRule{{Protocol: "all"}},
+	}
+
+	tc.Client.EXPECT().
+		CreateLink("id1", link).
+		Return(nil)
+
+	flags := map[string]interface{}{
+		"cidr": "10.0.0.0/16",
+		"port": []string{"all"},
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name1"}, flags)
+	if err := command.Link(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestEnvironmentLink_userInputErrors(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
@@ -227,6 +299,9 @@ func TestEnvironmentLink_userInputErrors(t *testing.T) {
 	contexts := map[string]*cli.Context{
 		"Missing SOURCE arg":      testutils.GetCLIContext(t, []string{}, nil),
 		"Missing DESTINATION arg": testutils.GetCLIContext(t, []string{"name"}, nil),
+		"Invalid port":            testutils.GetCLIContext(t, []string{"name1", "name2"}, map[string]interface{}{"port": []string{"abc/tcp"}}),
+		"Missing protocol":        testutils.GetCLIContext(t, []string{"name1", "name2"}, map[string]interface{}{"port": []string{"80"}}),
+		"Cidr and security group": testutils.GetCLIContext(t, []string{"name1"}, map[string]interface{}{"cidr": "10.0.0.0/16", "security-group": "sg-123"}),
 	}
 
 	for name, c := range contexts {
@@ -0,0 +1,140 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chzyer/readline"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/urfave/cli"
+)
+
+// how often the local terminal is checked for size changes during a tty exec session
+const execResizeInterval = time.Millisecond * 250
+
+var execFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "no-tty",
+		Usage: "do not allocate a tty, even if the cli is running in a terminal",
+	},
+}
+
+// parseExecArgs splits 'NAME [CONTAINER] -- COMMAND...' into its parts
+func parseExecArgs(received []string) (string, string, []string, error) {
+	for i, arg := range received {
+		if arg != "--" {
+			continue
+		}
+
+		args, command := received[:i], received[i+1:]
+		if len(args) == 0 {
+			return "", "", nil, NewUsageError("Argument NAME is required")
+		}
+
+		if len(args) > 2 {
+			return "", "", nil, NewUsageError("Too many arguments before '--', expected NAME [CONTAINER]")
+		}
+
+		if len(command) == 0 {
+			return "", "", nil, NewUsageError("A command is required after '--'")
+		}
+
+		var container string
+		if len(args) == 2 {
+			container = args[1]
+		}
+
+		return args[0], container, command, nil
+	}
+
+	return "", "", nil, NewUsageError("The command must be separated from the arguments with '--', e.g. NAME -- sh")
+}
+
+// exec resolves the NAME arg to an entity of the specified type and runs the command
+// in one of its containers, attaching the local stdin, stdout and stderr
+func (cm *Command) exec(c *cli.Context, entityType string, exec func(string, models.ExecRequest, models.ExecStreams) (int, error)) error {
+	name, container, command, err := parseExecArgs(c.Args())
+	if err != nil {
+		return err
+	}
+
+	id, err := cm.resolveSingleID(entityType, name)
+	if err != nil {
+		return err
+	}
+
+	stdin := int(os.Stdin.Fd())
+	stdout := int(os.Stdout.Fd())
+
+	req := models.ExecRequest{
+		Command:       command,
+		ContainerName: container,
+		TTY:           !c.Bool("no-tty") && readline.IsTerminal(stdin) && readline.IsTerminal(stdout),
+	}
+
+	streams := models.ExecStreams{
+		Stderr: os.Stderr,
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+	}
+
+	if req.TTY {
+		state, err := readline.MakeRaw(stdin)
+		if err != nil {
+			return err
+		}
+		defer readline.Restore(stdin, state)
+
+		done := make(chan struct{})
+		defer close(done)
+
+		streams.Resize = watchTerminalSize(stdout, done)
+	}
+
+	exitCode, err := exec(id, req, streams)
+	if err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("Command exited with code %d", exitCode)
+	}
+
+	return nil
+}
+
+// watchTerminalSize sends the current size of the terminal, and then any changes to it, until done is closed
+func watchTerminalSize(fd int, done <-chan struct{}) <-chan models.TerminalSize {
+	sizes := make(chan models.TerminalSize)
+
+	go func() {
+		defer close(sizes)
+
+		var current models.TerminalSize
+		ticker := time.NewTicker(execResizeInterval)
+		defer ticker.Stop()
+
+		for {
+			if width, height, err := readline.GetSize(fd); err == nil {
+				size := models.TerminalSize{Height: height, Width: width}
+				if size != current {
+					select {
+					case sizes <- size:
+						current = size
+					case <-done:
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return sizes
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
 	"github.com/urfave/cli"
 )
 
@@ -30,12 +31,14 @@ func (j *JobCommand) GetCommand() cli.Command {
 				Usage:     "describe a job",
 				Action:    wrapAction(j.Command, j.Get),
 				ArgsUsage: "NAME",
+				Flags:     watchFlags,
 			},
 			{
 				Name:      "list",
 				Usage:     "list all jobs",
 				Action:    wrapAction(j.Command, j.List),
 				ArgsUsage: " ",
+				Flags:     watchFlags,
 			},
 			{
 				Name:      "logs",
@@ -66,31 +69,53 @@ func (j *JobCommand) Delete(c *cli.Context) error {
 }
 
 func (j *JobCommand) Get(c *cli.Context) error {
-	jobs := []*models.Job{}
-	getJobf := func(id string) error {
-		job, err := j.Client.GetJob(id)
-		if err != nil {
-			return err
+	return j.watch(c, "job", func() (interface{}, error) {
+		jobs := []*models.Job{}
+		getJobf := func(id string) error {
+			job, err := j.Client.GetJob(id)
+			if err != nil {
+				return err
+			}
+
+			jobs = append(jobs, job)
+			return nil
 		}
 
-		jobs = append(jobs, job)
-		return nil
-	}
-
-	if err := j.get(c, "job", getJobf); err != nil {
-		return err
-	}
+		if err := j.get(c, "job", getJobf); err != nil {
+			return nil, err
+		}
 
-	return j.Printer.PrintJobs(jobs...)
+		return watchedJobs(jobs), j.Printer.PrintJobs(jobs...)
+	})
 }
 
 func (j *JobCommand) List(c *cli.Context) error {
-	jobs, err := j.Client.ListJobs()
-	if err != nil {
-		return err
+	return j.watch(c, "job", func() (interface{}, error) {
+		jobs, err := j.Client.ListJobs()
+		if err != nil {
+			return nil, err
+		}
+
+		return watchedJobs(jobs), j.Printer.PrintJobs(jobs...)
+	})
+}
+
+// watchedJob adds the name of the job's status so --until conditions can use it, e.g. 'status==completed'
+type watchedJob struct {
+	*models.Job
+	Status string `json:"status"`
+}
+
+func watchedJobs(jobs []*models.Job) []watchedJob {
+	watched := make([]watchedJob, len(jobs))
+	for i, job := range jobs {
+		watched[i] = watchedJob{
+			Job:    job,
+			Status: types.JobStatus(job.JobStatus).String(),
+		}
 	}
 
-	return j.Printer.PrintJobs(jobs...)
+	return watched
 }
 
 func (j *JobCommand) Logs(c *cli.Context) error {
@@ -0,0 +1,167 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/urfave/cli"
+)
+
+// labelFlag is added to the list commands of entities that can be labelled
+var labelFlag = cli.StringSliceFlag{
+	Name:  "label",
+	Usage: "only list entities with the specified labels, e.g. 'team=payments' (can be specified multiple times)",
+}
+
+// labelCommand returns the 'label' command used to manage the user-defined labels of entities of the specified type
+func (cm *Command) labelCommand(entityType string) cli.Command {
+	entityName := strings.Replace(entityType, "_", " ", -1)
+
+	return cli.Command{
+		Name:  "label",
+		Usage: fmt.Sprintf("manage the labels of a %s", entityName),
+		Subcommands: []cli.Command{
+			{
+				Name:      "list",
+				Usage:     fmt.Sprintf("list the labels of a %s", entityName),
+				Action:    wrapAction(cm, func(c *cli.Context) error { return cm.listLabels(c, entityType) }),
+				ArgsUsage: "NAME",
+			},
+			{
+				Name:      "remove",
+				Usage:     fmt.Sprintf("remove labels from a %s", entityName),
+				Action:    wrapAction(cm, func(c *cli.Context) error { return cm.removeLabels(c, entityType) }),
+				ArgsUsage: "NAME KEY...",
+			},
+			{
+				Name:      "set",
+				Usage:     fmt.Sprintf("add or update labels of a %s", entityName),
+				Action:    wrapAction(cm, func(c *cli.Context) error { return cm.setLabels(c, entityType) }),
+				ArgsUsage: "NAME KEY=VALUE...",
+			},
+		},
+	}
+}
+
+func (cm *Command) listLabels(c *cli.Context, entityType string) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	id, err := cm.resolveSingleID(entityType, args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	labels, err := cm.Client.ListLabels(entityType, id)
+	if err != nil {
+		return err
+	}
+
+	return cm.Printer.PrintLabels(labels)
+}
+
+func (cm *Command) removeLabels(c *cli.Context, entityType string) error {
+	args, err := extractArgs(c.Args(), "NAME", "KEY")
+	if err != nil {
+		return err
+	}
+
+	id, err := cm.resolveSingleID(entityType, args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	for _, key := range c.Args().Tail() {
+		if err := cm.Client.DeleteLabel(entityType, id, key); err != nil {
+			return err
+		}
+	}
+
+	labels, err := cm.Client.ListLabels(entityType, id)
+	if err != nil {
+		return err
+	}
+
+	return cm.Printer.PrintLabels(labels)
+}
+
+func (cm *Command) setLabels(c *cli.Context, entityType string) error {
+	args, err := extractArgs(c.Args(), "NAME", "KEY=VALUE")
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]string{}
+	for _, arg := range c.Args().Tail() {
+		key, value, err := parseLabel(arg)
+		if err != nil {
+			return NewUsageError("%v", err)
+		}
+
+		labels[key] = value
+	}
+
+	id, err := cm.resolveSingleID(entityType, args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	for _, key := range sortedKeys(labels) {
+		if err := cm.Client.SetLabel(entityType, id, key, labels[key]); err != nil {
+			return err
+		}
+	}
+
+	current, err := cm.Client.ListLabels(entityType, id)
+	if err != nil {
+		return err
+	}
+
+	return cm.Printer.PrintLabels(current)
+}
+
+// selectByLabels returns the ids of the entities of the specified type that have each label in the 'label' flags,
+// or nil if no labels were specified
+func (cm *Command) selectByLabels(c *cli.Context, entityType string) (map[string]bool, error) {
+	labels := c.StringSlice("label")
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	params := map[string]string{"type": entityType}
+	for _, label := range labels {
+		selector, err := parseSelector(label)
+		if err != nil {
+			return nil, NewUsageError("Invalid --label: %v", err)
+		}
+
+		for key, value := range selector {
+			params[models.LabelKey(key)] = value
+		}
+	}
+
+	ewts, err := cm.Client.SelectByQuery(params)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[string]bool{}
+	for _, ewt := range ewts {
+		ids[ewt.EntityID] = true
+	}
+
+	return ids, nil
+}
+
+// parseLabel parses a label in the format 'key=value'
+func parseLabel(s string) (string, string, error) {
+	split := strings.SplitN(s, "=", 2)
+	if len(split) != 2 || strings.TrimSpace(split[0]) == "" {
+		return "", "", fmt.Errorf("'%s' is not in the format KEY=VALUE", s)
+	}
+
+	return strings.TrimSpace(split[0]), strings.TrimSpace(split[1]), nil
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/urfave/cli"
+)
+
+func TestSetLabels(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewServiceCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("service", "name").
+		Return([]string{"id"}, nil)
+
+	gomock.InOrder(
+		tc.Client.EXPECT().
+			SetLabel("service", "id", "branch", "feature-x").
+			Return(nil),
+		tc.Client.EXPECT().
+			SetLabel("service", "id", "team", "payments").
+			Return(nil),
+	)
+
+	tc.Client.EXPECT().
+		ListLabels("service", "id").
+		Return(map[string]string{"branch": "feature-x", "team": "payments"}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name", "team=payments", "branch=feature-x"}, nil)
+	if err := command.setLabels(c, "service"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetLabels_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewServiceCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg":      testutils.GetCLIContext(t, nil, nil),
+		"Missing KEY=VALUE arg": testutils.GetCLIContext(t, []string{"name"}, nil),
+		"Malformed label":       testutils.GetCLIContext(t, []string{"name", "team"}, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.setLabels(c, "service"); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestRemoveLabels(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTaskCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("task", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		DeleteLabel("task", "id", "team").
+		Return(nil)
+
+	tc.Client.EXPECT().
+		ListLabels("task", "id").
+		Return(map[string]string{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name", "team"}, nil)
+	if err := command.removeLabels(c, "task"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListLabels(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewDeployCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("deploy", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		ListLabels("deploy", "id").
+		Return(map[string]string{"team": "payments"}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, nil)
+	if err := command.listLabels(c, "deploy"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSelectByLabels(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewServiceCommand(tc.Command())
+
+	query := map[string]string{
+		"type":         "service",
+		"label:team":   "payments",
+		"label:branch": "feature-x",
+	}
+
+	tc.Client.EXPECT().
+		SelectByQuery(query).
+		Return([]*models.EntityWithTags{{EntityID: "id1"}, {EntityID: "id2"}}, nil)
+
+	flags := map[string]interface{}{
+		"label": []string{"team=payments", "branch=feature-x"},
+	}
+
+	c := testutils.GetCLIContext(t, nil, flags)
+	ids, err := command.selectByLabels(c, "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, ids, map[string]bool{"id1": true, "id2": true})
+}
+
+func TestListServices_label(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewServiceCommand(tc.Command())
+
+	tc.Client.EXPECT().
+		ListServices().
+		Return([]*models.ServiceSummary{{ServiceID: "id1"}, {ServiceID: "id2"}}, nil)
+
+	tc.Client.EXPECT().
+		SelectByQuery(map[string]string{"type": "service", "label:team": "payments"}).
+		Return([]*models.EntityWithTags{{EntityID: "id2"}}, nil)
+
+	c := testutils.GetCLIContext(t, nil, map[string]interface{}{"label": []string{"team=payments"}})
+	if err := command.List(c); err != nil {
+		t.Fatal(err)
+	}
+}
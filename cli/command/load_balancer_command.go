@@ -22,6 +22,35 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 		Name:  "loadbalancer",
 		Usage: "manage layer0 load balancers",
 		Subcommands: []cli.Command{
+			{
+				Name:      "access-logs",
+				Usage:     "view or update access logging for a load balancer",
+				Action:    wrapAction(l.Command, l.AccessLogs),
+				ArgsUsage: "NAME",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "enable",
+						Usage: "enable access logging (requires --bucket)",
+					},
+					cli.BoolFlag{
+						Name:  "disable",
+						Usage: "disable access logging",
+					},
+					cli.StringFlag{
+						Name:  "bucket",
+						Usage: "name of the S3 bucket to store access logs in",
+					},
+					cli.StringFlag{
+						Name:  "prefix",
+						Usage: "path prefix for access logs in the S3 bucket",
+					},
+					cli.IntFlag{
+						Name:  "interval",
+						Value: 60,
+						Usage: "interval in minutes to publish access logs: 5 or 60 (classic load balancers only)",
+					},
+				},
+			},
 			{
 				Name:      "addport",
 				Usage:     "add a new listener port on a load balancer",
@@ -34,6 +63,27 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "connection-draining",
+				Usage:     "view or update connection draining for a load balancer",
+				Action:    wrapAction(l.Command, l.ConnectionDraining),
+				ArgsUsage: "NAME",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "enable",
+						Usage: "enable connection draining",
+					},
+					cli.BoolFlag{
+						Name:  "disable",
+						Usage: "disable connection draining",
+					},
+					cli.IntFlag{
+						Name:  "drain-timeout",
+						Value: 300,
+						Usage: "maximum time in seconds to keep existing connections open before deregistering instances",
+					},
+				},
+			},
 			{
 				Name:      "create",
 				Usage:     "create a new load balancer",
@@ -86,6 +136,11 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 						Name:  "disable-cross-zone",
 						Usage: "if specified, disables cross-zone load balancing (default is enabled)",
 					},
+					cli.StringFlag{
+						Name:  "type",
+						Value: "elb",
+						Usage: "type of load balancer: 'elb' (classic) or 'alb' (application, supports host and path routing)",
+					},
 				},
 			},
 			{
@@ -109,12 +164,12 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 				Usage:     "delete a load balancer",
 				ArgsUsage: "NAME",
 				Action:    wrapAction(l.Command, l.Delete),
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.BoolFlag{
 						Name:  "wait",
 						Usage: "wait for the job to complete before returning",
 					},
-				},
+				}, bulkDeleteFlags...),
 			},
 			{
 				Name:      "dropport",
@@ -127,6 +182,13 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 				Usage:     "describe a load balancer",
 				Action:    wrapAction(l.Command, l.Get),
 				ArgsUsage: "NAME",
+				Flags:     watchFlags,
+			},
+			{
+				Name:      "health",
+				Usage:     "view the health of each instance registered with a load balancer",
+				Action:    wrapAction(l.Command, l.Health),
+				ArgsUsage: "NAME",
 			},
 			{
 				Name:      "healthcheck",
@@ -162,16 +224,69 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 				Action:    wrapAction(l.Command, l.IdleTimeout),
 				ArgsUsage: "NAME TIMEOUT",
 			},
+			l.labelCommand("load_balancer"),
 			{
 				Name:      "list",
 				Usage:     "list all load balancers",
 				Action:    wrapAction(l.Command, l.List),
 				ArgsUsage: " ",
+				Flags:     append([]cli.Flag{labelFlag}, watchFlags...),
 			},
 		},
 	}
 }
 
+func (l *LoadBalancerCommand) AccessLogs(c *cli.Context) error {
+	enableAccessLogs := c.Bool("enable")
+	disableAccessLogs := c.Bool("disable")
+
+	if enableAccessLogs && disableAccessLogs {
+		return NewUsageError("Must not specify both 'enable' and 'disable' flags")
+	}
+
+	if enableAccessLogs && c.String("bucket") == "" {
+		return NewUsageError("Must specify 'bucket' flag when enabling access logs")
+	}
+
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	id, err := l.resolveSingleID("load_balancer", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	loadBalancer, err := l.Client.GetLoadBalancer(id)
+	if err != nil {
+		return err
+	}
+
+	if enableAccessLogs {
+		accessLogs := models.AccessLogs{
+			Enabled:        true,
+			S3BucketName:   c.String("bucket"),
+			S3BucketPrefix: c.String("prefix"),
+			EmitInterval:   c.Int("interval"),
+		}
+
+		loadBalancer, err = l.Client.UpdateLoadBalancerAccessLogs(id, accessLogs)
+		if err != nil {
+			return err
+		}
+	}
+
+	if disableAccessLogs {
+		loadBalancer, err = l.Client.UpdateLoadBalancerAccessLogs(id, models.AccessLogs{Enabled: false})
+		if err != nil {
+			return err
+		}
+	}
+
+	return l.Printer.PrintLoadBalancerAccessLogs(loadBalancer)
+}
+
 func (l *LoadBalancerCommand) AddPort(c *cli.Context) error {
 	args, err := extractArgs(c.Args(), "NAME", "PORT")
 	if err != nil {
@@ -202,6 +317,51 @@ func (l *LoadBalancerCommand) AddPort(c *cli.Context) error {
 	return l.Printer.PrintLoadBalancers(loadBalancer)
 }
 
+func (l *LoadBalancerCommand) ConnectionDraining(c *cli.Context) error {
+	enableConnectionDraining := c.Bool("enable")
+	disableConnectionDraining := c.Bool("disable")
+
+	if enableConnectionDraining && disableConnectionDraining {
+		return NewUsageError("Must not specify both 'enable' and 'disable' flags")
+	}
+
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	id, err := l.resolveSingleID("load_balancer", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	loadBalancer, err := l.Client.GetLoadBalancer(id)
+	if err != nil {
+		return err
+	}
+
+	if enableConnectionDraining {
+		connectionDraining := models.ConnectionDraining{
+			Enabled: true,
+			Timeout: c.Int("drain-timeout"),
+		}
+
+		loadBalancer, err = l.Client.UpdateLoadBalancerConnectionDraining(id, connectionDraining)
+		if err != nil {
+			return err
+		}
+	}
+
+	if disableConnectionDraining {
+		loadBalancer, err = l.Client.UpdateLoadBalancerConnectionDraining(id, models.ConnectionDraining{Enabled: false})
+		if err != nil {
+			return err
+		}
+	}
+
+	return l.Printer.PrintLoadBalancerConnectionDraining(loadBalancer)
+}
+
 func (l *LoadBalancerCommand) Create(c *cli.Context) error {
 	args, err := extractArgs(c.Args(), "ENVIRONMENT", "NAME")
 	if err != nil {
@@ -218,6 +378,11 @@ func (l *LoadBalancerCommand) Create(c *cli.Context) error {
 		ports = append(ports, *port)
 	}
 
+	loadBalancerType := strings.ToLower(c.String("type"))
+	if loadBalancerType != "elb" && loadBalancerType != "alb" {
+		return NewUsageError("Type must be 'elb' or 'alb'")
+	}
+
 	if len(ports) == 0 {
 		port := models.Port{
 			HostPort:      80,
@@ -225,6 +390,11 @@ func (l *LoadBalancerCommand) Create(c *cli.Context) error {
 			Protocol:      "tcp",
 		}
 
+		// application load balancers only support http and https listeners
+		if loadBalancerType == "alb" {
+			port.Protocol = "http"
+		}
+
 		ports = append(ports, port)
 	}
 
@@ -243,7 +413,7 @@ func (l *LoadBalancerCommand) Create(c *cli.Context) error {
 
 	idleTimeout := c.Int("idle-timeout")
 	crossZone := !c.Bool("disable-cross-zone")
-	loadBalancer, err := l.Client.CreateLoadBalancer(args["NAME"], environmentID, healthCheck, ports, !c.Bool("private"), idleTimeout, crossZone)
+	loadBalancer, err := l.Client.CreateLoadBalancer(args["NAME"], environmentID, healthCheck, ports, !c.Bool("private"), idleTimeout, crossZone, loadBalancerType)
 	if err != nil {
 		return err
 	}
@@ -337,22 +507,43 @@ func (l *LoadBalancerCommand) DropPort(c *cli.Context) error {
 }
 
 func (l *LoadBalancerCommand) Get(c *cli.Context) error {
-	loadBalancers := []*models.LoadBalancer{}
-	getLoadBalancerf := func(id string) error {
-		loadBalancer, err := l.Client.GetLoadBalancer(id)
-		if err != nil {
-			return err
+	return l.watch(c, "load_balancer", func() (interface{}, error) {
+		loadBalancers := []*models.LoadBalancer{}
+		getLoadBalancerf := func(id string) error {
+			loadBalancer, err := l.Client.GetLoadBalancer(id)
+			if err != nil {
+				return err
+			}
+
+			loadBalancers = append(loadBalancers, loadBalancer)
+			return nil
 		}
 
-		loadBalancers = append(loadBalancers, loadBalancer)
-		return nil
+		if err := l.get(c, "load_balancer", getLoadBalancerf); err != nil {
+			return nil, err
+		}
+
+		return loadBalancers, l.Printer.PrintLoadBalancers(loadBalancers...)
+	})
+}
+
+func (l *LoadBalancerCommand) Health(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	id, err := l.resolveSingleID("load_balancer", args["NAME"])
+	if err != nil {
+		return err
 	}
 
-	if err := l.get(c, "load_balancer", getLoadBalancerf); err != nil {
+	health, err := l.Client.GetLoadBalancerHealth(id)
+	if err != nil {
 		return err
 	}
 
-	return l.Printer.PrintLoadBalancers(loadBalancers...)
+	return l.Printer.PrintLoadBalancerHealth(health)
 }
 
 func (l *LoadBalancerCommand) HealthCheck(c *cli.Context) error {
@@ -479,12 +670,30 @@ func (l *LoadBalancerCommand) IdleTimeout(c *cli.Context) error {
 }
 
 func (l *LoadBalancerCommand) List(c *cli.Context) error {
-	loadBalancerSummaries, err := l.Client.ListLoadBalancers()
-	if err != nil {
-		return err
-	}
+	return l.watch(c, "load_balancer", func() (interface{}, error) {
+		loadBalancerSummaries, err := l.Client.ListLoadBalancers()
+		if err != nil {
+			return nil, err
+		}
+
+		ids, err := l.selectByLabels(c, "load_balancer")
+		if err != nil {
+			return nil, err
+		}
 
-	return l.Printer.PrintLoadBalancerSummaries(loadBalancerSummaries...)
+		if ids != nil {
+			filtered := []*models.LoadBalancerSummary{}
+			for _, summary := range loadBalancerSummaries {
+				if ids[summary.LoadBalancerID] {
+					filtered = append(filtered, summary)
+				}
+			}
+
+			loadBalancerSummaries = filtered
+		}
+
+		return loadBalancerSummaries, l.Printer.PrintLoadBalancerSummaries(loadBalancerSummaries...)
+	})
 }
 
 func parsePort(port, certificate string) (*models.Port, error) {
@@ -167,7 +167,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 	}
 
 	tc.Client.EXPECT().
-		CreateLoadBalancer("name", "environmentID", healthCheck, ports, false, 60, true).
+		CreateLoadBalancer("name", "environmentID", healthCheck, ports, false, 60, true, "elb").
 		Return(&models.LoadBalancer{}, nil)
 
 	flags := map[string]interface{}{
@@ -181,6 +181,53 @@ func TestCreateLoadBalancer(t *testing.T) {
 		"healthcheck-unhealthy-threshold": 2,
 		"idle-timeout":                    60,
 		"disable-cross-zone":              false,
+		"type":                            "elb",
+	}
+
+	c := testutils.GetCLIContext(t, []string{"environment", "name"}, flags)
+	if err := command.Create(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateLoadBalancer_application(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "environment").
+		Return([]string{"environmentID"}, nil)
+
+	healthCheck := models.HealthCheck{
+		Target:             "HTTP:80/health",
+		Interval:           30,
+		Timeout:            5,
+		HealthyThreshold:   2,
+		UnhealthyThreshold: 2,
+	}
+
+	ports := []models.Port{
+		{
+			HostPort:      80,
+			ContainerPort: 8080,
+			Protocol:      "http",
+		},
+	}
+
+	tc.Client.EXPECT().
+		CreateLoadBalancer("name", "environmentID", healthCheck, ports, true, 60, true, "alb").
+		Return(&models.LoadBalancer{}, nil)
+
+	flags := map[string]interface{}{
+		"port":                            []string{"80:8080/http"},
+		"healthcheck-target":              "HTTP:80/health",
+		"healthcheck-interval":            30,
+		"healthcheck-timeout":             5,
+		"healthcheck-healthy-threshold":   2,
+		"healthcheck-unhealthy-threshold": 2,
+		"idle-timeout":                    60,
+		"type":                            "alb",
 	}
 
 	c := testutils.GetCLIContext(t, []string{"environment", "name"}, flags)
@@ -197,6 +244,7 @@ func TestCreateLoadBalancer_userInputErrors(t *testing.T) {
 	contexts := map[string]*cli.Context{
 		"Missing ENVIRONMENT arg": testutils.GetCLIContext(t, nil, nil),
 		"Missing NAME arg":        testutils.GetCLIContext(t, []string{"environment"}, nil),
+		"Invalid type":            testutils.GetCLIContext(t, []string{"environment", "name"}, map[string]interface{}{"type": "nlb"}),
 	}
 
 	for name, c := range contexts {
@@ -314,6 +362,41 @@ func TestListLoadBalancers(t *testing.T) {
 	}
 }
 
+func TestLoadBalancerHealth(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("load_balancer", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		GetLoadBalancerHealth("id").
+		Return(&models.LoadBalancerHealth{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, nil)
+	if err := command.Health(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadBalancerHealth_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg": testutils.GetCLIContext(t, nil, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.Health(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
 func TestHealthCheck_noUpdateRequired(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
@@ -518,3 +601,121 @@ func TestLoadBalancerCrossZone_userInputErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadBalancerAccessLogs_enableAccessLogs(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("load_balancer", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		GetLoadBalancer("id").
+		Return(&models.LoadBalancer{}, nil)
+
+	accessLogs := models.AccessLogs{
+		Enabled:        true,
+		S3BucketName:   "bucket",
+		S3BucketPrefix: "prefix",
+		EmitInterval:   5,
+	}
+
+	tc.Client.EXPECT().
+		UpdateLoadBalancerAccessLogs("id", accessLogs).
+		Return(&models.LoadBalancer{}, nil)
+
+	flags := map[string]interface{}{
+		"enable":   true,
+		"bucket":   "bucket",
+		"prefix":   "prefix",
+		"interval": 5,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.AccessLogs(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadBalancerAccessLogs_disableAccessLogs(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("load_balancer", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		GetLoadBalancer("id").
+		Return(&models.LoadBalancer{}, nil)
+
+	tc.Client.EXPECT().
+		UpdateLoadBalancerAccessLogs("id", models.AccessLogs{Enabled: false}).
+		Return(&models.LoadBalancer{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"disable": true})
+	if err := command.AccessLogs(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadBalancerAccessLogs_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg": testutils.GetCLIContext(t, nil, nil),
+		"Both '--enable' and '--disable' flags passed": testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"enable": true, "disable": true}),
+		"Missing '--bucket' flag":                      testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"enable": true}),
+	}
+
+	for name, c := range contexts {
+		if err := command.AccessLogs(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestLoadBalancerConnectionDraining_enableConnectionDraining(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("load_balancer", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		GetLoadBalancer("id").
+		Return(&models.LoadBalancer{}, nil)
+
+	tc.Client.EXPECT().
+		UpdateLoadBalancerConnectionDraining("id", models.ConnectionDraining{Enabled: true, Timeout: 120}).
+		Return(&models.LoadBalancer{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"enable": true, "drain-timeout": 120})
+	if err := command.ConnectionDraining(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadBalancerConnectionDraining_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg": testutils.GetCLIContext(t, nil, nil),
+		"Both '--enable' and '--disable' flags passed": testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"enable": true, "disable": true}),
+	}
+
+	for name, c := range contexts {
+		if err := command.ConnectionDraining(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
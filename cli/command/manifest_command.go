@@ -0,0 +1,116 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/quintilesims/layer0/cli/manifest"
+	"github.com/urfave/cli"
+)
+
+var pruneFlag = cli.BoolFlag{
+	Name:  "prune",
+	Usage: "delete the services and load balancers in the environment that the manifest does not declare",
+}
+
+type PlanCommand struct {
+	*Command
+}
+
+func NewPlanCommand(command *Command) *PlanCommand {
+	return &PlanCommand{command}
+}
+
+func (p *PlanCommand) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "plan",
+		Usage:     "show the changes needed to converge layer0 to an application manifest",
+		Action:    wrapAction(p.Command, p.Plan),
+		ArgsUsage: "MANIFEST",
+		Flags:     []cli.Flag{pruneFlag},
+	}
+}
+
+func (p *PlanCommand) Plan(c *cli.Context) error {
+	plan, err := p.loadPlan(c)
+	if err != nil {
+		return err
+	}
+
+	return p.Printer.PrintPlan(plan)
+}
+
+type ApplyCommand struct {
+	*Command
+}
+
+func NewApplyCommand(command *Command) *ApplyCommand {
+	return &ApplyCommand{command}
+}
+
+func (a *ApplyCommand) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "apply",
+		Usage:     "create, update and (with --prune) delete entities to converge layer0 to an application manifest",
+		Action:    wrapAction(a.Command, a.Apply),
+		ArgsUsage: "MANIFEST",
+		Flags:     []cli.Flag{pruneFlag},
+	}
+}
+
+func (a *ApplyCommand) Apply(c *cli.Context) error {
+	plan, err := a.loadPlan(c)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Printer.PrintPlan(plan); err != nil {
+		return err
+	}
+
+	if len(plan.Actions) == 0 {
+		return nil
+	}
+
+	if deletes := plan.Count(manifest.ActionDelete); deletes > 0 {
+		if err := a.confirm("Apply the plan, deleting %d entities from environment '%s'?", deletes, plan.Environment); err != nil {
+			return err
+		}
+	}
+
+	timeout, err := getTimeout(c)
+	if err != nil {
+		return err
+	}
+
+	onAction := func(action *manifest.Action) {
+		a.Printer.StopSpinner()
+		a.Printer.StartSpinner(fmt.Sprintf("Running %s %s %s", action.Type, action.EntityType, action.Name))
+	}
+
+	err = plan.Apply(a.Client, timeout, onAction)
+	a.Printer.StopSpinner()
+	if err != nil {
+		return err
+	}
+
+	a.Printer.Printf("Apply complete: %d created, %d updated, %d deleted\n",
+		plan.Count(manifest.ActionCreate),
+		plan.Count(manifest.ActionUpdate),
+		plan.Count(manifest.ActionDelete))
+
+	return nil
+}
+
+func (cm *Command) loadPlan(c *cli.Context) (*manifest.Plan, error) {
+	args, err := extractArgs(c.Args(), "MANIFEST")
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := manifest.Load(args["MANIFEST"])
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest.NewPlan(cm.Client, m, c.Bool("prune"))
+}
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/urfave/cli"
+)
+
+func TestPlan(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewPlanCommand(tc.Command())
+
+	file, close := tempFile(t, "environment:\n  name: prod\n")
+	defer close()
+
+	tc.Client.EXPECT().
+		ListEnvironments().
+		Return([]*models.EnvironmentSummary{}, nil)
+
+	tc.Client.EXPECT().
+		ListDeploys().
+		Return([]*models.DeploySummary{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{file.Name()}, nil)
+	if err := command.Plan(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApply_notConfirmed(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+
+	cmd := tc.Command()
+	cmd.Confirm = func(string) (bool, error) { return false, nil }
+	command := NewApplyCommand(cmd)
+
+	file, close := tempFile(t, "environment:\n  name: prod\n")
+	defer close()
+
+	tc.Client.EXPECT().
+		ListEnvironments().
+		Return([]*models.EnvironmentSummary{{EnvironmentID: "eid", EnvironmentName: "prod"}}, nil)
+
+	tc.Client.EXPECT().
+		GetEnvironment("eid").
+		Return(&models.Environment{InstanceSize: "m3.medium", OperatingSystem: "linux"}, nil)
+
+	tc.Client.EXPECT().
+		ListLoadBalancers().
+		Return([]*models.LoadBalancerSummary{}, nil)
+
+	tc.Client.EXPECT().
+		ListServices().
+		Return([]*models.ServiceSummary{{ServiceID: "sid", ServiceName: "old", EnvironmentID: "eid"}}, nil)
+
+	tc.Client.EXPECT().
+		ListDeploys().
+		Return([]*models.DeploySummary{}, nil)
+
+	flags := map[string]interface{}{"prune": true}
+	c := testutils.GetCLIContext(t, []string{file.Name()}, flags)
+	if err := command.Apply(c); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
+
+func TestApply_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewApplyCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing MANIFEST arg": testutils.GetCLIContext(t, nil, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.Apply(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
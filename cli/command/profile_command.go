@@ -0,0 +1,182 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/urfave/cli"
+)
+
+type ProfileCommand struct {
+	*Command
+	ConfigPath string
+}
+
+func NewProfileCommand(command *Command, configPath string) *ProfileCommand {
+	return &ProfileCommand{
+		Command:    command,
+		ConfigPath: configPath,
+	}
+}
+
+func (p *ProfileCommand) GetCommand() cli.Command {
+	return cli.Command{
+		Name:  "profile",
+		Usage: "manage the profiles used to connect to layer0 instances",
+		Subcommands: []cli.Command{
+			{
+				Name:      "add",
+				Usage:     "add a profile, or replace an existing profile with the same name",
+				Action:    wrapAction(p.Command, p.Add),
+				ArgsUsage: "NAME",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "endpoint",
+						Usage: "the layer0 api endpoint",
+					},
+					cli.StringFlag{
+						Name:  "token",
+						Usage: "the layer0 auth token",
+					},
+					cli.BoolFlag{
+						Name:  "skip-ssl-verify",
+						Usage: "do not verify the api's ssl certificate",
+					},
+					cli.BoolFlag{
+						Name:  "skip-version-verify",
+						Usage: "do not verify the cli and api versions match",
+					},
+					cli.BoolFlag{
+						Name:  "confirm",
+						Usage: "prompt for confirmation before running destructive commands",
+					},
+					cli.BoolFlag{
+						Name:  "use",
+						Usage: "make this the current profile",
+					},
+				},
+			},
+			{
+				Name:      "list",
+				Usage:     "list all profiles",
+				Action:    wrapAction(p.Command, p.List),
+				ArgsUsage: " ",
+			},
+			{
+				Name:      "use",
+				Usage:     "set the profile used when --profile is not specified",
+				Action:    wrapAction(p.Command, p.Use),
+				ArgsUsage: "NAME",
+			},
+		},
+	}
+}
+
+func (p *ProfileCommand) Add(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	if c.String("endpoint") == "" {
+		return NewUsageError("Flag --endpoint is required")
+	}
+
+	if c.String("token") == "" {
+		return NewUsageError("Flag --token is required")
+	}
+
+	profileConfig, err := config.LoadProfileConfig(p.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	profile := &config.Profile{
+		Confirm:           c.Bool("confirm"),
+		Endpoint:          c.String("endpoint"),
+		Name:              args["NAME"],
+		SkipSSLVerify:     c.Bool("skip-ssl-verify"),
+		SkipVersionVerify: c.Bool("skip-version-verify"),
+		Token:             c.String("token"),
+	}
+
+	profileConfig.SetProfile(profile)
+	if c.Bool("use") {
+		profileConfig.CurrentProfile = profile.Name
+	}
+
+	if err := profileConfig.Save(p.ConfigPath); err != nil {
+		return err
+	}
+
+	return p.Printer.PrintProfiles(profileConfig.CurrentProfile, redactProfiles(profile)...)
+}
+
+func (p *ProfileCommand) List(c *cli.Context) error {
+	profileConfig, err := config.LoadProfileConfig(p.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	return p.Printer.PrintProfiles(profileConfig.CurrentProfile, redactProfiles(profileConfig.Profiles...)...)
+}
+
+func (p *ProfileCommand) Use(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	profileConfig, err := config.LoadProfileConfig(p.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	profile, ok := profileConfig.Profile(args["NAME"])
+	if !ok {
+		return fmt.Errorf("Profile '%s' does not exist. Run `l0 profile add %s` to create it", args["NAME"], args["NAME"])
+	}
+
+	profileConfig.CurrentProfile = profile.Name
+	if err := profileConfig.Save(p.ConfigPath); err != nil {
+		return err
+	}
+
+	return p.Printer.PrintProfiles(profileConfig.CurrentProfile, redactProfiles(profile)...)
+}
+
+// redactProfiles returns copies of the profiles without their auth tokens so they are never printed
+func redactProfiles(profiles ...*config.Profile) []*config.Profile {
+	redacted := make([]*config.Profile, len(profiles))
+	for i, profile := range profiles {
+		copy := *profile
+		copy.Token = ""
+		redacted[i] = &copy
+	}
+
+	return redacted
+}
+
+// NewPromptConfirm returns a ConfirmFunc that asks for confirmation on out and reads the answer from in
+func NewPromptConfirm(profileName string, in io.Reader, out io.Writer) ConfirmFunc {
+	reader := bufio.NewReader(in)
+
+	return func(message string) (bool, error) {
+		fmt.Fprintf(out, "[profile: %s] %s [y/N]: ", profileName, message)
+
+		answer, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return false, err
+		}
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true, nil
+		default:
+			return false, nil
+		}
+	}
+}
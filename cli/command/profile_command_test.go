@@ -0,0 +1,144 @@
+package command
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/urfave/cli"
+)
+
+func tempProfileConfigPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Join(dir, "config"), func() { os.RemoveAll(dir) }
+}
+
+func TestAddProfile(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+
+	path, cleanup := tempProfileConfigPath(t)
+	defer cleanup()
+
+	command := NewProfileCommand(tc.Command(), path)
+
+	flags := map[string]interface{}{
+		"endpoint": "https://prod",
+		"token":    "token",
+		"confirm":  true,
+		"use":      true,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"prod"}, flags)
+	if err := command.Add(c); err != nil {
+		t.Fatal(err)
+	}
+
+	profileConfig, err := config.LoadProfileConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &config.Profile{
+		Confirm:  true,
+		Endpoint: "https://prod",
+		Name:     "prod",
+		Token:    "token",
+	}
+
+	testutils.AssertEqual(t, profileConfig.CurrentProfile, "prod")
+	testutils.AssertEqual(t, profileConfig.Profiles, []*config.Profile{expected})
+}
+
+func TestAddProfile_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+
+	path, cleanup := tempProfileConfigPath(t)
+	defer cleanup()
+
+	command := NewProfileCommand(tc.Command(), path)
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg":   testutils.GetCLIContext(t, nil, map[string]interface{}{"endpoint": "e", "token": "t"}),
+		"Missing --endpoint": testutils.GetCLIContext(t, []string{"prod"}, map[string]interface{}{"token": "t"}),
+		"Missing --token":    testutils.GetCLIContext(t, []string{"prod"}, map[string]interface{}{"endpoint": "e"}),
+	}
+
+	for name, c := range contexts {
+		if err := command.Add(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestUseProfile(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+
+	path, cleanup := tempProfileConfigPath(t)
+	defer cleanup()
+
+	profileConfig := &config.ProfileConfig{
+		CurrentProfile: "dev",
+		Profiles: []*config.Profile{
+			{Name: "dev"},
+			{Name: "prod"},
+		},
+	}
+
+	if err := profileConfig.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	command := NewProfileCommand(tc.Command(), path)
+
+	c := testutils.GetCLIContext(t, []string{"prod"}, nil)
+	if err := command.Use(c); err != nil {
+		t.Fatal(err)
+	}
+
+	profileConfig, err := config.LoadProfileConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, profileConfig.CurrentProfile, "prod")
+
+	c = testutils.GetCLIContext(t, []string{"staging"}, nil)
+	if err := command.Use(c); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
+
+func TestPromptConfirm(t *testing.T) {
+	cases := map[string]bool{
+		"y\n":   true,
+		"YES\n": true,
+		"n\n":   false,
+		"\n":    false,
+		"":      false,
+	}
+
+	for input, expected := range cases {
+		var out bytes.Buffer
+		confirm := NewPromptConfirm("prod", strings.NewReader(input), &out)
+
+		ok, err := confirm("Delete service 'api'?")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertEqual(t, ok, expected)
+		testutils.AssertEqual(t, out.String(), "[profile: prod] Delete service 'api'? [y/N]: ")
+	}
+}
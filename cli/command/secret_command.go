@@ -0,0 +1,129 @@
+package command
+
+import (
+	"io/ioutil"
+
+	"github.com/urfave/cli"
+)
+
+type SecretCommand struct {
+	*Command
+}
+
+func NewSecretCommand(command *Command) *SecretCommand {
+	return &SecretCommand{command}
+}
+
+func (s *SecretCommand) GetCommand() cli.Command {
+	return cli.Command{
+		Name:  "secret",
+		Usage: "manage layer0 secrets",
+		Subcommands: []cli.Command{
+			{
+				Name:      "create",
+				Usage:     "create or replace a secret in an environment",
+				Action:    wrapAction(s.Command, s.Create),
+				ArgsUsage: "ENVIRONMENT NAME [VALUE]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file",
+						Usage: "path to a file containing the secret value (used instead of VALUE)",
+					},
+				},
+			},
+			{
+				Name:      "delete",
+				Usage:     "delete a secret from an environment",
+				Action:    wrapAction(s.Command, s.Delete),
+				ArgsUsage: "ENVIRONMENT NAME",
+			},
+			{
+				Name:      "list",
+				Usage:     "list the secrets in an environment",
+				Action:    wrapAction(s.Command, s.List),
+				ArgsUsage: "ENVIRONMENT",
+			},
+		},
+	}
+}
+
+func (s *SecretCommand) Create(c *cli.Context) error {
+	var value string
+	var args map[string]string
+	if path := c.String("file"); path != "" {
+		a, err := extractArgs(c.Args(), "ENVIRONMENT", "NAME")
+		if err != nil {
+			return err
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		args = a
+		value = string(content)
+	} else {
+		a, err := extractArgs(c.Args(), "ENVIRONMENT", "NAME", "VALUE")
+		if err != nil {
+			return err
+		}
+
+		args = a
+		value = args["VALUE"]
+	}
+
+	environmentID, err := s.resolveSingleID("environment", args["ENVIRONMENT"])
+	if err != nil {
+		return err
+	}
+
+	secret, err := s.Client.CreateSecret(environmentID, args["NAME"], value)
+	if err != nil {
+		return err
+	}
+
+	return s.Printer.PrintSecrets(secret)
+}
+
+func (s *SecretCommand) Delete(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "ENVIRONMENT", "NAME")
+	if err != nil {
+		return err
+	}
+
+	environmentID, err := s.resolveSingleID("environment", args["ENVIRONMENT"])
+	if err != nil {
+		return err
+	}
+
+	if err := s.confirm("Delete secret '%s' in environment '%s'?", args["NAME"], args["ENVIRONMENT"]); err != nil {
+		return err
+	}
+
+	if err := s.Client.DeleteSecret(environmentID, args["NAME"]); err != nil {
+		return err
+	}
+
+	s.Printer.Printf("Secret successfully deleted\n")
+	return nil
+}
+
+func (s *SecretCommand) List(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "ENVIRONMENT")
+	if err != nil {
+		return err
+	}
+
+	environmentID, err := s.resolveSingleID("environment", args["ENVIRONMENT"])
+	if err != nil {
+		return err
+	}
+
+	secrets, err := s.Client.ListSecrets(environmentID)
+	if err != nil {
+		return err
+	}
+
+	return s.Printer.PrintSecrets(secrets...)
+}
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/urfave/cli"
+)
+
+func TestCreateSecret(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewSecretCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "environment").
+		Return([]string{"environmentID"}, nil)
+
+	tc.Client.EXPECT().
+		CreateSecret("environmentID", "name", "value").
+		Return(&models.Secret{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"environment", "name", "value"}, nil)
+	if err := command.Create(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateSecret_file(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewSecretCommand(tc.Command())
+
+	file, close := tempFile(t, "value")
+	defer close()
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "environment").
+		Return([]string{"environmentID"}, nil)
+
+	tc.Client.EXPECT().
+		CreateSecret("environmentID", "name", "value").
+		Return(&models.Secret{}, nil)
+
+	flags := map[string]interface{}{
+		"file": file.Name(),
+	}
+
+	c := testutils.GetCLIContext(t, []string{"environment", "name"}, flags)
+	if err := command.Create(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateSecret_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewSecretCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing ENVIRONMENT arg": testutils.GetCLIContext(t, nil, nil),
+		"Missing NAME arg":        testutils.GetCLIContext(t, []string{"environment"}, nil),
+		"Missing VALUE arg":       testutils.GetCLIContext(t, []string{"environment", "name"}, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.Create(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestDeleteSecret(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewSecretCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "environment").
+		Return([]string{"environmentID"}, nil)
+
+	tc.Client.EXPECT().
+		DeleteSecret("environmentID", "name").
+		Return(nil)
+
+	c := testutils.GetCLIContext(t, []string{"environment", "name"}, nil)
+	if err := command.Delete(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListSecrets(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewSecretCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "environment").
+		Return([]string{"environmentID"}, nil)
+
+	tc.Client.EXPECT().
+		ListSecrets("environmentID").
+		Return([]*models.Secret{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"environment"}, nil)
+	if err := command.List(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListSecrets_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewSecretCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing ENVIRONMENT arg": testutils.GetCLIContext(t, nil, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.List(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
@@ -30,10 +30,26 @@ func (s *ServiceCommand) GetCommand() cli.Command {
 						Name:  "loadbalancer",
 						Usage: "attach the service to the specified load balancer",
 					},
+					cli.StringFlag{
+						Name:  "host-header",
+						Usage: "only route requests with this host header to the service (application load balancers only)",
+					},
+					cli.StringFlag{
+						Name:  "path-pattern",
+						Usage: "only route requests matching this path pattern, e.g. '/api/*', to the service (application load balancers only)",
+					},
+					cli.IntFlag{
+						Name:  "priority",
+						Usage: "priority of the service's routing rule, lower values are evaluated first (application load balancers only)",
+					},
 					cli.BoolFlag{
 						Name:  "wait",
 						Usage: "wait until deployment completes before returning",
 					},
+					cli.BoolFlag{
+						Name:  "healthy",
+						Usage: "when used with --wait, also wait until the load balancer reports each instance as InService",
+					},
 				},
 			},
 			{
@@ -41,12 +57,19 @@ func (s *ServiceCommand) GetCommand() cli.Command {
 				Usage:     "delete a Service",
 				ArgsUsage: "NAME",
 				Action:    wrapAction(s.Command, s.Delete),
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.BoolFlag{
 						Name:  "wait",
 						Usage: "wait for the job to complete before returning",
 					},
-				},
+				}, bulkDeleteFlags...),
+			},
+			{
+				Name:      "exec",
+				Usage:     "run a command in a container of one of the service's running tasks",
+				Action:    wrapAction(s.Command, s.Exec),
+				ArgsUsage: "NAME [CONTAINER] -- COMMAND...",
+				Flags:     execFlags,
 			},
 			{
 				Name:      "update",
@@ -58,6 +81,10 @@ func (s *ServiceCommand) GetCommand() cli.Command {
 						Name:  "wait",
 						Usage: "wait until the deployment completes before returning",
 					},
+					cli.BoolFlag{
+						Name:  "healthy",
+						Usage: "when used with --wait, also wait until the load balancer reports each instance as InService",
+					},
 				},
 			},
 			{
@@ -65,12 +92,15 @@ func (s *ServiceCommand) GetCommand() cli.Command {
 				Usage:     "describe a service",
 				Action:    wrapAction(s.Command, s.Get),
 				ArgsUsage: "NAME",
+				Flags:     watchFlags,
 			},
+			s.labelCommand("service"),
 			{
 				Name:      "list",
 				Usage:     "list all services",
 				Action:    wrapAction(s.Command, s.List),
 				ArgsUsage: " ",
+				Flags:     append([]cli.Flag{labelFlag}, watchFlags...),
 			},
 			{
 				Name:      "logs",
@@ -102,6 +132,10 @@ func (s *ServiceCommand) GetCommand() cli.Command {
 						Name:  "wait",
 						Usage: "wait until the deployment completes before returning",
 					},
+					cli.BoolFlag{
+						Name:  "healthy",
+						Usage: "when used with --wait, also wait until the load balancer reports each instance as InService",
+					},
 				},
 			},
 		},
@@ -114,6 +148,17 @@ func (s *ServiceCommand) Create(c *cli.Context) error {
 		return err
 	}
 
+	loadBalancerName := c.String("loadbalancer")
+	loadBalancerRule := models.LoadBalancerRule{
+		HostHeader:  c.String("host-header"),
+		PathPattern: c.String("path-pattern"),
+		Priority:    c.Int("priority"),
+	}
+
+	if loadBalancerName == "" && loadBalancerRule != (models.LoadBalancerRule{}) {
+		return NewUsageError("The 'host-header', 'path-pattern' and 'priority' flags require a load balancer")
+	}
+
 	environmentID, err := s.resolveSingleID("environment", args["ENVIRONMENT"])
 	if err != nil {
 		return err
@@ -125,7 +170,7 @@ func (s *ServiceCommand) Create(c *cli.Context) error {
 	}
 
 	var loadBalancerID string
-	if loadBalancerName := c.String("loadbalancer"); loadBalancerName != "" {
+	if loadBalancerName != "" {
 		id, err := s.resolveSingleID("load_balancer", loadBalancerName)
 		if err != nil {
 			return err
@@ -134,7 +179,7 @@ func (s *ServiceCommand) Create(c *cli.Context) error {
 		loadBalancerID = id
 	}
 
-	service, err := s.Client.CreateService(args["NAME"], environmentID, deployID, loadBalancerID)
+	service, err := s.Client.CreateService(args["NAME"], environmentID, deployID, loadBalancerID, loadBalancerRule)
 	if err != nil {
 		return err
 	}
@@ -149,7 +194,7 @@ func (s *ServiceCommand) Create(c *cli.Context) error {
 	}
 
 	s.Printer.StartSpinner("Waiting for Deployment")
-	service, err = s.Client.WaitForDeployment(service.ServiceID, timeout)
+	service, err = s.Client.WaitForDeployment(service.ServiceID, timeout, c.Bool("healthy"))
 	if err != nil {
 		return err
 	}
@@ -161,6 +206,10 @@ func (s *ServiceCommand) Delete(c *cli.Context) error {
 	return s.deleteWithJob(c, "service", s.Client.DeleteService)
 }
 
+func (s *ServiceCommand) Exec(c *cli.Context) error {
+	return s.exec(c, "service", s.Client.ExecService)
+}
+
 func (s *ServiceCommand) Update(c *cli.Context) error {
 	args, err := extractArgs(c.Args(), "NAME", "DEPLOY")
 	if err != nil {
@@ -192,7 +241,7 @@ func (s *ServiceCommand) Update(c *cli.Context) error {
 	}
 
 	s.Printer.StartSpinner("Waiting for Deployment")
-	service, err = s.Client.WaitForDeployment(serviceID, timeout)
+	service, err = s.Client.WaitForDeployment(serviceID, timeout, c.Bool("healthy"))
 	if err != nil {
 		return err
 	}
@@ -201,31 +250,51 @@ func (s *ServiceCommand) Update(c *cli.Context) error {
 }
 
 func (s *ServiceCommand) Get(c *cli.Context) error {
-	services := []*models.Service{}
-	getServicef := func(id string) error {
-		service, err := s.Client.GetService(id)
-		if err != nil {
-			return err
+	return s.watch(c, "service", func() (interface{}, error) {
+		services := []*models.Service{}
+		getServicef := func(id string) error {
+			service, err := s.Client.GetService(id)
+			if err != nil {
+				return err
+			}
+
+			services = append(services, service)
+			return nil
 		}
 
-		services = append(services, service)
-		return nil
-	}
-
-	if err := s.get(c, "service", getServicef); err != nil {
-		return err
-	}
+		if err := s.get(c, "service", getServicef); err != nil {
+			return nil, err
+		}
 
-	return s.Printer.PrintServices(services...)
+		return services, s.Printer.PrintServices(services...)
+	})
 }
 
 func (s *ServiceCommand) List(c *cli.Context) error {
-	serviceSummaries, err := s.Client.ListServices()
-	if err != nil {
-		return err
-	}
+	return s.watch(c, "service", func() (interface{}, error) {
+		serviceSummaries, err := s.Client.ListServices()
+		if err != nil {
+			return nil, err
+		}
+
+		ids, err := s.selectByLabels(c, "service")
+		if err != nil {
+			return nil, err
+		}
+
+		if ids != nil {
+			filtered := []*models.ServiceSummary{}
+			for _, summary := range serviceSummaries {
+				if ids[summary.ServiceID] {
+					filtered = append(filtered, summary)
+				}
+			}
+
+			serviceSummaries = filtered
+		}
 
-	return s.Printer.PrintServiceSummaries(serviceSummaries...)
+		return serviceSummaries, s.Printer.PrintServiceSummaries(serviceSummaries...)
+	})
 }
 
 func (s *ServiceCommand) Logs(c *cli.Context) error {
@@ -278,7 +347,7 @@ func (s *ServiceCommand) Scale(c *cli.Context) error {
 	}
 
 	s.Printer.StartSpinner("Waiting for Deployment")
-	service, err = s.Client.WaitForDeployment(id, timeout)
+	service, err = s.Client.WaitForDeployment(id, timeout, c.Bool("healthy"))
 	if err != nil {
 		return err
 	}
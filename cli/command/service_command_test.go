@@ -3,6 +3,7 @@ package command
 import (
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 	"github.com/urfave/cli"
@@ -26,7 +27,7 @@ func TestCreateService(t *testing.T) {
 		Return([]string{"loadBalancerID"}, nil)
 
 	tc.Client.EXPECT().
-		CreateService("name", "environmentID", "deployID", "loadBalancerID").
+		CreateService("name", "environmentID", "deployID", "loadBalancerID", models.LoadBalancerRule{}).
 		Return(&models.Service{}, nil)
 
 	flags := map[string]interface{}{
@@ -39,6 +40,46 @@ func TestCreateService(t *testing.T) {
 	}
 }
 
+func TestCreateService_loadBalancerRule(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewServiceCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "environment").
+		Return([]string{"environmentID"}, nil)
+
+	tc.Resolver.EXPECT().
+		Resolve("deploy", "deploy").
+		Return([]string{"deployID"}, nil)
+
+	tc.Resolver.EXPECT().
+		Resolve("load_balancer", "load_balancer").
+		Return([]string{"loadBalancerID"}, nil)
+
+	rule := models.LoadBalancerRule{
+		HostHeader:  "api.example.com",
+		PathPattern: "/v1/*",
+		Priority:    10,
+	}
+
+	tc.Client.EXPECT().
+		CreateService("name", "environmentID", "deployID", "loadBalancerID", rule).
+		Return(&models.Service{}, nil)
+
+	flags := map[string]interface{}{
+		"loadbalancer": "load_balancer",
+		"host-header":  "api.example.com",
+		"path-pattern": "/v1/*",
+		"priority":     10,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"environment", "name", "deploy"}, flags)
+	if err := command.Create(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestCreateServiceWait(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
@@ -57,11 +98,11 @@ func TestCreateServiceWait(t *testing.T) {
 		Return([]string{"loadBalancerID"}, nil)
 
 	tc.Client.EXPECT().
-		CreateService("name", "environmentID", "deployID", "loadBalancerID").
+		CreateService("name", "environmentID", "deployID", "loadBalancerID", models.LoadBalancerRule{}).
 		Return(&models.Service{ServiceID: "serviceID"}, nil)
 
 	tc.Client.EXPECT().
-		WaitForDeployment("serviceID", testutils.TEST_TIMEOUT).
+		WaitForDeployment("serviceID", testutils.TEST_TIMEOUT, false).
 		Return(&models.Service{}, nil)
 
 	flags := map[string]interface{}{
@@ -81,9 +122,10 @@ func TestCreateService_userInputErrors(t *testing.T) {
 	command := NewServiceCommand(tc.Command())
 
 	contexts := map[string]*cli.Context{
-		"Missing ENVIRONMENT arg": testutils.GetCLIContext(t, nil, nil),
-		"Missing NAME arg":        testutils.GetCLIContext(t, []string{"environment"}, nil),
-		"Missing DEPLOY arg":      testutils.GetCLIContext(t, []string{"environment", "name"}, nil),
+		"Missing ENVIRONMENT arg":    testutils.GetCLIContext(t, nil, nil),
+		"Missing NAME arg":           testutils.GetCLIContext(t, []string{"environment"}, nil),
+		"Missing DEPLOY arg":         testutils.GetCLIContext(t, []string{"environment", "name"}, nil),
+		"Rule without load balancer": testutils.GetCLIContext(t, []string{"environment", "name", "deploy"}, map[string]interface{}{"path-pattern": "/v1/*"}),
 	}
 
 	for name, c := range contexts {
@@ -112,6 +154,47 @@ func TestDeleteService(t *testing.T) {
 	}
 }
 
+func TestDeleteService_notConfirmed(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+
+	cmd := tc.Command()
+	cmd.Confirm = func(message string) (bool, error) {
+		testutils.AssertEqual(t, message, "Delete service 'name' (id)?")
+		return false, nil
+	}
+
+	command := NewServiceCommand(cmd)
+
+	tc.Resolver.EXPECT().
+		Resolve("service", "name").
+		Return([]string{"id"}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, nil)
+	if err := command.Delete(c); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
+
+func TestExecService(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewServiceCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("service", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		ExecService("id", models.ExecRequest{Command: []string{"sh"}}, gomock.Any()).
+		Return(0, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name", "--", "sh"}, map[string]interface{}{"no-tty": true})
+	if err := command.Exec(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestDeleteServiceWait(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
@@ -192,7 +275,7 @@ func TestUpdateServiceWait(t *testing.T) {
 		Return(&models.Service{}, nil)
 
 	tc.Client.EXPECT().
-		WaitForDeployment("serviceID", testutils.TEST_TIMEOUT).
+		WaitForDeployment("serviceID", testutils.TEST_TIMEOUT, false).
 		Return(&models.Service{}, nil)
 
 	c := testutils.GetCLIContext(t, []string{"service", "deploy"}, map[string]interface{}{"wait": true})
@@ -341,7 +424,7 @@ func TestScaleServiceWait(t *testing.T) {
 		Return(&models.Service{}, nil)
 
 	tc.Client.EXPECT().
-		WaitForDeployment("id", testutils.TEST_TIMEOUT).
+		WaitForDeployment("id", testutils.TEST_TIMEOUT, false).
 		Return(&models.Service{}, nil)
 
 	c := testutils.GetCLIContext(t, []string{"name", "2"}, map[string]interface{}{"wait": true})
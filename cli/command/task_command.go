@@ -1,11 +1,15 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/shellutils"
 	"github.com/urfave/cli"
 )
 
@@ -37,6 +41,22 @@ func (t *TaskCommand) GetCommand() cli.Command {
 						Name:  "env",
 						Usage: "environment variable override in format 'CONTAINER:VAR=VAL' (can be specified multiple times)",
 					},
+					cli.StringSliceFlag{
+						Name:  "command",
+						Usage: "command override in format 'CONTAINER:COMMAND', where COMMAND is split into arguments like a shell would, or is a JSON array of arguments (can be specified multiple times)",
+					},
+					cli.StringSliceFlag{
+						Name:  "memory",
+						Usage: "memory limit override in MiB in format 'CONTAINER:MEMORY' (can be specified multiple times)",
+					},
+					cli.StringSliceFlag{
+						Name:  "memory-reservation",
+						Usage: "memory reservation override in MiB in format 'CONTAINER:MEMORY' (can be specified multiple times)",
+					},
+					cli.StringSliceFlag{
+						Name:  "cpu",
+						Usage: "cpu units override in format 'CONTAINER:UNITS' (can be specified multiple times)",
+					},
 					cli.BoolFlag{
 						Name:  "wait",
 						Usage: "wait for the job to complete before returning",
@@ -48,24 +68,40 @@ func (t *TaskCommand) GetCommand() cli.Command {
 				Usage:     "delete a task",
 				ArgsUsage: "NAME",
 				Action:    wrapAction(t.Command, t.Delete),
+				Flags:     bulkDeleteFlags,
+			},
+			{
+				Name:      "exec",
+				Usage:     "run a command in a container of a running task",
+				Action:    wrapAction(t.Command, t.Exec),
+				ArgsUsage: "NAME [CONTAINER] -- COMMAND...",
+				Flags:     execFlags,
 			},
 			{
 				Name:      "get",
 				Usage:     "describe a task",
 				Action:    wrapAction(t.Command, t.Get),
 				ArgsUsage: "NAME",
+				Flags: append([]cli.Flag{
+					cli.BoolFlag{
+						Name:  "details",
+						Usage: "show the status, exit code and stop reason of each container",
+					},
+				}, watchFlags...),
 			},
+			t.labelCommand("task"),
 			{
 				Name:      "list",
 				Usage:     "list all tasks",
 				Action:    wrapAction(t.Command, t.List),
 				ArgsUsage: " ",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.BoolFlag{
 						Name:  "all",
 						Usage: "included deleted tasks",
 					},
-				},
+					labelFlag,
+				}, watchFlags...),
 			},
 			{
 				Name:      "logs",
@@ -87,6 +123,12 @@ func (t *TaskCommand) GetCommand() cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "wait",
+				Usage:     "wait for all copies of a task to stop (exits with an error if any container failed)",
+				Action:    wrapAction(t.Command, t.Wait),
+				ArgsUsage: "NAME",
+			},
 		},
 	}
 }
@@ -97,7 +139,7 @@ func (t *TaskCommand) Create(c *cli.Context) error {
 		return err
 	}
 
-	overrides, err := parseOverrides(c.StringSlice("env"))
+	overrides, err := parseOverrides(c)
 	if err != nil {
 		return err
 	}
@@ -174,10 +216,20 @@ func (t *TaskCommand) Delete(c *cli.Context) error {
 	return t.delete(c, "task", t.Client.DeleteTask)
 }
 
+func (t *TaskCommand) Exec(c *cli.Context) error {
+	return t.exec(c, "task", t.Client.ExecTask)
+}
+
 func (t *TaskCommand) Get(c *cli.Context) error {
+	return t.watch(c, "task", func() (interface{}, error) {
+		return t.getTasks(c)
+	})
+}
+
+func (t *TaskCommand) getTasks(c *cli.Context) ([]*models.Task, error) {
 	taskSummaries, err := t.Client.ListTasks()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	taskExists := func(id string) bool {
@@ -207,23 +259,45 @@ func (t *TaskCommand) Get(c *cli.Context) error {
 	}
 
 	if err := t.get(c, "task", getTaskf); err != nil {
-		return err
+		return nil, err
 	}
 
-	return t.Printer.PrintTasks(tasks...)
+	if c.Bool("details") {
+		return tasks, t.Printer.PrintTaskDetails(tasks...)
+	}
+
+	return tasks, t.Printer.PrintTasks(tasks...)
 }
 
 func (t *TaskCommand) List(c *cli.Context) error {
-	taskSummaries, err := t.Client.ListTasks()
-	if err != nil {
-		return err
-	}
+	return t.watch(c, "task", func() (interface{}, error) {
+		taskSummaries, err := t.Client.ListTasks()
+		if err != nil {
+			return nil, err
+		}
 
-	if !c.Bool("all") {
-		taskSummaries = filterTaskSummaries(taskSummaries)
-	}
+		if !c.Bool("all") {
+			taskSummaries = filterTaskSummaries(taskSummaries)
+		}
+
+		ids, err := t.selectByLabels(c, "task")
+		if err != nil {
+			return nil, err
+		}
+
+		if ids != nil {
+			filtered := []*models.TaskSummary{}
+			for _, summary := range taskSummaries {
+				if ids[summary.TaskID] {
+					filtered = append(filtered, summary)
+				}
+			}
 
-	return t.Printer.PrintTaskSummaries(taskSummaries...)
+			taskSummaries = filtered
+		}
+
+		return taskSummaries, t.Printer.PrintTaskSummaries(taskSummaries...)
+	})
 }
 
 func (t *TaskCommand) Logs(c *cli.Context) error {
@@ -245,6 +319,54 @@ func (t *TaskCommand) Logs(c *cli.Context) error {
 	return t.Printer.PrintLogs(logs...)
 }
 
+func (t *TaskCommand) Wait(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	ids, err := t.Resolver.Resolve("task", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return noMatchesError("task", args["NAME"])
+	}
+
+	timeout, err := getTimeout(c)
+	if err != nil {
+		return err
+	}
+
+	t.Printer.StartSpinner("Waiting for Task")
+
+	tasks := make([]*models.Task, len(ids))
+	for i, id := range ids {
+		task, err := t.Client.WaitForTask(id, timeout)
+		if err != nil {
+			return err
+		}
+
+		tasks[i] = task
+	}
+
+	if err := t.Printer.PrintTaskDetails(tasks...); err != nil {
+		return err
+	}
+
+	failures := []string{}
+	for _, task := range tasks {
+		failures = append(failures, task.Failures()...)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d container(s) failed:\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+
+	return nil
+}
+
 func filterTaskSummaries(tasks []*models.TaskSummary) []*models.TaskSummary {
 	filtered := []*models.TaskSummary{}
 
@@ -257,10 +379,20 @@ func filterTaskSummaries(tasks []*models.TaskSummary) []*models.TaskSummary {
 	return filtered
 }
 
-func parseOverrides(overrides []string) ([]models.ContainerOverride, error) {
-	catalog := map[string]models.ContainerOverride{}
+func parseOverrides(c *cli.Context) ([]models.ContainerOverride, error) {
+	catalog := map[string]*models.ContainerOverride{}
+	getOverride := func(container string) *models.ContainerOverride {
+		if _, ok := catalog[container]; !ok {
+			catalog[container] = &models.ContainerOverride{
+				ContainerName:        container,
+				EnvironmentOverrides: map[string]string{},
+			}
+		}
+
+		return catalog[container]
+	}
 
-	for _, o := range overrides {
+	for _, o := range c.StringSlice("env") {
 		split := strings.FieldsFunc(o, func(r rune) bool {
 			return r == ':' || r == '='
 		})
@@ -273,21 +405,85 @@ func parseOverrides(overrides []string) ([]models.ContainerOverride, error) {
 		key := split[1]
 		val := split[2]
 
-		if _, ok := catalog[container]; !ok {
-			catalog[container] = models.ContainerOverride{
-				ContainerName:        container,
-				EnvironmentOverrides: map[string]string{},
+		getOverride(container).EnvironmentOverrides[key] = val
+	}
+
+	for _, o := range c.StringSlice("command") {
+		container, command, ok := splitContainerOverride(o)
+		if !ok {
+			return nil, NewUsageError("Command Override format is: CONTAINER:COMMAND")
+		}
+
+		args, err := splitCommand(command)
+		if err != nil || len(args) == 0 {
+			return nil, NewUsageError("Command Override format is: CONTAINER:COMMAND (%s is not a valid command)", command)
+		}
+
+		getOverride(container).Command = args
+	}
+
+	intOverrides := []struct {
+		Flag  string
+		Usage string
+		Set   func(*models.ContainerOverride, int64)
+	}{
+		{"memory", "Memory Override format is: CONTAINER:MEMORY", func(o *models.ContainerOverride, v int64) { o.Memory = v }},
+		{"memory-reservation", "Memory Reservation Override format is: CONTAINER:MEMORY", func(o *models.ContainerOverride, v int64) { o.MemoryReservation = v }},
+		{"cpu", "CPU Override format is: CONTAINER:UNITS", func(o *models.ContainerOverride, v int64) { o.CPU = v }},
+	}
+
+	for _, intOverride := range intOverrides {
+		for _, o := range c.StringSlice(intOverride.Flag) {
+			container, value, ok := splitContainerOverride(o)
+			if !ok {
+				return nil, NewUsageError("%s", intOverride.Usage)
 			}
+
+			v, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || v < 1 {
+				return nil, NewUsageError("%s (%s must be a positive integer)", intOverride.Usage, intOverride.Flag)
+			}
+
+			intOverride.Set(getOverride(container), v)
 		}
+	}
 
-		catalog[container].EnvironmentOverrides[key] = val
+	containers := []string{}
+	for container := range catalog {
+		containers = append(containers, container)
 	}
 
+	sort.Strings(containers)
+
 	models := []models.ContainerOverride{}
-	for _, override := range catalog {
-		models = append(models, override)
+	for _, container := range containers {
+		models = append(models, *catalog[container])
 	}
 
 	return models, nil
+}
+
+// splitCommand splits command into arguments. Commands that start with '[' are parsed as a JSON array
+// of arguments, e.g. '["sh", "-c", "echo $HOME"]'. Otherwise, they are split like in a shell by shellutils.Split.
+func splitCommand(command string) ([]string, error) {
+	if strings.HasPrefix(strings.TrimSpace(command), "[") {
+		var args []string
+		if err := json.Unmarshal([]byte(command), &args); err != nil {
+			return nil, err
+		}
+
+		return args, nil
+	}
+
+	return shellutils.Split(command)
+}
+
+// splitContainerOverride splits an override in format 'CONTAINER:VALUE'
+func splitContainerOverride(o string) (string, string, bool) {
+	split := strings.SplitN(o, ":", 2)
+	if len(split) != 2 || split[0] == "" || split[1] == "" {
+		return "", "", false
+	}
 
+	return split[0], split[1], true
 }
@@ -10,41 +10,78 @@ import (
 )
 
 func TestParseOverrides(t *testing.T) {
-	input := []string{
-		"container1:key1=val1",
-		"container1:key2=val2",
-		"container2:k1=v1"}
+	flags := map[string]interface{}{
+		"env": []string{
+			"container1:key1=val1",
+			"container1:key2=val2",
+			"container2:k1=v1",
+		},
+		"command":            []string{"container3:python manage.py migrate"},
+		"memory":             []string{"container1:1024"},
+		"memory-reservation": []string{"container1:512"},
+		"cpu":                []string{"container2:256"},
+	}
 
 	expected := []models.ContainerOverride{
 		{
 			ContainerName:        "container1",
 			EnvironmentOverrides: map[string]string{"key1": "val1", "key2": "val2"},
+			Memory:               1024,
+			MemoryReservation:    512,
 		},
 		{
 			ContainerName:        "container2",
+			CPU:                  256,
 			EnvironmentOverrides: map[string]string{"k1": "v1"},
 		},
+		{
+			Command:              []string{"python", "manage.py", "migrate"},
+			ContainerName:        "container3",
+			EnvironmentOverrides: map[string]string{},
+		},
 	}
 
-	output, err := parseOverrides(input)
+	output, err := parseOverrides(testutils.GetCLIContext(t, nil, flags))
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	testutils.AssertEqual(t, len(output), len(expected))
-	testutils.AssertInSlice(t, expected[0], output)
-	testutils.AssertInSlice(t, expected[1], output)
+	testutils.AssertEqual(t, output, expected)
+}
+
+func TestSplitCommand(t *testing.T) {
+	cases := map[string][]string{
+		`sh -c "echo hello  world"`:        {"sh", "-c", "echo hello  world"},
+		`["sh", "-c", "echo hello world"]`: {"sh", "-c", "echo hello world"},
+	}
+
+	for command, expected := range cases {
+		args, err := splitCommand(command)
+		if err != nil {
+			t.Fatalf("%s: %v", command, err)
+		}
+
+		testutils.AssertEqual(t, args, expected)
+	}
 }
 
 func TestParseOverridesErrors(t *testing.T) {
-	cases := map[string]string{
-		"Missing CONTAINER": ":key=val",
-		"Missing KEY":       "container:=val",
-		"Missing VAL":       "container:key=",
+	cases := map[string]map[string]interface{}{
+		"Missing CONTAINER":         {"env": []string{":key=val"}},
+		"Missing KEY":               {"env": []string{"container:=val"}},
+		"Missing VAL":               {"env": []string{"container:key="}},
+		"Missing COMMAND":           {"command": []string{"container:"}},
+		"Missing command CONTAINER": {"command": []string{":migrate"}},
+		"Unterminated quote":        {"command": []string{"container:sh -c 'echo"}},
+		"Invalid JSON command":      {"command": []string{"container:[\"sh\","}},
+		"Empty JSON command":        {"command": []string{"container:[]"}},
+		"Non-numeric MEMORY":        {"memory": []string{"container:1g"}},
+		"Negative MEMORY":           {"memory-reservation": []string{"container:-1"}},
+		"Missing UNITS":             {"cpu": []string{"container"}},
 	}
 
-	for name, input := range cases {
-		if _, err := parseOverrides([]string{input}); err == nil {
+	for name, flags := range cases {
+		if _, err := parseOverrides(testutils.GetCLIContext(t, nil, flags)); err == nil {
 			t.Fatalf("%s: error was nil!", name)
 		}
 	}
@@ -64,8 +101,10 @@ func TestCreateTask(t *testing.T) {
 		Return([]string{"deployID"}, nil)
 
 	overrides := []models.ContainerOverride{{
+		Command:              []string{"echo", "hello"},
 		ContainerName:        "container",
 		EnvironmentOverrides: map[string]string{"key": "val"},
+		Memory:               128,
 	}}
 
 	tc.Client.EXPECT().
@@ -73,8 +112,10 @@ func TestCreateTask(t *testing.T) {
 		Return("jobid", nil)
 
 	flags := map[string]interface{}{
-		"env":    []string{"container:key=val"},
-		"copies": 1,
+		"env":     []string{"container:key=val"},
+		"command": []string{"container:echo hello"},
+		"memory":  []string{"container:128"},
+		"copies":  1,
 	}
 
 	c := testutils.GetCLIContext(t, []string{"environment", "name", "deploy"}, flags)
@@ -194,6 +235,68 @@ func TestDeleteTask_userInputErrors(t *testing.T) {
 	}
 }
 
+func TestExecTask(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTaskCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("task", "name").
+		Return([]string{"id"}, nil)
+
+	req := models.ExecRequest{
+		Command:       []string{"ls", "-la"},
+		ContainerName: "container",
+	}
+
+	tc.Client.EXPECT().
+		ExecTask("id", req, gomock.Any()).
+		Return(0, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name", "container", "--", "ls", "-la"}, map[string]interface{}{"no-tty": true})
+	if err := command.Exec(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExecTask_nonZeroExitCode(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTaskCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("task", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		ExecTask("id", models.ExecRequest{Command: []string{"false"}}, gomock.Any()).
+		Return(1, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name", "--", "false"}, map[string]interface{}{"no-tty": true})
+	if err := command.Exec(c); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
+
+func TestExecTask_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTaskCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg":   testutils.GetCLIContext(t, []string{"--", "ls"}, nil),
+		"Missing '--'":       testutils.GetCLIContext(t, []string{"name", "ls"}, nil),
+		"Missing command":    testutils.GetCLIContext(t, []string{"name", "--"}, nil),
+		"Too many arguments": testutils.GetCLIContext(t, []string{"name", "container", "extra", "--", "ls"}, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.Exec(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
 func TestGetTask(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
@@ -337,3 +440,88 @@ func TestFilterTaskSummaries(t *testing.T) {
 	testutils.AssertInSlice(t, input[0], output)
 	testutils.AssertInSlice(t, input[1], output)
 }
+
+func TestWaitTask(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTaskCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("task", "name").
+		Return([]string{"id1", "id2"}, nil)
+
+	task := &models.Task{
+		Copies: []models.TaskCopy{
+			{
+				LastStatus: "STOPPED",
+				Details:    []models.TaskDetail{{ContainerName: "c1", LastStatus: "STOPPED"}},
+			},
+		},
+	}
+
+	tc.Client.EXPECT().
+		WaitForTask("id1", testutils.TEST_TIMEOUT).
+		Return(task, nil)
+
+	tc.Client.EXPECT().
+		WaitForTask("id2", testutils.TEST_TIMEOUT).
+		Return(task, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, nil)
+	if err := command.Wait(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitTask_containerFailed(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTaskCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("task", "name").
+		Return([]string{"id"}, nil)
+
+	task := &models.Task{
+		TaskID: "id",
+		Copies: []models.TaskCopy{
+			{
+				LastStatus: "STOPPED",
+				Details: []models.TaskDetail{
+					{ContainerName: "c1", LastStatus: "STOPPED"},
+					{ContainerName: "c2", LastStatus: "STOPPED", ExitCode: 1},
+				},
+			},
+		},
+	}
+
+	tc.Client.EXPECT().
+		WaitForTask("id", testutils.TEST_TIMEOUT).
+		Return(task, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, nil)
+	if err := command.Wait(c); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
+
+func TestWaitTask_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTaskCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("task", "name").
+		Return([]string{}, nil)
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg": testutils.GetCLIContext(t, nil, nil),
+		"No matches":       testutils.GetCLIContext(t, []string{"name"}, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.Wait(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
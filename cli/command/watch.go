@@ -0,0 +1,349 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+)
+
+// the number of recent changes shown beneath a watched table
+const maxWatchChanges = 10
+
+// watchFlags are added to the get and list commands of entities that change over time
+var watchFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "watch",
+		Usage: "redraw the output on an interval, showing what changed, until interrupted",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Value: time.Second * 5,
+		Usage: "how often to refresh the output when watching",
+	},
+	cli.StringFlag{
+		Name:  "until",
+		Usage: "watch until the condition is true for every entity, e.g. 'running==desired' or 'status==completed'",
+	},
+}
+
+// watch calls render once, or on an interval if the 'watch' or 'until' flags are specified.
+// render should fetch and print the entities, then return them so the changes between calls can be shown
+// and the 'until' condition evaluated against their fields.
+func (cm *Command) watch(c *cli.Context, entityType string, render func() (interface{}, error)) error {
+	until := c.String("until")
+	if !c.Bool("watch") && until == "" {
+		_, err := render()
+		return err
+	}
+
+	var cond *condition
+	if until != "" {
+		parsed, err := parseCondition(until)
+		if err != nil {
+			return NewUsageError("Invalid --until condition: %v", err)
+		}
+
+		cond = parsed
+	}
+
+	interval := c.Duration("interval")
+	if interval <= 0 {
+		return NewUsageError("--interval must be greater than 0")
+	}
+
+	var previous map[string]map[string]string
+	changes := []string{}
+	for {
+		cm.Printer.ClearScreen()
+
+		entities, err := render()
+		if err != nil {
+			return err
+		}
+
+		current, err := flattenEntities(entityType, entities)
+		if err != nil {
+			return err
+		}
+
+		if previous != nil {
+			changes = append(changes, diffEntities(entityType, previous, current, time.Now())...)
+			if len(changes) > maxWatchChanges {
+				changes = changes[len(changes)-maxWatchChanges:]
+			}
+		}
+
+		previous = current
+
+		if len(changes) > 0 {
+			cm.Printer.Printf("\nRecent changes:\n%s\n", strings.Join(changes, "\n"))
+		}
+
+		if cond != nil {
+			done, err := cond.matchAll(current)
+			if err != nil {
+				return err
+			}
+
+			if done {
+				return nil
+			}
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// flattenEntities returns the fields of each entity keyed by the entity's id, since names are not unique.
+// Entities without an id are keyed by their position. Nested fields are joined with a '.', e.g. 'deployments.0.status'.
+func flattenEntities(entityType string, entities interface{}) (map[string]map[string]string, error) {
+	data, err := json.Marshal(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	flattened := map[string]map[string]string{}
+	for i, value := range values {
+		fields := map[string]string{}
+		flattenValue("", value, fields)
+
+		key := fields[entityType+"_id"]
+		if key == "" {
+			key = fmt.Sprintf("#%d", i)
+		}
+
+		flattened[key] = fields
+	}
+
+	return flattened, nil
+}
+
+func flattenValue(prefix string, value interface{}, fields map[string]string) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+
+		return fmt.Sprintf("%s.%s", prefix, key)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			flattenValue(join(key), val, fields)
+		}
+	case []interface{}:
+		for i, val := range v {
+			flattenValue(join(strconv.Itoa(i)), val, fields)
+		}
+	case float64:
+		fields[prefix] = strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		fields[prefix] = ""
+	default:
+		fields[prefix] = fmt.Sprintf("%v", v)
+	}
+}
+
+// entityLabel returns the name and id of an entity for display, or just its key if it has no name
+func entityLabel(entityType, key string, fields map[string]string) string {
+	if name := fields[entityType+"_name"]; name != "" {
+		return fmt.Sprintf("%s (%s)", name, key)
+	}
+
+	return key
+}
+
+// diffEntities describes each field that changed between previous and current
+func diffEntities(entityType string, previous, current map[string]map[string]string, now time.Time) []string {
+	timestamp := now.Format("15:04:05")
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+
+		return s
+	}
+
+	changes := []string{}
+	for _, key := range sortedFieldKeys(current) {
+		after := current[key]
+		label := entityLabel(entityType, key, after)
+
+		before, ok := previous[key]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s %s: added", timestamp, label))
+			continue
+		}
+
+		fieldNames := map[string]string{}
+		for field := range before {
+			fieldNames[field] = ""
+		}
+
+		for field := range after {
+			fieldNames[field] = ""
+		}
+
+		for _, field := range sortedKeys(fieldNames) {
+			if before[field] != after[field] {
+				changes = append(changes, fmt.Sprintf("%s %s: %s %s -> %s", timestamp, label, field, orDash(before[field]), orDash(after[field])))
+			}
+		}
+	}
+
+	for _, key := range sortedFieldKeys(previous) {
+		if _, ok := current[key]; !ok {
+			changes = append(changes, fmt.Sprintf("%s %s: removed", timestamp, entityLabel(entityType, key, previous[key])))
+		}
+	}
+
+	return changes
+}
+
+func sortedFieldKeys(m map[string]map[string]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// the order matters: two character operators must be matched before their one character prefixes
+var conditionOperators = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// condition compares a field of an entity with another field or a value, e.g. 'running==desired'
+type condition struct {
+	Left     string
+	Operator string
+	Right    string
+}
+
+func parseCondition(expression string) (*condition, error) {
+	for _, operator := range conditionOperators {
+		i := strings.Index(expression, operator)
+		if i < 0 {
+			continue
+		}
+
+		cond := &condition{
+			Left:     strings.TrimSpace(expression[:i]),
+			Operator: operator,
+			Right:    strings.TrimSpace(expression[i+len(operator):]),
+		}
+
+		if cond.Left == "" || cond.Right == "" {
+			return nil, fmt.Errorf("expected FIELD%sVALUE", operator)
+		}
+
+		return cond, nil
+	}
+
+	return nil, fmt.Errorf("expected one of the operators %s", strings.Join(conditionOperators, " "))
+}
+
+// matchAll returns true if there is at least one entity and the condition holds for each of them
+func (c *condition) matchAll(entities map[string]map[string]string) (bool, error) {
+	if len(entities) == 0 {
+		return false, nil
+	}
+
+	for _, fields := range entities {
+		ok, err := c.match(fields)
+		if err != nil {
+			return false, err
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (c *condition) match(fields map[string]string) (bool, error) {
+	left, ok := lookupField(fields, c.Left)
+	if !ok {
+		return false, fmt.Errorf("Unknown field '%s'", c.Left)
+	}
+
+	right, ok := lookupField(fields, c.Right)
+	if !ok {
+		right = c.Right
+	}
+
+	leftNumber, leftErr := strconv.ParseFloat(left, 64)
+	rightNumber, rightErr := strconv.ParseFloat(right, 64)
+	if leftErr == nil && rightErr == nil {
+		switch c.Operator {
+		case "==":
+			return leftNumber == rightNumber, nil
+		case "!=":
+			return leftNumber != rightNumber, nil
+		case ">=":
+			return leftNumber >= rightNumber, nil
+		case "<=":
+			return leftNumber <= rightNumber, nil
+		case ">":
+			return leftNumber > rightNumber, nil
+		case "<":
+			return leftNumber < rightNumber, nil
+		}
+	}
+
+	switch c.Operator {
+	case "==":
+		return strings.EqualFold(left, right), nil
+	case "!=":
+		return !strings.EqualFold(left, right), nil
+	default:
+		return false, fmt.Errorf("Operator '%s' requires numbers, but '%s' and '%s' were compared", c.Operator, left, right)
+	}
+}
+
+// lookupField matches name with a field exactly, or in short form: 'running' for 'running_count'
+// and 'status' for 'job_status' for example
+func lookupField(fields map[string]string, name string) (string, bool) {
+	name = strings.ToLower(name)
+	for _, key := range []string{name, name + "_count"} {
+		if value, ok := fields[key]; ok {
+			return value, true
+		}
+	}
+
+	matches := []string{}
+	for key := range fields {
+		if !strings.Contains(key, ".") && strings.HasSuffix(key, "_"+name) {
+			matches = append(matches, key)
+		}
+	}
+
+	if len(matches) != 1 {
+		return "", false
+	}
+
+	return fields[matches[0]], true
+}
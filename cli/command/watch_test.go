@@ -0,0 +1,183 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestParseCondition(t *testing.T) {
+	cases := map[string]condition{
+		"running==desired":  {Left: "running", Operator: "==", Right: "desired"},
+		"pending != 0":      {Left: "pending", Operator: "!=", Right: "0"},
+		"running>=2":        {Left: "running", Operator: ">=", Right: "2"},
+		"status==completed": {Left: "status", Operator: "==", Right: "completed"},
+		"cluster_count<3":   {Left: "cluster_count", Operator: "<", Right: "3"},
+	}
+
+	for expression, expected := range cases {
+		cond, err := parseCondition(expression)
+		if err != nil {
+			t.Fatalf("%s: %v", expression, err)
+		}
+
+		testutils.AssertEqual(t, *cond, expected)
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	for _, expression := range []string{"running", "==desired", "running>="} {
+		if _, err := parseCondition(expression); err == nil {
+			t.Fatalf("%s: error was nil!", expression)
+		}
+	}
+}
+
+func TestConditionMatch(t *testing.T) {
+	fields := map[string]string{
+		"desired_count": "2",
+		"running_count": "2",
+		"pending_count": "0",
+		"job_status":    "3",
+		"status":        "completed",
+	}
+
+	cases := map[string]bool{
+		"running==desired":  true,
+		"pending>0":         false,
+		"running_count<=1":  false,
+		"status==Completed": true,
+		"status!=error":     true,
+	}
+
+	for expression, expected := range cases {
+		cond, err := parseCondition(expression)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := cond.match(fields)
+		if err != nil {
+			t.Fatalf("%s: %v", expression, err)
+		}
+
+		if result != expected {
+			t.Fatalf("%s: expected %t", expression, expected)
+		}
+	}
+
+	for _, expression := range []string{"missing==1", "status>completed"} {
+		cond, err := parseCondition(expression)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := cond.match(fields); err == nil {
+			t.Fatalf("%s: error was nil!", expression)
+		}
+	}
+}
+
+func TestDiffEntities(t *testing.T) {
+	previous, err := flattenEntities("service", []*models.Service{
+		{ServiceID: "id1", ServiceName: "web", RunningCount: 1, Deployments: []models.Deployment{{Status: "PRIMARY"}}},
+		{ServiceID: "id2", ServiceName: "web", RunningCount: 1},
+		{ServiceID: "id3", ServiceName: "old"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current, err := flattenEntities("service", []*models.Service{
+		{ServiceID: "id1", ServiceName: "web", RunningCount: 2, Deployments: []models.Deployment{{Status: "ACTIVE"}}},
+		{ServiceID: "id2", ServiceName: "web", RunningCount: 1},
+		{ServiceID: "id4", ServiceName: "new"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2017, 1, 1, 12, 30, 0, 0, time.UTC)
+	expected := []string{
+		"12:30:00 web (id1): deployments.0.status PRIMARY -> ACTIVE",
+		"12:30:00 web (id1): running_count 1 -> 2",
+		"12:30:00 new (id4): added",
+		"12:30:00 old (id3): removed",
+	}
+
+	testutils.AssertEqual(t, diffEntities("service", previous, current, now), expected)
+}
+
+func TestConditionMatchAll_sameName(t *testing.T) {
+	// services in different environments may share a name; each must be checked
+	entities, err := flattenEntities("service", []*models.Service{
+		{ServiceID: "id1", ServiceName: "web", RunningCount: 2, DesiredCount: 2},
+		{ServiceID: "id2", ServiceName: "web", RunningCount: 1, DesiredCount: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(entities), 2)
+
+	cond, err := parseCondition("running==desired")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := cond.matchAll(entities)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, ok, false)
+}
+
+func TestGetService_watchUntil(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewServiceCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("service", "name").
+		Return([]string{"id"}, nil).
+		Times(2)
+
+	gomock.InOrder(
+		tc.Client.EXPECT().
+			GetService("id").
+			Return(&models.Service{ServiceID: "id", RunningCount: 1, DesiredCount: 2}, nil),
+		tc.Client.EXPECT().
+			GetService("id").
+			Return(&models.Service{ServiceID: "id", RunningCount: 2, DesiredCount: 2}, nil),
+	)
+
+	flags := map[string]interface{}{
+		"interval": "1ms",
+		"until":    "running==desired",
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Get(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetService_watchUserInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewServiceCommand(tc.Command())
+
+	flags := map[string]interface{}{
+		"interval": "1ms",
+		"until":    "running",
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Get(c); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
@@ -3,10 +3,13 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/chzyer/readline"
 	"github.com/quintilesims/layer0/cli/client"
 	"github.com/quintilesims/layer0/cli/command"
 	"github.com/quintilesims/layer0/cli/printer"
@@ -36,7 +39,7 @@ func RunApp() {
 		cli.StringFlag{
 			Name:  "o, output",
 			Value: "text",
-			Usage: "output format [text,json]",
+			Usage: "output format [text,wide,json,yaml,template=TEMPLATE]; a go template is executed for each listed entity",
 		},
 		cli.StringFlag{
 			Name:  "t, timeout",
@@ -47,22 +50,31 @@ func RunApp() {
 			Name:  "d, debug",
 			Usage: "Print debug statements",
 		},
+		cli.StringFlag{
+			Name:   "p, profile",
+			Usage:  "name of the profile to use (default: the current profile, see 'l0 profile list')",
+			EnvVar: config.PROFILE,
+		},
+		cli.BoolFlag{
+			Name:  "y, yes",
+			Usage: "do not prompt for confirmation, even if the profile requires it or the command is a bulk delete",
+		},
 	}
 
-	apiClient := client.NewAPIClient(client.Config{
-		Endpoint:      config.APIEndpoint(),
-		Token:         config.AuthToken(),
-		VerifySSL:     config.ShouldVerifySSL(),
-		VerifyVersion: config.ShouldVerifyVersion(),
-		Clock:         waitutils.RealClock{},
-	})
+	// the client is configured in app.Before, once the profile is known
+	cmd := &command.Command{}
+	profileConfigPath := config.ProfileConfigPath()
 
-	commands := getCommands(apiClient)
+	commands := getCommands(cmd, profileConfigPath)
 	for _, cmd := range commands {
 		app.Commands = append(app.Commands, cmd.GetCommand())
 	}
 
-	app.Commands = append(app.Commands, command.HelpCommand(app))
+	completion := command.NewCompletionCommand(cmd, app)
+	app.Commands = append(app.Commands,
+		command.HelpCommand(app),
+		completion.GetCommand(),
+		completion.GetCompleteCommand())
 
 	var timeout time.Duration
 	var wg sync.WaitGroup
@@ -71,15 +83,9 @@ func RunApp() {
 	app.Before = func(c *cli.Context) error {
 		defer wg.Done()
 
-		var p printer.Printer
-		format := c.GlobalString("output")
-		switch format {
-		case "text":
-			p = &printer.TextPrinter{}
-		case "json":
-			p = &printer.JSONPrinter{}
-		default:
-			return fmt.Errorf("Unrecognized output format '%s'", format)
+		p, err := getPrinter(c.GlobalString("output"))
+		if err != nil {
+			return err
 		}
 
 		for _, cmd := range commands {
@@ -97,6 +103,58 @@ func RunApp() {
 			log.SetLevel(log.DebugLevel)
 		}
 
+		profileConfig, err := config.LoadProfileConfig(profileConfigPath)
+		if err != nil {
+			return err
+		}
+
+		profile, err := profileConfig.Resolve(c.GlobalString("profile"))
+		if err != nil {
+			// profile commands must still work so a missing profile can be added or replaced,
+			// and completion must not print errors into the user's shell
+			switch c.Args().First() {
+			case "profile", "completion", "__complete":
+			default:
+				return err
+			}
+
+			profile = config.EnvironmentProfile()
+		}
+
+		apiClient := client.NewAPIClient(client.Config{
+			Endpoint:      profile.Endpoint,
+			Token:         profile.Token,
+			VerifySSL:     !profile.SkipSSLVerify,
+			VerifyVersion: !profile.SkipVersionVerify,
+			Clock:         waitutils.RealClock{},
+		})
+
+		cmd.Client = apiClient
+		cmd.Resolver = command.NewTagResolver(apiClient)
+
+		completion.CacheDir = command.CompletionCacheDir(filepath.Dir(profileConfigPath), profile.Endpoint)
+
+		// bulk deletes always ask, whether or not the profile requires confirmation
+		if !c.GlobalBool("yes") {
+			if !readline.IsTerminal(int(os.Stdin.Fd())) {
+				cmd.BulkConfirm = func(string) (bool, error) {
+					return false, fmt.Errorf("Bulk deletes require confirmation. Use --yes to run them non-interactively")
+				}
+			} else {
+				cmd.BulkConfirm = command.NewPromptConfirm(profile.Name, os.Stdin, os.Stderr)
+			}
+		}
+
+		if profile.Confirm && !c.GlobalBool("yes") {
+			if !readline.IsTerminal(int(os.Stdin.Fd())) {
+				cmd.Confirm = func(string) (bool, error) {
+					return false, fmt.Errorf("Profile '%s' requires confirmation for this command. Use --yes to run it non-interactively", profile.Name)
+				}
+			} else {
+				cmd.Confirm = command.NewPromptConfirm(profile.Name, os.Stdin, os.Stderr)
+			}
+		}
+
 		return nil
 	}
 
@@ -115,19 +173,36 @@ func RunApp() {
 	log.Fatalf("Timeout after %v", timeout)
 }
 
-func getCommands(client *client.APIClient) []command.CommandGroup {
-	cmd := &command.Command{
-		Client:   client,
-		Resolver: command.NewTagResolver(client),
-		Printer:  nil,
+func getPrinter(format string) (printer.Printer, error) {
+	if strings.HasPrefix(format, "template=") {
+		return printer.NewTemplatePrinter(strings.TrimPrefix(format, "template="))
 	}
 
+	switch format {
+	case "text":
+		return &printer.TextPrinter{}, nil
+	case "wide":
+		return &printer.TextPrinter{Wide: true}, nil
+	case "json":
+		return printer.NewJSONPrinter(), nil
+	case "yaml":
+		return printer.NewYAMLPrinter(), nil
+	default:
+		return nil, fmt.Errorf("Unrecognized output format '%s'", format)
+	}
+}
+
+func getCommands(cmd *command.Command, profileConfigPath string) []command.CommandGroup {
 	return []command.CommandGroup{
 		command.NewAdminCommand(cmd),
+		command.NewApplyCommand(cmd),
 		command.NewDeployCommand(cmd),
 		command.NewEnvironmentCommand(cmd),
 		command.NewJobCommand(cmd),
 		command.NewLoadBalancerCommand(cmd),
+		command.NewPlanCommand(cmd),
+		command.NewProfileCommand(cmd, profileConfigPath),
+		command.NewSecretCommand(cmd),
 		command.NewServiceCommand(cmd),
 		command.NewTaskCommand(cmd),
 	}
@@ -0,0 +1,310 @@
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/quintilesims/layer0/common/models"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Manifest declares the entities of an application in a single environment.
+// Services and load balancers in the environment that are not declared are only deleted when the plan prunes them.
+type Manifest struct {
+	Environment   Environment    `yaml:"environment"`
+	LoadBalancers []LoadBalancer `yaml:"load_balancers"`
+	Deploys       []Deploy       `yaml:"deploys"`
+	Services      []Service      `yaml:"services"`
+}
+
+type Environment struct {
+	Name     string `yaml:"name"`
+	Size     string `yaml:"size"`
+	MinCount int    `yaml:"min_count"`
+	OS       string `yaml:"os"`
+	AMI      string `yaml:"ami"`
+	// UserData is the path of a user data template, relative to the manifest
+	UserData string `yaml:"user_data"`
+}
+
+type LoadBalancer struct {
+	Name        string       `yaml:"name"`
+	Type        string       `yaml:"type"`
+	Private     bool         `yaml:"private"`
+	Ports       []Port       `yaml:"ports"`
+	HealthCheck *HealthCheck `yaml:"health_check"`
+	IdleTimeout int          `yaml:"idle_timeout"`
+	CrossZone   *bool        `yaml:"cross_zone"`
+}
+
+type Port struct {
+	HostPort      int64  `yaml:"host_port"`
+	ContainerPort int64  `yaml:"container_port"`
+	Protocol      string `yaml:"protocol"`
+	Certificate   string `yaml:"certificate"`
+}
+
+type HealthCheck struct {
+	Target             string `yaml:"target"`
+	Interval           int    `yaml:"interval"`
+	Timeout            int    `yaml:"timeout"`
+	HealthyThreshold   int    `yaml:"healthy_threshold"`
+	UnhealthyThreshold int    `yaml:"unhealthy_threshold"`
+}
+
+// Deploys are created as templates so the api records their content, which is how changes are detected
+type Deploy struct {
+	Name string `yaml:"name"`
+	// File is the path of the Dockerrun.aws.json file or template, relative to the manifest
+	File      string            `yaml:"file"`
+	Variables map[string]string `yaml:"variables"`
+	Content   []byte            `yaml:"-"`
+}
+
+type Service struct {
+	Name         string `yaml:"name"`
+	Deploy       string `yaml:"deploy"`
+	LoadBalancer string `yaml:"load_balancer"`
+	Scale        *int   `yaml:"scale"`
+	HostHeader   string `yaml:"host_header"`
+	PathPattern  string `yaml:"path_pattern"`
+	Priority     int    `yaml:"priority"`
+}
+
+// Load reads, validates and applies defaults to the manifest at path.
+// The files it references are read relative to the manifest's directory.
+func Load(path string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid manifest '%s': %v", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	for i, deploy := range m.Deploys {
+		content, err := ioutil.ReadFile(relativePath(dir, deploy.File))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read deploy '%s': %v", deploy.Name, err)
+		}
+
+		m.Deploys[i].Content = content
+	}
+
+	if m.Environment.UserData != "" {
+		m.Environment.UserData = relativePath(dir, m.Environment.UserData)
+	}
+
+	return m, nil
+}
+
+// Parse decodes a manifest, validates it and applies defaults; deploy files are not read
+func Parse(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	m.setDefaults()
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (m *Manifest) setDefaults() {
+	if m.Environment.Size == "" {
+		m.Environment.Size = "m3.medium"
+	}
+
+	if m.Environment.OS == "" {
+		m.Environment.OS = "linux"
+	}
+
+	for i := range m.LoadBalancers {
+		l := &m.LoadBalancers[i]
+		l.Type = strings.ToLower(l.Type)
+		if l.Type == "" {
+			l.Type = "elb"
+		}
+
+		if len(l.Ports) == 0 {
+			port := Port{HostPort: 80, ContainerPort: 80, Protocol: "tcp"}
+
+			// application load balancers only support http and https listeners
+			if l.Type == "alb" {
+				port.Protocol = "http"
+			}
+
+			l.Ports = []Port{port}
+		}
+
+		if l.HealthCheck == nil {
+			l.HealthCheck = &HealthCheck{}
+		}
+
+		if l.HealthCheck.Target == "" {
+			l.HealthCheck.Target = "TCP:80"
+		}
+
+		if l.HealthCheck.Interval == 0 {
+			l.HealthCheck.Interval = 30
+		}
+
+		if l.HealthCheck.Timeout == 0 {
+			l.HealthCheck.Timeout = 5
+		}
+
+		if l.HealthCheck.HealthyThreshold == 0 {
+			l.HealthCheck.HealthyThreshold = 2
+		}
+
+		if l.HealthCheck.UnhealthyThreshold == 0 {
+			l.HealthCheck.UnhealthyThreshold = 2
+		}
+
+		if l.IdleTimeout == 0 {
+			l.IdleTimeout = 60
+		}
+
+		if l.CrossZone == nil {
+			crossZone := true
+			l.CrossZone = &crossZone
+		}
+	}
+
+	for i := range m.Services {
+		if m.Services[i].Scale == nil {
+			scale := 1
+			m.Services[i].Scale = &scale
+		}
+	}
+}
+
+func (m *Manifest) validate() error {
+	if m.Environment.Name == "" {
+		return fmt.Errorf("environment.name is required")
+	}
+
+	if m.Environment.OS != "linux" && m.Environment.OS != "windows" {
+		return fmt.Errorf("environment.os must be 'linux' or 'windows'")
+	}
+
+	loadBalancers := map[string]bool{}
+	for _, l := range m.LoadBalancers {
+		if l.Name == "" {
+			return fmt.Errorf("load_balancers: name is required")
+		}
+
+		if loadBalancers[l.Name] {
+			return fmt.Errorf("load_balancers: '%s' is declared more than once", l.Name)
+		}
+
+		if l.Type != "elb" && l.Type != "alb" {
+			return fmt.Errorf("load_balancers: '%s' type must be 'elb' or 'alb'", l.Name)
+		}
+
+		for _, p := range l.Ports {
+			if p.HostPort == 0 || p.ContainerPort == 0 || p.Protocol == "" {
+				return fmt.Errorf("load_balancers: '%s' ports require host_port, container_port and protocol", l.Name)
+			}
+		}
+
+		loadBalancers[l.Name] = true
+	}
+
+	deploys := map[string]bool{}
+	for _, d := range m.Deploys {
+		if d.Name == "" {
+			return fmt.Errorf("deploys: name is required")
+		}
+
+		if deploys[d.Name] {
+			return fmt.Errorf("deploys: '%s' is declared more than once", d.Name)
+		}
+
+		if d.File == "" {
+			return fmt.Errorf("deploys: '%s' file is required", d.Name)
+		}
+
+		deploys[d.Name] = true
+	}
+
+	services := map[string]bool{}
+	for _, s := range m.Services {
+		if s.Name == "" {
+			return fmt.Errorf("services: name is required")
+		}
+
+		if services[s.Name] {
+			return fmt.Errorf("services: '%s' is declared more than once", s.Name)
+		}
+
+		if !deploys[s.Deploy] {
+			return fmt.Errorf("services: '%s' deploy '%s' is not declared in deploys", s.Name, s.Deploy)
+		}
+
+		if s.LoadBalancer != "" && !loadBalancers[s.LoadBalancer] {
+			return fmt.Errorf("services: '%s' load_balancer '%s' is not declared in load_balancers", s.Name, s.LoadBalancer)
+		}
+
+		if *s.Scale < 0 {
+			return fmt.Errorf("services: '%s' scale must be >= 0", s.Name)
+		}
+
+		services[s.Name] = true
+	}
+
+	return nil
+}
+
+func relativePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(dir, path)
+}
+
+func (l LoadBalancer) ports() []models.Port {
+	ports := make([]models.Port, len(l.Ports))
+	for i, p := range l.Ports {
+		ports[i] = models.Port{
+			HostPort:      p.HostPort,
+			ContainerPort: p.ContainerPort,
+			Protocol:      strings.ToLower(p.Protocol),
+		}
+
+		if strings.HasPrefix(strings.ToLower(p.Certificate), "arn:") {
+			ports[i].CertificateARN = p.Certificate
+		} else {
+			ports[i].CertificateName = p.Certificate
+		}
+	}
+
+	return ports
+}
+
+func (l LoadBalancer) healthCheck() models.HealthCheck {
+	return models.HealthCheck{
+		Target:             l.HealthCheck.Target,
+		Interval:           l.HealthCheck.Interval,
+		Timeout:            l.HealthCheck.Timeout,
+		HealthyThreshold:   l.HealthCheck.HealthyThreshold,
+		UnhealthyThreshold: l.HealthCheck.UnhealthyThreshold,
+	}
+}
+
+func (s Service) loadBalancerRule() models.LoadBalancerRule {
+	return models.LoadBalancerRule{
+		HostHeader:  s.HostHeader,
+		PathPattern: s.PathPattern,
+		Priority:    s.Priority,
+	}
+}
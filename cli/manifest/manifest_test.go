@@ -0,0 +1,161 @@
+package manifest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestParse_defaults(t *testing.T) {
+	data := `
+environment:
+  name: prod
+load_balancers:
+  - name: web
+deploys:
+  - name: web
+    file: web.json
+services:
+  - name: web
+    deploy: web
+    load_balancer: web
+`
+
+	m, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crossZone := true
+	scale := 1
+
+	expected := &Manifest{
+		Environment: Environment{
+			Name: "prod",
+			Size: "m3.medium",
+			OS:   "linux",
+		},
+		LoadBalancers: []LoadBalancer{
+			{
+				Name:  "web",
+				Type:  "elb",
+				Ports: []Port{{HostPort: 80, ContainerPort: 80, Protocol: "tcp"}},
+				HealthCheck: &HealthCheck{
+					Target:             "TCP:80",
+					Interval:           30,
+					Timeout:            5,
+					HealthyThreshold:   2,
+					UnhealthyThreshold: 2,
+				},
+				IdleTimeout: 60,
+				CrossZone:   &crossZone,
+			},
+		},
+		Deploys: []Deploy{
+			{Name: "web", File: "web.json"},
+		},
+		Services: []Service{
+			{Name: "web", Deploy: "web", LoadBalancer: "web", Scale: &scale},
+		},
+	}
+
+	testutils.AssertEqual(t, m, expected)
+}
+
+func TestParse_errors(t *testing.T) {
+	cases := map[string]string{
+		"Missing environment name": `
+environment:
+  size: m3.medium
+`,
+		"Invalid os": `
+environment:
+  name: prod
+  os: solaris
+`,
+		"Invalid load balancer type": `
+environment:
+  name: prod
+load_balancers:
+  - name: web
+    type: nlb
+`,
+		"Duplicate deploy": `
+environment:
+  name: prod
+deploys:
+  - name: web
+    file: web.json
+  - name: web
+    file: web.json
+`,
+		"Undeclared deploy": `
+environment:
+  name: prod
+services:
+  - name: web
+    deploy: web
+`,
+		"Undeclared load balancer": `
+environment:
+  name: prod
+deploys:
+  - name: web
+    file: web.json
+services:
+  - name: web
+    deploy: web
+    load_balancer: web
+`,
+	}
+
+	for name, data := range cases {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	data := `
+environment:
+  name: prod
+  user_data: user_data.sh
+deploys:
+  - name: web
+    file: deploys/web.json
+`
+
+	files := map[string]string{
+		"layer0.yml":       data,
+		"deploys/web.json": "{}",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := Load(filepath.Join(dir, "layer0.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, string(m.Deploys[0].Content), "{}")
+	testutils.AssertEqual(t, m.Environment.UserData, filepath.Join(dir, "user_data.sh"))
+}
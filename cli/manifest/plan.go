@@ -0,0 +1,675 @@
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quintilesims/layer0/cli/client"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Action is a single change needed to converge Layer0 to a manifest
+type Action struct {
+	Type       string   `json:"type"`
+	EntityType string   `json:"entity_type"`
+	Name       string   `json:"name"`
+	Changes    []string `json:"changes,omitempty"`
+	apply      func(*applier) error
+}
+
+// Plan holds the actions needed to converge Layer0 to a manifest, in the order they are applied
+type Plan struct {
+	Environment string    `json:"environment"`
+	Actions     []*Action `json:"actions"`
+	// ids of the existing entities the manifest references, by name
+	environmentID   string
+	deployIDs       map[string]string
+	loadBalancerIDs map[string]string
+}
+
+// Count returns the number of actions of the specified type
+func (p *Plan) Count(actionType string) int {
+	var count int
+	for _, action := range p.Actions {
+		if action.Type == actionType {
+			count++
+		}
+	}
+
+	return count
+}
+
+// applier tracks the ids of the entities referenced by the manifest as the plan is applied
+type applier struct {
+	client          client.Client
+	timeout         time.Duration
+	environmentID   string
+	deployIDs       map[string]string
+	loadBalancerIDs map[string]string
+}
+
+// Apply runs each action of the plan in order, calling onAction before each one
+func (p *Plan) Apply(apiClient client.Client, timeout time.Duration, onAction func(*Action)) error {
+	a := &applier{
+		client:          apiClient,
+		timeout:         timeout,
+		environmentID:   p.environmentID,
+		deployIDs:       copyMap(p.deployIDs),
+		loadBalancerIDs: copyMap(p.loadBalancerIDs),
+	}
+
+	for _, action := range p.Actions {
+		if onAction != nil {
+			onAction(action)
+		}
+
+		if err := action.apply(a); err != nil {
+			return fmt.Errorf("Failed to %s %s '%s': %v", action.Type, action.EntityType, action.Name, err)
+		}
+	}
+
+	return nil
+}
+
+type planner struct {
+	client   client.Client
+	manifest *Manifest
+	plan     *Plan
+	// existing services by name, and the deploys that get a new version
+	serviceIDs map[string]string
+	newDeploys map[string]bool
+	// existing load balancers by name, so services can compare their rules
+	loadBalancers map[string]*models.LoadBalancer
+	environment   *models.Environment
+	prune         bool
+}
+
+// NewPlan compares the manifest with the current state of Layer0 and returns the actions needed to converge them.
+// If prune is true, the services and load balancers in the environment that the manifest does not declare are deleted.
+func NewPlan(apiClient client.Client, m *Manifest, prune bool) (*Plan, error) {
+	p := &planner{
+		client:   apiClient,
+		manifest: m,
+		prune:    prune,
+		plan: &Plan{
+			Environment:     m.Environment.Name,
+			Actions:         []*Action{},
+			deployIDs:       map[string]string{},
+			loadBalancerIDs: map[string]string{},
+		},
+		serviceIDs:    map[string]string{},
+		newDeploys:    map[string]bool{},
+		loadBalancers: map[string]*models.LoadBalancer{},
+	}
+
+	steps := []func() error{
+		p.planEnvironment,
+		p.lookupEntities,
+		p.planDeploys,
+		p.planLoadBalancers,
+		p.planServices,
+		p.planDeletes,
+	}
+
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.plan, nil
+}
+
+func (p *planner) add(action *Action) {
+	p.plan.Actions = append(p.plan.Actions, action)
+}
+
+func (p *planner) planEnvironment() error {
+	desired := p.manifest.Environment
+
+	environments, err := p.client.ListEnvironments()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range environments {
+		if e.EnvironmentName == desired.Name {
+			p.plan.environmentID = e.EnvironmentID
+		}
+	}
+
+	if p.plan.environmentID == "" {
+		p.add(&Action{
+			Type:       ActionCreate,
+			EntityType: "environment",
+			Name:       desired.Name,
+			apply: func(a *applier) error {
+				var userData []byte
+				if desired.UserData != "" {
+					content, err := ioutil.ReadFile(desired.UserData)
+					if err != nil {
+						return err
+					}
+
+					userData = content
+				}
+
+				environment, err := a.client.CreateEnvironment(desired.Name, desired.Size, desired.MinCount, userData, desired.OS, desired.AMI)
+				if err != nil {
+					return err
+				}
+
+				a.environmentID = environment.EnvironmentID
+				return nil
+			},
+		})
+
+		return nil
+	}
+
+	environmentID := p.plan.environmentID
+	environment, err := p.client.GetEnvironment(environmentID)
+	if err != nil {
+		return err
+	}
+
+	if environment.InstanceSize != desired.Size {
+		return fmt.Errorf("Environment '%s' has size '%s'; changing the size requires replacing the environment", desired.Name, environment.InstanceSize)
+	}
+
+	if !strings.EqualFold(environment.OperatingSystem, desired.OS) {
+		return fmt.Errorf("Environment '%s' has os '%s'; changing the os requires replacing the environment", desired.Name, environment.OperatingSystem)
+	}
+
+	// the cluster count changes as the environment scales, so only a count below the minimum is a change
+	if environment.ClusterCount < desired.MinCount {
+		p.add(&Action{
+			Type:       ActionUpdate,
+			EntityType: "environment",
+			Name:       desired.Name,
+			Changes:    []string{fmt.Sprintf("min_count: %d -> %d", environment.ClusterCount, desired.MinCount)},
+			apply: func(a *applier) error {
+				_, err := a.client.UpdateEnvironment(environmentID, desired.MinCount)
+				return err
+			},
+		})
+	}
+
+	return nil
+}
+
+// lookupEntities finds the load balancers and services that already exist in the manifest's environment
+func (p *planner) lookupEntities() error {
+	if p.plan.environmentID == "" {
+		return nil
+	}
+
+	loadBalancers, err := p.client.ListLoadBalancers()
+	if err != nil {
+		return err
+	}
+
+	for _, l := range loadBalancers {
+		if l.EnvironmentID == p.plan.environmentID {
+			p.plan.loadBalancerIDs[l.LoadBalancerName] = l.LoadBalancerID
+		}
+	}
+
+	services, err := p.client.ListServices()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range services {
+		if s.EnvironmentID == p.plan.environmentID {
+			p.serviceIDs[s.ServiceName] = s.ServiceID
+		}
+	}
+
+	return nil
+}
+
+func (p *planner) planDeploys() error {
+	deploys, err := p.client.ListDeploys()
+	if err != nil {
+		return err
+	}
+
+	latest := map[string]*models.DeploySummary{}
+	for _, d := range deploys {
+		current, ok := latest[d.DeployName]
+		if !ok || deployVersion(d) > deployVersion(current) {
+			latest[d.DeployName] = d
+		}
+	}
+
+	for _, d := range p.manifest.Deploys {
+		desired := d
+		action := &Action{
+			Type:       ActionCreate,
+			EntityType: "deploy",
+			Name:       desired.Name,
+			apply: func(a *applier) error {
+				deploy, err := a.client.CreateDeployFromTemplate(desired.Name, desired.Content, desired.Variables)
+				if err != nil {
+					return err
+				}
+
+				a.deployIDs[desired.Name] = deploy.DeployID
+				return nil
+			},
+		}
+
+		summary, ok := latest[desired.Name]
+		if !ok {
+			p.newDeploys[desired.Name] = true
+			p.add(action)
+			continue
+		}
+
+		deploy, err := p.client.GetDeploy(summary.DeployID)
+		if err != nil {
+			return err
+		}
+
+		if string(deploy.Template) != string(desired.Content) {
+			action.Changes = append(action.Changes, fmt.Sprintf("content of '%s' differs from version %s", desired.File, deploy.Version))
+		}
+
+		if !equalVariables(deploy.Variables, desired.Variables) {
+			action.Changes = append(action.Changes, fmt.Sprintf("variables differ from version %s", deploy.Version))
+		}
+
+		// deploys are immutable, so a change creates a new version
+		if len(action.Changes) > 0 {
+			p.newDeploys[desired.Name] = true
+			p.add(action)
+			continue
+		}
+
+		p.plan.deployIDs[desired.Name] = deploy.DeployID
+	}
+
+	return nil
+}
+
+func (p *planner) planLoadBalancers() error {
+	for _, l := range p.manifest.LoadBalancers {
+		desired := l
+
+		loadBalancerID, ok := p.plan.loadBalancerIDs[desired.Name]
+		if !ok {
+			p.add(&Action{
+				Type:       ActionCreate,
+				EntityType: "load_balancer",
+				Name:       desired.Name,
+				apply: func(a *applier) error {
+					loadBalancer, err := a.client.CreateLoadBalancer(
+						desired.Name,
+						a.environmentID,
+						desired.healthCheck(),
+						desired.ports(),
+						!desired.Private,
+						desired.IdleTimeout,
+						*desired.CrossZone,
+						desired.Type)
+					if err != nil {
+						return err
+					}
+
+					a.loadBalancerIDs[desired.Name] = loadBalancer.LoadBalancerID
+					return nil
+				},
+			})
+
+			continue
+		}
+
+		current, err := p.client.GetLoadBalancer(loadBalancerID)
+		if err != nil {
+			return err
+		}
+
+		p.loadBalancers[desired.Name] = current
+
+		if current.LoadBalancerType != "" && !strings.EqualFold(current.LoadBalancerType, desired.Type) {
+			return fmt.Errorf("Load balancer '%s' has type '%s'; changing the type requires replacing the load balancer", desired.Name, current.LoadBalancerType)
+		}
+
+		if current.IsPublic == desired.Private {
+			return fmt.Errorf("Load balancer '%s' cannot be changed between public and private without replacing it", desired.Name)
+		}
+
+		action := &Action{
+			Type:       ActionUpdate,
+			EntityType: "load_balancer",
+			Name:       desired.Name,
+		}
+
+		updates := []func(*applier) error{}
+
+		if !equalPorts(current.Ports, desired.ports()) {
+			action.Changes = append(action.Changes, fmt.Sprintf("ports: %s -> %s", formatPorts(current.Ports), formatPorts(desired.ports())))
+			updates = append(updates, func(a *applier) error {
+				_, err := a.client.UpdateLoadBalancerPorts(loadBalancerID, desired.ports())
+				return err
+			})
+		}
+
+		if current.HealthCheck != desired.healthCheck() {
+			action.Changes = append(action.Changes, fmt.Sprintf("health_check: %+v -> %+v", current.HealthCheck, desired.healthCheck()))
+			updates = append(updates, func(a *applier) error {
+				_, err := a.client.UpdateLoadBalancerHealthCheck(loadBalancerID, desired.healthCheck())
+				return err
+			})
+		}
+
+		if current.IdleTimeout != desired.IdleTimeout {
+			action.Changes = append(action.Changes, fmt.Sprintf("idle_timeout: %d -> %d", current.IdleTimeout, desired.IdleTimeout))
+			updates = append(updates, func(a *applier) error {
+				_, err := a.client.UpdateLoadBalancerIdleTimeout(loadBalancerID, desired.IdleTimeout)
+				return err
+			})
+		}
+
+		if current.CrossZone != *desired.CrossZone {
+			action.Changes = append(action.Changes, fmt.Sprintf("cross_zone: %t -> %t", current.CrossZone, *desired.CrossZone))
+			updates = append(updates, func(a *applier) error {
+				_, err := a.client.UpdateLoadBalancerCrossZone(loadBalancerID, *desired.CrossZone)
+				return err
+			})
+		}
+
+		if len(updates) > 0 {
+			action.apply = applyAll(updates)
+			p.add(action)
+		}
+	}
+
+	return nil
+}
+
+func (p *planner) planServices() error {
+	for _, s := range p.manifest.Services {
+		desired := s
+
+		serviceID, ok := p.serviceIDs[desired.Name]
+		if !ok {
+			p.add(&Action{
+				Type:       ActionCreate,
+				EntityType: "service",
+				Name:       desired.Name,
+				apply: func(a *applier) error {
+					service, err := a.client.CreateService(
+						desired.Name,
+						a.environmentID,
+						a.deployIDs[desired.Deploy],
+						a.loadBalancerIDs[desired.LoadBalancer],
+						desired.loadBalancerRule())
+					if err != nil {
+						return err
+					}
+
+					if *desired.Scale != 1 {
+						if _, err := a.client.ScaleService(service.ServiceID, *desired.Scale); err != nil {
+							return err
+						}
+					}
+
+					return nil
+				},
+			})
+
+			continue
+		}
+
+		current, err := p.client.GetService(serviceID)
+		if err != nil {
+			return err
+		}
+
+		if current.LoadBalancerName != desired.LoadBalancer {
+			return fmt.Errorf("Service '%s' has load balancer '%s'; changing the load balancer requires replacing the service", desired.Name, current.LoadBalancerName)
+		}
+
+		// the rule of a service cannot be updated, since it is created along with the service's target group
+		if loadBalancer, ok := p.loadBalancers[desired.LoadBalancer]; ok {
+			for _, rule := range loadBalancer.Rules {
+				if rule.ServiceID == serviceID && !ruleMatches(rule, desired.loadBalancerRule()) {
+					return fmt.Errorf("Service '%s' has the load balancer rule %s; changing the rule requires replacing the service", desired.Name, describeRule(rule))
+				}
+			}
+		}
+
+		action := &Action{
+			Type:       ActionUpdate,
+			EntityType: "service",
+			Name:       desired.Name,
+		}
+
+		updates := []func(*applier) error{}
+
+		currentDeployID := primaryDeployID(current)
+		if p.newDeploys[desired.Deploy] || currentDeployID != p.plan.deployIDs[desired.Deploy] {
+			action.Changes = append(action.Changes, fmt.Sprintf("deploy: %s -> latest version of %s", currentDeployID, desired.Deploy))
+			updates = append(updates, func(a *applier) error {
+				_, err := a.client.UpdateService(serviceID, a.deployIDs[desired.Deploy])
+				return err
+			})
+		}
+
+		if int(current.DesiredCount) != *desired.Scale {
+			action.Changes = append(action.Changes, fmt.Sprintf("scale: %d -> %d", current.DesiredCount, *desired.Scale))
+			updates = append(updates, func(a *applier) error {
+				_, err := a.client.ScaleService(serviceID, *desired.Scale)
+				return err
+			})
+		}
+
+		if len(updates) > 0 {
+			action.apply = applyAll(updates)
+			p.add(action)
+		}
+	}
+
+	return nil
+}
+
+// ruleMatches returns true if the current rule of a service is the one desired. A desired priority of 0
+// matches any priority, since the API picks one, and a rule without conditions matches all paths.
+func ruleMatches(current, desired models.LoadBalancerRule) bool {
+	pathPattern := desired.PathPattern
+	if pathPattern == "" && desired.HostHeader == "" {
+		pathPattern = "*"
+	}
+
+	if current.HostHeader != desired.HostHeader || current.PathPattern != pathPattern {
+		return false
+	}
+
+	return desired.Priority == 0 || current.Priority == desired.Priority
+}
+
+func describeRule(rule models.LoadBalancerRule) string {
+	return fmt.Sprintf("(host_header: '%s', path_pattern: '%s', priority: %d)", rule.HostHeader, rule.PathPattern, rule.Priority)
+}
+
+// planDeletes removes the services and load balancers in the environment that are not in the manifest.
+// Services are deleted first so the load balancers they use are free to be deleted.
+// Nothing is deleted unless the plan prunes, since the environment may hold entities managed by other means.
+func (p *planner) planDeletes() error {
+	if !p.prune {
+		return nil
+	}
+
+	services := map[string]bool{}
+	for _, s := range p.manifest.Services {
+		services[s.Name] = true
+	}
+
+	for _, name := range sortedKeys(p.serviceIDs) {
+		if services[name] {
+			continue
+		}
+
+		serviceID := p.serviceIDs[name]
+		p.add(&Action{
+			Type:       ActionDelete,
+			EntityType: "service",
+			Name:       name,
+			apply: func(a *applier) error {
+				jobID, err := a.client.DeleteService(serviceID)
+				if err != nil {
+					return err
+				}
+
+				return a.client.WaitForJob(jobID, a.timeout)
+			},
+		})
+	}
+
+	loadBalancers := map[string]bool{}
+	for _, l := range p.manifest.LoadBalancers {
+		loadBalancers[l.Name] = true
+	}
+
+	for _, name := range sortedKeys(p.plan.loadBalancerIDs) {
+		if loadBalancers[name] {
+			continue
+		}
+
+		loadBalancerID := p.plan.loadBalancerIDs[name]
+		p.add(&Action{
+			Type:       ActionDelete,
+			EntityType: "load_balancer",
+			Name:       name,
+			apply: func(a *applier) error {
+				jobID, err := a.client.DeleteLoadBalancer(loadBalancerID)
+				if err != nil {
+					return err
+				}
+
+				return a.client.WaitForJob(jobID, a.timeout)
+			},
+		})
+	}
+
+	return nil
+}
+
+func applyAll(updates []func(*applier) error) func(*applier) error {
+	return func(a *applier) error {
+		for _, update := range updates {
+			if err := update(a); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func primaryDeployID(service *models.Service) string {
+	for _, deployment := range service.Deployments {
+		if deployment.Status == "PRIMARY" {
+			return deployment.DeployID
+		}
+	}
+
+	return ""
+}
+
+func deployVersion(deploy *models.DeploySummary) int {
+	version, err := strconv.Atoi(deploy.Version)
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
+
+func equalVariables(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// equalPorts compares ports regardless of order; the api reports both the name and arn
+// of a port's certificate, so a certificate in the manifest matches on either
+func equalPorts(current, desired []models.Port) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+
+	matches := func(c, d models.Port) bool {
+		if c.HostPort != d.HostPort || c.ContainerPort != d.ContainerPort || !strings.EqualFold(c.Protocol, d.Protocol) {
+			return false
+		}
+
+		switch {
+		case d.CertificateARN != "":
+			return c.CertificateARN == d.CertificateARN
+		case d.CertificateName != "":
+			return c.CertificateName == d.CertificateName
+		default:
+			return c.CertificateARN == "" && c.CertificateName == ""
+		}
+	}
+
+	for _, d := range desired {
+		found := false
+		for _, c := range current {
+			if matches(c, d) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func formatPorts(ports []models.Port) string {
+	formatted := make([]string, len(ports))
+	for i, p := range ports {
+		formatted[i] = fmt.Sprintf("%d:%d/%s", p.HostPort, p.ContainerPort, strings.ToLower(p.Protocol))
+	}
+
+	return strings.Join(formatted, ",")
+}
+
+func copyMap(m map[string]string) map[string]string {
+	copy := map[string]string{}
+	for key, val := range m {
+		copy[key] = val
+	}
+
+	return copy
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
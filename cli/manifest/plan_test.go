@@ -0,0 +1,302 @@
+package manifest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/cli/client/mock_client"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func testManifest(t *testing.T) *Manifest {
+	data := `
+environment:
+  name: prod
+load_balancers:
+  - name: web
+deploys:
+  - name: web
+    file: web.json
+services:
+  - name: web
+    deploy: web
+    load_balancer: web
+    scale: 2
+`
+
+	m, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Deploys[0].Content = []byte("{}")
+	return m
+}
+
+func actionSummaries(plan *Plan) []string {
+	summaries := []string{}
+	for _, action := range plan.Actions {
+		summaries = append(summaries, action.Type+" "+action.EntityType+" "+action.Name)
+	}
+
+	return summaries
+}
+
+func TestPlanApply_newEnvironment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := testManifest(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+
+	mockClient.EXPECT().
+		ListEnvironments().
+		Return([]*models.EnvironmentSummary{}, nil)
+
+	mockClient.EXPECT().
+		ListDeploys().
+		Return([]*models.DeploySummary{}, nil)
+
+	plan, err := NewPlan(mockClient, m, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"create environment prod",
+		"create deploy web",
+		"create load_balancer web",
+		"create service web",
+	}
+
+	testutils.AssertEqual(t, actionSummaries(plan), expected)
+
+	gomock.InOrder(
+		mockClient.EXPECT().
+			CreateEnvironment("prod", "m3.medium", 0, nil, "linux", "").
+			Return(&models.Environment{EnvironmentID: "eid"}, nil),
+		mockClient.EXPECT().
+			CreateDeployFromTemplate("web", []byte("{}"), nil).
+			Return(&models.Deploy{DeployID: "web.1"}, nil),
+		mockClient.EXPECT().
+			CreateLoadBalancer("web", "eid", m.LoadBalancers[0].healthCheck(), m.LoadBalancers[0].ports(), true, 60, true, "elb").
+			Return(&models.LoadBalancer{LoadBalancerID: "lid"}, nil),
+		mockClient.EXPECT().
+			CreateService("web", "eid", "web.1", "lid", models.LoadBalancerRule{}).
+			Return(&models.Service{ServiceID: "sid"}, nil),
+		mockClient.EXPECT().
+			ScaleService("sid", 2).
+			Return(&models.Service{}, nil),
+	)
+
+	if err := plan.Apply(mockClient, time.Minute, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlanApply_existingEnvironment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := testManifest(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+
+	mockClient.EXPECT().
+		ListEnvironments().
+		Return([]*models.EnvironmentSummary{{EnvironmentID: "eid", EnvironmentName: "prod"}}, nil)
+
+	mockClient.EXPECT().
+		GetEnvironment("eid").
+		Return(&models.Environment{EnvironmentID: "eid", InstanceSize: "m3.medium", OperatingSystem: "linux"}, nil)
+
+	mockClient.EXPECT().
+		ListLoadBalancers().
+		Return([]*models.LoadBalancerSummary{
+			{LoadBalancerID: "lid", LoadBalancerName: "web", EnvironmentID: "eid"},
+			{LoadBalancerID: "other_lid", LoadBalancerName: "web", EnvironmentID: "other_eid"},
+		}, nil)
+
+	mockClient.EXPECT().
+		ListServices().
+		Return([]*models.ServiceSummary{
+			{ServiceID: "sid", ServiceName: "web", EnvironmentID: "eid"},
+			{ServiceID: "old_sid", ServiceName: "old", EnvironmentID: "eid"},
+		}, nil)
+
+	mockClient.EXPECT().
+		ListDeploys().
+		Return([]*models.DeploySummary{
+			{DeployID: "web.1", DeployName: "web", Version: "1"},
+			{DeployID: "web.2", DeployName: "web", Version: "2"},
+		}, nil)
+
+	mockClient.EXPECT().
+		GetDeploy("web.2").
+		Return(&models.Deploy{DeployID: "web.2", Template: []byte("{}"), Version: "2"}, nil)
+
+	mockClient.EXPECT().
+		GetLoadBalancer("lid").
+		Return(&models.LoadBalancer{
+			CrossZone:        true,
+			HealthCheck:      m.LoadBalancers[0].healthCheck(),
+			IdleTimeout:      60,
+			IsPublic:         true,
+			LoadBalancerType: "elb",
+			Ports:            []models.Port{{HostPort: 80, ContainerPort: 80, Protocol: "TCP"}},
+			Rules:            []models.LoadBalancerRule{{PathPattern: "*", Priority: 1, ServiceID: "sid"}},
+		}, nil)
+
+	mockClient.EXPECT().
+		GetService("sid").
+		Return(&models.Service{
+			DesiredCount:     1,
+			LoadBalancerName: "web",
+			Deployments:      []models.Deployment{{DeployID: "web.2", Status: "PRIMARY"}},
+		}, nil)
+
+	plan, err := NewPlan(mockClient, m, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"update service web",
+		"delete service old",
+	}
+
+	testutils.AssertEqual(t, actionSummaries(plan), expected)
+	testutils.AssertEqual(t, plan.Actions[0].Changes, []string{"scale: 1 -> 2"})
+
+	gomock.InOrder(
+		mockClient.EXPECT().
+			ScaleService("sid", 2).
+			Return(&models.Service{}, nil),
+		mockClient.EXPECT().
+			DeleteService("old_sid").
+			Return("jid", nil),
+		mockClient.EXPECT().
+			WaitForJob("jid", time.Minute).
+			Return(nil),
+	)
+
+	if err := plan.Apply(mockClient, time.Minute, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewPlan_replacementErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := testManifest(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+
+	mockClient.EXPECT().
+		ListEnvironments().
+		Return([]*models.EnvironmentSummary{{EnvironmentID: "eid", EnvironmentName: "prod"}}, nil)
+
+	mockClient.EXPECT().
+		GetEnvironment("eid").
+		Return(&models.Environment{EnvironmentID: "eid", InstanceSize: "t2.small", OperatingSystem: "linux"}, nil)
+
+	if _, err := NewPlan(mockClient, m, true); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
+
+func TestNewPlan_ruleReplacementError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := testManifest(t)
+	m.Services[0].HostHeader = "api.example.com"
+	mockClient := mock_client.NewMockClient(ctrl)
+
+	mockClient.EXPECT().
+		ListEnvironments().
+		Return([]*models.EnvironmentSummary{{EnvironmentID: "eid", EnvironmentName: "prod"}}, nil)
+
+	mockClient.EXPECT().
+		GetEnvironment("eid").
+		Return(&models.Environment{EnvironmentID: "eid", InstanceSize: "m3.medium", OperatingSystem: "linux"}, nil)
+
+	mockClient.EXPECT().
+		ListLoadBalancers().
+		Return([]*models.LoadBalancerSummary{{LoadBalancerID: "lid", LoadBalancerName: "web", EnvironmentID: "eid"}}, nil)
+
+	mockClient.EXPECT().
+		ListServices().
+		Return([]*models.ServiceSummary{{ServiceID: "sid", ServiceName: "web", EnvironmentID: "eid"}}, nil)
+
+	mockClient.EXPECT().
+		ListDeploys().
+		Return([]*models.DeploySummary{{DeployID: "web.1", DeployName: "web", Version: "1"}}, nil)
+
+	mockClient.EXPECT().
+		GetDeploy("web.1").
+		Return(&models.Deploy{DeployID: "web.1", Template: []byte("{}"), Version: "1"}, nil)
+
+	mockClient.EXPECT().
+		GetLoadBalancer("lid").
+		Return(&models.LoadBalancer{
+			CrossZone:        true,
+			HealthCheck:      m.LoadBalancers[0].healthCheck(),
+			IdleTimeout:      60,
+			IsPublic:         true,
+			LoadBalancerType: "elb",
+			Ports:            []models.Port{{HostPort: 80, ContainerPort: 80, Protocol: "TCP"}},
+			Rules:            []models.LoadBalancerRule{{PathPattern: "*", Priority: 1, ServiceID: "sid"}},
+		}, nil)
+
+	mockClient.EXPECT().
+		GetService("sid").
+		Return(&models.Service{
+			DesiredCount:     2,
+			LoadBalancerName: "web",
+			Deployments:      []models.Deployment{{DeployID: "web.1", Status: "PRIMARY"}},
+		}, nil)
+
+	if _, err := NewPlan(mockClient, m, true); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	current := models.LoadBalancerRule{HostHeader: "api.example.com", PathPattern: "/v1/*", Priority: 5, ServiceID: "sid"}
+	cases := map[models.LoadBalancerRule]bool{
+		{HostHeader: "api.example.com", PathPattern: "/v1/*"}:              true,
+		{HostHeader: "api.example.com", PathPattern: "/v1/*", Priority: 5}: true,
+		{HostHeader: "api.example.com", PathPattern: "/v1/*", Priority: 6}: false,
+		{HostHeader: "api.example.com", PathPattern: "/v2/*"}:              false,
+		{PathPattern: "/v1/*"}: false,
+	}
+
+	for desired, expected := range cases {
+		testutils.AssertEqual(t, ruleMatches(current, desired), expected)
+	}
+
+	testutils.AssertEqual(t, ruleMatches(models.LoadBalancerRule{PathPattern: "*", Priority: 1}, models.LoadBalancerRule{}), true)
+}
+
+func TestPlanDeletes_noPrune(t *testing.T) {
+	p := &planner{
+		manifest:   &Manifest{},
+		plan:       &Plan{Actions: []*Action{}, loadBalancerIDs: map[string]string{"old": "old_lid"}},
+		serviceIDs: map[string]string{"old": "old_sid"},
+	}
+
+	if err := p.planDeletes(); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(p.plan.Actions), 0)
+
+	p.prune = true
+	if err := p.planDeletes(); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, actionSummaries(p.plan), []string{"delete service old", "delete load_balancer old"})
+}
@@ -1,14 +1,21 @@
 package printer
 
 import (
+	"github.com/quintilesims/layer0/cli/manifest"
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/models"
 )
 
 type Printer interface {
 	StartSpinner(message string)
 	StopSpinner()
+	ClearScreen()
 	PrintDeploys(deploys ...*models.Deploy) error
+	PrintDeployDetails(deploys ...*models.Deploy) error
+	PrintDeployDiff(diff *models.DeployDiff) error
+	PrintDeployPruneReport(report *models.PruneDeploysReport) error
 	PrintDeploySummaries(deploys ...*models.DeploySummary) error
+	PrintDeployValidation(validation *models.DeployValidation) error
 	PrintEnvironments(environments ...*models.Environment) error
 	PrintEnvironmentSummaries(environments ...*models.EnvironmentSummary) error
 	PrintJobs(jobs ...*models.Job) error
@@ -17,10 +24,18 @@ type Printer interface {
 	PrintLoadBalancerHealthCheck(loadBalancer *models.LoadBalancer) error
 	PrintLoadBalancerIdleTimeout(loadBalancer *models.LoadBalancer) error
 	PrintLoadBalancerCrossZone(loadBalancer *models.LoadBalancer) error
+	PrintLoadBalancerAccessLogs(loadBalancer *models.LoadBalancer) error
+	PrintLoadBalancerConnectionDraining(loadBalancer *models.LoadBalancer) error
+	PrintLoadBalancerHealth(health *models.LoadBalancerHealth) error
+	PrintLabels(labels map[string]string) error
 	PrintLogs(logs ...*models.LogFile) error
+	PrintPlan(plan *manifest.Plan) error
+	PrintProfiles(current string, profiles ...*config.Profile) error
 	PrintScalerRunInfo(*models.ScalerRunInfo) error
+	PrintSecrets(secrets ...*models.Secret) error
 	PrintServices(services ...*models.Service) error
 	PrintServiceSummaries(services ...*models.ServiceSummary) error
+	PrintTaskDetails(tasks ...*models.Task) error
 	PrintTasks(tasks ...*models.Task) error
 	PrintTaskSummaries(tasks ...*models.TaskSummary) error
 	Printf(format string, tokens ...interface{})
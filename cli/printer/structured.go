@@ -0,0 +1,250 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/quintilesims/layer0/cli/manifest"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/models"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// StructuredPrinter prints the entities passed to each Print method with an encoding
+// rather than as a table; json, yaml and go templates are supported
+type StructuredPrinter struct {
+	encode func(obj interface{}) ([]byte, error)
+}
+
+func NewJSONPrinter() *StructuredPrinter {
+	return &StructuredPrinter{encode: encodeJSON}
+}
+
+func NewYAMLPrinter() *StructuredPrinter {
+	return &StructuredPrinter{encode: encodeYAML}
+}
+
+// NewTemplatePrinter executes text once for each entity, or once for entities that are not listed
+func NewTemplatePrinter(text string) (*StructuredPrinter, error) {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid output template: %v", err)
+	}
+
+	return &StructuredPrinter{encode: templateEncoder(tmpl)}, nil
+}
+
+type message struct {
+	Message string
+}
+
+type fatalMessage struct {
+	Code    int64
+	Message string
+}
+
+func (s *StructuredPrinter) StartSpinner(string) {}
+func (s *StructuredPrinter) StopSpinner()        {}
+func (s *StructuredPrinter) ClearScreen()        {}
+
+func (s *StructuredPrinter) Printf(format string, tokens ...interface{}) {
+	if err := s.print(message{Message: fmt.Sprintf(format, tokens...)}); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func (s *StructuredPrinter) Fatalf(code int64, format string, tokens ...interface{}) {
+	if err := s.print(fatalMessage{Code: code, Message: fmt.Sprintf(format, tokens...)}); err != nil {
+		fmt.Println(err)
+	}
+
+	os.Exit(1)
+}
+
+func (s *StructuredPrinter) print(obj interface{}) error {
+	data, err := s.encode(obj)
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	fmt.Println(strings.TrimSuffix(string(data), "\n"))
+	return nil
+}
+
+func encodeJSON(obj interface{}) ([]byte, error) {
+	return json.MarshalIndent(obj, "", "    ")
+}
+
+// encodeYAML converts obj through json so the yaml keys match the json output
+func encodeYAML(obj interface{}) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(value)
+}
+
+func templateEncoder(tmpl *template.Template) func(obj interface{}) ([]byte, error) {
+	return func(obj interface{}) ([]byte, error) {
+		// messages are printed as they are, since the template is written for entities
+		switch m := obj.(type) {
+		case message:
+			return []byte(m.Message), nil
+		case fatalMessage:
+			return []byte(m.Message), nil
+		}
+
+		var buffer bytes.Buffer
+		value := reflect.ValueOf(obj)
+		if value.Kind() != reflect.Slice {
+			if err := tmpl.Execute(&buffer, obj); err != nil {
+				return nil, err
+			}
+
+			return buffer.Bytes(), nil
+		}
+
+		for i := 0; i < value.Len(); i++ {
+			if err := tmpl.Execute(&buffer, value.Index(i).Interface()); err != nil {
+				return nil, err
+			}
+
+			buffer.WriteString("\n")
+		}
+
+		return buffer.Bytes(), nil
+	}
+}
+
+func (s *StructuredPrinter) PrintDeploys(deploys ...*models.Deploy) error {
+	return s.print(deploys)
+}
+
+func (s *StructuredPrinter) PrintDeployDetails(deploys ...*models.Deploy) error {
+	return s.print(deploys)
+}
+
+func (s *StructuredPrinter) PrintDeployDiff(diff *models.DeployDiff) error {
+	return s.print(diff)
+}
+
+func (s *StructuredPrinter) PrintDeployPruneReport(report *models.PruneDeploysReport) error {
+	return s.print(report)
+}
+
+func (s *StructuredPrinter) PrintDeploySummaries(deploys ...*models.DeploySummary) error {
+	return s.print(deploys)
+}
+
+func (s *StructuredPrinter) PrintDeployValidation(validation *models.DeployValidation) error {
+	return s.print(validation)
+}
+
+func (s *StructuredPrinter) PrintEnvironments(environments ...*models.Environment) error {
+	return s.print(environments)
+}
+
+func (s *StructuredPrinter) PrintEnvironmentSummaries(environments ...*models.EnvironmentSummary) error {
+	return s.print(environments)
+}
+
+func (s *StructuredPrinter) PrintJobs(jobs ...*models.Job) error {
+	return s.print(jobs)
+}
+
+func (s *StructuredPrinter) PrintLoadBalancers(loadBalancers ...*models.LoadBalancer) error {
+	return s.print(loadBalancers)
+}
+
+func (s *StructuredPrinter) PrintLoadBalancerSummaries(loadBalancers ...*models.LoadBalancerSummary) error {
+	return s.print(loadBalancers)
+}
+
+func (s *StructuredPrinter) PrintLoadBalancerHealthCheck(loadBalancer *models.LoadBalancer) error {
+	return s.print(loadBalancer)
+}
+
+func (s *StructuredPrinter) PrintLoadBalancerIdleTimeout(loadBalancer *models.LoadBalancer) error {
+	return s.print(loadBalancer)
+}
+
+func (s *StructuredPrinter) PrintLoadBalancerCrossZone(loadBalancer *models.LoadBalancer) error {
+	return s.print(loadBalancer)
+}
+
+func (s *StructuredPrinter) PrintLoadBalancerAccessLogs(loadBalancer *models.LoadBalancer) error {
+	return s.print(loadBalancer)
+}
+
+func (s *StructuredPrinter) PrintLoadBalancerConnectionDraining(loadBalancer *models.LoadBalancer) error {
+	return s.print(loadBalancer)
+}
+
+func (s *StructuredPrinter) PrintLoadBalancerHealth(health *models.LoadBalancerHealth) error {
+	return s.print(health)
+}
+
+func (s *StructuredPrinter) PrintLabels(labels map[string]string) error {
+	return s.print(labels)
+}
+
+func (s *StructuredPrinter) PrintLogs(logs ...*models.LogFile) error {
+	return s.print(logs)
+}
+
+func (s *StructuredPrinter) PrintPlan(plan *manifest.Plan) error {
+	return s.print(plan)
+}
+
+func (s *StructuredPrinter) PrintProfiles(current string, profiles ...*config.Profile) error {
+	return s.print(struct {
+		CurrentProfile string            `json:"current_profile"`
+		Profiles       []*config.Profile `json:"profiles"`
+	}{
+		CurrentProfile: current,
+		Profiles:       profiles,
+	})
+}
+
+func (s *StructuredPrinter) PrintScalerRunInfo(runInfo *models.ScalerRunInfo) error {
+	return s.print(runInfo)
+}
+
+func (s *StructuredPrinter) PrintSecrets(secrets ...*models.Secret) error {
+	return s.print(secrets)
+}
+
+func (s *StructuredPrinter) PrintServices(services ...*models.Service) error {
+	return s.print(services)
+}
+
+func (s *StructuredPrinter) PrintServiceSummaries(services ...*models.ServiceSummary) error {
+	return s.print(services)
+}
+
+func (s *StructuredPrinter) PrintTaskDetails(tasks ...*models.Task) error {
+	return s.print(tasks)
+}
+
+func (s *StructuredPrinter) PrintTasks(tasks ...*models.Task) error {
+	return s.print(tasks)
+}
+
+func (s *StructuredPrinter) PrintTaskSummaries(tasks ...*models.TaskSummary) error {
+	return s.print(tasks)
+}
@@ -0,0 +1,62 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+)
+
+func ExampleYAMLPrintServiceSummaries() {
+	printer := NewYAMLPrinter()
+	services := []*models.ServiceSummary{
+		{ServiceID: "id1", ServiceName: "svc1", EnvironmentID: "eid1", EnvironmentName: "ename1"},
+	}
+
+	printer.PrintServiceSummaries(services...)
+	// Output:
+	//- environment_id: eid1
+	//   environment_name: ename1
+	//   service_id: id1
+	//   service_name: svc1
+}
+
+func ExampleTemplatePrintServices() {
+	printer, err := NewTemplatePrinter("{{.ServiceName}} {{.RunningCount}}")
+	if err != nil {
+		panic(err)
+	}
+
+	services := []*models.Service{
+		{ServiceName: "svc1", RunningCount: 1},
+		{ServiceName: "svc2", RunningCount: 2},
+	}
+
+	printer.PrintServices(services...)
+	printer.Printf("done")
+	// Output:
+	//svc1 1
+	//svc2 2
+	//done
+}
+
+func ExampleTemplatePrintLoadBalancerHealthCheck() {
+	printer, err := NewTemplatePrinter("{{.LoadBalancerName}} {{.HealthCheck.Target}}")
+	if err != nil {
+		panic(err)
+	}
+
+	loadBalancer := &models.LoadBalancer{
+		LoadBalancerName: "lb1",
+		HealthCheck:      models.HealthCheck{Target: "HTTP:80/health"},
+	}
+
+	printer.PrintLoadBalancerHealthCheck(loadBalancer)
+	// Output:
+	//lb1 HTTP:80/health
+}
+
+func TestNewTemplatePrinter_invalid(t *testing.T) {
+	if _, err := NewTemplatePrinter("{{.ServiceName"); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
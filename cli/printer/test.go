@@ -1,6 +1,8 @@
 package printer
 
 import (
+	"github.com/quintilesims/layer0/cli/manifest"
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/models"
 )
 
@@ -10,10 +12,15 @@ type TestPrinter struct{}
 
 func (t *TestPrinter) StartSpinner(string)                                             {}
 func (t *TestPrinter) StopSpinner()                                                    {}
+func (t *TestPrinter) ClearScreen()                                                    {}
 func (t *TestPrinter) Printf(string, ...interface{})                                   {}
 func (t *TestPrinter) Fatalf(int64, string, ...interface{})                            {}
 func (t *TestPrinter) PrintDeploys(...*models.Deploy) error                            { return nil }
+func (t *TestPrinter) PrintDeployDetails(...*models.Deploy) error                      { return nil }
+func (t *TestPrinter) PrintDeployDiff(*models.DeployDiff) error                        { return nil }
+func (t *TestPrinter) PrintDeployPruneReport(*models.PruneDeploysReport) error         { return nil }
 func (t *TestPrinter) PrintDeploySummaries(...*models.DeploySummary) error             { return nil }
+func (t *TestPrinter) PrintDeployValidation(*models.DeployValidation) error            { return nil }
 func (t *TestPrinter) PrintEnvironments(...*models.Environment) error                  { return nil }
 func (t *TestPrinter) PrintEnvironmentSummaries(...*models.EnvironmentSummary) error   { return nil }
 func (t *TestPrinter) PrintJobs(...*models.Job) error                                  { return nil }
@@ -22,9 +29,17 @@ func (t *TestPrinter) PrintLoadBalancerSummaries(...*models.LoadBalancerSummary)
 func (t *TestPrinter) PrintLoadBalancerHealthCheck(*models.LoadBalancer) error         { return nil }
 func (t *TestPrinter) PrintLoadBalancerIdleTimeout(*models.LoadBalancer) error         { return nil }
 func (t *TestPrinter) PrintLoadBalancerCrossZone(*models.LoadBalancer) error           { return nil }
+func (t *TestPrinter) PrintLoadBalancerAccessLogs(*models.LoadBalancer) error          { return nil }
+func (t *TestPrinter) PrintLoadBalancerConnectionDraining(*models.LoadBalancer) error  { return nil }
+func (t *TestPrinter) PrintLoadBalancerHealth(*models.LoadBalancerHealth) error        { return nil }
+func (t *TestPrinter) PrintLabels(map[string]string) error                             { return nil }
 func (t *TestPrinter) PrintLogs(...*models.LogFile) error                              { return nil }
+func (t *TestPrinter) PrintPlan(*manifest.Plan) error                                  { return nil }
+func (t *TestPrinter) PrintProfiles(string, ...*config.Profile) error                  { return nil }
 func (t *TestPrinter) PrintScalerRunInfo(*models.ScalerRunInfo) error                  { return nil }
+func (t *TestPrinter) PrintSecrets(...*models.Secret) error                            { return nil }
 func (t *TestPrinter) PrintServices(...*models.Service) error                          { return nil }
 func (t *TestPrinter) PrintServiceSummaries(...*models.ServiceSummary) error           { return nil }
+func (t *TestPrinter) PrintTaskDetails(...*models.Task) error                          { return nil }
 func (t *TestPrinter) PrintTasks(...*models.Task) error                                { return nil }
 func (t *TestPrinter) PrintTaskSummaries(...*models.TaskSummary) error                 { return nil }
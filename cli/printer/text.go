@@ -1,12 +1,19 @@
 package printer
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
+	"github.com/chzyer/readline"
+	"github.com/quintilesims/layer0/cli/manifest"
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/types"
 	"github.com/ryanuber/columnize"
@@ -15,6 +22,8 @@ import (
 const TIME_FORMAT = "2006-01-02 15:04:05"
 
 type TextPrinter struct {
+	// Wide adds columns with extra details to the tables that support them
+	Wide    bool
 	spinner *spinner.Spinner
 }
 
@@ -35,6 +44,13 @@ func (t *TextPrinter) StopSpinner() {
 	}
 }
 
+// ClearScreen moves the cursor to the top of an empty terminal so the next table is redrawn in place
+func (t *TextPrinter) ClearScreen() {
+	if readline.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Print("\033[H\033[2J")
+	}
+}
+
 func (t *TextPrinter) Printf(format string, tokens ...interface{}) {
 	t.StopSpinner()
 	fmt.Printf(format, tokens...)
@@ -57,6 +73,115 @@ func (t *TextPrinter) PrintDeploys(deploys ...*models.Deploy) error {
 	return nil
 }
 
+func (t *TextPrinter) PrintDeployDetails(deploys ...*models.Deploy) error {
+	for _, d := range deploys {
+		if err := t.PrintDeploys(d); err != nil {
+			return err
+		}
+
+		if len(d.Variables) > 0 {
+			keys := []string{}
+			for key := range d.Variables {
+				keys = append(keys, key)
+			}
+
+			sort.Strings(keys)
+
+			fmt.Println("Variables:")
+			for _, key := range keys {
+				fmt.Printf("  %s=%s\n", key, d.Variables[key])
+			}
+
+			fmt.Println()
+		}
+
+		if len(d.Template) > 0 {
+			fmt.Println("Template:")
+			fmt.Println(strings.TrimSpace(string(d.Template)))
+			fmt.Println()
+		}
+
+		dockerrun := d.Dockerrun
+		var buffer bytes.Buffer
+		if err := json.Indent(&buffer, d.Dockerrun, "", "  "); err == nil {
+			dockerrun = buffer.Bytes()
+		}
+
+		fmt.Println("Dockerrun:")
+		fmt.Println(string(dockerrun))
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func (t *TextPrinter) PrintDeployDiff(diff *models.DeployDiff) error {
+	if len(diff.Containers) == 0 && len(diff.Volumes) == 0 {
+		fmt.Println("No differences")
+		return nil
+	}
+
+	valueOrDash := func(v string) string {
+		if v == "" {
+			return "-"
+		}
+
+		return v
+	}
+
+	rows := []string{"CONTAINER | STATUS | FIELD | FROM | TO"}
+	for _, c := range diff.Containers {
+		for _, change := range c.Changes {
+			row := fmt.Sprintf("%s | %s | %s | %s | %s",
+				c.ContainerName,
+				c.Status,
+				change.Field,
+				valueOrDash(change.From),
+				valueOrDash(change.To))
+
+			rows = append(rows, row)
+		}
+	}
+
+	for _, change := range diff.Volumes {
+		row := fmt.Sprintf("- | - | %s | %s | %s",
+			change.Field,
+			valueOrDash(change.From),
+			valueOrDash(change.To))
+
+		rows = append(rows, row)
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
+func (t *TextPrinter) PrintDeployPruneReport(report *models.PruneDeploysReport) error {
+	if len(report.Pruned) == 0 {
+		fmt.Println("No deploys to prune")
+		return nil
+	}
+
+	status := "pruned"
+	if report.DryRun {
+		status = "would prune"
+	}
+
+	rows := []string{"DEPLOY ID | DEPLOY NAME | VERSION | STATUS"}
+	for _, d := range report.Pruned {
+		row := fmt.Sprintf("%s | %s | %s | %s",
+			d.DeployID,
+			d.DeployName,
+			d.Version,
+			status)
+
+		rows = append(rows, row)
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
 func (t *TextPrinter) PrintDeploySummaries(deploys ...*models.DeploySummary) error {
 	rows := []string{"DEPLOY ID | DEPLOY NAME | VERSION"}
 	for _, d := range deploys {
@@ -68,16 +193,40 @@ func (t *TextPrinter) PrintDeploySummaries(deploys ...*models.DeploySummary) err
 	return nil
 }
 
+func (t *TextPrinter) PrintDeployValidation(validation *models.DeployValidation) error {
+	if len(validation.Errors) == 0 && len(validation.Warnings) == 0 {
+		fmt.Println("Deploy is valid")
+		return nil
+	}
+
+	rows := []string{"LEVEL | MESSAGE"}
+	for _, e := range validation.Errors {
+		rows = append(rows, fmt.Sprintf("error | %s", e))
+	}
+
+	for _, w := range validation.Warnings {
+		rows = append(rows, fmt.Sprintf("warning | %s", w))
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
 func (t *TextPrinter) PrintEnvironments(environments ...*models.Environment) error {
 	getLink := func(e *models.Environment, i int) string {
 		if i > len(e.Links)-1 {
 			return ""
 		}
 
-		return e.Links[i]
+		return formatEnvironmentLink(e.Links[i])
+	}
+
+	header := "ENVIRONMENT ID | ENVIRONMENT NAME | OS | CLUSTER COUNT | INSTANCE SIZE | LINKS"
+	if t.Wide {
+		header += " | AMI | SECURITY GROUP"
 	}
 
-	rows := []string{"ENVIRONMENT ID | ENVIRONMENT NAME | OS | CLUSTER COUNT | INSTANCE SIZE | LINKS"}
+	rows := []string{header}
 	for _, e := range environments {
 		row := fmt.Sprintf("%s | %s | %s | %d | %s | %s",
 			e.EnvironmentID,
@@ -87,6 +236,10 @@ func (t *TextPrinter) PrintEnvironments(environments ...*models.Environment) err
 			e.InstanceSize,
 			getLink(e, 0))
 
+		if t.Wide {
+			row += fmt.Sprintf(" | %s | %s", e.AMIID, e.SecurityGroupID)
+		}
+
 		rows = append(rows, row)
 
 		// add the extra link rows
@@ -100,6 +253,30 @@ func (t *TextPrinter) PrintEnvironments(environments ...*models.Environment) err
 	return nil
 }
 
+func formatEnvironmentLink(link models.EnvironmentLink) string {
+	if link.Direction == "both" && len(link.Rules) == 0 {
+		return link.Peer()
+	}
+
+	rules := []string{}
+	for _, rule := range link.Rules {
+		switch {
+		case rule.Protocol == "all" || rule.Protocol == "icmp":
+			rules = append(rules, rule.Protocol)
+		case rule.FromPort == rule.ToPort:
+			rules = append(rules, fmt.Sprintf("%d/%s", rule.FromPort, rule.Protocol))
+		default:
+			rules = append(rules, fmt.Sprintf("%d-%d/%s", rule.FromPort, rule.ToPort, rule.Protocol))
+		}
+	}
+
+	if len(rules) == 0 {
+		rules = append(rules, "all")
+	}
+
+	return fmt.Sprintf("%s (%s %s)", link.Peer(), link.Direction, strings.Join(rules, ","))
+}
+
 func (t *TextPrinter) PrintEnvironmentSummaries(environments ...*models.EnvironmentSummary) error {
 	rows := []string{"ENVIRONMENT ID | ENVIRONMENT NAME | OS "}
 	for _, e := range environments {
@@ -147,12 +324,27 @@ func (t *TextPrinter) PrintLoadBalancers(loadBalancers ...*models.LoadBalancer)
 		return l.EnvironmentID
 	}
 
-	getService := func(l *models.LoadBalancer) string {
-		if l.ServiceName != "" {
-			return l.ServiceName
+	// application load balancers list each service along with its routing rule
+	getServices := func(l *models.LoadBalancer) []string {
+		if len(l.Rules) == 0 {
+			if l.ServiceName != "" {
+				return []string{l.ServiceName}
+			}
+
+			return []string{l.ServiceID}
 		}
 
-		return l.ServiceID
+		services := []string{}
+		for _, r := range l.Rules {
+			service := r.ServiceName
+			if service == "" {
+				service = r.ServiceID
+			}
+
+			services = append(services, fmt.Sprintf("%s (%s%s)", service, r.HostHeader, r.PathPattern))
+		}
+
+		return services
 	}
 
 	getPort := func(l *models.LoadBalancer, i int) string {
@@ -164,23 +356,45 @@ func (t *TextPrinter) PrintLoadBalancers(loadBalancers ...*models.LoadBalancer)
 		return fmt.Sprintf("%d:%d/%s", p.HostPort, p.ContainerPort, strings.ToUpper(p.Protocol))
 	}
 
-	rows := []string{"LOADBALANCER ID | LOADBALANCER NAME | ENVIRONMENT | SERVICE | PORTS | PUBLIC | URL | IDLE TIMEOUT "}
+	getService := func(services []string, i int) string {
+		if i > len(services)-1 {
+			return ""
+		}
+
+		return services[i]
+	}
+
+	header := "LOADBALANCER ID | LOADBALANCER NAME | ENVIRONMENT | SERVICE | PORTS | PUBLIC | URL | IDLE TIMEOUT "
+	if t.Wide {
+		header += " | TYPE | CROSS-ZONE | HEALTH CHECK"
+	}
+
+	rows := []string{header}
 	for _, l := range loadBalancers {
+		services := getServices(l)
 		row := fmt.Sprintf("%s | %s | %s | %s | %s | %t | %s | %d",
 			l.LoadBalancerID,
 			l.LoadBalancerName,
 			getEnvironment(l),
-			getService(l),
+			getService(services, 0),
 			getPort(l, 0),
 			l.IsPublic,
 			l.URL,
 			l.IdleTimeout)
 
+		if t.Wide {
+			row += fmt.Sprintf(" | %s | %t | %s", l.LoadBalancerType, l.CrossZone, l.HealthCheck.Target)
+		}
+
 		rows = append(rows, row)
 
-		// add the extra port rows
-		for i := 1; i < len(l.Ports); i++ {
-			row := fmt.Sprintf(" | | | | %s | |", getPort(l, i))
+		// add the extra port and service rows
+		for i := 1; i < len(l.Ports) || i < len(services); i++ {
+			row := fmt.Sprintf(" | | | %s", getService(services, i))
+			if port := getPort(l, i); port != "" {
+				row += fmt.Sprintf(" | %s | |", port)
+			}
+
 			rows = append(rows, row)
 		}
 	}
@@ -282,6 +496,135 @@ func (t *TextPrinter) PrintLoadBalancerCrossZone(loadBalancer *models.LoadBalanc
 	return nil
 }
 
+func (t *TextPrinter) PrintLoadBalancerAccessLogs(loadBalancer *models.LoadBalancer) error {
+	getEnvironment := func(l *models.LoadBalancer) string {
+		if l.EnvironmentName != "" {
+			return l.EnvironmentName
+		}
+
+		return l.EnvironmentID
+	}
+
+	getAccessLogs := func(l *models.LoadBalancer) string {
+		if !l.AccessLogs.Enabled {
+			return "disabled"
+		}
+
+		location := fmt.Sprintf("s3://%s", l.AccessLogs.S3BucketName)
+		if l.AccessLogs.S3BucketPrefix != "" {
+			location = fmt.Sprintf("%s/%s", location, l.AccessLogs.S3BucketPrefix)
+		}
+
+		return fmt.Sprintf("%s (every %dm)", location, l.AccessLogs.EmitInterval)
+	}
+
+	rows := []string{"LOADBALANCER ID | LOADBALANCER NAME | ENVIRONMENT | ACCESS LOGS "}
+	row := fmt.Sprintf("%s | %s | %s | %s",
+		loadBalancer.LoadBalancerID,
+		loadBalancer.LoadBalancerName,
+		getEnvironment(loadBalancer),
+		getAccessLogs(loadBalancer))
+
+	rows = append(rows, row)
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
+func (t *TextPrinter) PrintLoadBalancerConnectionDraining(loadBalancer *models.LoadBalancer) error {
+	getEnvironment := func(l *models.LoadBalancer) string {
+		if l.EnvironmentName != "" {
+			return l.EnvironmentName
+		}
+
+		return l.EnvironmentID
+	}
+
+	getConnectionDraining := func(l *models.LoadBalancer) string {
+		if !l.ConnectionDraining.Enabled {
+			return "disabled"
+		}
+
+		return fmt.Sprintf("%ds", l.ConnectionDraining.Timeout)
+	}
+
+	rows := []string{"LOADBALANCER ID | LOADBALANCER NAME | ENVIRONMENT | CONNECTION DRAINING "}
+	row := fmt.Sprintf("%s | %s | %s | %s",
+		loadBalancer.LoadBalancerID,
+		loadBalancer.LoadBalancerName,
+		getEnvironment(loadBalancer),
+		getConnectionDraining(loadBalancer))
+
+	rows = append(rows, row)
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
+func (t *TextPrinter) PrintLoadBalancerHealth(health *models.LoadBalancerHealth) error {
+	getService := func(i models.InstanceHealth) string {
+		if i.ServiceName != "" {
+			return i.ServiceName
+		}
+
+		return i.ServiceID
+	}
+
+	getTask := func(i models.InstanceHealth, j int) string {
+		if j > len(i.TaskIDs)-1 {
+			return ""
+		}
+
+		return i.TaskIDs[j]
+	}
+
+	getReason := func(i models.InstanceHealth) string {
+		if i.Description != "" {
+			return i.Description
+		}
+
+		return i.ReasonCode
+	}
+
+	rows := []string{"INSTANCE ID | STATE | REASON | SERVICE | TASKS "}
+	for _, i := range health.Instances {
+		row := fmt.Sprintf("%s | %s | %s | %s | %s",
+			i.InstanceID,
+			i.State,
+			getReason(i),
+			getService(i),
+			getTask(i, 0))
+
+		rows = append(rows, row)
+
+		// add the extra task rows
+		for j := 1; j < len(i.TaskIDs); j++ {
+			row := fmt.Sprintf(" | | | | %s", getTask(i, j))
+			rows = append(rows, row)
+		}
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
+func (t *TextPrinter) PrintLabels(labels map[string]string) error {
+	keys := []string{}
+	for key := range labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	rows := []string{"KEY | VALUE"}
+	for _, key := range keys {
+		rows = append(rows, fmt.Sprintf("%s | %s", key, labels[key]))
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
 func (t *TextPrinter) PrintLogs(logs ...*models.LogFile) error {
 	for _, l := range logs {
 		fmt.Println(l.Name)
@@ -299,6 +642,56 @@ func (t *TextPrinter) PrintLogs(logs ...*models.LogFile) error {
 	return nil
 }
 
+func (t *TextPrinter) PrintPlan(plan *manifest.Plan) error {
+	if len(plan.Actions) == 0 {
+		fmt.Printf("No changes; environment '%s' matches the manifest\n", plan.Environment)
+		return nil
+	}
+
+	symbols := map[string]string{
+		manifest.ActionCreate: "+",
+		manifest.ActionUpdate: "~",
+		manifest.ActionDelete: "-",
+	}
+
+	for _, action := range plan.Actions {
+		fmt.Printf("%s %s %s %s\n", symbols[action.Type], action.Type, action.EntityType, action.Name)
+		for _, change := range action.Changes {
+			fmt.Printf("    %s\n", change)
+		}
+	}
+
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to delete.\n",
+		plan.Count(manifest.ActionCreate),
+		plan.Count(manifest.ActionUpdate),
+		plan.Count(manifest.ActionDelete))
+
+	return nil
+}
+
+func (t *TextPrinter) PrintProfiles(current string, profiles ...*config.Profile) error {
+	getName := func(p *config.Profile) string {
+		if p.Name == current {
+			return fmt.Sprintf("%s (current)", p.Name)
+		}
+
+		return p.Name
+	}
+
+	rows := []string{"PROFILE | ENDPOINT | CONFIRM"}
+	for _, p := range profiles {
+		row := fmt.Sprintf("%s | %s | %t",
+			getName(p),
+			p.Endpoint,
+			p.Confirm)
+
+		rows = append(rows, row)
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
 func (t *TextPrinter) PrintScalerRunInfo(runInfo *models.ScalerRunInfo) error {
 	rows := []string{
 		"ENVIRONMENT | CURRENT SCALE | DESIRED SCALE",
@@ -309,6 +702,28 @@ func (t *TextPrinter) PrintScalerRunInfo(runInfo *models.ScalerRunInfo) error {
 	return nil
 }
 
+func (t *TextPrinter) PrintSecrets(secrets ...*models.Secret) error {
+	getEnvironment := func(s *models.Secret) string {
+		if s.EnvironmentName != "" {
+			return s.EnvironmentName
+		}
+
+		return s.EnvironmentID
+	}
+
+	rows := []string{"SECRET NAME | ENVIRONMENT"}
+	for _, s := range secrets {
+		row := fmt.Sprintf("%s | %s",
+			s.SecretName,
+			getEnvironment(s))
+
+		rows = append(rows, row)
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
 func (t *TextPrinter) PrintServices(services ...*models.Service) error {
 	getEnvironment := func(s *models.Service) string {
 		if s.EnvironmentName != "" {
@@ -353,7 +768,26 @@ func (t *TextPrinter) PrintServices(services ...*models.Service) error {
 		return scale
 	}
 
-	rows := []string{"SERVICE ID | SERVICE NAME | ENVIRONMENT | LOADBALANCER | DEPLOYMENTS | SCALE "}
+	// the wide columns show the id, status and scale of each deployment
+	getDeploymentDetails := func(s *models.Service, i int) string {
+		if i > len(s.Deployments)-1 {
+			return " | | | "
+		}
+
+		deployment := s.Deployments[i]
+		return fmt.Sprintf(" | %s | %s | %d/%d",
+			deployment.DeployID,
+			deployment.Status,
+			deployment.RunningCount,
+			deployment.DesiredCount)
+	}
+
+	header := "SERVICE ID | SERVICE NAME | ENVIRONMENT | LOADBALANCER | DEPLOYMENTS | SCALE "
+	if t.Wide {
+		header += " | DEPLOY ID | DEPLOYMENT STATUS | DEPLOYMENT SCALE"
+	}
+
+	rows := []string{header}
 	for _, s := range services {
 		row := fmt.Sprintf("%s | %s | %s | %s | %s | %s",
 			s.ServiceID,
@@ -363,11 +797,19 @@ func (t *TextPrinter) PrintServices(services ...*models.Service) error {
 			getDeployment(s, 0),
 			getScale(s))
 
+		if t.Wide {
+			row += getDeploymentDetails(s, 0)
+		}
+
 		rows = append(rows, row)
 
 		// add the extra deployment rows
 		for i := 1; i < len(s.Deployments); i++ {
 			row := fmt.Sprintf(" | | | | %s | ", getDeployment(s, i))
+			if t.Wide {
+				row += getDeploymentDetails(s, i)
+			}
+
 			rows = append(rows, row)
 		}
 	}
@@ -399,6 +841,56 @@ func (t *TextPrinter) PrintServiceSummaries(services ...*models.ServiceSummary)
 	return nil
 }
 
+func (t *TextPrinter) PrintTaskDetails(tasks ...*models.Task) error {
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+
+		return s
+	}
+
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+
+		return t.Format(TIME_FORMAT)
+	}
+
+	rows := []string{"TASK ID | TASK NAME | CONTAINER | STATUS | EXIT CODE | STARTED | STOPPED | REASON"}
+	for _, task := range tasks {
+		for _, copy := range task.Copies {
+			for _, detail := range copy.Details {
+				exitCode := "-"
+				if detail.LastStatus == "STOPPED" {
+					exitCode = strconv.FormatInt(detail.ExitCode, 10)
+				}
+
+				reason := detail.Reason
+				if reason == "" {
+					reason = copy.Reason
+				}
+
+				row := fmt.Sprintf("%s | %s | %s | %s | %s | %s | %s | %s",
+					task.TaskID,
+					orDash(task.TaskName),
+					detail.ContainerName,
+					orDash(detail.LastStatus),
+					exitCode,
+					formatTime(copy.StartedAt),
+					formatTime(copy.StoppedAt),
+					orDash(reason))
+
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
 func (t *TextPrinter) PrintTasks(tasks ...*models.Task) error {
 	getEnvironment := func(t *models.Task) string {
 		if t.EnvironmentName != "" {
@@ -425,16 +917,40 @@ func (t *TextPrinter) PrintTasks(tasks ...*models.Task) error {
 		return scale
 	}
 
-	rows := []string{"TASK ID | TASK NAME | ENVIRONMENT | DEPLOY | COUNT "}
-	for _, t := range tasks {
+	// the wide columns show the deploy id, along with the ecs task and status of each copy
+	getCopy := func(t *models.Task, i int) string {
+		if i > len(t.Copies)-1 {
+			return " | | "
+		}
+
+		copy := t.Copies[i]
+		return fmt.Sprintf(" | %s | %s", copy.TaskCopyID, copy.LastStatus)
+	}
+
+	header := "TASK ID | TASK NAME | ENVIRONMENT | DEPLOY | COUNT "
+	if t.Wide {
+		header += " | DEPLOY ID | TASK COPY | STATUS"
+	}
+
+	rows := []string{header}
+	for _, task := range tasks {
 		row := fmt.Sprintf("%s | %s | %s | %s | %s",
-			t.TaskID,
-			t.TaskName,
-			getEnvironment(t),
-			getDeploy(t),
-			getScale(t))
+			task.TaskID,
+			task.TaskName,
+			getEnvironment(task),
+			getDeploy(task),
+			getScale(task))
+
+		if t.Wide {
+			row += fmt.Sprintf(" | %s%s", task.DeployID, getCopy(task, 0))
+		}
 
 		rows = append(rows, row)
+
+		// add the extra copy rows
+		for i := 1; t.Wide && i < len(task.Copies); i++ {
+			rows = append(rows, " | | | | | "+getCopy(task, i))
+		}
 	}
 
 	fmt.Println(columnize.SimpleFormat(rows))
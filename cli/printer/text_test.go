@@ -3,6 +3,8 @@ package printer
 import (
 	"time"
 
+	"github.com/quintilesims/layer0/cli/manifest"
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/types"
 )
@@ -23,6 +25,72 @@ func ExampleTextPrintDeploys() {
 	// id2        name2        2
 }
 
+func ExampleTextPrintDeployDetails() {
+	printer := &TextPrinter{}
+	deploy := &models.Deploy{
+		DeployID:   "id1",
+		DeployName: "name1",
+		Version:    "1",
+		Dockerrun:  []byte(`{"containerDefinitions":[{"image":"app:v2"}]}`),
+		Template:   []byte(`{"containerDefinitions":[{"image":"app:{{ .tag }}"}]}`),
+		Variables:  map[string]string{"tag": "v2", "env": "prod"},
+	}
+
+	printer.PrintDeployDetails(deploy)
+	// Output:
+	// DEPLOY ID  DEPLOY NAME  VERSION
+	// id1        name1        1
+	// Variables:
+	//   env=prod
+	//   tag=v2
+	//
+	// Template:
+	// {"containerDefinitions":[{"image":"app:{{ .tag }}"}]}
+	//
+	// Dockerrun:
+	// {
+	//   "containerDefinitions": [
+	//     {
+	//       "image": "app:v2"
+	//     }
+	//   ]
+	// }
+}
+
+func ExampleTextPrintDeployDiff() {
+	printer := &TextPrinter{}
+	diff := &models.DeployDiff{
+		Containers: []models.ContainerDiff{
+			{
+				ContainerName: "api",
+				Status:        "changed",
+				Changes: []models.DeployChange{
+					{Field: "environment.DEBUG", From: "false"},
+					{Field: "image", From: "api:1", To: "api:2"},
+				},
+			},
+			{
+				ContainerName: "worker",
+				Status:        "added",
+				Changes: []models.DeployChange{
+					{Field: "image", To: "worker:1"},
+				},
+			},
+		},
+		Volumes: []models.DeployChange{
+			{Field: "volume.data", From: "/var/data", To: "docker managed"},
+		},
+	}
+
+	printer.PrintDeployDiff(diff)
+	// Output:
+	// CONTAINER  STATUS   FIELD              FROM       TO
+	// api        changed  environment.DEBUG  false      -
+	// api        changed  image              api:1      api:2
+	// worker     added    image              -          worker:1
+	// -          -        volume.data        /var/data  docker managed
+}
+
 func ExampleTextPrintDeploySummaries() {
 	printer := &TextPrinter{}
 	deploys := []*models.DeploySummary{
@@ -37,6 +105,37 @@ func ExampleTextPrintDeploySummaries() {
 	// id2        name2        2
 }
 
+func ExampleTextPrintDeployPruneReport() {
+	printer := &TextPrinter{}
+	report := &models.PruneDeploysReport{
+		DryRun: true,
+		Pruned: []models.DeploySummary{
+			{DeployID: "id.1", DeployName: "name", Version: "1"},
+			{DeployID: "id.2", DeployName: "name", Version: "2"},
+		},
+	}
+
+	printer.PrintDeployPruneReport(report)
+	// Output:
+	// DEPLOY ID  DEPLOY NAME  VERSION  STATUS
+	// id.1       name         1        would prune
+	// id.2       name         2        would prune
+}
+
+func ExampleTextPrintDeployValidation() {
+	printer := &TextPrinter{}
+	validation := &models.DeployValidation{
+		Errors:   []string{"Container 'api' must specify 'memory' or 'memoryReservation'"},
+		Warnings: []string{"Unknown field 'imagee' in container definition 0 will be ignored"},
+	}
+
+	printer.PrintDeployValidation(validation)
+	// Output:
+	// LEVEL    MESSAGE
+	// error    Container 'api' must specify 'memory' or 'memoryReservation'
+	// warning  Unknown field 'imagee' in container definition 0 will be ignored
+}
+
 func ExampleTextPrintEnvironments() {
 	printer := &TextPrinter{}
 	environments := []*models.Environment{
@@ -46,7 +145,9 @@ func ExampleTextPrintEnvironments() {
 			OperatingSystem: "linux",
 			ClusterCount:    1,
 			InstanceSize:    "m3.medium",
-			Links:           []string{"id2"},
+			Links: []models.EnvironmentLink{
+				{EnvironmentID: "id2", Direction: "both"},
+			},
 		},
 		{
 			EnvironmentID:   "id2",
@@ -54,7 +155,13 @@ func ExampleTextPrintEnvironments() {
 			OperatingSystem: "windows",
 			ClusterCount:    2,
 			InstanceSize:    "m3.xlarge",
-			Links:           []string{"id1", "api"},
+			Links: []models.EnvironmentLink{
+				{EnvironmentID: "id1", Direction: "both"},
+				{EnvironmentID: "api", Direction: "outbound", Rules: []models.EnvironmentLinkRule{
+					{Protocol: "tcp", FromPort: 443, ToPort: 443},
+				}},
+				{CIDR: "10.0.0.0/16", Direction: "inbound"},
+			},
 		},
 	}
 
@@ -63,7 +170,8 @@ func ExampleTextPrintEnvironments() {
 	// ENVIRONMENT ID  ENVIRONMENT NAME  OS       CLUSTER COUNT  INSTANCE SIZE  LINKS
 	// id1             name1             linux    1              m3.medium      id2
 	// id2             name2             windows  2              m3.xlarge      id1
-	//                                                                          api
+	//                                                                          api (outbound 443/tcp)
+	//                                                                          10.0.0.0/16 (inbound all)
 }
 
 func ExampleTextPrintEnvironmentSummaries() {
@@ -144,6 +252,26 @@ func ExampleTextPrintLoadBalancers() {
 			},
 			IdleTimeout: 80,
 		},
+		{
+			LoadBalancerID:   "id3",
+			LoadBalancerName: "lb3",
+			LoadBalancerType: "alb",
+			EnvironmentID:    "eid3",
+			IsPublic:         true,
+			URL:              "url3",
+			Ports: []models.Port{
+				{
+					HostPort:      80,
+					ContainerPort: 8080,
+					Protocol:      "http",
+				},
+			},
+			Rules: []models.LoadBalancerRule{
+				{ServiceID: "sid3", ServiceName: "sname3", PathPattern: "/api/*", Priority: 1},
+				{ServiceID: "sid4", HostHeader: "example.com", Priority: 2},
+			},
+			IdleTimeout: 60,
+		},
 		{
 			LoadBalancerID:   "id2",
 			LoadBalancerName: "lb2",
@@ -169,10 +297,12 @@ func ExampleTextPrintLoadBalancers() {
 
 	printer.PrintLoadBalancers(loadBalancers...)
 	// Output:
-	// LOADBALANCER ID  LOADBALANCER NAME  ENVIRONMENT  SERVICE  PORTS         PUBLIC  URL   IDLE TIMEOUT
-	// id1              lb1                ename1       sname1   80:80/HTTP    true    url1  80
-	// id2              lb2                eid2         sid2     443:80/HTTPS  false   url2  90
-	//                                                           22:22/TCP
+	// LOADBALANCER ID  LOADBALANCER NAME  ENVIRONMENT  SERVICE             PORTS         PUBLIC  URL   IDLE TIMEOUT
+	// id1              lb1                ename1       sname1              80:80/HTTP    true    url1  80
+	// id3              lb3                eid3         sname3 (/api/*)     80:8080/HTTP  true    url3  60
+	//                                                  sid4 (example.com)
+	// id2              lb2                eid2         sid2                443:80/HTTPS  false   url2  90
+	//                                                                      22:22/TCP
 
 }
 
@@ -310,6 +440,108 @@ func ExampleTextPrintLoadBalancerCrossZone() {
 	// id2              lb2                eid1         false
 }
 
+func ExampleTextPrintLoadBalancerAccessLogs() {
+	printer := &TextPrinter{}
+	loadBalancer1 := &models.LoadBalancer{
+		LoadBalancerID:   "id1",
+		LoadBalancerName: "lb1",
+		EnvironmentID:    "eid1",
+		EnvironmentName:  "ename1",
+		AccessLogs: models.AccessLogs{
+			Enabled:        true,
+			S3BucketName:   "bucket",
+			S3BucketPrefix: "lb1",
+			EmitInterval:   5,
+		},
+	}
+
+	loadBalancer2 := &models.LoadBalancer{
+		LoadBalancerID:   "id2",
+		LoadBalancerName: "lb2",
+		EnvironmentID:    "eid1",
+	}
+
+	printer.PrintLoadBalancerAccessLogs(loadBalancer1)
+	printer.PrintLoadBalancerAccessLogs(loadBalancer2)
+	// Output:
+	// LOADBALANCER ID  LOADBALANCER NAME  ENVIRONMENT  ACCESS LOGS
+	// id1              lb1                ename1       s3://bucket/lb1 (every 5m)
+	// LOADBALANCER ID  LOADBALANCER NAME  ENVIRONMENT  ACCESS LOGS
+	// id2              lb2                eid1         disabled
+}
+
+func ExampleTextPrintLoadBalancerConnectionDraining() {
+	printer := &TextPrinter{}
+	loadBalancer1 := &models.LoadBalancer{
+		LoadBalancerID:   "id1",
+		LoadBalancerName: "lb1",
+		EnvironmentID:    "eid1",
+		EnvironmentName:  "ename1",
+		ConnectionDraining: models.ConnectionDraining{
+			Enabled: true,
+			Timeout: 300,
+		},
+	}
+
+	loadBalancer2 := &models.LoadBalancer{
+		LoadBalancerID:   "id2",
+		LoadBalancerName: "lb2",
+		EnvironmentID:    "eid1",
+	}
+
+	printer.PrintLoadBalancerConnectionDraining(loadBalancer1)
+	printer.PrintLoadBalancerConnectionDraining(loadBalancer2)
+	// Output:
+	// LOADBALANCER ID  LOADBALANCER NAME  ENVIRONMENT  CONNECTION DRAINING
+	// id1              lb1                ename1       300s
+	// LOADBALANCER ID  LOADBALANCER NAME  ENVIRONMENT  CONNECTION DRAINING
+	// id2              lb2                eid1         disabled
+}
+
+func ExampleTextPrintLoadBalancerHealth() {
+	printer := &TextPrinter{}
+	health := &models.LoadBalancerHealth{
+		LoadBalancerID: "id1",
+		Instances: []models.InstanceHealth{
+			{
+				InstanceID:  "i-1",
+				State:       "InService",
+				ServiceID:   "sid1",
+				ServiceName: "svc1",
+				TaskIDs:     []string{"task1", "task2"},
+			},
+			{
+				InstanceID: "i-2",
+				State:      "OutOfService",
+				ServiceID:  "sid1",
+				ReasonCode: "Instance",
+				TaskIDs:    []string{"task3"},
+			},
+		},
+	}
+
+	printer.PrintLoadBalancerHealth(health)
+	// Output:
+	// INSTANCE ID  STATE         REASON    SERVICE  TASKS
+	// i-1          InService               svc1     task1
+	//                                               task2
+	// i-2          OutOfService  Instance  sid1     task3
+}
+
+func ExampleTextPrintLabels() {
+	printer := &TextPrinter{}
+	labels := map[string]string{
+		"team":   "payments",
+		"branch": "feature-x",
+	}
+
+	printer.PrintLabels(labels)
+	// Output:
+	// KEY     VALUE
+	// branch  feature-x
+	// team    payments
+}
+
 func ExampleTextPrintLogs() {
 	printer := &TextPrinter{}
 	logs := []*models.LogFile{
@@ -332,6 +564,41 @@ func ExampleTextPrintLogs() {
 	//lineC
 }
 
+func ExampleTextPrintPlan() {
+	printer := &TextPrinter{}
+	plan := &manifest.Plan{
+		Environment: "prod",
+		Actions: []*manifest.Action{
+			{Type: manifest.ActionCreate, EntityType: "deploy", Name: "web"},
+			{Type: manifest.ActionUpdate, EntityType: "service", Name: "web", Changes: []string{"scale: 1 -> 2"}},
+			{Type: manifest.ActionDelete, EntityType: "service", Name: "old"},
+		},
+	}
+
+	printer.PrintPlan(plan)
+	// Output:
+	//+ create deploy web
+	//~ update service web
+	//     scale: 1 -> 2
+	//- delete service old
+	//
+	//Plan: 1 to create, 1 to update, 1 to delete.
+}
+
+func ExampleTextPrintProfiles() {
+	printer := &TextPrinter{}
+	profiles := []*config.Profile{
+		{Name: "dev", Endpoint: "https://dev.example.com"},
+		{Name: "prod", Endpoint: "https://prod.example.com", Confirm: true},
+	}
+
+	printer.PrintProfiles("dev", profiles...)
+	// Output:
+	//PROFILE        ENDPOINT                  CONFIRM
+	//dev (current)  https://dev.example.com   false
+	//prod           https://prod.example.com  true
+}
+
 func ExampleTextPrintScalerRunInfo() {
 	printer := &TextPrinter{}
 	runInfo := &models.ScalerRunInfo{
@@ -346,6 +613,20 @@ func ExampleTextPrintScalerRunInfo() {
 	//eid1         1              2
 }
 
+func ExampleTextPrintSecrets() {
+	printer := &TextPrinter{}
+	secrets := []*models.Secret{
+		{SecretName: "db_password", EnvironmentID: "eid1", EnvironmentName: "ename1"},
+		{SecretName: "api_key", EnvironmentID: "eid2"},
+	}
+
+	printer.PrintSecrets(secrets...)
+	// Output:
+	// SECRET NAME  ENVIRONMENT
+	// db_password  ename1
+	// api_key      eid2
+}
+
 func ExampleTextPrintServices() {
 	printer := &TextPrinter{}
 	services := []*models.Service{
@@ -419,6 +700,30 @@ func ExampleTextPrintServices() {
 	//                                                      d5:2*
 }
 
+func ExampleTextPrintServices_wide() {
+	printer := &TextPrinter{Wide: true}
+	services := []*models.Service{
+		{
+			ServiceID:       "id1",
+			ServiceName:     "svc1",
+			EnvironmentID:   "eid1",
+			EnvironmentName: "ename1",
+			RunningCount:    2,
+			DesiredCount:    2,
+			Deployments: []models.Deployment{
+				{DeployID: "d1.2", DeployName: "d1", DeployVersion: "2", Status: "PRIMARY", RunningCount: 1, DesiredCount: 2},
+				{DeployID: "d1.1", DeployName: "d1", DeployVersion: "1", Status: "ACTIVE", RunningCount: 1, DesiredCount: 0},
+			},
+		},
+	}
+
+	printer.PrintServices(services...)
+	// Output:
+	//SERVICE ID  SERVICE NAME  ENVIRONMENT  LOADBALANCER  DEPLOYMENTS  SCALE  DEPLOY ID  DEPLOYMENT STATUS  DEPLOYMENT SCALE
+	//id1         svc1          ename1                     d1:2*        2/2    d1.2       PRIMARY            1/2
+	//                                                      d1:1*               d1.1       ACTIVE             1/0
+}
+
 func ExampleTextPrintServiceSummaries() {
 	printer := &TextPrinter{}
 	services := []*models.ServiceSummary{
@@ -433,6 +738,47 @@ func ExampleTextPrintServiceSummaries() {
 	// id2         svc2          eid2
 }
 
+func ExampleTextPrintTaskDetails() {
+	printer := &TextPrinter{}
+	startedAt := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	tasks := []*models.Task{
+		{
+			TaskID:   "id1",
+			TaskName: "tsk1",
+			Copies: []models.TaskCopy{
+				{
+					LastStatus: "STOPPED",
+					Reason:     "Essential container in task exited",
+					StartedAt:  startedAt,
+					StoppedAt:  startedAt.Add(time.Minute),
+					Details: []models.TaskDetail{
+						{ContainerName: "migrate", LastStatus: "STOPPED", ExitCode: 1},
+					},
+				},
+			},
+		},
+		{
+			TaskID:   "id2",
+			TaskName: "tsk2",
+			Copies: []models.TaskCopy{
+				{
+					LastStatus: "RUNNING",
+					StartedAt:  startedAt,
+					Details: []models.TaskDetail{
+						{ContainerName: "worker", LastStatus: "RUNNING"},
+					},
+				},
+			},
+		},
+	}
+
+	printer.PrintTaskDetails(tasks...)
+	// Output:
+	// TASK ID  TASK NAME  CONTAINER  STATUS   EXIT CODE  STARTED              STOPPED              REASON
+	// id1      tsk1       migrate    STOPPED  1          2017-01-01 12:00:00  2017-01-01 12:01:00  Essential container in task exited
+	// id2      tsk2       worker     RUNNING  -          2017-01-01 12:00:00  -                    -
+}
+
 func ExampleTextPrintTasks() {
 	printer := &TextPrinter{}
 	tasks := []*models.Task{
@@ -14,6 +14,7 @@ type Provider interface {
 	AuthorizeSecurityGroupIngress(input []*SecurityGroupIngress) error
 	RevokeSecurityGroupIngress(input []*SecurityGroupIngress) error
 	RevokeSecurityGroupIngressHelper(groupID string, permission IpPermission) error
+	AuthorizeSecurityGroupIngressHelper(groupID string, permission IpPermission) error
 	AuthorizeSecurityGroupIngressFromGroup(groupId, sourceGroupId string) error
 	DescribeSecurityGroup(name string) (*SecurityGroup, error)
 	DescribeSubnet(subnetId string) (*Subnet, error)
@@ -447,6 +448,24 @@ func (this *EC2) RevokeSecurityGroupIngressHelper(groupID string, permission IpP
 	return nil
 }
 
+func (this *EC2) AuthorizeSecurityGroupIngressHelper(groupID string, permission IpPermission) error {
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	input := &ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       aws.String(groupID),
+		IpPermissions: []*ec2.IpPermission{permission.IpPermission},
+	}
+
+	if _, err := connection.AuthorizeSecurityGroupIngress(input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (this *EC2) AuthorizeSecurityGroupIngressFromGroup(groupId, sourceGroupId string) error {
 	input := &ec2.AuthorizeSecurityGroupIngressInput{
 		GroupId: aws.String(groupId),
@@ -44,6 +44,15 @@ func (this *ProviderDecorator) RevokeSecurityGroupIngressHelper(p0 string, p1 Ip
 	err = this.Decorator("RevokeSecurityGroupIngressHelper", call)
 	return err
 }
+func (this *ProviderDecorator) AuthorizeSecurityGroupIngressHelper(p0 string, p1 IpPermission) (err error) {
+	call := func() error {
+		var err error
+		err = this.Inner.AuthorizeSecurityGroupIngressHelper(p0, p1)
+		return err
+	}
+	err = this.Decorator("AuthorizeSecurityGroupIngressHelper", call)
+	return err
+}
 func (this *ProviderDecorator) AuthorizeSecurityGroupIngressFromGroup(p0 string, p1 string) (err error) {
 	call := func() error {
 		var err error
@@ -57,6 +57,18 @@ func (mr *MockProviderMockRecorder) AuthorizeSecurityGroupIngressFromGroup(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeSecurityGroupIngressFromGroup", reflect.TypeOf((*MockProvider)(nil).AuthorizeSecurityGroupIngressFromGroup), arg0, arg1)
 }
 
+// AuthorizeSecurityGroupIngressHelper mocks base method
+func (m *MockProvider) AuthorizeSecurityGroupIngressHelper(arg0 string, arg1 ec2.IpPermission) error {
+	ret := m.ctrl.Call(m, "AuthorizeSecurityGroupIngressHelper", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeSecurityGroupIngressHelper indicates an expected call of AuthorizeSecurityGroupIngressHelper
+func (mr *MockProviderMockRecorder) AuthorizeSecurityGroupIngressHelper(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeSecurityGroupIngressHelper", reflect.TypeOf((*MockProvider)(nil).AuthorizeSecurityGroupIngressHelper), arg0, arg1)
+}
+
 // CreateSecurityGroup mocks base method
 func (m *MockProvider) CreateSecurityGroup(arg0, arg1, arg2 string) (*string, error) {
 	ret := m.ctrl.Call(m, "CreateSecurityGroup", arg0, arg1, arg2)
@@ -186,6 +186,16 @@ func NewLoadBalancer(containerName string, containerPort int64, loadBalancerName
 	}
 }
 
+func NewTargetGroupLoadBalancer(containerName string, containerPort int64, targetGroupARN string) *LoadBalancer {
+	return &LoadBalancer{
+		&ecs.LoadBalancer{
+			ContainerName:  &containerName,
+			ContainerPort:  aws.Int64(containerPort),
+			TargetGroupArn: &targetGroupARN,
+		},
+	}
+}
+
 func NewContainerInstance(agentConnected bool, cpuRegistered, memoryRegistered int, portsRegistered, udpPortsRegistered []*string) *ContainerInstance {
 	return &ContainerInstance{
 		&ecs.ContainerInstance{
@@ -22,6 +22,8 @@ type Provider interface {
 	DeleteLoadBalancerListeners(loadBalancerName string, listeners []*Listener) error
 	SetIdleTimeout(loadBalancerName string, idleTimeout int) error
 	SetCrossZone(loadBalancerName string, crossZone bool) error
+	SetAccessLogs(loadBalancerName string, enabled bool, s3BucketName, s3BucketPrefix string, emitInterval int) error
+	SetConnectionDraining(loadBalancerName string, enabled bool, timeout int) error
 }
 
 type Listener struct {
@@ -449,3 +451,53 @@ func (this *ELB) SetCrossZone(loadBalancerName string, crossZone bool) error {
 	_, err = connection.ModifyLoadBalancerAttributes(input)
 	return err
 }
+
+func (this *ELB) SetAccessLogs(loadBalancerName string, enabled bool, s3BucketName, s3BucketPrefix string, emitInterval int) error {
+	accessLog := &elb.AccessLog{}
+	accessLog.SetEnabled(enabled)
+
+	if enabled {
+		accessLog.SetS3BucketName(s3BucketName)
+		accessLog.SetS3BucketPrefix(s3BucketPrefix)
+		accessLog.SetEmitInterval(int64(emitInterval))
+	}
+
+	loadBalancerAttributes := &elb.LoadBalancerAttributes{}
+	loadBalancerAttributes.SetAccessLog(accessLog)
+
+	input := &elb.ModifyLoadBalancerAttributesInput{}
+	input.SetLoadBalancerName(loadBalancerName)
+	input.SetLoadBalancerAttributes(loadBalancerAttributes)
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ModifyLoadBalancerAttributes(input)
+	return err
+}
+
+func (this *ELB) SetConnectionDraining(loadBalancerName string, enabled bool, timeout int) error {
+	connectionDraining := &elb.ConnectionDraining{}
+	connectionDraining.SetEnabled(enabled)
+
+	if enabled {
+		connectionDraining.SetTimeout(int64(timeout))
+	}
+
+	loadBalancerAttributes := &elb.LoadBalancerAttributes{}
+	loadBalancerAttributes.SetConnectionDraining(connectionDraining)
+
+	input := &elb.ModifyLoadBalancerAttributesInput{}
+	input.SetLoadBalancerName(loadBalancerName)
+	input.SetLoadBalancerAttributes(loadBalancerAttributes)
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ModifyLoadBalancerAttributes(input)
+	return err
+}
@@ -126,3 +126,21 @@ func (this *ProviderDecorator) SetCrossZone(p0 string, p1 bool) (err error) {
 	return err
 }
 
+func (this *ProviderDecorator) SetAccessLogs(p0 string, p1 bool, p2 string, p3 string, p4 int) (err error) {
+	call := func() error {
+		var err error
+		err = this.Inner.SetAccessLogs(p0, p1, p2, p3, p4)
+		return err
+	}
+	err = this.Decorator("SetAccessLogs", call)
+	return err
+}
+func (this *ProviderDecorator) SetConnectionDraining(p0 string, p1 bool, p2 int) (err error) {
+	call := func() error {
+		var err error
+		err = this.Inner.SetConnectionDraining(p0, p1, p2)
+		return err
+	}
+	err = this.Decorator("SetConnectionDraining", call)
+	return err
+}
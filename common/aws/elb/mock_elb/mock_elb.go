@@ -170,6 +170,30 @@ func (mr *MockProviderMockRecorder) RegisterInstancesWithLoadBalancer(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterInstancesWithLoadBalancer", reflect.TypeOf((*MockProvider)(nil).RegisterInstancesWithLoadBalancer), arg0, arg1)
 }
 
+// SetAccessLogs mocks base method
+func (m *MockProvider) SetAccessLogs(arg0 string, arg1 bool, arg2, arg3 string, arg4 int) error {
+	ret := m.ctrl.Call(m, "SetAccessLogs", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAccessLogs indicates an expected call of SetAccessLogs
+func (mr *MockProviderMockRecorder) SetAccessLogs(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccessLogs", reflect.TypeOf((*MockProvider)(nil).SetAccessLogs), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SetConnectionDraining mocks base method
+func (m *MockProvider) SetConnectionDraining(arg0 string, arg1 bool, arg2 int) error {
+	ret := m.ctrl.Call(m, "SetConnectionDraining", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetConnectionDraining indicates an expected call of SetConnectionDraining
+func (mr *MockProviderMockRecorder) SetConnectionDraining(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConnectionDraining", reflect.TypeOf((*MockProvider)(nil).SetConnectionDraining), arg0, arg1, arg2)
+}
+
 // SetCrossZone mocks base method
 func (m *MockProvider) SetCrossZone(arg0 string, arg1 bool) error {
 	ret := m.ctrl.Call(m, "SetCrossZone", arg0, arg1)
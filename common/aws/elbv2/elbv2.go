@@ -0,0 +1,761 @@
+package elbv2
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/quintilesims/layer0/common/aws/provider"
+)
+
+type Provider interface {
+	CreateLoadBalancer(loadBalancerName, scheme string, securityGroups, subnets []*string) (*LoadBalancer, error)
+	DescribeLoadBalancer(loadBalancerName string) (*LoadBalancer, error)
+	DescribeLoadBalancers() ([]*LoadBalancer, error)
+	DescribeLoadBalancerAttributes(loadBalancerARN string) (map[string]string, error)
+	DeleteLoadBalancer(loadBalancerARN string) error
+	SetIdleTimeout(loadBalancerARN string, idleTimeout int) error
+	SetAccessLogs(loadBalancerARN string, enabled bool, s3BucketName, s3BucketPrefix string) error
+	CreateTargetGroup(targetGroupName, protocol string, port int64, vpcID string, healthCheck *HealthCheck) (*TargetGroup, error)
+	DescribeTargetGroup(targetGroupName string) (*TargetGroup, error)
+	DescribeTargetGroups(targetGroupARNs []string) ([]*TargetGroup, error)
+	DescribeLoadBalancerTargetGroups(loadBalancerARN string) ([]*TargetGroup, error)
+	ModifyTargetGroupHealthCheck(targetGroupARN string, healthCheck *HealthCheck) error
+	DescribeTargetGroupAttributes(targetGroupARN string) (map[string]string, error)
+	SetDeregistrationDelay(targetGroupARN string, timeout int) error
+	DescribeTargetHealth(targetGroupARN string) ([]*TargetHealthDescription, error)
+	DeleteTargetGroup(targetGroupARN string) error
+	CreateListener(loadBalancerARN string, listener *Listener) error
+	DescribeListeners(loadBalancerARN string) ([]*Listener, error)
+	DeleteListener(listenerARN string) error
+	CreateRule(listenerARN string, rule *Rule) error
+	DescribeRules(listenerARN string) ([]*Rule, error)
+	DeleteRule(ruleARN string) error
+}
+
+type LoadBalancer struct {
+	*elbv2.LoadBalancer
+}
+
+func NewLoadBalancer(name, scheme string) *LoadBalancer {
+	return &LoadBalancer{
+		&elbv2.LoadBalancer{
+			LoadBalancerArn:  aws.String(name),
+			LoadBalancerName: aws.String(name),
+			DNSName:          aws.String(name),
+			Scheme:           aws.String(scheme),
+			Type:             aws.String(elbv2.LoadBalancerTypeEnumApplication),
+		},
+	}
+}
+
+type TargetGroup struct {
+	*elbv2.TargetGroup
+}
+
+func NewTargetGroup(name string, port int64) *TargetGroup {
+	return &TargetGroup{
+		&elbv2.TargetGroup{
+			TargetGroupArn:             aws.String(name),
+			TargetGroupName:            aws.String(name),
+			Port:                       aws.Int64(port),
+			Protocol:                   aws.String(elbv2.ProtocolEnumHttp),
+			HealthCheckProtocol:        aws.String(elbv2.ProtocolEnumHttp),
+			HealthCheckPath:            aws.String("/"),
+			HealthCheckIntervalSeconds: aws.Int64(30),
+			HealthCheckTimeoutSeconds:  aws.Int64(5),
+			HealthyThresholdCount:      aws.Int64(2),
+			UnhealthyThresholdCount:    aws.Int64(2),
+		},
+	}
+}
+
+// HealthCheck holds the target group health check settings.
+// Checks are always made against the port each target receives traffic on,
+// which allows targets to be registered with dynamic host ports.
+type HealthCheck struct {
+	Protocol           string
+	Path               string
+	Interval           int64
+	Timeout            int64
+	HealthyThreshold   int64
+	UnhealthyThreshold int64
+}
+
+func NewHealthCheck(protocol, path string, interval, timeout, healthyThresh, unhealthyThresh int64) *HealthCheck {
+	return &HealthCheck{
+		Protocol:           protocol,
+		Path:               path,
+		Interval:           interval,
+		Timeout:            timeout,
+		HealthyThreshold:   healthyThresh,
+		UnhealthyThreshold: unhealthyThresh,
+	}
+}
+
+type Listener struct {
+	*elbv2.Listener
+}
+
+func NewListener(port int64, protocol, certificateARN, targetGroupARN string) *Listener {
+	listener := &Listener{
+		&elbv2.Listener{
+			Port:     aws.Int64(port),
+			Protocol: aws.String(protocol),
+			DefaultActions: []*elbv2.Action{
+				{
+					TargetGroupArn: aws.String(targetGroupARN),
+					Type:           aws.String(elbv2.ActionTypeEnumForward),
+				},
+			},
+		},
+	}
+
+	if certificateARN != "" {
+		listener.Certificates = []*elbv2.Certificate{
+			{CertificateArn: aws.String(certificateARN)},
+		}
+	}
+
+	return listener
+}
+
+// TargetGroupARN returns the target group the listener forwards to by default
+func (l *Listener) TargetGroupARN() string {
+	for _, action := range l.DefaultActions {
+		if aws.StringValue(action.Type) == elbv2.ActionTypeEnumForward {
+			return aws.StringValue(action.TargetGroupArn)
+		}
+	}
+
+	return ""
+}
+
+// CertificateARN returns the default certificate of the listener
+func (l *Listener) CertificateARN() string {
+	if len(l.Certificates) > 0 {
+		return aws.StringValue(l.Certificates[0].CertificateArn)
+	}
+
+	return ""
+}
+
+type TargetHealthDescription struct {
+	*elbv2.TargetHealthDescription
+}
+
+func NewTargetHealthDescription(instanceID string, port int64, state string) *TargetHealthDescription {
+	return &TargetHealthDescription{
+		&elbv2.TargetHealthDescription{
+			Target: &elbv2.TargetDescription{
+				Id:   aws.String(instanceID),
+				Port: aws.Int64(port),
+			},
+			TargetHealth: &elbv2.TargetHealth{
+				State: aws.String(state),
+			},
+		},
+	}
+}
+
+type Rule struct {
+	*elbv2.Rule
+}
+
+func NewRule(priority int64, hostHeader, pathPattern, targetGroupARN string) *Rule {
+	conditions := []*elbv2.RuleCondition{}
+	if hostHeader != "" {
+		conditions = append(conditions, &elbv2.RuleCondition{
+			Field:  aws.String("host-header"),
+			Values: []*string{aws.String(hostHeader)},
+		})
+	}
+
+	if pathPattern != "" {
+		conditions = append(conditions, &elbv2.RuleCondition{
+			Field:  aws.String("path-pattern"),
+			Values: []*string{aws.String(pathPattern)},
+		})
+	}
+
+	return &Rule{
+		&elbv2.Rule{
+			Priority:   aws.String(strconv.FormatInt(priority, 10)),
+			Conditions: conditions,
+			IsDefault:  aws.Bool(false),
+			Actions: []*elbv2.Action{
+				{
+					TargetGroupArn: aws.String(targetGroupARN),
+					Type:           aws.String(elbv2.ActionTypeEnumForward),
+				},
+			},
+		},
+	}
+}
+
+// Condition returns the first value of the rule condition with the specified field,
+// e.g. "host-header" or "path-pattern"
+func (r *Rule) Condition(field string) string {
+	for _, condition := range r.Conditions {
+		if aws.StringValue(condition.Field) == field && len(condition.Values) > 0 {
+			return aws.StringValue(condition.Values[0])
+		}
+	}
+
+	return ""
+}
+
+// TargetGroupARN returns the target group the rule forwards to
+func (r *Rule) TargetGroupARN() string {
+	for _, action := range r.Actions {
+		if aws.StringValue(action.Type) == elbv2.ActionTypeEnumForward {
+			return aws.StringValue(action.TargetGroupArn)
+		}
+	}
+
+	return ""
+}
+
+// PriorityValue returns the rule's priority, or 0 for the default rule
+func (r *Rule) PriorityValue() int64 {
+	priority, err := strconv.ParseInt(aws.StringValue(r.Priority), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return priority
+}
+
+type ELBV2 struct {
+	credProvider provider.CredProvider
+	region       string
+	Connect      func() (ELBV2Internal, error)
+}
+
+type ELBV2Internal interface {
+	CreateLoadBalancer(input *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error)
+	DescribeLoadBalancers(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
+	DescribeLoadBalancerAttributes(input *elbv2.DescribeLoadBalancerAttributesInput) (*elbv2.DescribeLoadBalancerAttributesOutput, error)
+	ModifyLoadBalancerAttributes(input *elbv2.ModifyLoadBalancerAttributesInput) (*elbv2.ModifyLoadBalancerAttributesOutput, error)
+	DeleteLoadBalancer(input *elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error)
+	CreateTargetGroup(input *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error)
+	DescribeTargetGroups(input *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error)
+	ModifyTargetGroup(input *elbv2.ModifyTargetGroupInput) (*elbv2.ModifyTargetGroupOutput, error)
+	DescribeTargetGroupAttributes(input *elbv2.DescribeTargetGroupAttributesInput) (*elbv2.DescribeTargetGroupAttributesOutput, error)
+	ModifyTargetGroupAttributes(input *elbv2.ModifyTargetGroupAttributesInput) (*elbv2.ModifyTargetGroupAttributesOutput, error)
+	DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error)
+	DeleteTargetGroup(input *elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error)
+	CreateListener(input *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error)
+	DescribeListeners(input *elbv2.DescribeListenersInput) (*elbv2.DescribeListenersOutput, error)
+	DeleteListener(input *elbv2.DeleteListenerInput) (*elbv2.DeleteListenerOutput, error)
+	CreateRule(input *elbv2.CreateRuleInput) (*elbv2.CreateRuleOutput, error)
+	DescribeRules(input *elbv2.DescribeRulesInput) (*elbv2.DescribeRulesOutput, error)
+	DeleteRule(input *elbv2.DeleteRuleInput) (*elbv2.DeleteRuleOutput, error)
+}
+
+func NewELBV2(credProvider provider.CredProvider, region string) (Provider, error) {
+	elbv2 := ELBV2{
+		credProvider,
+		region,
+		func() (ELBV2Internal, error) {
+			return Connect(credProvider, region)
+		},
+	}
+
+	_, err := elbv2.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	return &elbv2, nil
+}
+
+func Connect(credProvider provider.CredProvider, region string) (ELBV2Internal, error) {
+	connection, err := provider.GetELBV2Connection(credProvider, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return connection, nil
+}
+
+func (this *ELBV2) CreateLoadBalancer(loadBalancerName, scheme string, securityGroups, subnets []*string) (*LoadBalancer, error) {
+	if len(subnets) == 0 {
+		return nil, fmt.Errorf("Must specify at least 1 subnet")
+	}
+
+	input := &elbv2.CreateLoadBalancerInput{
+		Name:           aws.String(loadBalancerName),
+		Scheme:         aws.String(scheme),
+		SecurityGroups: securityGroups,
+		Subnets:        subnets,
+		Type:           aws.String(elbv2.LoadBalancerTypeEnumApplication),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := connection.CreateLoadBalancer(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out.LoadBalancers) == 0 {
+		return nil, fmt.Errorf("Load balancer '%s' was not returned after creation", loadBalancerName)
+	}
+
+	return &LoadBalancer{out.LoadBalancers[0]}, nil
+}
+
+func (this *ELBV2) DescribeLoadBalancer(loadBalancerName string) (*LoadBalancer, error) {
+	input := &elbv2.DescribeLoadBalancersInput{
+		Names: []*string{aws.String(loadBalancerName)},
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := connection.DescribeLoadBalancers(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out.LoadBalancers) == 0 {
+		msg := fmt.Sprintf("Load balancer '%s' does not exist", loadBalancerName)
+		return nil, awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, msg, nil)
+	}
+
+	return &LoadBalancer{out.LoadBalancers[0]}, nil
+}
+
+func (this *ELBV2) DescribeLoadBalancers() ([]*LoadBalancer, error) {
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	loadBalancers := []*LoadBalancer{}
+	input := &elbv2.DescribeLoadBalancersInput{}
+	for {
+		out, err := connection.DescribeLoadBalancers(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, loadBalancer := range out.LoadBalancers {
+			loadBalancers = append(loadBalancers, &LoadBalancer{loadBalancer})
+		}
+
+		if out.NextMarker == nil {
+			break
+		}
+
+		input.Marker = out.NextMarker
+	}
+
+	return loadBalancers, nil
+}
+
+func (this *ELBV2) DescribeLoadBalancerAttributes(loadBalancerARN string) (map[string]string, error) {
+	input := &elbv2.DescribeLoadBalancerAttributesInput{}
+	input.SetLoadBalancerArn(loadBalancerARN)
+
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := connection.DescribeLoadBalancerAttributes(input)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]string{}
+	for _, attribute := range out.Attributes {
+		attributes[aws.StringValue(attribute.Key)] = aws.StringValue(attribute.Value)
+	}
+
+	return attributes, nil
+}
+
+func (this *ELBV2) DeleteLoadBalancer(loadBalancerARN string) error {
+	input := &elbv2.DeleteLoadBalancerInput{
+		LoadBalancerArn: aws.String(loadBalancerARN),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.DeleteLoadBalancer(input)
+	return err
+}
+
+func (this *ELBV2) SetIdleTimeout(loadBalancerARN string, idleTimeout int) error {
+	attribute := &elbv2.LoadBalancerAttribute{}
+	attribute.SetKey("idle_timeout.timeout_seconds")
+	attribute.SetValue(strconv.Itoa(idleTimeout))
+
+	input := &elbv2.ModifyLoadBalancerAttributesInput{}
+	input.SetLoadBalancerArn(loadBalancerARN)
+	input.SetAttributes([]*elbv2.LoadBalancerAttribute{attribute})
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ModifyLoadBalancerAttributes(input)
+	return err
+}
+
+func (this *ELBV2) SetAccessLogs(loadBalancerARN string, enabled bool, s3BucketName, s3BucketPrefix string) error {
+	attributes := []*elbv2.LoadBalancerAttribute{
+		{
+			Key:   aws.String("access_logs.s3.enabled"),
+			Value: aws.String(strconv.FormatBool(enabled)),
+		},
+	}
+
+	if enabled {
+		attributes = append(attributes,
+			&elbv2.LoadBalancerAttribute{
+				Key:   aws.String("access_logs.s3.bucket"),
+				Value: aws.String(s3BucketName),
+			},
+			&elbv2.LoadBalancerAttribute{
+				Key:   aws.String("access_logs.s3.prefix"),
+				Value: aws.String(s3BucketPrefix),
+			})
+	}
+
+	input := &elbv2.ModifyLoadBalancerAttributesInput{}
+	input.SetLoadBalancerArn(loadBalancerARN)
+	input.SetAttributes(attributes)
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ModifyLoadBalancerAttributes(input)
+	return err
+}
+
+func (this *ELBV2) CreateTargetGroup(targetGroupName, protocol string, port int64, vpcID string, healthCheck *HealthCheck) (*TargetGroup, error) {
+	input := &elbv2.CreateTargetGroupInput{
+		Name:                       aws.String(targetGroupName),
+		Protocol:                   aws.String(protocol),
+		Port:                       aws.Int64(port),
+		VpcId:                      aws.String(vpcID),
+		HealthCheckPort:            aws.String("traffic-port"),
+		HealthCheckProtocol:        aws.String(healthCheck.Protocol),
+		HealthCheckPath:            aws.String(healthCheck.Path),
+		HealthCheckIntervalSeconds: aws.Int64(healthCheck.Interval),
+		HealthCheckTimeoutSeconds:  aws.Int64(healthCheck.Timeout),
+		HealthyThresholdCount:      aws.Int64(healthCheck.HealthyThreshold),
+		UnhealthyThresholdCount:    aws.Int64(healthCheck.UnhealthyThreshold),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := connection.CreateTargetGroup(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out.TargetGroups) == 0 {
+		return nil, fmt.Errorf("Target group '%s' was not returned after creation", targetGroupName)
+	}
+
+	return &TargetGroup{out.TargetGroups[0]}, nil
+}
+
+func (this *ELBV2) DescribeTargetGroup(targetGroupName string) (*TargetGroup, error) {
+	input := &elbv2.DescribeTargetGroupsInput{
+		Names: []*string{aws.String(targetGroupName)},
+	}
+
+	targetGroups, err := this.describeTargetGroups(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(targetGroups) == 0 {
+		msg := fmt.Sprintf("Target group '%s' does not exist", targetGroupName)
+		return nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, msg, nil)
+	}
+
+	return targetGroups[0], nil
+}
+
+func (this *ELBV2) DescribeTargetGroups(targetGroupARNs []string) ([]*TargetGroup, error) {
+	if len(targetGroupARNs) == 0 {
+		return []*TargetGroup{}, nil
+	}
+
+	input := &elbv2.DescribeTargetGroupsInput{
+		TargetGroupArns: aws.StringSlice(targetGroupARNs),
+	}
+
+	return this.describeTargetGroups(input)
+}
+
+func (this *ELBV2) DescribeLoadBalancerTargetGroups(loadBalancerARN string) ([]*TargetGroup, error) {
+	input := &elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String(loadBalancerARN),
+	}
+
+	return this.describeTargetGroups(input)
+}
+
+func (this *ELBV2) describeTargetGroups(input *elbv2.DescribeTargetGroupsInput) ([]*TargetGroup, error) {
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	targetGroups := []*TargetGroup{}
+	for {
+		out, err := connection.DescribeTargetGroups(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, targetGroup := range out.TargetGroups {
+			targetGroups = append(targetGroups, &TargetGroup{targetGroup})
+		}
+
+		if out.NextMarker == nil {
+			break
+		}
+
+		input.Marker = out.NextMarker
+	}
+
+	return targetGroups, nil
+}
+
+func (this *ELBV2) ModifyTargetGroupHealthCheck(targetGroupARN string, healthCheck *HealthCheck) error {
+	input := &elbv2.ModifyTargetGroupInput{
+		TargetGroupArn:             aws.String(targetGroupARN),
+		HealthCheckPort:            aws.String("traffic-port"),
+		HealthCheckProtocol:        aws.String(healthCheck.Protocol),
+		HealthCheckPath:            aws.String(healthCheck.Path),
+		HealthCheckIntervalSeconds: aws.Int64(healthCheck.Interval),
+		HealthCheckTimeoutSeconds:  aws.Int64(healthCheck.Timeout),
+		HealthyThresholdCount:      aws.Int64(healthCheck.HealthyThreshold),
+		UnhealthyThresholdCount:    aws.Int64(healthCheck.UnhealthyThreshold),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ModifyTargetGroup(input)
+	return err
+}
+
+func (this *ELBV2) DescribeTargetGroupAttributes(targetGroupARN string) (map[string]string, error) {
+	input := &elbv2.DescribeTargetGroupAttributesInput{}
+	input.SetTargetGroupArn(targetGroupARN)
+
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := connection.DescribeTargetGroupAttributes(input)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]string{}
+	for _, attribute := range out.Attributes {
+		attributes[aws.StringValue(attribute.Key)] = aws.StringValue(attribute.Value)
+	}
+
+	return attributes, nil
+}
+
+func (this *ELBV2) SetDeregistrationDelay(targetGroupARN string, timeout int) error {
+	attribute := &elbv2.TargetGroupAttribute{}
+	attribute.SetKey("deregistration_delay.timeout_seconds")
+	attribute.SetValue(strconv.Itoa(timeout))
+
+	input := &elbv2.ModifyTargetGroupAttributesInput{}
+	input.SetTargetGroupArn(targetGroupARN)
+	input.SetAttributes([]*elbv2.TargetGroupAttribute{attribute})
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ModifyTargetGroupAttributes(input)
+	return err
+}
+
+func (this *ELBV2) DescribeTargetHealth(targetGroupARN string) ([]*TargetHealthDescription, error) {
+	input := &elbv2.DescribeTargetHealthInput{}
+	input.SetTargetGroupArn(targetGroupARN)
+
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := connection.DescribeTargetHealth(input)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := []*TargetHealthDescription{}
+	for _, description := range out.TargetHealthDescriptions {
+		descriptions = append(descriptions, &TargetHealthDescription{description})
+	}
+
+	return descriptions, nil
+}
+
+func (this *ELBV2) DeleteTargetGroup(targetGroupARN string) error {
+	input := &elbv2.DeleteTargetGroupInput{
+		TargetGroupArn: aws.String(targetGroupARN),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.DeleteTargetGroup(input)
+	return err
+}
+
+func (this *ELBV2) CreateListener(loadBalancerARN string, listener *Listener) error {
+	input := &elbv2.CreateListenerInput{
+		LoadBalancerArn: aws.String(loadBalancerARN),
+		Port:            listener.Port,
+		Protocol:        listener.Protocol,
+		Certificates:    listener.Certificates,
+		DefaultActions:  listener.DefaultActions,
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.CreateListener(input)
+	return err
+}
+
+func (this *ELBV2) DescribeListeners(loadBalancerARN string) ([]*Listener, error) {
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	listeners := []*Listener{}
+	input := &elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(loadBalancerARN),
+	}
+
+	for {
+		out, err := connection.DescribeListeners(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, listener := range out.Listeners {
+			listeners = append(listeners, &Listener{listener})
+		}
+
+		if out.NextMarker == nil {
+			break
+		}
+
+		input.Marker = out.NextMarker
+	}
+
+	return listeners, nil
+}
+
+func (this *ELBV2) DeleteListener(listenerARN string) error {
+	input := &elbv2.DeleteListenerInput{
+		ListenerArn: aws.String(listenerARN),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.DeleteListener(input)
+	return err
+}
+
+func (this *ELBV2) CreateRule(listenerARN string, rule *Rule) error {
+	input := &elbv2.CreateRuleInput{
+		ListenerArn: aws.String(listenerARN),
+		Priority:    aws.Int64(rule.PriorityValue()),
+		Conditions:  rule.Conditions,
+		Actions:     rule.Actions,
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.CreateRule(input)
+	return err
+}
+
+func (this *ELBV2) DescribeRules(listenerARN string) ([]*Rule, error) {
+	input := &elbv2.DescribeRulesInput{
+		ListenerArn: aws.String(listenerARN),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := connection.DescribeRules(input)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []*Rule{}
+	for _, rule := range out.Rules {
+		rules = append(rules, &Rule{rule})
+	}
+
+	return rules, nil
+}
+
+func (this *ELBV2) DeleteRule(ruleARN string) error {
+	input := &elbv2.DeleteRuleInput{
+		RuleArn: aws.String(ruleARN),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.DeleteRule(input)
+	return err
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/elasticbeanstalk"
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/quintilesims/layer0/common/config"
@@ -183,6 +184,16 @@ var GetELBConnection = func(credProvider CredProvider, region string) (connectio
 	return
 }
 
+var GetELBV2Connection = func(credProvider CredProvider, region string) (connection *elbv2.ELBV2, err error) {
+	sess, err := getConfig(credProvider, region)
+	if err != nil {
+		return
+	}
+
+	connection = elbv2.New(sess)
+	return
+}
+
 var GetAutoScalingConnection = func(credProvider CredProvider, region string) (connection *autoscaling.AutoScaling, err error) {
 	sess, err := getConfig(credProvider, region)
 	if err != nil {
@@ -6,7 +6,7 @@ package mock_s3
 
 import (
 	gomock "github.com/golang/mock/gomock"
-	os "os"
+	fs "io/fs"
 	reflect "reflect"
 )
 
@@ -59,7 +59,7 @@ func (mr *MockProviderMockRecorder) GetObject(arg0, arg1 interface{}) *gomock.Ca
 }
 
 // GetObjectToFile mocks base method
-func (m *MockProvider) GetObjectToFile(arg0, arg1, arg2 string, arg3 os.FileMode) error {
+func (m *MockProvider) GetObjectToFile(arg0, arg1, arg2 string, arg3 fs.FileMode) error {
 	ret := m.ctrl.Call(m, "GetObjectToFile", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -83,6 +83,18 @@ func (mr *MockProviderMockRecorder) ListObjects(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockProvider)(nil).ListObjects), arg0, arg1)
 }
 
+// PutEncryptedObject mocks base method
+func (m *MockProvider) PutEncryptedObject(arg0, arg1 string, arg2 []byte) error {
+	ret := m.ctrl.Call(m, "PutEncryptedObject", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutEncryptedObject indicates an expected call of PutEncryptedObject
+func (mr *MockProviderMockRecorder) PutEncryptedObject(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutEncryptedObject", reflect.TypeOf((*MockProvider)(nil).PutEncryptedObject), arg0, arg1, arg2)
+}
+
 // PutObject mocks base method
 func (m *MockProvider) PutObject(arg0, arg1 string, arg2 []byte) error {
 	ret := m.ctrl.Call(m, "PutObject", arg0, arg1, arg2)
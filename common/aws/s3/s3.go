@@ -12,6 +12,7 @@ import (
 
 type Provider interface {
 	PutObject(string, string, []byte) error
+	PutEncryptedObject(bucket, key string, body []byte) error
 	ListObjects(string, string) ([]string, error)
 	GetObject(string, string) ([]byte, error)
 	DeleteObject(string, string) error
@@ -76,6 +77,28 @@ func (this *S3) PutObject(bucket, key string, body []byte) error {
 	return nil
 }
 
+// PutEncryptedObject stores the object using server-side encryption with the account's default KMS key
+func (this *S3) PutEncryptedObject(bucket, key string, body []byte) error {
+	input := &s3.PutObjectInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(body),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.PutObject(input)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (this *S3) PutObjectFromFile(bucket, key, path string) error {
 	body, err := ioutil.ReadFile(path)
 	if err != nil {
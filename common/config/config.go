@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -39,6 +40,12 @@ const (
 	TEST_AWS_TAG_DYNAMO_TABLE = "LAYER0_TEST_AWS_TAG_DYNAMO_TABLE"
 	TEST_AWS_JOB_DYNAMO_TABLE = "LAYER0_TEST_AWS_JOB_DYNAMO_TABLE"
 	AWS_TIME_BETWEEN_REQUESTS = "LAYER0_AWS_TIME_BETWEEN_REQUESTS"
+	DEPLOY_RETENTION_COUNT    = "LAYER0_DEPLOY_RETENTION_COUNT"
+	DOCKER_TLS_CA             = "LAYER0_DOCKER_TLS_CA"
+	DOCKER_TLS_CERT           = "LAYER0_DOCKER_TLS_CERT"
+	DOCKER_TLS_KEY            = "LAYER0_DOCKER_TLS_KEY"
+	PROFILE                   = "LAYER0_PROFILE"
+	PROFILE_CONFIG_PATH       = "LAYER0_PROFILE_CONFIG_PATH"
 )
 
 // defaults
@@ -175,6 +182,20 @@ func AWSECSRole() string {
 	return get(AWS_ECS_ROLE)
 }
 
+// DockerTLSCA, DockerTLSCert, and DockerTLSKey are the base64 encoded pem blocks the api
+// uses to connect to the docker daemon on environment instances
+func DockerTLSCA() string {
+	return get(DOCKER_TLS_CA)
+}
+
+func DockerTLSCert() string {
+	return get(DOCKER_TLS_CERT)
+}
+
+func DockerTLSKey() string {
+	return get(DOCKER_TLS_KEY)
+}
+
 func AWSKeyPair() string {
 	return get(AWS_SSH_KEY_PAIR)
 }
@@ -221,6 +242,17 @@ func AWSTimeBetweenRequests() string {
 	return getOr(AWS_TIME_BETWEEN_REQUESTS, DEFAULT_TIME_BETWEEN_REQUESTS)
 }
 
+// DeployRetentionCount is the number of versions of each deploy family the api keeps.
+// Versions in use by a service or task are always kept. A value of 0 disables pruning.
+func DeployRetentionCount() int {
+	count, err := strconv.Atoi(getOr(DEPLOY_RETENTION_COUNT, "0"))
+	if err != nil {
+		return 0
+	}
+
+	return count
+}
+
 func Prefix() string {
 	return getOr(PREFIX, "l0")
 }
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/docker/docker/pkg/homedir"
+)
+
+// Profile holds the settings used to connect to a Layer0 instance
+type Profile struct {
+	Confirm           bool   `json:"confirm"`
+	Endpoint          string `json:"endpoint"`
+	Name              string `json:"name"`
+	SkipSSLVerify     bool   `json:"skip_ssl_verify"`
+	SkipVersionVerify bool   `json:"skip_version_verify"`
+	Token             string `json:"token,omitempty"`
+}
+
+// ProfileConfig holds the named profiles the cli can use to connect to Layer0 instances
+type ProfileConfig struct {
+	CurrentProfile string     `json:"current_profile"`
+	Profiles       []*Profile `json:"profiles"`
+}
+
+// ProfileConfigPath returns the location of the profile config file, ~/.layer0/config by default
+func ProfileConfigPath() string {
+	return getOr(PROFILE_CONFIG_PATH, filepath.Join(homedir.Get(), ".layer0", "config"))
+}
+
+// LoadProfileConfig reads the profile config at path; a missing file is treated as an empty config
+func LoadProfileConfig(path string) (*ProfileConfig, error) {
+	profileConfig := &ProfileConfig{
+		Profiles: []*Profile{},
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return profileConfig, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, profileConfig); err != nil {
+		return nil, fmt.Errorf("Failed to parse profile config '%s': %v", path, err)
+	}
+
+	return profileConfig, nil
+}
+
+// Save writes the profile config to path; the file is only readable by the current user since it holds auth tokens
+func (p *ProfileConfig) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+func (p *ProfileConfig) Profile(name string) (*Profile, bool) {
+	for _, profile := range p.Profiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+
+	return nil, false
+}
+
+// SetProfile adds the profile, replacing any existing profile with the same name
+func (p *ProfileConfig) SetProfile(profile *Profile) {
+	profiles := []*Profile{profile}
+	for _, existing := range p.Profiles {
+		if existing.Name != profile.Name {
+			profiles = append(profiles, existing)
+		}
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	p.Profiles = profiles
+}
+
+// Resolve returns the profile the cli should use. A profile selected by name is used as-is.
+// Otherwise the LAYER0_API_ENDPOINT and LAYER0_AUTH_TOKEN environment variables are used if either is set,
+// followed by the current profile and finally the default endpoint and token.
+func (p *ProfileConfig) Resolve(name string) (*Profile, error) {
+	if name != "" {
+		profile, ok := p.Profile(name)
+		if !ok {
+			return nil, fmt.Errorf("Profile '%s' does not exist. Run `l0 profile list` to see available profiles", name)
+		}
+
+		return profile, nil
+	}
+
+	if get(API_ENDPOINT) == "" && get(AUTH_TOKEN) == "" && p.CurrentProfile != "" {
+		profile, ok := p.Profile(p.CurrentProfile)
+		if !ok {
+			return nil, fmt.Errorf("Current profile '%s' does not exist. Run `l0 profile use` to select another profile", p.CurrentProfile)
+		}
+
+		return profile, nil
+	}
+
+	return EnvironmentProfile(), nil
+}
+
+// EnvironmentProfile returns a profile built from the cli's environment variables
+func EnvironmentProfile() *Profile {
+	return &Profile{
+		Endpoint:          APIEndpoint(),
+		Token:             AuthToken(),
+		SkipSSLVerify:     !ShouldVerifySSL(),
+		SkipVersionVerify: !ShouldVerifyVersion(),
+	}
+}
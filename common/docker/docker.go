@@ -0,0 +1,142 @@
+package docker
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+	dc "github.com/fsouza/go-dockerclient"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+// DaemonPort is the port the docker daemon on each environment instance listens on.
+// The daemon requires tls client certificates, so only the api can connect to it.
+const DaemonPort = 2376
+
+// DaemonServerName is the name in the certificate of each daemon; instances are addressed by ip
+const DaemonServerName = "layer0-docker"
+
+// the ecs agent labels each container it starts with its task arn and container name
+const (
+	taskARNLabel       = "com.amazonaws.ecs.task-arn"
+	containerNameLabel = "com.amazonaws.ecs.container-name"
+)
+
+type Provider interface {
+	GetTaskContainerID(taskARN, containerName string) (string, error)
+	Exec(containerID string, command []string, tty bool, streams models.ExecStreams) (int, error)
+}
+
+// Factory returns a Provider connected to the docker daemon on the specified host
+type Factory func(host string) (Provider, error)
+
+type Docker struct {
+	Client *dc.Client
+}
+
+func NewDocker(host string) (Provider, error) {
+	pemBlocks := map[string][]byte{}
+	for name, encoded := range map[string]string{
+		config.DOCKER_TLS_CA:   config.DockerTLSCA(),
+		config.DOCKER_TLS_CERT: config.DockerTLSCert(),
+		config.DOCKER_TLS_KEY:  config.DockerTLSKey(),
+	} {
+		if encoded == "" {
+			return nil, fmt.Errorf("Required environment variable '%s' not set", name)
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode %s: %v", name, err)
+		}
+
+		pemBlocks[name] = decoded
+	}
+
+	client, err := dc.NewTLSClientFromBytes(
+		fmt.Sprintf("tcp://%s:%d", host, DaemonPort),
+		pemBlocks[config.DOCKER_TLS_CERT],
+		pemBlocks[config.DOCKER_TLS_KEY],
+		pemBlocks[config.DOCKER_TLS_CA])
+	if err != nil {
+		return nil, err
+	}
+
+	// the transport and the exec connections share this config
+	client.TLSConfig.ServerName = DaemonServerName
+	return &Docker{Client: client}, nil
+}
+
+func (d *Docker) GetTaskContainerID(taskARN, containerName string) (string, error) {
+	filters := map[string][]string{
+		"label": {
+			fmt.Sprintf("%s=%s", taskARNLabel, taskARN),
+			fmt.Sprintf("%s=%s", containerNameLabel, containerName),
+		},
+	}
+
+	containers, err := d.Client.ListContainers(dc.ListContainersOptions{Filters: filters})
+	if err != nil {
+		return "", err
+	}
+
+	if len(containers) == 0 {
+		return "", fmt.Errorf("Container '%s' is not running", containerName)
+	}
+
+	return containers[0].ID, nil
+}
+
+// Exec runs command in the specified container and blocks until it exits, returning its exit code
+func (d *Docker) Exec(containerID string, command []string, tty bool, streams models.ExecStreams) (int, error) {
+	exec, err := d.Client.CreateExec(dc.CreateExecOptions{
+		Container:    containerID,
+		Cmd:          command,
+		Tty:          tty,
+		AttachStdin:  streams.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	success := make(chan struct{})
+	waiter, err := d.Client.StartExecNonBlocking(exec.ID, dc.StartExecOptions{
+		InputStream:  streams.Stdin,
+		OutputStream: streams.Stdout,
+		ErrorStream:  streams.Stderr,
+		Tty:          tty,
+		RawTerminal:  tty,
+		Success:      success,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// the exec's tty can only be resized once the session is attached
+	<-success
+	success <- struct{}{}
+
+	if tty && streams.Resize != nil {
+		go func() {
+			for size := range streams.Resize {
+				if err := d.Client.ResizeExecTTY(exec.ID, size.Height, size.Width); err != nil {
+					log.Debugf("Failed to resize exec %s: %v", exec.ID, err)
+				}
+			}
+		}()
+	}
+
+	if err := waiter.Wait(); err != nil {
+		return 0, err
+	}
+
+	inspect, err := d.Client.InspectExec(exec.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return inspect.ExitCode, nil
+}
@@ -32,4 +32,14 @@ const (
 	LoadBalancerAttributeNotFound
 	ServiceDoesNotExist
 	TaskDoesNotExist
+	InvalidEnvironmentLink
+	InvalidLoadBalancerType
+	InvalidLoadBalancerRule
+	InvalidLoadBalancerAttribute
+	InvalidDeployTemplate
+	InvalidSecretName
+	SecretDoesNotExist
+	InvalidCompose
+	InvalidExecRequest
+	InvalidBulkRequest
 )
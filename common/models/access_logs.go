@@ -0,0 +1,8 @@
+package models
+
+type AccessLogs struct {
+	Enabled        bool   `json:"enabled"`
+	S3BucketName   string `json:"s3_bucket_name"`
+	S3BucketPrefix string `json:"s3_bucket_prefix"`
+	EmitInterval   int    `json:"emit_interval"`
+}
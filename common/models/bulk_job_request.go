@@ -0,0 +1,8 @@
+package models
+
+// BulkJobRequest is the request of a bulk job; it holds the entities matched when the job was created
+type BulkJobRequest struct {
+	Action     string   `json:"action"`
+	EntityIDs  []string `json:"entity_ids"`
+	EntityType string   `json:"entity_type"`
+}
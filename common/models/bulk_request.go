@@ -0,0 +1,11 @@
+package models
+
+type BulkRequest struct {
+	Action     string            `json:"action"`
+	All        bool              `json:"all"`
+	DryRun     bool              `json:"dry_run"`
+	EntityType string            `json:"entity_type"`
+	Selector   map[string]string `json:"selector"`
+	// EntityIDs limits the action to these entities, e.g. the ones a dry run returned; they must still match the selector
+	EntityIDs []string `json:"entity_ids,omitempty"`
+}
@@ -0,0 +1,8 @@
+package models
+
+type BulkResponse struct {
+	Action     string   `json:"action"`
+	EntityIDs  []string `json:"entity_ids"`
+	EntityType string   `json:"entity_type"`
+	JobID      string   `json:"job_id"`
+}
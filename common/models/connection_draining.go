@@ -0,0 +1,6 @@
+package models
+
+type ConnectionDraining struct {
+	Enabled bool `json:"enabled"`
+	Timeout int  `json:"timeout"`
+}
@@ -0,0 +1,7 @@
+package models
+
+type ContainerDiff struct {
+	Changes       []DeployChange `json:"changes"`
+	ContainerName string         `json:"container_name"`
+	Status        string         `json:"status"`
+}
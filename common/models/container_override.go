@@ -1,6 +1,11 @@
 package models
 
+// Zero values for Command, CPU, Memory and MemoryReservation leave the deploy's values unchanged
 type ContainerOverride struct {
+	Command              []string          `json:"command"`
 	ContainerName        string            `json:"container_name"`
+	CPU                  int64             `json:"cpu"`
 	EnvironmentOverrides map[string]string `json:"environment_overrides"`
+	Memory               int64             `json:"memory"`
+	MemoryReservation    int64             `json:"memory_reservation"`
 }
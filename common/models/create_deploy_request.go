@@ -1,6 +1,9 @@
 package models
 
 type CreateDeployRequest struct {
-	DeployName string `json:"deploy_name"`
-	Dockerrun  []byte `json:"dockerrun"`
+	Compose    []byte            `json:"compose"`
+	DeployName string            `json:"deploy_name"`
+	Dockerrun  []byte            `json:"dockerrun"`
+	Template   []byte            `json:"template"`
+	Variables  map[string]string `json:"variables"`
 }
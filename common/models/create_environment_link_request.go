@@ -1,5 +1,9 @@
 package models
 
 type CreateEnvironmentLinkRequest struct {
-	EnvironmentID string `json:"environment_id"`
+	EnvironmentID   string                `json:"environment_id"`
+	CIDR            string                `json:"cidr"`
+	SecurityGroupID string                `json:"security_group_id"`
+	Direction       string                `json:"direction"`
+	Rules           []EnvironmentLinkRule `json:"rules"`
 }
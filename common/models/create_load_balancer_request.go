@@ -2,6 +2,7 @@ package models
 
 type CreateLoadBalancerRequest struct {
 	LoadBalancerName string      `json:"load_balancer_name"`
+	LoadBalancerType string      `json:"load_balancer_type"`
 	EnvironmentID    string      `json:"environment_id"`
 	IsPublic         bool        `json:"is_public"`
 	Ports            []Port      `json:"ports"`
@@ -0,0 +1,7 @@
+package models
+
+type CreateSecretRequest struct {
+	EnvironmentID string `json:"environment_id"`
+	SecretName    string `json:"secret_name"`
+	Value         string `json:"value"`
+}
@@ -1,8 +1,9 @@
 package models
 
 type CreateServiceRequest struct {
-	DeployID       string `json:"deploy_id"`
-	EnvironmentID  string `json:"environment_id"`
-	LoadBalancerID string `json:"load_balancer_id"`
-	ServiceName    string `json:"service_name"`
+	DeployID         string           `json:"deploy_id"`
+	EnvironmentID    string           `json:"environment_id"`
+	LoadBalancerID   string           `json:"load_balancer_id"`
+	LoadBalancerRule LoadBalancerRule `json:"load_balancer_rule"`
+	ServiceName      string           `json:"service_name"`
 }
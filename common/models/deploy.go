@@ -1,8 +1,10 @@
 package models
 
 type Deploy struct {
-	Dockerrun  []byte `json:"dockerrun"`
-	DeployID   string `json:"deploy_id"`
-	DeployName string `json:"deploy_name"`
-	Version    string `json:"version"`
+	Dockerrun  []byte            `json:"dockerrun"`
+	DeployID   string            `json:"deploy_id"`
+	DeployName string            `json:"deploy_name"`
+	Template   []byte            `json:"template"`
+	Variables  map[string]string `json:"variables"`
+	Version    string            `json:"version"`
 }
@@ -0,0 +1,7 @@
+package models
+
+type DeployChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
@@ -0,0 +1,8 @@
+package models
+
+type DeployDiff struct {
+	Containers    []ContainerDiff `json:"containers"`
+	DeployID      string          `json:"deploy_id"`
+	OtherDeployID string          `json:"other_deploy_id"`
+	Volumes       []DeployChange  `json:"volumes"`
+}
@@ -0,0 +1,7 @@
+package models
+
+type DeployValidation struct {
+	Errors   []string `json:"errors"`
+	Valid    bool     `json:"valid"`
+	Warnings []string `json:"warnings"`
+}
@@ -30,6 +30,18 @@ func (e EntitiesWithTags) WithKey(key string) EntitiesWithTags {
 	})
 }
 
+// removes each EntityWithTags object from e if e.Tags does
+// not contain a tag with both the specified key and value
+func (e EntitiesWithTags) WithTag(key, value string) EntitiesWithTags {
+	return e.RemoveIf(func(ewt EntityWithTags) bool {
+		hasTag := ewt.Tags.Any(func(t Tag) bool {
+			return t.Key == key && t.Value == value
+		})
+
+		return !hasTag
+	})
+}
+
 // removes each EntityWithTags object from e if e.Tags does
 // not contain at least one tag with the specified value
 func (e EntitiesWithTags) WithValue(value string) EntitiesWithTags {
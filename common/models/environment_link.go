@@ -0,0 +1,21 @@
+package models
+
+type EnvironmentLink struct {
+	EnvironmentID   string                `json:"environment_id"`
+	CIDR            string                `json:"cidr"`
+	SecurityGroupID string                `json:"security_group_id"`
+	Direction       string                `json:"direction"`
+	Rules           []EnvironmentLinkRule `json:"rules"`
+}
+
+// Peer returns the environment id, cidr, or security group id the link points to
+func (e EnvironmentLink) Peer() string {
+	switch {
+	case e.EnvironmentID != "":
+		return e.EnvironmentID
+	case e.CIDR != "":
+		return e.CIDR
+	default:
+		return e.SecurityGroupID
+	}
+}
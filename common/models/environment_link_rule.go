@@ -0,0 +1,7 @@
+package models
+
+type EnvironmentLinkRule struct {
+	Protocol string `json:"protocol"`
+	FromPort int    `json:"from_port"`
+	ToPort   int    `json:"to_port"`
+}
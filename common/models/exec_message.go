@@ -0,0 +1,9 @@
+package models
+
+type ExecMessage struct {
+	Error    string `json:"error,omitempty"`
+	ExitCode int    `json:"exit_code"`
+	Height   int    `json:"height,omitempty"`
+	Type     string `json:"type"`
+	Width    int    `json:"width,omitempty"`
+}
@@ -0,0 +1,7 @@
+package models
+
+type ExecRequest struct {
+	Command       []string `json:"command"`
+	ContainerName string   `json:"container_name"`
+	TTY           bool     `json:"tty"`
+}
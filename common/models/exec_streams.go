@@ -0,0 +1,18 @@
+package models
+
+import (
+	"io"
+)
+
+// ExecStreams connect an exec session to its caller; Resize is only used when a tty is allocated
+type ExecStreams struct {
+	Resize <-chan TerminalSize
+	Stderr io.Writer
+	Stdin  io.Reader
+	Stdout io.Writer
+}
+
+type TerminalSize struct {
+	Height int `json:"height"`
+	Width  int `json:"width"`
+}
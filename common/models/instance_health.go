@@ -0,0 +1,11 @@
+package models
+
+type InstanceHealth struct {
+	Description string   `json:"description"`
+	InstanceID  string   `json:"instance_id"`
+	ReasonCode  string   `json:"reason_code"`
+	ServiceID   string   `json:"service_id"`
+	ServiceName string   `json:"service_name"`
+	State       string   `json:"state"`
+	TaskIDs     []string `json:"task_ids"`
+}
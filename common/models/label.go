@@ -0,0 +1,31 @@
+package models
+
+import (
+	"strings"
+)
+
+// LabelPrefix namespaces user-defined labels in the tag store so they can't
+// collide with the tags layer0 uses internally, such as 'name' or 'environment_id'
+const LabelPrefix = "label:"
+
+// LabelKey returns the tag key used to store the label with the specified key
+func LabelKey(key string) string {
+	return LabelPrefix + key
+}
+
+// IsLabelKey returns true if the tag key belongs to a user-defined label
+func IsLabelKey(key string) bool {
+	return strings.HasPrefix(key, LabelPrefix)
+}
+
+// Labels returns the user-defined labels in t, keyed without the label prefix
+func (t Tags) Labels() map[string]string {
+	labels := map[string]string{}
+	for _, tag := range t {
+		if IsLabelKey(tag.Key) {
+			labels[strings.TrimPrefix(tag.Key, LabelPrefix)] = tag.Value
+		}
+	}
+
+	return labels
+}
@@ -1,16 +1,20 @@
 package models
 
 type LoadBalancer struct {
-	CrossZone        bool        `json:"cross_zone"`
-	EnvironmentID    string      `json:"environment_id"`
-	EnvironmentName  string      `json:"environment_name"`
-	HealthCheck      HealthCheck `json:"health_check"`
-	IdleTimeout      int         `json:"idle_timeout"`
-	IsPublic         bool        `json:"is_public"`
-	LoadBalancerID   string      `json:"load_balancer_id"`
-	LoadBalancerName string      `json:"load_balancer_name"`
-	Ports            []Port      `json:"ports"`
-	ServiceID        string      `json:"service_id"`
-	ServiceName      string      `json:"service_name"`
-	URL              string      `json:"url"`
+	AccessLogs         AccessLogs         `json:"access_logs"`
+	ConnectionDraining ConnectionDraining `json:"connection_draining"`
+	CrossZone          bool               `json:"cross_zone"`
+	EnvironmentID      string             `json:"environment_id"`
+	EnvironmentName    string             `json:"environment_name"`
+	HealthCheck        HealthCheck        `json:"health_check"`
+	IdleTimeout        int                `json:"idle_timeout"`
+	IsPublic           bool               `json:"is_public"`
+	LoadBalancerID     string             `json:"load_balancer_id"`
+	LoadBalancerName   string             `json:"load_balancer_name"`
+	LoadBalancerType   string             `json:"load_balancer_type"`
+	Ports              []Port             `json:"ports"`
+	Rules              []LoadBalancerRule `json:"rules"`
+	ServiceID          string             `json:"service_id"`
+	ServiceName        string             `json:"service_name"`
+	URL                string             `json:"url"`
 }
@@ -0,0 +1,9 @@
+package models
+
+type LoadBalancerHealth struct {
+	EnvironmentID    string           `json:"environment_id"`
+	EnvironmentName  string           `json:"environment_name"`
+	Instances        []InstanceHealth `json:"instances"`
+	LoadBalancerID   string           `json:"load_balancer_id"`
+	LoadBalancerName string           `json:"load_balancer_name"`
+}
@@ -0,0 +1,9 @@
+package models
+
+type LoadBalancerRule struct {
+	HostHeader  string `json:"host_header"`
+	PathPattern string `json:"path_pattern"`
+	Priority    int    `json:"priority"`
+	ServiceID   string `json:"service_id"`
+	ServiceName string `json:"service_name"`
+}
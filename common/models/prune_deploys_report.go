@@ -0,0 +1,6 @@
+package models
+
+type PruneDeploysReport struct {
+	DryRun bool            `json:"dry_run"`
+	Pruned []DeploySummary `json:"pruned"`
+}
@@ -0,0 +1,6 @@
+package models
+
+type PruneDeploysRequest struct {
+	DryRun bool `json:"dry_run"`
+	Keep   int  `json:"keep"`
+}
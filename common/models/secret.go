@@ -0,0 +1,31 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// deploys reference secrets by setting an environment variable's value to 'secret://<environment id>/<secret name>'.
+// A deploy can only reference the secrets of a single environment, since its task role can only read those secrets.
+const SECRET_REFERENCE_PREFIX = "secret://"
+
+type Secret struct {
+	EnvironmentID   string `json:"environment_id"`
+	EnvironmentName string `json:"environment_name"`
+	SecretName      string `json:"secret_name"`
+}
+
+// IsSecretReference returns true if value references a secret, even if the reference is invalid
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, SECRET_REFERENCE_PREFIX)
+}
+
+// ParseSecretReference returns the environment id and secret name of a 'secret://<environment id>/<secret name>' reference
+func ParseSecretReference(value string) (string, string, error) {
+	split := strings.Split(strings.TrimPrefix(value, SECRET_REFERENCE_PREFIX), "/")
+	if !IsSecretReference(value) || len(split) != 2 || split[0] == "" || split[1] == "" {
+		return "", "", fmt.Errorf("Invalid secret reference '%s': the format is '%s<environment id>/<secret name>'", value, SECRET_REFERENCE_PREFIX)
+	}
+
+	return split[0], split[1], nil
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestParseSecretReference(t *testing.T) {
+	environmentID, secretName, err := ParseSecretReference("secret://envid/db_password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, environmentID, "envid")
+	testutils.AssertEqual(t, secretName, "db_password")
+
+	for _, value := range []string{"secret://db_password", "secret:///db_password", "secret://envid/", "secret://a/b/c", "envid/db_password"} {
+		if _, _, err := ParseSecretReference(value); err == nil {
+			t.Errorf("%s: error was nil!", value)
+		}
+	}
+}
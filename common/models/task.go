@@ -1,5 +1,9 @@
 package models
 
+import (
+	"fmt"
+)
+
 type Task struct {
 	Copies          []TaskCopy `json:"copies"`
 	DeployID        string     `json:"deploy_id"`
@@ -12,3 +16,25 @@ type Task struct {
 	TaskID          string     `json:"task_id"`
 	TaskName        string     `json:"task_name"`
 }
+
+// Failures describes each container of the task that exited with a non-zero exit code or was
+// stopped for a reason, e.g. if its image could not be pulled
+func (t *Task) Failures() []string {
+	failures := []string{}
+	for _, copy := range t.Copies {
+		for _, detail := range copy.Details {
+			if detail.ExitCode == 0 && detail.Reason == "" {
+				continue
+			}
+
+			failure := fmt.Sprintf("Task '%s' container '%s' failed (exit code %d)", t.TaskID, detail.ContainerName, detail.ExitCode)
+			if detail.Reason != "" {
+				failure = fmt.Sprintf("%s: %s", failure, detail.Reason)
+			}
+
+			failures = append(failures, failure)
+		}
+	}
+
+	return failures
+}
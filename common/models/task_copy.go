@@ -1,7 +1,14 @@
 package models
 
+import (
+	"time"
+)
+
 type TaskCopy struct {
 	Details    []TaskDetail `json:"details"`
+	LastStatus string       `json:"last_status"`
 	Reason     string       `json:"reason"`
+	StartedAt  time.Time    `json:"started_at"`
+	StoppedAt  time.Time    `json:"stopped_at"`
 	TaskCopyID string       `json:"task_copy_id"`
 }
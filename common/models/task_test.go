@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestTaskFailures(t *testing.T) {
+	task := &Task{
+		TaskID: "id",
+		Copies: []TaskCopy{
+			{
+				Details: []TaskDetail{
+					{ContainerName: "ok"},
+					{ContainerName: "exited", ExitCode: 2},
+					{ContainerName: "pull", Reason: "CannotPullContainerError"},
+				},
+			},
+		},
+	}
+
+	expected := []string{
+		"Task 'id' container 'exited' failed (exit code 2)",
+		"Task 'id' container 'pull' failed (exit code 0): CannotPullContainerError",
+	}
+
+	testutils.AssertEqual(t, task.Failures(), expected)
+}
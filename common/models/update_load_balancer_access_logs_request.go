@@ -0,0 +1,5 @@
+package models
+
+type UpdateLoadBalancerAccessLogsRequest struct {
+	AccessLogs AccessLogs `json:"access_logs"`
+}
@@ -0,0 +1,5 @@
+package models
+
+type UpdateLoadBalancerConnectionDrainingRequest struct {
+	ConnectionDraining ConnectionDraining `json:"connection_draining"`
+}
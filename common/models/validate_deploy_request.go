@@ -0,0 +1,7 @@
+package models
+
+type ValidateDeployRequest struct {
+	DeployName    string `json:"deploy_name"`
+	Dockerrun     []byte `json:"dockerrun"`
+	EnvironmentID string `json:"environment_id"`
+}
@@ -0,0 +1,53 @@
+package shellutils
+
+import (
+	"bytes"
+	"fmt"
+	"unicode"
+)
+
+// Split splits command into arguments the way a shell would: arguments are separated by whitespace
+// and can be quoted or escaped, e.g. 'sh -c "echo hello world"'. Variables and globs are not expanded.
+func Split(command string) ([]string, error) {
+	args := []string{}
+	var current bytes.Buffer
+	var inArg, escaped bool
+	var quote rune
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if escaped || quote != 0 {
+		return nil, fmt.Errorf("unterminated quote or escape")
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
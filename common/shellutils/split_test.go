@@ -0,0 +1,35 @@
+package shellutils
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestSplit(t *testing.T) {
+	cases := map[string][]string{
+		"":                          {},
+		"python manage.py migrate":  {"python", "manage.py", "migrate"},
+		`sh -c "echo hello  world"`: {"sh", "-c", "echo hello  world"},
+		`sh -c 'echo "$HOME"'`:      {"sh", "-c", `echo "$HOME"`},
+		`echo a\ b ""`:              {"echo", "a b", ""},
+		`echo 'a\b'`:                {"echo", `a\b`},
+	}
+
+	for command, expected := range cases {
+		args, err := Split(command)
+		if err != nil {
+			t.Fatalf("%s: %v", command, err)
+		}
+
+		testutils.AssertEqual(t, args, expected)
+	}
+}
+
+func TestSplitErrors(t *testing.T) {
+	for _, command := range []string{`sh -c 'echo`, `sh -c "echo`, `echo \`} {
+		if _, err := Split(command); err == nil {
+			t.Fatalf("%s: error was nil!", command)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/quintilesims/layer0/common/aws/ec2"
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/aws/elb"
+	"github.com/quintilesims/layer0/common/aws/elbv2"
 	"github.com/quintilesims/layer0/common/aws/iam"
 	"github.com/quintilesims/layer0/common/aws/provider"
 	"github.com/quintilesims/layer0/common/aws/s3"
@@ -45,6 +46,11 @@ func GetBackend(credProvider provider.CredProvider, region string) (*ecsbackend.
 		return nil, err
 	}
 
+	elbv2Provider, err := elbv2.NewELBV2(credProvider, region)
+	if err != nil {
+		return nil, err
+	}
+
 	cloudWatchLogsProvider, err := cloudwatchlogs.NewCloudWatchLogs(credProvider, region)
 	if err != nil {
 		return nil, err
@@ -57,6 +63,7 @@ func GetBackend(credProvider provider.CredProvider, region string) (*ecsbackend.
 
 	ec2Provider = wrapEC2(ec2Provider)
 	elbProvider = wrapELB(elbProvider)
+	elbv2Provider = wrapELBV2(elbv2Provider)
 	cloudWatchLogsProvider = wrapCloudWatchLogs(cloudWatchLogsProvider)
 
 	ecsProvider, err := GetECS(credProvider, region)
@@ -76,6 +83,7 @@ func GetBackend(credProvider provider.CredProvider, region string) (*ecsbackend.
 		ec2Provider,
 		ecsProvider,
 		elbProvider,
+		elbv2Provider,
 		autoscalingProvider,
 		cloudWatchLogsProvider)
 
@@ -216,6 +224,15 @@ func wrapELB(e elb.Provider) elb.Provider {
 	return wrap
 }
 
+func wrapELBV2(e elbv2.Provider) elbv2.Provider {
+	wrap := &elbv2.ProviderDecorator{
+		Inner:     e,
+		Decorator: decorators.CallWithLogging,
+	}
+
+	return wrap
+}
+
 func wrapCloudWatchLogs(c cloudwatchlogs.Provider) cloudwatchlogs.Provider {
 	wrap := &cloudwatchlogs.ProviderDecorator{
 		Inner:     c,
@@ -0,0 +1,15 @@
+package types
+
+// Binary exec messages are prefixed with the stream they belong to
+const (
+	ExecStdin byte = iota
+	ExecStdout
+	ExecStderr
+)
+
+// Text exec messages are json-encoded models.ExecMessage objects with one of these types
+const (
+	ExecResizeMessage = "resize"
+	ExecExitMessage   = "exit"
+	ExecErrorMessage  = "error"
+)
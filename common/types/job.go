@@ -36,6 +36,7 @@ const (
 	DeleteLoadBalancerJob
 	DeleteTaskJob
 	CreateTaskJob
+	BulkDeleteJob
 )
 
 var jobTypeStrings = []string{
@@ -44,6 +45,7 @@ var jobTypeStrings = []string{
 	"delete load balancer",
 	"delete task",
 	"create task",
+	"bulk delete",
 }
 
 func (jobType JobType) String() string {
@@ -21,6 +21,11 @@ func dataSourceLayer0Deploy() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -46,8 +51,14 @@ func datasourceLayer0DeployRead(d *schema.ResourceData, meta interface{}) error
 
 	d.SetId(deploy.DeployID)
 
+	labels, err := client.API.ListLabels("deploy", deployID)
+	if err != nil {
+		return err
+	}
+
 	return setResourceData(d.Set, map[string]interface{}{
 		"name":    deploy.DeployName,
 		"version": deploy.Version,
+		"tags":    labels,
 	})
 }
@@ -34,6 +34,10 @@ func TestDeployDataResourceSelectByQueryParams(t *testing.T) {
 		GetDeploy(deployId).
 		Return(&models.Deploy{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("deploy", deployId).
+		Return(map[string]string{}, nil)
+
 	deployResource := provider.DataSourcesMap["layer0_deploy"]
 	d := schema.TestResourceDataRaw(t, deployResource.Schema, map[string]interface{}{
 		"name":    deployName,
@@ -33,6 +33,11 @@ func dataSourceLayer0Environment() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -54,10 +59,16 @@ func datasourceLayer0EnvironmentRead(d *schema.ResourceData, meta interface{}) e
 
 	d.SetId(environment.EnvironmentID)
 
+	labels, err := client.API.ListLabels("environment", environmentID)
+	if err != nil {
+		return err
+	}
+
 	return setResourceData(d.Set, map[string]interface{}{
 		"size":      environment.InstanceSize,
 		"min_count": environment.ClusterCount,
 		"os":        environment.OperatingSystem,
 		"ami":       environment.AMIID,
+		"tags":      labels,
 	})
 }
@@ -32,6 +32,10 @@ func TestEnvironmentDataResourceSelectByQueryParams(t *testing.T) {
 		GetEnvironment(environmentID).
 		Return(&models.Environment{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("environment", environmentID).
+		Return(map[string]string{}, nil)
+
 	environmentResource := provider.DataSourcesMap["layer0_environment"]
 	d := schema.TestResourceDataRaw(t, environmentResource.Schema, map[string]interface{}{
 		"name": environmentName,
@@ -37,6 +37,11 @@ func dataSourcelayer0LoadBalancer() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -61,6 +66,12 @@ func dataSourcelayer0LoadBalancerRead(d *schema.ResourceData, meta interface{})
 	}
 
 	d.SetId(loadbalancer.LoadBalancerID)
+
+	labels, err := client.API.ListLabels("load_balancer", loadbalancerID)
+	if err != nil {
+		return err
+	}
+
 	return setResourceData(d.Set, map[string]interface{}{
 		"name":             loadbalancer.LoadBalancerName,
 		"private":          !loadbalancer.IsPublic,
@@ -68,5 +79,6 @@ func dataSourcelayer0LoadBalancerRead(d *schema.ResourceData, meta interface{})
 		"environment_id":   loadbalancer.EnvironmentID,
 		"environment_name": loadbalancer.EnvironmentName,
 		"cross_zone":       loadbalancer.CrossZone,
+		"tags":             labels,
 	})
 }
@@ -34,6 +34,10 @@ func TestLoadBalancerDataResourceSelectByQueryParams(t *testing.T) {
 		GetLoadBalancer(loadBalancerID).
 		Return(&models.LoadBalancer{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("load_balancer", loadBalancerID).
+		Return(map[string]string{}, nil)
+
 	loadbalancerResource := provider.DataSourcesMap["layer0_load_balancer"]
 	d := schema.TestResourceDataRaw(t, loadbalancerResource.Schema, map[string]interface{}{
 		"name":           loadbalancerName,
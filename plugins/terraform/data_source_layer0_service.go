@@ -29,6 +29,11 @@ func dataSourcelayer0Service() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -54,10 +59,16 @@ func dataSourcelayer0ServiceRead(d *schema.ResourceData, meta interface{}) error
 
 	d.SetId(service.ServiceID)
 
+	labels, err := client.API.ListLabels("service", serviceID)
+	if err != nil {
+		return err
+	}
+
 	return setResourceData(d.Set, map[string]interface{}{
 		"name":             service.ServiceName,
 		"environment_id":   service.EnvironmentID,
 		"environment_name": service.EnvironmentName,
 		"scale":            service.DesiredCount,
+		"tags":             labels,
 	})
 }
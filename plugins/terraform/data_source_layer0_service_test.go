@@ -34,6 +34,10 @@ func TestServiceDataResourceSelectByQueryParams(t *testing.T) {
 		GetService(serviceID).
 		Return(&models.Service{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("service", serviceID).
+		Return(map[string]string{}, nil)
+
 	serviceResource := provider.DataSourcesMap["layer0_service"]
 	d := schema.TestResourceDataRaw(t, serviceResource.Schema, map[string]interface{}{
 		"name":           serviceName,
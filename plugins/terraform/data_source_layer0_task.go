@@ -0,0 +1,96 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceLayer0Task() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceLayer0TaskRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"environment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"environment_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deploy_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deploy_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deploy_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"pending_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"running_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"containers": taskContainersSchema(),
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceLayer0TaskRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Layer0Client)
+
+	taskName := d.Get("name").(string)
+	environmentID := d.Get("environment_id").(string)
+	params := map[string]string{
+		"environment_id": environmentID,
+	}
+
+	taskID, err := resolveTags(client, taskName, "task", params)
+	if err != nil {
+		return err
+	}
+
+	task, err := client.API.GetTask(taskID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(task.TaskID)
+
+	labels, err := client.API.ListLabels("task", taskID)
+	if err != nil {
+		return err
+	}
+
+	return setResourceData(d.Set, map[string]interface{}{
+		"name":             task.TaskName,
+		"environment_id":   task.EnvironmentID,
+		"environment_name": task.EnvironmentName,
+		"deploy_id":        task.DeployID,
+		"deploy_name":      task.DeployName,
+		"deploy_version":   task.DeployVersion,
+		"pending_count":    int(task.PendingCount),
+		"running_count":    int(task.RunningCount),
+		"containers":       flattenTaskContainers(task),
+		"tags":             labels,
+	})
+}
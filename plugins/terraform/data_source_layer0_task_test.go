@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+func TestTaskDataResourceSelectByQueryParams(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	taskID := "task-id"
+	taskName := "task-name"
+	environmentID := "l0-env-id"
+
+	params := map[string]string{
+		"type":           "task",
+		"environment_id": environmentID,
+		"fuzz":           taskName,
+	}
+
+	mockClient.EXPECT().
+		SelectByQuery(params).
+		Return([]*models.EntityWithTags{
+			&models.EntityWithTags{
+				EntityID:   taskID,
+				EntityType: "task",
+			},
+		}, nil)
+
+	mockClient.EXPECT().
+		GetTask(taskID).
+		Return(&models.Task{TaskID: taskID}, nil)
+
+	mockClient.EXPECT().
+		ListLabels("task", taskID).
+		Return(map[string]string{}, nil)
+
+	taskResource := provider.DataSourcesMap["layer0_task"]
+	d := schema.TestResourceDataRaw(t, taskResource.Schema, map[string]interface{}{
+		"name":           taskName,
+		"environment_id": environmentID,
+	})
+
+	client := &Layer0Client{API: mockClient}
+	if err := taskResource.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -36,6 +36,7 @@ func Provider() terraform.ResourceProvider {
 			"layer0_environment_link": resourceLayer0EnvironmentLink(),
 			"layer0_load_balancer":    resourceLayer0LoadBalancer(),
 			"layer0_service":          resourceLayer0Service(),
+			"layer0_task":             resourceLayer0Task(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"layer0_api":           dataSourceLayer0API(),
@@ -43,6 +44,7 @@ func Provider() terraform.ResourceProvider {
 			"layer0_load_balancer": dataSourcelayer0LoadBalancer(),
 			"layer0_deploy":        dataSourceLayer0Deploy(),
 			"layer0_service":       dataSourcelayer0Service(),
+			"layer0_task":          dataSourceLayer0Task(),
 		},
 	}
 
@@ -5,12 +5,14 @@ import (
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
 )
 
 func resourceLayer0Deploy() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceLayer0DeployCreate,
 		Read:   resourceLayer0DeployRead,
+		Update: resourceLayer0DeployUpdate,
 		Delete: resourceLayer0DeployDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -26,10 +28,21 @@ func resourceLayer0Deploy() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"variables": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"rendered": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"version": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags": labelsSchema(),
 		},
 	}
 }
@@ -40,12 +53,31 @@ func resourceLayer0DeployCreate(d *schema.ResourceData, meta interface{}) error
 	name := d.Get("name").(string)
 	content := d.Get("content").(string)
 
-	deploy, err := client.API.CreateDeploy(name, []byte(content))
+	var deploy *models.Deploy
+	var err error
+
+	// content is rendered as a template when variables are specified
+	if v, ok := d.GetOk("variables"); ok {
+		variables := map[string]string{}
+		for key, val := range v.(map[string]interface{}) {
+			variables[key] = val.(string)
+		}
+
+		deploy, err = client.API.CreateDeployFromTemplate(name, []byte(content), variables)
+	} else {
+		deploy, err = client.API.CreateDeploy(name, []byte(content))
+	}
+
 	if err != nil {
 		return err
 	}
 
 	d.SetId(deploy.DeployID)
+
+	if err := updateLabels(client, d, "deploy", deploy.DeployID); err != nil {
+		return err
+	}
+
 	return resourceLayer0DeployRead(d, meta)
 }
 
@@ -66,13 +98,28 @@ func resourceLayer0DeployRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("name", deploy.DeployName)
 	d.Set("version", deploy.Version)
+	d.Set("rendered", string(deploy.Dockerrun))
+
+	if len(deploy.Variables) > 0 {
+		d.Set("variables", deploy.Variables)
+	}
 
 	// do not set content as it fails to properly diff against what's
 	// returned by the Layer0 API
 	// TODO: improve suppressEquivalentDockerrunDiffs to ignore non-critical
 	// differences between dockerruns
 
-	return nil
+	return readLabels(client, d, "deploy", deployID)
+}
+
+func resourceLayer0DeployUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Layer0Client)
+
+	if err := updateLabels(client, d, "deploy", d.Id()); err != nil {
+		return err
+	}
+
+	return resourceLayer0DeployRead(d, meta)
 }
 
 func resourceLayer0DeployDelete(d *schema.ResourceData, meta interface{}) error {
@@ -19,6 +19,10 @@ func TestDeployCreate(t *testing.T) {
 		GetDeploy("did").
 		Return(&models.Deploy{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("deploy", "did").
+		Return(map[string]string{}, nil)
+
 	deployResource := provider.ResourcesMap["layer0_deploy"]
 	d := schema.TestResourceDataRaw(t, deployResource.Schema, map[string]interface{}{
 		"name":    "test-dep",
@@ -31,6 +35,35 @@ func TestDeployCreate(t *testing.T) {
 	}
 }
 
+func TestDeployCreate_variables(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	mockClient.EXPECT().
+		CreateDeployFromTemplate("test-dep", []byte("sample {{ .tag }}"), map[string]string{"tag": "v2"}).
+		Return(&models.Deploy{DeployID: "did"}, nil)
+
+	mockClient.EXPECT().
+		GetDeploy("did").
+		Return(&models.Deploy{}, nil)
+
+	mockClient.EXPECT().
+		ListLabels("deploy", "did").
+		Return(map[string]string{}, nil)
+
+	deployResource := provider.ResourcesMap["layer0_deploy"]
+	d := schema.TestResourceDataRaw(t, deployResource.Schema, map[string]interface{}{
+		"name":      "test-dep",
+		"content":   "sample {{ .tag }}",
+		"variables": map[string]interface{}{"tag": "v2"},
+	})
+
+	client := &Layer0Client{API: mockClient}
+	if err := deployResource.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestDeployRead(t *testing.T) {
 	ctrl, mockClient, provider := setupUnitTest(t)
 	defer ctrl.Finish()
@@ -39,6 +72,10 @@ func TestDeployRead(t *testing.T) {
 		GetDeploy("did").
 		Return(&models.Deploy{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("deploy", "did").
+		Return(map[string]string{}, nil)
+
 	deployResource := provider.ResourcesMap["layer0_deploy"]
 	d := schema.TestResourceDataRaw(t, deployResource.Schema, map[string]interface{}{})
 	d.SetId("did")
@@ -57,6 +57,7 @@ func resourceLayer0Environment() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags": labelsSchema(),
 		},
 	}
 }
@@ -77,6 +78,11 @@ func resourceLayer0EnvironmentCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	d.SetId(environment.EnvironmentID)
+
+	if err := updateLabels(client, d, "environment", environment.EnvironmentID); err != nil {
+		return err
+	}
+
 	return resourceLayer0EnvironmentRead(d, meta)
 }
 
@@ -102,13 +108,17 @@ func resourceLayer0EnvironmentRead(d *schema.ResourceData, meta interface{}) err
 	d.Set("os", environment.OperatingSystem)
 	d.Set("ami", environment.AMIID)
 
-	return nil
+	return readLabels(client, d, "environment", environmentID)
 }
 
 func resourceLayer0EnvironmentUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Layer0Client)
 	environmentID := d.Id()
 
+	if err := updateLabels(client, d, "environment", environmentID); err != nil {
+		return err
+	}
+
 	if d.HasChange("min_count") {
 		minCount := d.Get("min_count").(int)
 
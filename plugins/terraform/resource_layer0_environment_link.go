@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/quintilesims/layer0/common/models"
 )
 
 func resourceLayer0EnvironmentLink() *schema.Resource {
@@ -21,9 +22,49 @@ func resourceLayer0EnvironmentLink() *schema.Resource {
 			},
 			"dest": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				ForceNew: true,
+			},
+			"cidr": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"security_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
 				ForceNew: true,
 			},
+			"direction": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"from_port": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"to_port": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -31,9 +72,16 @@ func resourceLayer0EnvironmentLink() *schema.Resource {
 func resourceLayer0EnvironmentLinkCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Layer0Client)
 	sourceID := d.Get("source").(string)
-	destID := d.Get("dest").(string)
 
-	if err := client.API.CreateLink(sourceID, destID); err != nil {
+	link := models.EnvironmentLink{
+		EnvironmentID:   d.Get("dest").(string),
+		CIDR:            d.Get("cidr").(string),
+		SecurityGroupID: d.Get("security_group_id").(string),
+		Direction:       d.Get("direction").(string),
+		Rules:           expandEnvironmentLinkRules(d.Get("rule").([]interface{})),
+	}
+
+	if err := client.API.CreateLink(sourceID, link); err != nil {
 		return err
 	}
 
@@ -43,7 +91,7 @@ func resourceLayer0EnvironmentLinkCreate(d *schema.ResourceData, meta interface{
 func resourceLayer0EnvironmentLinkRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Layer0Client)
 	sourceID := d.Get("source").(string)
-	destID := d.Get("dest").(string)
+	peer := environmentLinkPeer(d)
 
 	sourceEnvironment, err := client.API.GetEnvironment(sourceID)
 	if err != nil {
@@ -56,8 +104,9 @@ func resourceLayer0EnvironmentLinkRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	for _, v := range sourceEnvironment.Links {
-		if v == destID {
+	for _, link := range sourceEnvironment.Links {
+		if link.Peer() == peer {
+			d.Set("direction", link.Direction)
 			return nil
 		}
 	}
@@ -70,11 +119,38 @@ func resourceLayer0EnvironmentLinkRead(d *schema.ResourceData, meta interface{})
 func resourceLayer0EnvironmentLinkDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Layer0Client)
 	sourceID := d.Get("source").(string)
-	destID := d.Get("dest").(string)
 
-	if err := client.API.DeleteLink(sourceID, destID); err != nil {
+	if err := client.API.DeleteLink(sourceID, environmentLinkPeer(d)); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func environmentLinkPeer(d *schema.ResourceData) string {
+	for _, key := range []string{"dest", "cidr", "security_group_id"} {
+		if v := d.Get(key).(string); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func expandEnvironmentLinkRules(flattened []interface{}) []models.EnvironmentLinkRule {
+	rules := []models.EnvironmentLinkRule{}
+
+	for _, flat := range flattened {
+		data := flat.(map[string]interface{})
+
+		rule := models.EnvironmentLinkRule{
+			Protocol: data["protocol"].(string),
+			FromPort: data["from_port"].(int),
+			ToPort:   data["to_port"].(int),
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
@@ -13,7 +13,7 @@ func TestEnvironmentLinkCreate(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		CreateLink("test-env", "test-env2").
+		CreateLink("test-env", models.EnvironmentLink{EnvironmentID: "test-env2", Rules: []models.EnvironmentLinkRule{}}).
 		Return(nil)
 
 	mockClient.EXPECT().
@@ -32,6 +32,50 @@ func TestEnvironmentLinkCreate(t *testing.T) {
 	}
 }
 
+func TestEnvironmentLinkCreate_rules(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	link := models.EnvironmentLink{
+		CIDR:      "10.0.0.0/16",
+		Direction: "inbound",
+		Rules: []models.EnvironmentLinkRule{
+			{Protocol: "tcp", FromPort: 443, ToPort: 443},
+		},
+	}
+
+	mockClient.EXPECT().
+		CreateLink("test-env", link).
+		Return(nil)
+
+	environment := &models.Environment{
+		Links: []models.EnvironmentLink{link},
+	}
+
+	mockClient.EXPECT().
+		GetEnvironment("test-env").
+		Return(environment, nil)
+
+	environmentResource := provider.ResourcesMap["layer0_environment_link"]
+	d := schema.TestResourceDataRaw(t, environmentResource.Schema, map[string]interface{}{
+		"source":    "test-env",
+		"cidr":      "10.0.0.0/16",
+		"direction": "inbound",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"protocol":  "tcp",
+				"from_port": 443,
+				"to_port":   443,
+			},
+		},
+	})
+
+	client := &Layer0Client{API: mockClient}
+	if err := environmentResource.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestEnvironmentLinkRead(t *testing.T) {
 	ctrl, mockClient, provider := setupUnitTest(t)
 	defer ctrl.Finish()
@@ -21,6 +21,10 @@ func TestEnvironmentCreate_defaults(t *testing.T) {
 		GetEnvironment("eid").
 		Return(&models.Environment{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("environment", "eid").
+		Return(map[string]string{}, nil)
+
 	environmentResource := provider.ResourcesMap["layer0_environment"]
 	d := schema.TestResourceDataRaw(t, environmentResource.Schema, map[string]interface{}{
 		"name": "test-env",
@@ -44,6 +48,10 @@ func TestEnvironmentCreate_specifyOptional(t *testing.T) {
 		GetEnvironment("eid").
 		Return(&models.Environment{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("environment", "eid").
+		Return(map[string]string{}, nil)
+
 	environmentResource := provider.ResourcesMap["layer0_environment"]
 	d := schema.TestResourceDataRaw(t, environmentResource.Schema, map[string]interface{}{
 		"name":      "test-env",
@@ -68,6 +76,10 @@ func TestEnvironmentRead(t *testing.T) {
 		GetEnvironment("eid").
 		Return(&models.Environment{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("environment", "eid").
+		Return(map[string]string{}, nil)
+
 	environmentResource := provider.ResourcesMap["layer0_environment"]
 	d := schema.TestResourceDataRaw(t, environmentResource.Schema, map[string]interface{}{})
 	d.SetId("eid")
@@ -91,6 +103,10 @@ func TestEnvironmentUpdate(t *testing.T) {
 			GetEnvironment("eid").
 			Return(&models.Environment{EnvironmentID: "eid"}, nil),
 
+		mockClient.EXPECT().
+			ListLabels("environment", "eid").
+			Return(map[string]string{}, nil),
+
 		mockClient.EXPECT().
 			UpdateEnvironment("eid", 3).
 			Return(&models.Environment{EnvironmentID: "eid"}, nil),
@@ -98,6 +114,10 @@ func TestEnvironmentUpdate(t *testing.T) {
 		mockClient.EXPECT().
 			GetEnvironment("eid").
 			Return(&models.Environment{EnvironmentID: "eid"}, nil),
+
+		mockClient.EXPECT().
+			ListLabels("environment", "eid").
+			Return(map[string]string{}, nil),
 	)
 
 	environmentResource := provider.ResourcesMap["layer0_environment"]
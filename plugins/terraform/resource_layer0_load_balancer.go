@@ -37,6 +37,12 @@ func resourceLayer0LoadBalancer() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "elb",
+			},
 			"url": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -111,6 +117,55 @@ func resourceLayer0LoadBalancer() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"access_logs": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"bucket": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"interval": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  60,
+						},
+					},
+				},
+			},
+			"connection_draining": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  300,
+						},
+					},
+				},
+			},
+			"tags": labelsSchema(),
 		},
 	}
 }
@@ -125,6 +180,7 @@ func resourceLayer0LoadBalancerCreate(d *schema.ResourceData, meta interface{})
 	healthCheck := expandHealthCheck(d.Get("health_check"))
 	idleTimeout := d.Get("idle_timeout").(int)
 	crossZone := d.Get("cross_zone").(bool)
+	loadBalancerType := d.Get("type").(string)
 
 	if healthCheck == nil {
 		healthCheck = &models.HealthCheck{
@@ -136,12 +192,29 @@ func resourceLayer0LoadBalancerCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	loadBalancer, err := client.API.CreateLoadBalancer(name, environmentID, *healthCheck, ports, !private, idleTimeout, crossZone)
+	loadBalancer, err := client.API.CreateLoadBalancer(name, environmentID, *healthCheck, ports, !private, idleTimeout, crossZone, loadBalancerType)
 	if err != nil {
 		return err
 	}
 
 	d.SetId(loadBalancer.LoadBalancerID)
+
+	if err := updateLabels(client, d, "load_balancer", loadBalancer.LoadBalancerID); err != nil {
+		return err
+	}
+
+	if accessLogs := expandAccessLogs(d.Get("access_logs")); accessLogs != nil {
+		if _, err := client.API.UpdateLoadBalancerAccessLogs(loadBalancer.LoadBalancerID, *accessLogs); err != nil {
+			return err
+		}
+	}
+
+	if connectionDraining := expandConnectionDraining(d.Get("connection_draining")); connectionDraining != nil {
+		if _, err := client.API.UpdateLoadBalancerConnectionDraining(loadBalancer.LoadBalancerID, *connectionDraining); err != nil {
+			return err
+		}
+	}
+
 	return resourceLayer0LoadBalancerRead(d, meta)
 }
 
@@ -164,18 +237,25 @@ func resourceLayer0LoadBalancerRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("environment", loadBalancer.EnvironmentID)
 	d.Set("health_check", flattenHealthCheck(loadBalancer.HealthCheck))
 	d.Set("private", !loadBalancer.IsPublic)
+	d.Set("type", loadBalancer.LoadBalancerType)
 	d.Set("port", flattenPorts(loadBalancer.Ports))
 	d.Set("url", loadBalancer.URL)
 	d.Set("idle_timeout", loadBalancer.IdleTimeout)
 	d.Set("cross_zone", loadBalancer.CrossZone)
+	d.Set("access_logs", flattenAccessLogs(loadBalancer.AccessLogs))
+	d.Set("connection_draining", flattenConnectionDraining(loadBalancer.ConnectionDraining))
 
-	return nil
+	return readLabels(client, d, "load_balancer", loadBalancerID)
 }
 
 func resourceLayer0LoadBalancerUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Layer0Client)
 	loadBalancerID := d.Id()
 
+	if err := updateLabels(client, d, "load_balancer", loadBalancerID); err != nil {
+		return err
+	}
+
 	if d.HasChange("port") {
 		ports := expandPorts(d.Get("port").(*schema.Set).List())
 
@@ -208,6 +288,28 @@ func resourceLayer0LoadBalancerUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if d.HasChange("access_logs") {
+		accessLogs := expandAccessLogs(d.Get("access_logs"))
+		if accessLogs == nil {
+			accessLogs = &models.AccessLogs{Enabled: false}
+		}
+
+		if _, err := client.API.UpdateLoadBalancerAccessLogs(loadBalancerID, *accessLogs); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("connection_draining") {
+		connectionDraining := expandConnectionDraining(d.Get("connection_draining"))
+		if connectionDraining == nil {
+			connectionDraining = &models.ConnectionDraining{Enabled: false}
+		}
+
+		if _, err := client.API.UpdateLoadBalancerConnectionDraining(loadBalancerID, *connectionDraining); err != nil {
+			return err
+		}
+	}
+
 	return resourceLayer0LoadBalancerRead(d, meta)
 }
 
@@ -283,6 +385,64 @@ func flattenHealthCheck(healthCheck models.HealthCheck) []map[string]interface{}
 	return result
 }
 
+func expandAccessLogs(flattened interface{}) *models.AccessLogs {
+	al := flattened.([]interface{})
+
+	if len(al) > 0 {
+		logs := al[0].(map[string]interface{})
+
+		return &models.AccessLogs{
+			Enabled:        logs["enabled"].(bool),
+			S3BucketName:   logs["bucket"].(string),
+			S3BucketPrefix: logs["prefix"].(string),
+			EmitInterval:   logs["interval"].(int),
+		}
+	}
+
+	return nil
+}
+
+func flattenAccessLogs(accessLogs models.AccessLogs) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, 1)
+
+	logs := make(map[string]interface{})
+	logs["enabled"] = accessLogs.Enabled
+	logs["bucket"] = accessLogs.S3BucketName
+	logs["prefix"] = accessLogs.S3BucketPrefix
+	logs["interval"] = accessLogs.EmitInterval
+
+	result = append(result, logs)
+
+	return result
+}
+
+func expandConnectionDraining(flattened interface{}) *models.ConnectionDraining {
+	cd := flattened.([]interface{})
+
+	if len(cd) > 0 {
+		draining := cd[0].(map[string]interface{})
+
+		return &models.ConnectionDraining{
+			Enabled: draining["enabled"].(bool),
+			Timeout: draining["timeout"].(int),
+		}
+	}
+
+	return nil
+}
+
+func flattenConnectionDraining(connectionDraining models.ConnectionDraining) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, 1)
+
+	draining := make(map[string]interface{})
+	draining["enabled"] = connectionDraining.Enabled
+	draining["timeout"] = connectionDraining.Timeout
+
+	result = append(result, draining)
+
+	return result
+}
+
 func expandPorts(flattened []interface{}) []models.Port {
 	ports := []models.Port{}
 
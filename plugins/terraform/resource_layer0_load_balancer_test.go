@@ -22,13 +22,17 @@ func TestLoadBalancerCreate_defaults(t *testing.T) {
 	}
 
 	mockClient.EXPECT().
-		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, ports, true, 60, true).
+		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, ports, true, 60, true, "elb").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
 	mockClient.EXPECT().
 		GetLoadBalancer("lbid").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("load_balancer", "lbid").
+		Return(map[string]string{}, nil)
+
 	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
 	d := schema.TestResourceDataRaw(t, loadBalancerResource.Schema, map[string]interface{}{
 		"name":        "test-lb",
@@ -67,13 +71,17 @@ func TestLoadBalancerCreate_specifyPorts(t *testing.T) {
 	}
 
 	mockClient.EXPECT().
-		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, ports, false, 60, true).
+		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, ports, false, 60, true, "elb").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
 	mockClient.EXPECT().
 		GetLoadBalancer("lbid").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("load_balancer", "lbid").
+		Return(map[string]string{}, nil)
+
 	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
 	d := schema.TestResourceDataRaw(t, loadBalancerResource.Schema, map[string]interface{}{
 		"name":        "test-lb",
@@ -93,13 +101,17 @@ func TestLoadBalancerCreate_specifyHealthCheck(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"HTTP:80/admin/healthcheck", 25, 10, 4, 3}, []models.Port{}, true, 60, true).
+		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"HTTP:80/admin/healthcheck", 25, 10, 4, 3}, []models.Port{}, true, 60, true, "elb").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
 	mockClient.EXPECT().
 		GetLoadBalancer("lbid").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("load_balancer", "lbid").
+		Return(map[string]string{}, nil)
+
 	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
 	d := schema.TestResourceDataRaw(t, loadBalancerResource.Schema, map[string]interface{}{
 		"name":        "test-lb",
@@ -124,13 +136,17 @@ func TestLoadBalancerCreate_specifyCrossZone(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, false).
+		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, false, "elb").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
 	mockClient.EXPECT().
 		GetLoadBalancer("lbid").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("load_balancer", "lbid").
+		Return(map[string]string{}, nil)
+
 	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
 	d := schema.TestResourceDataRaw(t, loadBalancerResource.Schema, map[string]interface{}{
 		"name":        "test-lb",
@@ -144,6 +160,66 @@ func TestLoadBalancerCreate_specifyCrossZone(t *testing.T) {
 	}
 }
 
+func TestLoadBalancerCreate_specifyAccessLogsAndConnectionDraining(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	mockClient.EXPECT().
+		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true, "elb").
+		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
+
+	accessLogs := models.AccessLogs{
+		Enabled:        true,
+		S3BucketName:   "bucket",
+		S3BucketPrefix: "test-lb",
+		EmitInterval:   5,
+	}
+
+	mockClient.EXPECT().
+		UpdateLoadBalancerAccessLogs("lbid", accessLogs).
+		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
+
+	connectionDraining := models.ConnectionDraining{
+		Enabled: true,
+		Timeout: 120,
+	}
+
+	mockClient.EXPECT().
+		UpdateLoadBalancerConnectionDraining("lbid", connectionDraining).
+		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
+
+	mockClient.EXPECT().
+		GetLoadBalancer("lbid").
+		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
+
+	mockClient.EXPECT().
+		ListLabels("load_balancer", "lbid").
+		Return(map[string]string{}, nil)
+
+	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
+	d := schema.TestResourceDataRaw(t, loadBalancerResource.Schema, map[string]interface{}{
+		"name":        "test-lb",
+		"environment": "test-env",
+		"access_logs": []interface{}{
+			map[string]interface{}{
+				"bucket":   "bucket",
+				"prefix":   "test-lb",
+				"interval": 5,
+			},
+		},
+		"connection_draining": []interface{}{
+			map[string]interface{}{
+				"timeout": 120,
+			},
+		},
+	})
+
+	client := &Layer0Client{API: mockClient}
+	if err := loadBalancerResource.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestLoadBalancerRead(t *testing.T) {
 	ctrl, mockClient, provider := setupUnitTest(t)
 	defer ctrl.Finish()
@@ -152,6 +228,10 @@ func TestLoadBalancerRead(t *testing.T) {
 		GetLoadBalancer("lbid").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("load_balancer", "lbid").
+		Return(map[string]string{}, nil)
+
 	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
 	d := schema.TestResourceDataRaw(t, loadBalancerResource.Schema, map[string]interface{}{})
 	d.SetId("lbid")
@@ -168,13 +248,17 @@ func TestLoadBalancerUpdate_ports(t *testing.T) {
 
 	gomock.InOrder(
 		mockClient.EXPECT().
-			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true).
+			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true, "elb").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
 		mockClient.EXPECT().
 			GetLoadBalancer("lbid").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
+		mockClient.EXPECT().
+			ListLabels("load_balancer", "lbid").
+			Return(map[string]string{}, nil),
+
 		mockClient.EXPECT().
 			UpdateLoadBalancerPorts("lbid", []models.Port{{"", "", 80, 80, "http"}}).
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
@@ -190,6 +274,10 @@ func TestLoadBalancerUpdate_ports(t *testing.T) {
 		mockClient.EXPECT().
 			GetLoadBalancer("lbid").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
+
+		mockClient.EXPECT().
+			ListLabels("load_balancer", "lbid").
+			Return(map[string]string{}, nil),
 	)
 
 	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
@@ -228,13 +316,17 @@ func TestLoadBalancerUpdate_healthCheck(t *testing.T) {
 
 	gomock.InOrder(
 		mockClient.EXPECT().
-			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true).
+			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true, "elb").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
 		mockClient.EXPECT().
 			GetLoadBalancer("lbid").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
+		mockClient.EXPECT().
+			ListLabels("load_balancer", "lbid").
+			Return(map[string]string{}, nil),
+
 		mockClient.EXPECT().
 			UpdateLoadBalancerHealthCheck("lbid", models.HealthCheck{"HTTP:80/admin/healthcheck", 25, 10, 4, 3}).
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
@@ -250,6 +342,10 @@ func TestLoadBalancerUpdate_healthCheck(t *testing.T) {
 		mockClient.EXPECT().
 			GetLoadBalancer("lbid").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
+
+		mockClient.EXPECT().
+			ListLabels("load_balancer", "lbid").
+			Return(map[string]string{}, nil),
 	)
 
 	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
@@ -288,7 +384,7 @@ func TestLoadBalancerUpdate_idleTimeout(t *testing.T) {
 
 	gomock.InOrder(
 		mockClient.EXPECT().
-			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 95, true).
+			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 95, true, "elb").
 			Return(&models.LoadBalancer{
 				LoadBalancerID: "lbid"}, nil),
 
@@ -296,6 +392,10 @@ func TestLoadBalancerUpdate_idleTimeout(t *testing.T) {
 			GetLoadBalancer("lbid").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
+		mockClient.EXPECT().
+			ListLabels("load_balancer", "lbid").
+			Return(map[string]string{}, nil),
+
 		mockClient.EXPECT().
 			UpdateLoadBalancerIdleTimeout("lbid", 120).
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid", IdleTimeout: 120}, nil),
@@ -306,6 +406,10 @@ func TestLoadBalancerUpdate_idleTimeout(t *testing.T) {
 		mockClient.EXPECT().
 			GetLoadBalancer("lbid").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid", IdleTimeout: 120}, nil),
+
+		mockClient.EXPECT().
+			ListLabels("load_balancer", "lbid").
+			Return(map[string]string{}, nil),
 	)
 
 	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
@@ -339,19 +443,27 @@ func TestLoadBalancerUpdate_crossZone(t *testing.T) {
 
 	gomock.InOrder(
 		mockClient.EXPECT().
-			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true).
+			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true, "elb").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
 		mockClient.EXPECT().
 			GetLoadBalancer("lbid").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
+		mockClient.EXPECT().
+			ListLabels("load_balancer", "lbid").
+			Return(map[string]string{}, nil),
+
 		mockClient.EXPECT().
 			UpdateLoadBalancerIdleTimeout("lbid", 60),
 
 		mockClient.EXPECT().
 			GetLoadBalancer("lbid").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
+
+		mockClient.EXPECT().
+			ListLabels("load_balancer", "lbid").
+			Return(map[string]string{}, nil),
 	)
 
 	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
 )
 
 func resourceLayer0Service() *schema.Resource {
@@ -36,11 +37,27 @@ func resourceLayer0Service() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"host_header": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"path_pattern": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"priority": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
 			"scale": {
 				Type:     schema.TypeInt,
 				Optional: true,
 				Default:  1,
 			},
+			"tags": labelsSchema(),
 		},
 	}
 }
@@ -54,7 +71,13 @@ func resourceLayer0ServiceCreate(d *schema.ResourceData, meta interface{}) error
 	loadBalancerID := d.Get("load_balancer").(string)
 	scale := d.Get("scale").(int)
 
-	service, err := client.API.CreateService(name, environmentID, deployID, loadBalancerID)
+	loadBalancerRule := models.LoadBalancerRule{
+		HostHeader:  d.Get("host_header").(string),
+		PathPattern: d.Get("path_pattern").(string),
+		Priority:    d.Get("priority").(int),
+	}
+
+	service, err := client.API.CreateService(name, environmentID, deployID, loadBalancerID, loadBalancerRule)
 	if err != nil {
 		return err
 	}
@@ -62,6 +85,10 @@ func resourceLayer0ServiceCreate(d *schema.ResourceData, meta interface{}) error
 	// set id first to tell terraform resource has been created
 	d.SetId(service.ServiceID)
 
+	if err := updateLabels(client, d, "service", service.ServiceID); err != nil {
+		return err
+	}
+
 	if scale != 1 {
 		if _, err := client.API.ScaleService(service.ServiceID, scale); err != nil {
 			return err
@@ -101,13 +128,17 @@ func resourceLayer0ServiceRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return nil
+	return readLabels(client, d, "service", serviceID)
 }
 
 func resourceLayer0ServiceUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Layer0Client)
 	serviceID := d.Id()
 
+	if err := updateLabels(client, d, "service", serviceID); err != nil {
+		return err
+	}
+
 	if d.HasChange("deploy") {
 		deployID := d.Get("deploy").(string)
 
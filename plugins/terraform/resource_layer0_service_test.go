@@ -7,6 +7,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
 )
 
 func TestServiceCreate_defaults(t *testing.T) {
@@ -14,7 +15,7 @@ func TestServiceCreate_defaults(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		CreateService("test-svc", "test-env", "test-dep", "").
+		CreateService("test-svc", "test-env", "test-dep", "", models.LoadBalancerRule{}).
 		Return(&models.Service{ServiceID: "sid"}, nil)
 
 	mockClient.EXPECT().
@@ -22,7 +23,11 @@ func TestServiceCreate_defaults(t *testing.T) {
 		Return(&models.Service{}, nil)
 
 	mockClient.EXPECT().
-		WaitForDeployment("sid", gomock.Any()).
+		ListLabels("service", "sid").
+		Return(map[string]string{}, nil)
+
+	mockClient.EXPECT().
+		WaitForDeployment("sid", gomock.Any(), false).
 		Return(&models.Service{ServiceID: "sid"}, nil)
 
 	serviceResource := provider.ResourcesMap["layer0_service"]
@@ -43,7 +48,7 @@ func TestServiceCreate_specifyOptional(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		CreateService("test-svc", "test-env", "test-dep", "test-lb").
+		CreateService("test-svc", "test-env", "test-dep", "test-lb", models.LoadBalancerRule{}).
 		Return(&models.Service{ServiceID: "sid"}, nil)
 
 	mockClient.EXPECT().
@@ -55,7 +60,11 @@ func TestServiceCreate_specifyOptional(t *testing.T) {
 		Return(&models.Service{}, nil)
 
 	mockClient.EXPECT().
-		WaitForDeployment("sid", gomock.Any()).
+		ListLabels("service", "sid").
+		Return(map[string]string{}, nil)
+
+	mockClient.EXPECT().
+		WaitForDeployment("sid", gomock.Any(), false).
 		Return(&models.Service{ServiceID: "sid"}, nil)
 
 	serviceResource := provider.ResourcesMap["layer0_service"]
@@ -73,6 +82,46 @@ func TestServiceCreate_specifyOptional(t *testing.T) {
 	}
 }
 
+func TestServiceCreate_tags(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	mockClient.EXPECT().
+		CreateService("test-svc", "test-env", "test-dep", "", models.LoadBalancerRule{}).
+		Return(&models.Service{ServiceID: "sid"}, nil)
+
+	mockClient.EXPECT().
+		SetLabel("service", "sid", "team", "payments").
+		Return(nil)
+
+	mockClient.EXPECT().
+		WaitForDeployment("sid", gomock.Any(), false).
+		Return(&models.Service{ServiceID: "sid"}, nil)
+
+	mockClient.EXPECT().
+		GetService("sid").
+		Return(&models.Service{}, nil)
+
+	mockClient.EXPECT().
+		ListLabels("service", "sid").
+		Return(map[string]string{"team": "payments"}, nil)
+
+	serviceResource := provider.ResourcesMap["layer0_service"]
+	d := schema.TestResourceDataRaw(t, serviceResource.Schema, map[string]interface{}{
+		"name":        "test-svc",
+		"environment": "test-env",
+		"deploy":      "test-dep",
+		"tags":        map[string]interface{}{"team": "payments"},
+	})
+
+	client := &Layer0Client{API: mockClient, StopContext: context.Background()}
+	if err := serviceResource.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, d.Get("tags.team"), "payments")
+}
+
 func TestServiceRead(t *testing.T) {
 	ctrl, mockClient, provider := setupUnitTest(t)
 	defer ctrl.Finish()
@@ -81,6 +130,10 @@ func TestServiceRead(t *testing.T) {
 		GetService("sid").
 		Return(&models.Service{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("service", "sid").
+		Return(map[string]string{}, nil)
+
 	serviceResource := provider.ResourcesMap["layer0_service"]
 	d := schema.TestResourceDataRaw(t, serviceResource.Schema, map[string]interface{}{})
 	d.SetId("sid")
@@ -96,13 +149,17 @@ func TestServiceUpdate(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		CreateService("test-svc", "test-env", "test-dep", "").
+		CreateService("test-svc", "test-env", "test-dep", "", models.LoadBalancerRule{}).
 		Return(&models.Service{ServiceID: "sid"}, nil)
 
 	mockClient.EXPECT().
 		GetService("sid").
 		Return(&models.Service{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("service", "sid").
+		Return(map[string]string{}, nil)
+
 	mockClient.EXPECT().
 		UpdateService("sid", "test-dep2").
 		Return(&models.Service{ServiceID: "sid"}, nil)
@@ -112,7 +169,7 @@ func TestServiceUpdate(t *testing.T) {
 		Return(&models.Service{ServiceID: "sid"}, nil)
 
 	mockClient.EXPECT().
-		WaitForDeployment("sid", gomock.Any()).
+		WaitForDeployment("sid", gomock.Any(), false).
 		Return(&models.Service{ServiceID: "sid"}, nil).
 		Times(2)
 
@@ -120,6 +177,10 @@ func TestServiceUpdate(t *testing.T) {
 		GetService("sid").
 		Return(&models.Service{}, nil)
 
+	mockClient.EXPECT().
+		ListLabels("service", "sid").
+		Return(map[string]string{}, nil)
+
 	serviceResource := provider.ResourcesMap["layer0_service"]
 	d1 := schema.TestResourceDataRaw(t, serviceResource.Schema, map[string]interface{}{
 		"name":        "test-svc",
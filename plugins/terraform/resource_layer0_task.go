@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+// Tasks are one-shot, so every argument except tags forces a new resource: changing any of them runs the task again.
+// Stopped tasks are eventually removed from Layer0; the last recorded status is kept in state when that happens
+// so a finished task is not run again. For the same reason, tags are not read back from Layer0.
+func resourceLayer0Task() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLayer0TaskCreate,
+		Read:   resourceLayer0TaskRead,
+		Update: resourceLayer0TaskUpdate,
+		Delete: resourceLayer0TaskDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"environment": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"deploy": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"copies": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  1,
+			},
+			"wait": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"container_override": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"container": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"command": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"environment": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"cpu": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"memory": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"memory_reservation": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"task_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"containers": taskContainersSchema(),
+			"tags":       labelsSchema(),
+		},
+	}
+}
+
+func taskContainersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"task_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"container_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"last_status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"exit_code": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"reason": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func resourceLayer0TaskCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Layer0Client)
+
+	name := d.Get("name").(string)
+	environmentID := d.Get("environment").(string)
+	deployID := d.Get("deploy").(string)
+	copies := d.Get("copies").(int)
+	overrides := expandContainerOverrides(d.Get("container_override"))
+
+	if copies < 1 {
+		return fmt.Errorf("copies must be >= 1")
+	}
+
+	// each copy is created in turn, and its id set once known, so the copies created
+	// before a failure are recorded in the state rather than orphaned
+	taskIDs := []string{}
+	for i := 0; i < copies; i++ {
+		jobID, err := client.API.CreateTask(name, environmentID, deployID, overrides)
+		if err != nil {
+			return err
+		}
+
+		if err := waitForJobWithContext(client, jobID); err != nil {
+			return err
+		}
+
+		job, err := client.API.GetJob(jobID)
+		if err != nil {
+			return err
+		}
+
+		taskID, ok := job.Meta["task_id"]
+		if !ok {
+			return fmt.Errorf("Job %s did not create a task", jobID)
+		}
+
+		taskIDs = append(taskIDs, taskID)
+		d.SetId(strings.Join(taskIDs, ","))
+		d.Set("task_ids", taskIDs)
+
+		if err := updateLabels(client, d, "task", taskID); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("wait").(bool) {
+		failures := []string{}
+		for _, taskID := range taskIDs {
+			task, err := waitForTaskWithContext(client, taskID)
+			if err != nil {
+				return err
+			}
+
+			failures = append(failures, task.Failures()...)
+		}
+
+		if len(failures) > 0 {
+			if err := resourceLayer0TaskRead(d, meta); err != nil {
+				return err
+			}
+
+			return fmt.Errorf("%d container(s) failed:\n%s", len(failures), strings.Join(failures, "\n"))
+		}
+	}
+
+	return resourceLayer0TaskRead(d, meta)
+}
+
+func resourceLayer0TaskRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Layer0Client)
+
+	recorded := map[string][]interface{}{}
+	for _, c := range d.Get("containers").([]interface{}) {
+		container := c.(map[string]interface{})
+		taskID := container["task_id"].(string)
+		recorded[taskID] = append(recorded[taskID], container)
+	}
+
+	containers := []interface{}{}
+	for _, t := range d.Get("task_ids").([]interface{}) {
+		taskID := t.(string)
+
+		task, err := client.API.GetTask(taskID)
+		if err != nil {
+			if err, ok := err.(*errors.ServerError); ok && err.Code == errors.TaskDoesNotExist {
+				log.Printf("[WARN] Task (%s) no longer exists, keeping its last recorded status", taskID)
+				containers = append(containers, recorded[taskID]...)
+				continue
+			}
+
+			return err
+		}
+
+		d.Set("name", task.TaskName)
+		d.Set("environment", task.EnvironmentID)
+		d.Set("deploy", task.DeployID)
+
+		for _, container := range flattenTaskContainers(task) {
+			containers = append(containers, container)
+		}
+	}
+
+	d.Set("containers", containers)
+	return nil
+}
+
+func resourceLayer0TaskUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Layer0Client)
+
+	taskIDs := []string{}
+	for _, t := range d.Get("task_ids").([]interface{}) {
+		taskIDs = append(taskIDs, t.(string))
+	}
+
+	if err := updateLabels(client, d, "task", taskIDs...); err != nil {
+		return err
+	}
+
+	return resourceLayer0TaskRead(d, meta)
+}
+
+func resourceLayer0TaskDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Layer0Client)
+
+	for _, t := range d.Get("task_ids").([]interface{}) {
+		if err := client.API.DeleteTask(t.(string)); err != nil {
+			if err, ok := err.(*errors.ServerError); ok && err.Code == errors.TaskDoesNotExist {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func expandContainerOverrides(flattened interface{}) []models.ContainerOverride {
+	overrides := []models.ContainerOverride{}
+	for _, o := range flattened.([]interface{}) {
+		override := o.(map[string]interface{})
+
+		command := []string{}
+		for _, arg := range override["command"].([]interface{}) {
+			command = append(command, arg.(string))
+		}
+
+		environment := map[string]string{}
+		for key, val := range override["environment"].(map[string]interface{}) {
+			environment[key] = val.(string)
+		}
+
+		overrides = append(overrides, models.ContainerOverride{
+			ContainerName:        override["container"].(string),
+			Command:              command,
+			EnvironmentOverrides: environment,
+			CPU:                  int64(override["cpu"].(int)),
+			Memory:               int64(override["memory"].(int)),
+			MemoryReservation:    int64(override["memory_reservation"].(int)),
+		})
+	}
+
+	return overrides
+}
+
+func flattenTaskContainers(task *models.Task) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	for _, copy := range task.Copies {
+		for _, detail := range copy.Details {
+			result = append(result, map[string]interface{}{
+				"task_id":        task.TaskID,
+				"container_name": detail.ContainerName,
+				"last_status":    detail.LastStatus,
+				"exit_code":      int(detail.ExitCode),
+				"reason":         detail.Reason,
+			})
+		}
+	}
+
+	return result
+}
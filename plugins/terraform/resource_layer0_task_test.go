@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestTaskCreate(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	overrides := []models.ContainerOverride{
+		{
+			ContainerName:        "app",
+			Command:              []string{"migrate", "up"},
+			EnvironmentOverrides: map[string]string{"key": "val"},
+			Memory:               512,
+		},
+	}
+
+	jobTaskIDs := map[string]string{
+		"jid1": "tid1",
+		"jid2": "tid2",
+	}
+
+	// each copy is created only once the previous one has been
+	calls := []*gomock.Call{}
+	for _, jobID := range []string{"jid1", "jid2"} {
+		calls = append(calls,
+			mockClient.EXPECT().
+				CreateTask("test-task", "test-env", "test-dep", overrides).
+				Return(jobID, nil),
+			mockClient.EXPECT().
+				WaitForJob(jobID, gomock.Any()).
+				Return(nil),
+			mockClient.EXPECT().
+				GetJob(jobID).
+				Return(&models.Job{Meta: map[string]string{"task_id": jobTaskIDs[jobID]}}, nil))
+	}
+
+	gomock.InOrder(calls...)
+
+	for _, taskID := range jobTaskIDs {
+		mockClient.EXPECT().
+			GetTask(taskID).
+			Return(&models.Task{TaskID: taskID}, nil)
+	}
+
+	taskResource := provider.ResourcesMap["layer0_task"]
+	d := schema.TestResourceDataRaw(t, taskResource.Schema, map[string]interface{}{
+		"name":        "test-task",
+		"environment": "test-env",
+		"deploy":      "test-dep",
+		"copies":      2,
+		"container_override": []interface{}{
+			map[string]interface{}{
+				"container":   "app",
+				"command":     []interface{}{"migrate", "up"},
+				"environment": map[string]interface{}{"key": "val"},
+				"memory":      512,
+			},
+		},
+	})
+
+	client := &Layer0Client{API: mockClient, StopContext: context.Background()}
+	if err := taskResource.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, d.Id(), "tid1,tid2")
+}
+
+func TestTaskCreate_copyFailure(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		mockClient.EXPECT().
+			CreateTask("test-task", "test-env", "test-dep", []models.ContainerOverride{}).
+			Return("jid1", nil),
+		mockClient.EXPECT().
+			WaitForJob("jid1", gomock.Any()).
+			Return(nil),
+		mockClient.EXPECT().
+			GetJob("jid1").
+			Return(&models.Job{Meta: map[string]string{"task_id": "tid1"}}, nil),
+		mockClient.EXPECT().
+			CreateTask("test-task", "test-env", "test-dep", []models.ContainerOverride{}).
+			Return("", errors.Newf(errors.UnexpectedError, "some error")),
+	)
+
+	taskResource := provider.ResourcesMap["layer0_task"]
+	d := schema.TestResourceDataRaw(t, taskResource.Schema, map[string]interface{}{
+		"name":        "test-task",
+		"environment": "test-env",
+		"deploy":      "test-dep",
+		"copies":      3,
+	})
+
+	client := &Layer0Client{API: mockClient, StopContext: context.Background()}
+	if err := taskResource.Create(d, client); err == nil {
+		t.Fatal("error was nil!")
+	}
+
+	// the copy created before the failure is recorded so terraform can destroy it
+	testutils.AssertEqual(t, d.Id(), "tid1")
+}
+
+func TestTaskCreate_waitFailure(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	task := &models.Task{
+		TaskID: "tid",
+		Copies: []models.TaskCopy{
+			{
+				Details: []models.TaskDetail{
+					{ContainerName: "app", LastStatus: "STOPPED", ExitCode: 1},
+				},
+			},
+		},
+	}
+
+	mockClient.EXPECT().
+		CreateTask("test-task", "test-env", "test-dep", []models.ContainerOverride{}).
+		Return("jid", nil)
+
+	mockClient.EXPECT().
+		WaitForJob("jid", gomock.Any()).
+		Return(nil)
+
+	mockClient.EXPECT().
+		GetJob("jid").
+		Return(&models.Job{Meta: map[string]string{"task_id": "tid"}}, nil)
+
+	mockClient.EXPECT().
+		WaitForTask("tid", gomock.Any()).
+		Return(task, nil)
+
+	mockClient.EXPECT().
+		GetTask("tid").
+		Return(task, nil)
+
+	taskResource := provider.ResourcesMap["layer0_task"]
+	d := schema.TestResourceDataRaw(t, taskResource.Schema, map[string]interface{}{
+		"name":        "test-task",
+		"environment": "test-env",
+		"deploy":      "test-dep",
+		"wait":        true,
+	})
+
+	client := &Layer0Client{API: mockClient, StopContext: context.Background()}
+	if err := taskResource.Create(d, client); err == nil {
+		t.Fatal("error was nil!")
+	}
+
+	// the failed task is still recorded so terraform marks it as tainted
+	testutils.AssertEqual(t, d.Id(), "tid")
+	testutils.AssertEqual(t, d.Get("containers.0.exit_code"), 1)
+}
+
+func TestTaskRead_taskDoesNotExist(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	mockClient.EXPECT().
+		GetTask("tid").
+		Return(nil, errors.Newf(errors.TaskDoesNotExist, ""))
+
+	taskResource := provider.ResourcesMap["layer0_task"]
+	d := schema.TestResourceDataRaw(t, taskResource.Schema, map[string]interface{}{})
+	d.SetId("tid")
+	d.Set("task_ids", []string{"tid"})
+	d.Set("containers", []map[string]interface{}{
+		{"task_id": "tid", "container_name": "app", "last_status": "STOPPED", "exit_code": 0},
+	})
+
+	client := &Layer0Client{API: mockClient, StopContext: context.Background()}
+	if err := taskResource.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, d.Id(), "tid")
+	testutils.AssertEqual(t, d.Get("containers.#"), 1)
+	testutils.AssertEqual(t, d.Get("containers.0.last_status"), "STOPPED")
+}
+
+func TestTaskDelete(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	mockClient.EXPECT().
+		DeleteTask("tid1").
+		Return(nil)
+
+	mockClient.EXPECT().
+		DeleteTask("tid2").
+		Return(errors.Newf(errors.TaskDoesNotExist, ""))
+
+	taskResource := provider.ResourcesMap["layer0_task"]
+	d := schema.TestResourceDataRaw(t, taskResource.Schema, map[string]interface{}{})
+	d.SetId("tid1,tid2")
+	d.Set("task_ids", []string{"tid1", "tid2"})
+
+	client := &Layer0Client{API: mockClient, StopContext: context.Background()}
+	if err := taskResource.Delete(d, client); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -45,6 +45,56 @@ func resolveTags(client *Layer0Client, target, entityType string, params map[str
 	return "", fmt.Errorf(text)
 }
 
+// labelsSchema is the 'tags' argument of a resource, which manages the user-defined labels of the entity
+func labelsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// readLabels sets the 'tags' attribute to the user-defined labels of the entity
+func readLabels(client *Layer0Client, d *schema.ResourceData, entityType, entityID string) error {
+	labels, err := client.API.ListLabels(entityType, entityID)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("tags", labels)
+}
+
+// updateLabels sets and removes the user-defined labels of each entity so they match the 'tags' argument
+func updateLabels(client *Layer0Client, d *schema.ResourceData, entityType string, entityIDs ...string) error {
+	if !d.HasChange("tags") {
+		return nil
+	}
+
+	o, n := d.GetChange("tags")
+	oldLabels := o.(map[string]interface{})
+	newLabels := n.(map[string]interface{})
+
+	for _, entityID := range entityIDs {
+		for key := range oldLabels {
+			if _, ok := newLabels[key]; !ok {
+				if err := client.API.DeleteLabel(entityType, entityID, key); err != nil {
+					return err
+				}
+			}
+		}
+
+		for key, value := range newLabels {
+			if oldLabels[key] != value {
+				if err := client.API.SetLabel(entityType, entityID, key, value.(string)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func setResourceData(setter func(string, interface{}) error, values map[string]interface{}) error {
 	for key, value := range values {
 		if err := setter(key, value); err != nil {
@@ -84,7 +134,7 @@ func waitForJobWithContext(client *Layer0Client, jobID string) error {
 func waitForDeploymentWithContext(client *Layer0Client, serviceID string) error {
 	result := make(chan error, 1)
 	go func() {
-		_, err := client.API.WaitForDeployment(serviceID, defaultTimeout)
+		_, err := client.API.WaitForDeployment(serviceID, defaultTimeout, false)
 		result <- err
 	}()
 
@@ -95,3 +145,23 @@ func waitForDeploymentWithContext(client *Layer0Client, serviceID string) error
 		return client.StopContext.Err()
 	}
 }
+
+func waitForTaskWithContext(client *Layer0Client, taskID string) (*models.Task, error) {
+	type taskResult struct {
+		task *models.Task
+		err  error
+	}
+
+	result := make(chan taskResult, 1)
+	go func() {
+		task, err := client.API.WaitForTask(taskID, defaultTimeout)
+		result <- taskResult{task, err}
+	}()
+
+	select {
+	case r := <-result:
+		return r.task, r.err
+	case <-client.StopContext.Done():
+		return nil, client.StopContext.Err()
+	}
+}
@@ -0,0 +1,86 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+// the number of times the deletion of each entity is attempted before it is recorded as failed
+const BULK_DELETE_ATTEMPTS = 3
+
+var BulkDeleteSteps = []Step{
+	{
+		Name:    "Bulk Delete",
+		Timeout: time.Hour,
+		Action:  BulkDelete,
+	},
+}
+
+// BulkDelete deletes each entity in the request, recording whether each one was deleted in the job's meta.
+// A failure doesn't stop the remaining deletions, but marks the job as failed once they are done.
+func BulkDelete(quit chan bool, context *JobContext) error {
+	var request models.BulkJobRequest
+	if err := json.Unmarshal([]byte(context.Request()), &request); err != nil {
+		return err
+	}
+
+	var deleteEntity func(string) error
+	switch request.EntityType {
+	case "deploy":
+		deleteEntity = context.DeployLogic.DeleteDeploy
+	case "load_balancer":
+		deleteEntity = context.LoadBalancerLogic.DeleteLoadBalancer
+	case "service":
+		deleteEntity = context.ServiceLogic.DeleteService
+	case "task":
+		deleteEntity = context.TaskLogic.DeleteTask
+	default:
+		return fmt.Errorf("Entities of type '%s' cannot be deleted in bulk", request.EntityType)
+	}
+
+	results := map[string]string{}
+	errs := []error{}
+	for _, entityID := range request.EntityIDs {
+		log.Infof("Running Action: Delete %s on '%s'", request.EntityType, entityID)
+
+		if err := deleteWithAttempts(quit, entityID, deleteEntity); err != nil {
+			results[entityID] = fmt.Sprintf("failed: %v", err)
+			errs = append(errs, fmt.Errorf("Failed to delete %s '%s': %v", request.EntityType, entityID, err))
+		} else {
+			results[entityID] = "deleted"
+		}
+
+		if err := context.SetJobMeta(results); err != nil {
+			return err
+		}
+	}
+
+	return errors.MultiError(errs)
+}
+
+func deleteWithAttempts(quit chan bool, entityID string, deleteEntity func(string) error) error {
+	var err error
+	for i := 0; i < BULK_DELETE_ATTEMPTS; i++ {
+		if err = deleteEntity(entityID); err == nil {
+			return nil
+		}
+
+		log.Warningf("Failed to delete '%s' (attempt %d/%d): %v", entityID, i+1, BULK_DELETE_ATTEMPTS, err)
+		if i == BULK_DELETE_ATTEMPTS-1 {
+			break
+		}
+
+		select {
+		case <-quit:
+			return fmt.Errorf("Quit signalled")
+		case <-time.After(time.Second * 10 * timeMultiplier):
+		}
+	}
+
+	return err
+}
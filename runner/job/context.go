@@ -8,6 +8,7 @@ type JobContext struct {
 	jobID             string
 	request           string
 	Logic             *logic.Logic
+	DeployLogic       logic.DeployLogic
 	LoadBalancerLogic logic.LoadBalancerLogic
 	ServiceLogic      logic.ServiceLogic
 	TaskLogic         logic.TaskLogic
@@ -19,6 +20,7 @@ func NewJobContext(jobID string, lgc *logic.Logic, request string) *JobContext {
 		jobID:             jobID,
 		request:           request,
 		Logic:             lgc,
+		DeployLogic:       logic.NewL0DeployLogic(*lgc),
 		LoadBalancerLogic: logic.NewL0LoadBalancerLogic(*lgc),
 		ServiceLogic:      logic.NewL0ServiceLogic(*lgc),
 		TaskLogic:         logic.NewL0TaskLogic(*lgc),
@@ -31,6 +33,7 @@ func (j *JobContext) CreateCopyWithNewRequest(request string) *JobContext {
 		jobID:             j.jobID,
 		request:           request,
 		Logic:             j.Logic,
+		DeployLogic:       j.DeployLogic,
 		LoadBalancerLogic: j.LoadBalancerLogic,
 		ServiceLogic:      j.ServiceLogic,
 		TaskLogic:         j.TaskLogic,
@@ -54,6 +54,8 @@ func (j *JobRunner) Load() error {
 		j.Steps = DeleteTaskSteps
 	case types.CreateTaskJob:
 		j.Steps = CreateTaskSteps
+	case types.BulkDeleteJob:
+		j.Steps = BulkDeleteSteps
 	default:
 		return fmt.Errorf("Unknown job type '%v'!", job.JobType)
 	}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+// l0-secrets is the entrypoint of containers that reference Layer0 secrets.
+// It replaces each environment variable whose value is 'secret://<environment id>/<secret name>' with
+// the value of the secret, and then runs its arguments in its place. The task role of the container
+// can only read the secrets of the environment the deploy references.
+
+var Version string
+
+// the l0-secrets image copies its certificates next to the binary, since the
+// containers it runs in may not have any
+const CA_CERTIFICATES_FILE = "/layer0/ca-certificates.crt"
+
+func main() {
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "--version" {
+		fmt.Println(getVersion())
+		return
+	}
+
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		logrus.Fatal("No command specified")
+	}
+
+	environmentID, err := getEnvironmentID()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	if _, err := os.Stat(CA_CERTIFICATES_FILE); err == nil && os.Getenv("SSL_CERT_FILE") == "" {
+		os.Setenv("SSL_CERT_FILE", CA_CERTIFICATES_FILE)
+	}
+
+	client := s3.New(session.New(&aws.Config{Region: aws.String(config.AWSRegion())}))
+	getSecret := func(secretName string) (string, error) {
+		input := &s3.GetObjectInput{
+			Bucket: aws.String(config.AWSS3Bucket()),
+			Key:    aws.String(fmt.Sprintf("secrets/%s/%s", environmentID, secretName)),
+		}
+
+		output, err := client.GetObject(input)
+		if err != nil {
+			return "", fmt.Errorf("Failed to get secret '%s': %v", secretName, err)
+		}
+
+		defer output.Body.Close()
+		value, err := ioutil.ReadAll(output.Body)
+		if err != nil {
+			return "", err
+		}
+
+		return string(value), nil
+	}
+
+	environ, err := resolveSecrets(os.Environ(), environmentID, getSecret)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	if err := syscall.Exec(path, args, environ); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func getVersion() string {
+	if Version == "" {
+		Version = "0.0.1x-unset-develop"
+	}
+
+	return Version
+}
+
+// getEnvironmentID uses the container metadata file written by the ecs agent to
+// find the environment the container is running in
+func getEnvironmentID() (string, error) {
+	path := os.Getenv("ECS_CONTAINER_METADATA_FILE")
+	if path == "" {
+		return "", fmt.Errorf("ECS_CONTAINER_METADATA_FILE is not set; container metadata must be enabled on the instance")
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	var metadata struct {
+		Cluster string
+	}
+
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return "", err
+	}
+
+	return parseEnvironmentID(metadata.Cluster, config.Prefix())
+}
+
+// clusters are named 'l0-<prefix>-<environment id>'
+func parseEnvironmentID(cluster, prefix string) (string, error) {
+	clusterPrefix := fmt.Sprintf("l0-%s-", prefix)
+	if !strings.HasPrefix(cluster, clusterPrefix) {
+		return "", fmt.Errorf("Cluster '%s' does not belong to Layer0 instance '%s'", cluster, prefix)
+	}
+
+	return strings.TrimPrefix(cluster, clusterPrefix), nil
+}
+
+// resolveSecrets returns an error if a secret belongs to an environment other than environmentID,
+// since the task role could not read it anyway
+func resolveSecrets(environ []string, environmentID string, getSecret func(secretName string) (string, error)) ([]string, error) {
+	resolved := make([]string, len(environ))
+	for i, variable := range environ {
+		resolved[i] = variable
+
+		split := strings.SplitN(variable, "=", 2)
+		if len(split) != 2 || !models.IsSecretReference(split[1]) {
+			continue
+		}
+
+		referenceEnvironmentID, secretName, err := models.ParseSecretReference(split[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if referenceEnvironmentID != environmentID {
+			return nil, fmt.Errorf("Secret '%s' belongs to environment '%s', but the container is running in environment '%s'", secretName, referenceEnvironmentID, environmentID)
+		}
+
+		value, err := getSecret(secretName)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved[i] = fmt.Sprintf("%s=%s", split[0], value)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	secrets := map[string]string{
+		"db_password": "hunter2",
+	}
+
+	getSecret := func(secretName string) (string, error) {
+		if value, ok := secrets[secretName]; ok {
+			return value, nil
+		}
+
+		return "", fmt.Errorf("Secret '%s' does not exist", secretName)
+	}
+
+	environ := []string{"MODE=prod", "DB_PASSWORD=secret://envid/db_password", "EMPTY="}
+	resolved, err := resolveSecrets(environ, "envid", getSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, resolved, []string{"MODE=prod", "DB_PASSWORD=hunter2", "EMPTY="})
+
+	for _, variable := range []string{"API_KEY=secret://envid/api_key", "DB_PASSWORD=secret://other/db_password", "DB_PASSWORD=secret://db_password"} {
+		if _, err := resolveSecrets([]string{variable}, "envid", getSecret); err == nil {
+			t.Errorf("%s: error was nil!", variable)
+		}
+	}
+}
+
+func TestParseEnvironmentID(t *testing.T) {
+	environmentID, err := parseEnvironmentID("l0-prod-envid123", "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, environmentID, "envid123")
+
+	if _, err := parseEnvironmentID("l0-dev-envid123", "prod"); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
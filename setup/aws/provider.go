@@ -3,6 +3,7 @@ package aws
 import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -11,15 +12,27 @@ import (
 
 const DEFAULT_AWS_REGION = "us-west-2"
 
+// DynamoDBAPI is the subset of the DynamoDB API used to lock, back up, restore, and migrate the tables of an instance
+type DynamoDBAPI interface {
+	DeleteItem(*dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error)
+	DescribeTable(*dynamodb.DescribeTableInput) (*dynamodb.DescribeTableOutput, error)
+	GetItem(*dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error)
+	PutItem(*dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error)
+	ScanPages(*dynamodb.ScanInput, func(*dynamodb.ScanOutput, bool) bool) error
+	UpdateItem(*dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error)
+}
+
 type Provider struct {
-	EC2 ec2iface.EC2API
-	S3  s3iface.S3API
+	DynamoDB DynamoDBAPI
+	EC2      ec2iface.EC2API
+	S3       s3iface.S3API
 }
 
 func NewProvider(config *aws.Config) *Provider {
 	session := session.New(config)
 	return &Provider{
-		EC2: ec2.New(session),
-		S3:  s3.New(session),
+		DynamoDB: dynamodb.New(session),
+		EC2:      ec2.New(session),
+		S3:       s3.New(session),
 	}
 }
@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 
+	"github.com/quintilesims/layer0/setup/aws"
 	"github.com/quintilesims/layer0/setup/instance"
 	"github.com/urfave/cli"
 )
@@ -29,11 +30,15 @@ func (f *CommandFactory) Apply() cli.Command {
 			}
 
 			inst := f.NewInstance(args["NAME"])
-			if err := inst.Apply(!c.Bool("quick")); err != nil {
-				return err
-			}
+			apply := func(*aws.Provider) error {
+				if err := inst.Apply(!c.Bool("quick")); err != nil {
+					return err
+				}
+
+				if !c.Bool("push") {
+					return nil
+				}
 
-			if c.Bool("push") {
 				region, err := inst.Output(instance.OUTPUT_AWS_REGION)
 				if err != nil {
 					return err
@@ -44,9 +49,11 @@ func (f *CommandFactory) Apply() cli.Command {
 					return err
 				}
 
-				if err := inst.Push(provider.S3); err != nil {
-					return err
-				}
+				return inst.Push(provider.S3)
+			}
+
+			if err := f.withLock(c, inst, "apply", apply); err != nil {
+				return err
 			}
 
 			fmt.Println("Apply complete!")
@@ -15,6 +15,8 @@ func TestApply(t *testing.T) {
 
 	instanceFactory := func(name string) instance.Instance {
 		mockInstance := mock_instance.NewMockInstance(ctrl)
+		expectUnlocked(mockInstance)
+
 		mockInstance.EXPECT().
 			Apply(true).
 			Return(nil)
@@ -37,6 +39,8 @@ func TestApplyQuick(t *testing.T) {
 
 	instanceFactory := func(name string) instance.Instance {
 		mockInstance := mock_instance.NewMockInstance(ctrl)
+		expectUnlocked(mockInstance)
+
 		mockInstance.EXPECT().
 			Apply(false).
 			Return(nil)
@@ -3,9 +3,50 @@ package command
 import (
 	"testing"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/setup/aws"
+	"github.com/quintilesims/layer0/setup/instance"
+	"github.com/quintilesims/layer0/setup/instance/mock_instance"
 	"github.com/urfave/cli"
 )
 
+// awsCredentialFlags stop the commands from looking up the default AWS credentials
+var awsCredentialFlags = map[string]interface{}{
+	"aws-access-key": "key",
+	"aws-secret-key": "secret",
+}
+
+func newTestAWSProvider(config *awssdk.Config) *aws.Provider {
+	return &aws.Provider{}
+}
+
+// expectUnlocked sets up mockInstance as an instance that has not been applied, so it cannot be locked
+func expectUnlocked(mockInstance *mock_instance.MockInstance) {
+	mockInstance.EXPECT().
+		IsApplied().
+		Return(false, nil)
+}
+
+// expectLock sets up mockInstance to be locked for operation, and returns the lock that must be released
+func expectLock(mockInstance *mock_instance.MockInstance, operation string) *instance.Lock {
+	lock := &instance.Lock{ID: "id"}
+
+	mockInstance.EXPECT().
+		IsApplied().
+		Return(true, nil)
+
+	mockInstance.EXPECT().
+		Output(instance.OUTPUT_AWS_REGION).
+		Return("us-west-2", nil)
+
+	mockInstance.EXPECT().
+		Lock(gomock.Any(), operation).
+		Return(lock, nil)
+
+	return lock
+}
+
 func extractAction(t *testing.T, command cli.Command) func(*cli.Context) error {
 	action, ok := command.Action.(func(*cli.Context) error)
 	if !ok {
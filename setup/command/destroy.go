@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 
+	"github.com/quintilesims/layer0/setup/aws"
 	"github.com/urfave/cli"
 )
 
@@ -11,12 +12,11 @@ func (f *CommandFactory) Destroy() cli.Command {
 		Name:      "destroy",
 		Usage:     "destroy all resources associated with a Layer0 instance",
 		ArgsUsage: "NAME",
-		Flags: []cli.Flag{
+		Flags: append(awsFlags,
 			cli.BoolFlag{
 				Name:  "force",
 				Usage: "skip confirmation prompt",
-			},
-		},
+			}),
 		Action: func(c *cli.Context) error {
 			args, err := extractArgs(c.Args(), "NAME")
 			if err != nil {
@@ -24,7 +24,8 @@ func (f *CommandFactory) Destroy() cli.Command {
 			}
 
 			instance := f.NewInstance(args["NAME"])
-			if err := instance.Destroy(c.Bool("force")); err != nil {
+			destroy := func(*aws.Provider) error { return instance.Destroy(c.Bool("force")) }
+			if err := f.withLock(c, instance, "destroy", destroy); err != nil {
 				return err
 			}
 
@@ -15,6 +15,8 @@ func TestDestroy(t *testing.T) {
 
 	instanceFactory := func(name string) instance.Instance {
 		mockInstance := mock_instance.NewMockInstance(ctrl)
+		expectUnlocked(mockInstance)
+
 		mockInstance.EXPECT().
 			Destroy(false).
 			Return(nil)
@@ -37,6 +39,8 @@ func TestDestroyForce(t *testing.T) {
 
 	instanceFactory := func(name string) instance.Instance {
 		mockInstance := mock_instance.NewMockInstance(ctrl)
+		expectUnlocked(mockInstance)
+
 		mockInstance.EXPECT().
 			Destroy(true).
 			Return(nil)
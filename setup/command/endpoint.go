@@ -11,7 +11,7 @@ import (
 func (f *CommandFactory) Endpoint() cli.Command {
 	return cli.Command{
 		Name:      "endpoint",
-		Usage:     "show environment variables used to connect to a Layer0 instance",
+		Usage:     "show environment variables used to connect to a Layer0 instance, or save them as an l0 profile",
 		ArgsUsage: "NAME",
 		Flags: []cli.Flag{
 			cli.BoolFlag{
@@ -27,6 +27,18 @@ func (f *CommandFactory) Endpoint() cli.Command {
 				Value: "bash",
 				Usage: "choose the syntax to display environment variables (choices: bash, cmd, powershell)",
 			},
+			cli.StringFlag{
+				Name:  "p, profile",
+				Usage: "save the endpoint and token as an l0 profile with the specified name instead of showing them",
+			},
+			cli.BoolFlag{
+				Name:  "confirm",
+				Usage: "when saving a profile, require confirmation before destructive l0 commands",
+			},
+			cli.BoolFlag{
+				Name:  "use",
+				Usage: "when saving a profile, make it the current l0 profile",
+			},
 		},
 		Action: func(c *cli.Context) error {
 			args, err := extractArgs(c.Args(), "NAME")
@@ -34,6 +46,10 @@ func (f *CommandFactory) Endpoint() cli.Command {
 				return err
 			}
 
+			if name := c.String("profile"); name != "" {
+				return saveEndpointProfile(f.NewInstance(args["NAME"]), name, c.Bool("insecure"), c.Bool("confirm"), c.Bool("use"))
+			}
+
 			outputEnvvars := map[string]string{
 				instance.OUTPUT_ENDPOINT: config.API_ENDPOINT,
 				instance.OUTPUT_TOKEN:    config.AUTH_TOKEN,
@@ -87,6 +103,45 @@ func (f *CommandFactory) Endpoint() cli.Command {
 	}
 }
 
+func saveEndpointProfile(i instance.Instance, name string, insecure, confirm, use bool) error {
+	endpoint, err := i.Output(instance.OUTPUT_ENDPOINT)
+	if err != nil {
+		return err
+	}
+
+	token, err := i.Output(instance.OUTPUT_TOKEN)
+	if err != nil {
+		return err
+	}
+
+	path := config.ProfileConfigPath()
+	profileConfig, err := config.LoadProfileConfig(path)
+	if err != nil {
+		return err
+	}
+
+	profileConfig.SetProfile(&config.Profile{
+		Confirm:           confirm,
+		Endpoint:          endpoint,
+		Name:              name,
+		SkipSSLVerify:     insecure,
+		SkipVersionVerify: insecure,
+		Token:             token,
+	})
+
+	if use {
+		profileConfig.CurrentProfile = name
+	}
+
+	if err := profileConfig.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved profile '%s' to %s\n", name, path)
+	fmt.Printf("Run `l0 --profile %s <command>` or `l0 profile use %s` to use it\n", name, name)
+	return nil
+}
+
 func printOutput(syntax, envvar, v string) error {
 	switch syntax {
 	case "bash":
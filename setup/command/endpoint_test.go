@@ -1,9 +1,13 @@
 package command
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/testutils"
 	"github.com/quintilesims/layer0/setup/instance"
 	"github.com/quintilesims/layer0/setup/instance/mock_instance"
@@ -93,3 +97,61 @@ func TestEndpointDev(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestEndpointProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config")
+	os.Setenv(config.PROFILE_CONFIG_PATH, path)
+	defer os.Unsetenv(config.PROFILE_CONFIG_PATH)
+
+	instanceFactory := func(name string) instance.Instance {
+		mockInstance := mock_instance.NewMockInstance(ctrl)
+
+		mockInstance.EXPECT().
+			Output(instance.OUTPUT_ENDPOINT).
+			Return("https://endpoint", nil)
+
+		mockInstance.EXPECT().
+			Output(instance.OUTPUT_TOKEN).
+			Return("token", nil)
+
+		return mockInstance
+	}
+
+	commandFactory := NewCommandFactory(instanceFactory, nil)
+	action := extractAction(t, commandFactory.Endpoint())
+
+	flags := map[string]interface{}{
+		"profile": "prod",
+		"confirm": true,
+		"use":     true,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := action(c); err != nil {
+		t.Fatal(err)
+	}
+
+	profileConfig, err := config.LoadProfileConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &config.Profile{
+		Confirm:  true,
+		Endpoint: "https://endpoint",
+		Name:     "prod",
+		Token:    "token",
+	}
+
+	testutils.AssertEqual(t, profileConfig.CurrentProfile, "prod")
+	testutils.AssertEqual(t, profileConfig.Profiles, []*config.Profile{expected})
+}
@@ -42,6 +42,10 @@ func (f *CommandFactory) Init() cli.Command {
 				Name:  "aws-ssh-key-pair",
 				Usage: instance.INPUT_AWS_SSH_KEY_PAIR_DESCRIPTION,
 			},
+			cli.StringFlag{
+				Name:  "deploy-retention-count",
+				Usage: instance.INPUT_RETENTION_COUNT_DESCRIPTION,
+			},
 		},
 		Action: func(c *cli.Context) error {
 			args, err := extractArgs(c.Args(), "NAME")
@@ -72,6 +76,10 @@ func (f *CommandFactory) Init() cli.Command {
 				overrides[instance.INPUT_AWS_SSH_KEY_PAIR] = v
 			}
 
+			if v := c.String("deploy-retention-count"); v != "" {
+				overrides[instance.INPUT_RETENTION_COUNT] = v
+			}
+
 			dockerPath := strings.Replace(c.String("docker-path"), "~", homedir.Get(), -1)
 			instance := f.NewInstance(args["NAME"])
 			if err := instance.Init(dockerPath, overrides); err != nil {
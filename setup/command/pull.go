@@ -16,7 +16,11 @@ func (f *CommandFactory) Pull() cli.Command {
 		Name:      "pull",
 		Usage:     "pull a Layer0 instance configuration from S3",
 		ArgsUsage: "NAME",
-		Flags:     awsFlags,
+		Flags: append(awsFlags,
+			cli.BoolFlag{
+				Name:  "force",
+				Usage: "overwrite local changes that have not been pushed",
+			}),
 		Action: func(c *cli.Context) error {
 			args, err := extractArgs(c.Args(), "NAME")
 			if err != nil {
@@ -53,7 +57,7 @@ func (f *CommandFactory) Pull() cli.Command {
 			}
 
 			instance := f.NewInstance(args["NAME"])
-			if err := instance.Pull(provider.S3); err != nil {
+			if err := instance.Pull(provider.S3, c.Bool("force")); err != nil {
 				return err
 			}
 
@@ -3,7 +3,7 @@ package command
 import (
 	"fmt"
 
-	"github.com/quintilesims/layer0/setup/instance"
+	"github.com/quintilesims/layer0/setup/aws"
 	"github.com/urfave/cli"
 )
 
@@ -20,17 +20,15 @@ func (f *CommandFactory) Push() cli.Command {
 			}
 
 			inst := f.NewInstance(args["NAME"])
-			region, err := inst.Output(instance.OUTPUT_AWS_REGION)
-			if err != nil {
-				return err
-			}
+			push := func(provider *aws.Provider) error {
+				if provider == nil {
+					return fmt.Errorf("Instance '%s' has not been applied, so there is nothing to push", args["NAME"])
+				}
 
-			provider, err := f.newAWSProviderHelper(c, region)
-			if err != nil {
-				return err
+				return inst.Push(provider.S3)
 			}
 
-			if err := inst.Push(provider.S3); err != nil {
+			if err := f.withLock(c, inst, "push", push); err != nil {
 				return err
 			}
 
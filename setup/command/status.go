@@ -0,0 +1,129 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/quintilesims/layer0/setup/instance"
+	"github.com/urfave/cli"
+)
+
+func (f *CommandFactory) Status() cli.Command {
+	return cli.Command{
+		Name:      "status",
+		Usage:     "show drift, versions, out-of-date inputs, and remote state of local Layer0 instance(s)",
+		ArgsUsage: "[NAME]",
+		Flags: append(awsFlags,
+			cli.BoolFlag{
+				Name:  "a, all",
+				Usage: "show the status of every local Layer0 instance",
+			}),
+		Action: func(c *cli.Context) error {
+			if !c.Bool("all") {
+				args, err := extractArgs(c.Args(), "NAME")
+				if err != nil {
+					return err
+				}
+
+				return f.printStatus(c, args["NAME"])
+			}
+
+			names, err := instance.ListLocalInstances()
+			if err != nil {
+				return err
+			}
+
+			// keep going so one broken instance doesn't hide the status of the others
+			var failed []string
+			for _, name := range names {
+				if err := f.printStatus(c, name); err != nil {
+					fmt.Printf("Failed to get status of instance '%s': %v\n\n", name, err)
+					failed = append(failed, name)
+				}
+			}
+
+			if len(failed) > 0 {
+				return fmt.Errorf("Failed to get status of instance(s): %s", strings.Join(failed, ", "))
+			}
+
+			return nil
+		},
+	}
+}
+
+func (f *CommandFactory) printStatus(c *cli.Context, name string) error {
+	inst := f.NewInstance(name)
+	applied, err := inst.IsApplied()
+	if err != nil {
+		return err
+	}
+
+	// instances that have not been applied have no remote state to check
+	var s3API s3iface.S3API
+	if applied {
+		region, err := inst.Output(instance.OUTPUT_AWS_REGION)
+		if err != nil {
+			return err
+		}
+
+		provider, err := f.newAWSProviderHelper(c, region)
+		if err != nil {
+			return err
+		}
+
+		s3API = provider.S3
+	}
+
+	status, err := inst.Status(s3API)
+	if err != nil {
+		return err
+	}
+
+	apiVersion := status.APIVersion
+	switch {
+	case !status.Applied:
+		apiVersion = "<not applied>"
+	case apiVersion == "":
+		apiVersion = "<unknown>"
+	}
+
+	drift := "none"
+	if status.Drift {
+		drift = status.PlanSummary
+	}
+
+	outdated := "none"
+	if len(status.OutdatedInputs) > 0 {
+		outdated = strings.Join(status.OutdatedInputs, ", ")
+	}
+
+	fmt.Printf("INSTANCE: %s\n", status.Name)
+	fmt.Printf("  Layer0 Version:   %s\n", status.Layer0Version)
+	fmt.Printf("  API Version:      %s\n", apiVersion)
+	fmt.Printf("  Drift:            %s\n", drift)
+	fmt.Printf("  Outdated Inputs:  %s\n", outdated)
+	fmt.Printf("  Local State:      %s\n", formatStateTime(status.LocalStateModified))
+	fmt.Printf("  Remote State:     %s\n", formatStateTime(status.RemoteStateModified))
+
+	if status.RemoteStateDiffers() {
+		fmt.Printf("  WARNING: the remote state differs from the local state; ")
+		fmt.Printf("if someone else pushed it, run 'l0-setup pull %s' before 'l0-setup push %s' to avoid overwriting it\n", name, name)
+	}
+
+	if status.Drift {
+		fmt.Printf("  Run 'l0-setup plan %s' to see the changes\n", name)
+	}
+
+	fmt.Println()
+	return nil
+}
+
+func formatStateTime(t time.Time) string {
+	if t.IsZero() {
+		return "<none>"
+	}
+
+	return t.Local().Format(time.RFC1123)
+}
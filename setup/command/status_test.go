@@ -0,0 +1,103 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/quintilesims/layer0/setup/aws"
+	"github.com/quintilesims/layer0/setup/instance"
+	"github.com/quintilesims/layer0/setup/instance/mock_instance"
+)
+
+func TestStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	instanceFactory := func(name string) instance.Instance {
+		testutils.AssertEqual(t, name, "name")
+
+		mockInstance := mock_instance.NewMockInstance(ctrl)
+		mockInstance.EXPECT().
+			IsApplied().
+			Return(true, nil)
+
+		mockInstance.EXPECT().
+			Output(instance.OUTPUT_AWS_REGION).
+			Return("us-west-2", nil)
+
+		status := &instance.InstanceStatus{
+			Name:                "name",
+			Layer0Version:       "v1.0.0",
+			Drift:               true,
+			PlanSummary:         "Plan: 1 to add, 0 to change, 0 to destroy.",
+			Applied:             true,
+			LocalStateModified:  time.Now().Add(-time.Hour),
+			RemoteStateModified: time.Now(),
+			LocalStateMD5:       "d41d8cd98f00b204e9800998ecf8427e",
+			RemoteStateETag:     "9e107d9d372bb6826bd81d3542a419d6",
+		}
+
+		mockInstance.EXPECT().
+			Status(gomock.Any()).
+			Return(status, nil)
+
+		return mockInstance
+	}
+
+	providerFactory := func(config *awssdk.Config) *aws.Provider {
+		testutils.AssertEqual(t, awssdk.StringValue(config.Region), "us-west-2")
+		return &aws.Provider{}
+	}
+
+	commandFactory := NewCommandFactory(instanceFactory, providerFactory)
+	action := extractAction(t, commandFactory.Status())
+
+	flags := map[string]interface{}{
+		"aws-access-key": "key",
+		"aws-secret-key": "secret",
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := action(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStatus_notApplied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	instanceFactory := func(name string) instance.Instance {
+		mockInstance := mock_instance.NewMockInstance(ctrl)
+		mockInstance.EXPECT().
+			IsApplied().
+			Return(false, nil)
+
+		mockInstance.EXPECT().
+			Status(nil).
+			Return(&instance.InstanceStatus{Name: "name", Layer0Version: "v1.0.0"}, nil)
+
+		return mockInstance
+	}
+
+	commandFactory := NewCommandFactory(instanceFactory, nil)
+	action := extractAction(t, commandFactory.Status())
+
+	c := testutils.GetCLIContext(t, []string{"name"}, nil)
+	if err := action(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStatus_missingName(t *testing.T) {
+	commandFactory := NewCommandFactory(nil, nil)
+	action := extractAction(t, commandFactory.Status())
+
+	c := testutils.GetCLIContext(t, nil, nil)
+	if err := action(c); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
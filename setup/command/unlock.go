@@ -0,0 +1,95 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/quintilesims/layer0/setup/aws"
+	"github.com/quintilesims/layer0/setup/instance"
+	"github.com/urfave/cli"
+)
+
+func (f *CommandFactory) Unlock() cli.Command {
+	return cli.Command{
+		Name:      "unlock",
+		Usage:     "release the lock of a Layer0 instance that was left behind by an interrupted operation",
+		ArgsUsage: "NAME",
+		Flags: append(awsFlags,
+			cli.BoolFlag{
+				Name:  "force",
+				Usage: "required; release the lock even though another user may still be running an operation",
+			}),
+		Action: func(c *cli.Context) error {
+			args, err := extractArgs(c.Args(), "NAME")
+			if err != nil {
+				return err
+			}
+
+			if !c.Bool("force") {
+				return fmt.Errorf("Releasing a lock held by another operation can corrupt the instance's state; use --force to confirm")
+			}
+
+			inst := f.NewInstance(args["NAME"])
+			region, err := inst.Output(instance.OUTPUT_AWS_REGION)
+			if err != nil {
+				return err
+			}
+
+			provider, err := f.newAWSProviderHelper(c, region)
+			if err != nil {
+				return err
+			}
+
+			if err := inst.ForceUnlock(provider.DynamoDB); err != nil {
+				return err
+			}
+
+			fmt.Println("Unlock complete!")
+			return nil
+		},
+	}
+}
+
+// withLock runs fn while holding the remote lock of inst. Instances that have not been applied
+// have no tables to hold a lock or state that others could change, so fn runs without one
+// and is passed a nil provider.
+func (f *CommandFactory) withLock(c *cli.Context, inst instance.Instance, operation string, fn func(*aws.Provider) error) error {
+	applied, err := inst.IsApplied()
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		logrus.Warningf("Running '%s' without a lock since the instance has not been applied", operation)
+		return fn(nil)
+	}
+
+	region, err := inst.Output(instance.OUTPUT_AWS_REGION)
+	if err != nil {
+		return err
+	}
+
+	provider, err := f.newAWSProviderHelper(c, region)
+	if err != nil {
+		return err
+	}
+
+	lock, err := inst.Lock(provider.DynamoDB, operation)
+	if err == instance.ErrNoLockTable {
+		logrus.Warningf("Running '%s' without a lock since the instance was applied by an older version of l0-setup; "+
+			"apply the instance to create its lock table", operation)
+		return fn(provider)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := inst.Unlock(provider.DynamoDB, lock); err != nil {
+			logrus.Warningf("Failed to release the lock: %v. Run `l0-setup unlock --force` to release it", err)
+		}
+	}()
+
+	return fn(provider)
+}
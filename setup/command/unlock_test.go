@@ -0,0 +1,141 @@
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/quintilesims/layer0/setup/instance"
+	"github.com/quintilesims/layer0/setup/instance/mock_instance"
+)
+
+func TestUnlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	instanceFactory := func(name string) instance.Instance {
+		mockInstance := mock_instance.NewMockInstance(ctrl)
+		mockInstance.EXPECT().
+			Output(instance.OUTPUT_AWS_REGION).
+			Return("us-west-2", nil)
+
+		mockInstance.EXPECT().
+			ForceUnlock(gomock.Any()).
+			Return(nil)
+
+		return mockInstance
+	}
+
+	commandFactory := NewCommandFactory(instanceFactory, newTestAWSProvider)
+	action := extractAction(t, commandFactory.Unlock())
+
+	flags := map[string]interface{}{"force": true}
+	for key, val := range awsCredentialFlags {
+		flags[key] = val
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := action(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnlockRequiresForce(t *testing.T) {
+	commandFactory := NewCommandFactory(nil, nil)
+	action := extractAction(t, commandFactory.Unlock())
+
+	c := testutils.GetCLIContext(t, []string{"name"}, nil)
+	if err := action(c); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
+
+func TestApplyLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	instanceFactory := func(name string) instance.Instance {
+		mockInstance := mock_instance.NewMockInstance(ctrl)
+		lock := expectLock(mockInstance, "apply")
+
+		gomock.InOrder(
+			mockInstance.EXPECT().
+				Apply(true).
+				Return(nil),
+			mockInstance.EXPECT().
+				Unlock(gomock.Any(), lock).
+				Return(nil),
+		)
+
+		return mockInstance
+	}
+
+	commandFactory := NewCommandFactory(instanceFactory, newTestAWSProvider)
+	action := extractAction(t, commandFactory.Apply())
+
+	c := testutils.GetCLIContext(t, []string{"name"}, awsCredentialFlags)
+	if err := action(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDestroyLockedByOtherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	instanceFactory := func(name string) instance.Instance {
+		mockInstance := mock_instance.NewMockInstance(ctrl)
+		mockInstance.EXPECT().
+			IsApplied().
+			Return(true, nil)
+
+		mockInstance.EXPECT().
+			Output(instance.OUTPUT_AWS_REGION).
+			Return("us-west-2", nil)
+
+		// Destroy must not be called
+		mockInstance.EXPECT().
+			Lock(gomock.Any(), "destroy").
+			Return(nil, fmt.Errorf("some error"))
+
+		return mockInstance
+	}
+
+	commandFactory := NewCommandFactory(instanceFactory, newTestAWSProvider)
+	action := extractAction(t, commandFactory.Destroy())
+
+	c := testutils.GetCLIContext(t, []string{"name"}, awsCredentialFlags)
+	if err := action(c); err == nil {
+		t.Fatal("error was nil!")
+	}
+}
+
+func TestPushLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	instanceFactory := func(name string) instance.Instance {
+		mockInstance := mock_instance.NewMockInstance(ctrl)
+		lock := expectLock(mockInstance, "push")
+
+		gomock.InOrder(
+			mockInstance.EXPECT().
+				Push(gomock.Any()).
+				Return(nil),
+			mockInstance.EXPECT().
+				Unlock(gomock.Any(), lock).
+				Return(nil),
+		)
+
+		return mockInstance
+	}
+
+	commandFactory := NewCommandFactory(instanceFactory, newTestAWSProvider)
+	action := extractAction(t, commandFactory.Push())
+
+	c := testutils.GetCLIContext(t, []string{"name"}, awsCredentialFlags)
+	if err := action(c); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 
+	"github.com/quintilesims/layer0/setup/aws"
 	"github.com/urfave/cli"
 )
 
@@ -11,12 +12,15 @@ func (f *CommandFactory) Upgrade() cli.Command {
 		Name:      "upgrade",
 		Usage:     "upgrade a Layer0 instance to a new version",
 		ArgsUsage: "NAME VERSION",
-		Flags: []cli.Flag{
+		Flags: append(awsFlags,
 			cli.BoolFlag{
 				Name:  "force",
-				Usage: "skips confirmation prompt",
+				Usage: "skips confirmation prompt and upgrade compatibility checks",
 			},
-		},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "show the checks, backups, and migrations the upgrade would run without changing anything",
+			}),
 		Action: func(c *cli.Context) error {
 			args, err := extractArgs(c.Args(), "NAME", "VERSION")
 			if err != nil {
@@ -24,7 +28,15 @@ func (f *CommandFactory) Upgrade() cli.Command {
 			}
 
 			instance := f.NewInstance(args["NAME"])
-			if err := instance.Upgrade(args["VERSION"], c.Bool("force")); err != nil {
+			if c.Bool("dry-run") {
+				return instance.Upgrade(nil, args["VERSION"], c.Bool("force"), true)
+			}
+
+			upgrade := func(provider *aws.Provider) error {
+				return instance.Upgrade(dynamoDB(provider), args["VERSION"], c.Bool("force"), false)
+			}
+
+			if err := f.withLock(c, instance, "upgrade", upgrade); err != nil {
 				return err
 			}
 
@@ -33,3 +45,44 @@ func (f *CommandFactory) Upgrade() cli.Command {
 		},
 	}
 }
+
+func (f *CommandFactory) Rollback() cli.Command {
+	return cli.Command{
+		Name:      "rollback",
+		Usage:     "undo the last upgrade of a Layer0 instance by restoring the backup it took; run apply afterwards",
+		ArgsUsage: "NAME",
+		Flags: append(awsFlags,
+			cli.BoolFlag{
+				Name:  "force",
+				Usage: "skip confirmation prompt",
+			}),
+		Action: func(c *cli.Context) error {
+			args, err := extractArgs(c.Args(), "NAME")
+			if err != nil {
+				return err
+			}
+
+			instance := f.NewInstance(args["NAME"])
+			rollback := func(provider *aws.Provider) error {
+				return instance.Rollback(dynamoDB(provider), c.Bool("force"))
+			}
+
+			if err := f.withLock(c, instance, "rollback", rollback); err != nil {
+				return err
+			}
+
+			fmt.Printf("Rollback complete! Run 'l0-setup apply %s' to deploy the previous version; ", args["NAME"])
+			fmt.Printf("until then, the instance is still running the upgraded version\n")
+			return nil
+		},
+	}
+}
+
+// dynamoDB returns nil if provider is nil, which is the case for instances that have not been applied
+func dynamoDB(provider *aws.Provider) aws.DynamoDBAPI {
+	if provider == nil {
+		return nil
+	}
+
+	return provider.DynamoDB
+}
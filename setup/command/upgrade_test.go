@@ -15,8 +15,10 @@ func TestUpgrade(t *testing.T) {
 
 	instanceFactory := func(name string) instance.Instance {
 		mockInstance := mock_instance.NewMockInstance(ctrl)
+		expectUnlocked(mockInstance)
+
 		mockInstance.EXPECT().
-			Upgrade("v1.0.0", false).
+			Upgrade(nil, "v1.0.0", false, false).
 			Return(nil)
 
 		return mockInstance
@@ -37,8 +39,10 @@ func TestUpgradeForce(t *testing.T) {
 
 	instanceFactory := func(name string) instance.Instance {
 		mockInstance := mock_instance.NewMockInstance(ctrl)
+		expectUnlocked(mockInstance)
+
 		mockInstance.EXPECT().
-			Upgrade("v1.0.0", true).
+			Upgrade(nil, "v1.0.0", true, false).
 			Return(nil)
 
 		return mockInstance
@@ -52,3 +56,89 @@ func TestUpgradeForce(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestUpgradeDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// dry runs don't change anything, so they don't take the lock
+	instanceFactory := func(name string) instance.Instance {
+		mockInstance := mock_instance.NewMockInstance(ctrl)
+		mockInstance.EXPECT().
+			Upgrade(nil, "v1.0.0", false, true).
+			Return(nil)
+
+		return mockInstance
+	}
+
+	commandFactory := NewCommandFactory(instanceFactory, nil)
+	action := extractAction(t, commandFactory.Upgrade())
+
+	c := testutils.GetCLIContext(t, []string{"name", "v1.0.0"}, map[string]interface{}{"dry-run": true})
+	if err := action(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpgradeLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	instanceFactory := func(name string) instance.Instance {
+		mockInstance := mock_instance.NewMockInstance(ctrl)
+		lock := expectLock(mockInstance, "upgrade")
+
+		gomock.InOrder(
+			mockInstance.EXPECT().
+				Upgrade(gomock.Any(), "v1.0.0", false, false).
+				Return(nil),
+			mockInstance.EXPECT().
+				Unlock(gomock.Any(), lock).
+				Return(nil),
+		)
+
+		return mockInstance
+	}
+
+	commandFactory := NewCommandFactory(instanceFactory, newTestAWSProvider)
+	action := extractAction(t, commandFactory.Upgrade())
+
+	c := testutils.GetCLIContext(t, []string{"name", "v1.0.0"}, awsCredentialFlags)
+	if err := action(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	instanceFactory := func(name string) instance.Instance {
+		mockInstance := mock_instance.NewMockInstance(ctrl)
+		lock := expectLock(mockInstance, "rollback")
+
+		gomock.InOrder(
+			mockInstance.EXPECT().
+				Rollback(gomock.Any(), true).
+				Return(nil),
+			mockInstance.EXPECT().
+				Unlock(gomock.Any(), lock).
+				Return(nil),
+		)
+
+		return mockInstance
+	}
+
+	commandFactory := NewCommandFactory(instanceFactory, newTestAWSProvider)
+	action := extractAction(t, commandFactory.Rollback())
+
+	flags := map[string]interface{}{"force": true}
+	for key, val := range awsCredentialFlags {
+		flags[key] = val
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := action(c); err != nil {
+		t.Fatal(err)
+	}
+}
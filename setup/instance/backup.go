@@ -0,0 +1,310 @@
+package instance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	setup_aws "github.com/quintilesims/layer0/setup/aws"
+)
+
+// backups are stored in the instance directory so they are pushed and pulled with the rest of the instance
+const BACKUP_DIR = "backups"
+
+const (
+	backupManifestFile = "backup.json"
+	backupConfigFile   = "main.tf.json"
+	backupTagsFile     = "tags.json"
+	backupJobsFile     = "jobs.json"
+	backupTouchedFile  = "touched.json"
+)
+
+// Backup describes the contents of a backup taken before an upgrade
+type Backup struct {
+	Dir     string    `json:"-"`
+	Version string    `json:"version"`
+	Created time.Time `json:"created"`
+	// Tables is nil if the instance had not been applied, in which case only its configuration is backed up
+	Tables *Tables `json:"tables,omitempty"`
+}
+
+type tableItems []map[string]*dynamodb.AttributeValue
+
+// touchedItems holds the items the migrations of an upgrade wrote, updated, or deleted, keyed by table.
+// Only the key attributes of each item are used to restore it.
+type touchedItems map[string]tableItems
+
+// recordingDynamoDB records the items changed through it, so a rollback only restores the items the migrations touched
+type recordingDynamoDB struct {
+	setup_aws.DynamoDBAPI
+	touched touchedItems
+}
+
+func newRecordingDynamoDB(d setup_aws.DynamoDBAPI) *recordingDynamoDB {
+	return &recordingDynamoDB{
+		DynamoDBAPI: d,
+		touched:     touchedItems{},
+	}
+}
+
+// items are recorded before they are changed, since a failed request may still have been applied
+func (r *recordingDynamoDB) record(table *string, item map[string]*dynamodb.AttributeValue) {
+	name := aws.StringValue(table)
+	r.touched[name] = append(r.touched[name], item)
+}
+
+func (r *recordingDynamoDB) DeleteItem(input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error) {
+	r.record(input.TableName, input.Key)
+	return r.DynamoDBAPI.DeleteItem(input)
+}
+
+func (r *recordingDynamoDB) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	r.record(input.TableName, input.Item)
+	return r.DynamoDBAPI.PutItem(input)
+}
+
+func (r *recordingDynamoDB) UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+	r.record(input.TableName, input.Key)
+	return r.DynamoDBAPI.UpdateItem(input)
+}
+
+// backup copies the configuration and state of the instance, and the items in its tables if tables is not nil
+func (l *LocalInstance) backup(d setup_aws.DynamoDBAPI, version string, tables *Tables) (*Backup, error) {
+	created := time.Now()
+	b := &Backup{
+		Dir:     fmt.Sprintf("%s/%s/%s", l.Dir, BACKUP_DIR, created.UTC().Format("20060102T150405Z")),
+		Version: version,
+		Created: created,
+		Tables:  tables,
+	}
+
+	if err := os.MkdirAll(b.Dir, 0700); err != nil {
+		return nil, err
+	}
+
+	for _, file := range []string{backupConfigFile, STATE_FILE} {
+		if err := copyFile(fmt.Sprintf("%s/%s", l.Dir, file), fmt.Sprintf("%s/%s", b.Dir, file)); err != nil {
+			return nil, err
+		}
+	}
+
+	if tables != nil {
+		for file, table := range map[string]string{backupTagsFile: tables.Tags, backupJobsFile: tables.Jobs} {
+			logrus.Infof("Backing up table %s", table)
+			items, err := scanTable(d, table)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := writeJSON(fmt.Sprintf("%s/%s", b.Dir, file), items); err != nil {
+				return nil, err
+			}
+		}
+
+		// no items have been touched until the migrations run
+		if err := b.recordTouched(touchedItems{}); err != nil {
+			return nil, err
+		}
+	}
+
+	// the manifest is written last, so incomplete backups are never restored
+	if err := writeJSON(fmt.Sprintf("%s/%s", b.Dir, backupManifestFile), b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// recordTouched saves the items touched by the migrations of the upgrade that took b
+func (b *Backup) recordTouched(touched touchedItems) error {
+	return writeJSON(fmt.Sprintf("%s/%s", b.Dir, backupTouchedFile), touched)
+}
+
+// loadTouched returns nil if b was taken by a version of l0-setup that did not record the touched items
+func (b *Backup) loadTouched() (touchedItems, error) {
+	var touched touchedItems
+	if err := readJSON(fmt.Sprintf("%s/%s", b.Dir, backupTouchedFile), &touched); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return touched, nil
+}
+
+// latestBackup returns nil if the instance has no complete backups
+func (l *LocalInstance) latestBackup() (*Backup, error) {
+	dir := fmt.Sprintf("%s/%s", l.Dir, BACKUP_DIR)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, file := range files {
+		if file.IsDir() {
+			names = append(names, file.Name())
+		}
+	}
+
+	// backup directories are named by their creation time
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	for _, name := range names {
+		data, err := ioutil.ReadFile(fmt.Sprintf("%s/%s/%s", dir, name, backupManifestFile))
+		if os.IsNotExist(err) {
+			logrus.Debugf("Skipping incomplete backup %s", name)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		var b *Backup
+		if err := json.Unmarshal(data, &b); err != nil {
+			return nil, err
+		}
+
+		b.Dir = fmt.Sprintf("%s/%s", dir, name)
+		return b, nil
+	}
+
+	return nil, nil
+}
+
+// restore replaces the configuration of the instance, and the items in its tables touched by the upgrade, with the
+// contents of b. If touched is nil, every item in the tables is restored. The state is not restored, since it must
+// match the resources that currently exist.
+func (l *LocalInstance) restore(d setup_aws.DynamoDBAPI, b *Backup, touched touchedItems) error {
+	if b.Tables != nil && d == nil {
+		return fmt.Errorf("The backup in %s includes tables, but the instance has not been applied", b.Dir)
+	}
+
+	if b.Tables != nil {
+		for file, table := range map[string]string{backupTagsFile: b.Tables.Tags, backupJobsFile: b.Tables.Jobs} {
+			var items tableItems
+			if err := readJSON(fmt.Sprintf("%s/%s", b.Dir, file), &items); err != nil {
+				return err
+			}
+
+			var tableTouched tableItems
+			if touched != nil {
+				tableTouched = touched[table]
+				if len(tableTouched) == 0 {
+					continue
+				}
+			}
+
+			logrus.Infof("Restoring table %s", table)
+			if err := restoreTable(d, table, items, tableTouched); err != nil {
+				return err
+			}
+		}
+	}
+
+	return copyFile(fmt.Sprintf("%s/%s", b.Dir, backupConfigFile), fmt.Sprintf("%s/%s", l.Dir, backupConfigFile))
+}
+
+// restoreTable restores each item of table in touched to its value in items, and deletes it if it is not in items.
+// If touched is nil, every item of table is restored, and the items created after the backup was taken are deleted.
+func restoreTable(d setup_aws.DynamoDBAPI, table string, items, touched tableItems) error {
+	output, err := d.DescribeTable(&dynamodb.DescribeTableInput{TableName: aws.String(table)})
+	if err != nil {
+		return err
+	}
+
+	keyNames := []string{}
+	for _, key := range output.Table.KeySchema {
+		keyNames = append(keyNames, aws.StringValue(key.AttributeName))
+	}
+
+	itemKey := func(item map[string]*dynamodb.AttributeValue) (string, map[string]*dynamodb.AttributeValue) {
+		key := map[string]*dynamodb.AttributeValue{}
+		id := ""
+		for _, name := range keyNames {
+			key[name] = item[name]
+			id += fmt.Sprintf("%v/", item[name])
+		}
+
+		return id, key
+	}
+
+	backedUp := map[string]map[string]*dynamodb.AttributeValue{}
+	for _, item := range items {
+		id, _ := itemKey(item)
+		backedUp[id] = item
+	}
+
+	if touched == nil {
+		current, err := scanTable(d, table)
+		if err != nil {
+			return err
+		}
+
+		touched = append(current, items...)
+	}
+
+	restored := map[string]bool{}
+	for _, item := range touched {
+		id, key := itemKey(item)
+		if restored[id] {
+			continue
+		}
+
+		restored[id] = true
+		if item, ok := backedUp[id]; ok {
+			if _, err := d.PutItem(&dynamodb.PutItemInput{TableName: aws.String(table), Item: item}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// the item was created after the backup was taken
+		if _, err := d.DeleteItem(&dynamodb.DeleteItemInput{TableName: aws.String(table), Key: key}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyFile does nothing if src does not exist
+func copyFile(src, dst string) error {
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return ioutil.WriteFile(dst, data, 0600)
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
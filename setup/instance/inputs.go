@@ -20,6 +20,7 @@ const (
 	INPUT_PASSWORD         = "password"
 	INPUT_DOCKERCFG        = "dockercfg"
 	INPUT_VPC_ID           = "vpc_id"
+	INPUT_RETENTION_COUNT  = "deploy_retention_count"
 )
 
 const INPUT_SOURCE_DESCRIPTION = `
@@ -99,6 +100,12 @@ created for you. Existing VPCs must satisfy the following constraints:
 Note that changing this value will destroy and recreate any existing resources.
 `
 
+const INPUT_RETENTION_COUNT_DESCRIPTION = `
+Deploy Retention Count (optional): The deploy_retention_count input variable specifies
+how many versions of each deploy the Layer0 API keeps. Older versions are deleted unless
+they are in use by a service or task. A value of 0 keeps every version.
+`
+
 type ModuleInput struct {
 	Name        string
 	Description string
@@ -177,6 +184,12 @@ var Layer0ModuleInputs = []*ModuleInput{
 		Description: INPUT_VPC_ID_DESCRIPTION,
 		prompter:    OptionalStringPrompter,
 	},
+	{
+		Name:        INPUT_RETENTION_COUNT,
+		Description: INPUT_RETENTION_COUNT_DESCRIPTION,
+		Default:     "0",
+		prompter:    OptionalStringPrompter,
+	},
 }
 
 func (m ModuleInput) Prompt(current interface{}) (interface{}, error) {
@@ -1,7 +1,9 @@
 package instance
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"regexp"
 
@@ -35,6 +37,41 @@ func (l *LocalInstance) assertExists() error {
 	return nil
 }
 
+// IsApplied returns true if the local state of the instance holds any resources,
+// i.e. it has been applied and has not been destroyed since
+func (l *LocalInstance) IsApplied() (bool, error) {
+	if err := l.assertExists(); err != nil {
+		return false, err
+	}
+
+	data, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", l.Dir, STATE_FILE))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	var state struct {
+		Modules []struct {
+			Resources map[string]interface{} `json:"resources"`
+		} `json:"modules"`
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return false, fmt.Errorf("Failed to parse the state of instance '%s': %v", l.Name, err)
+	}
+
+	for _, module := range state.Modules {
+		if len(module.Resources) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (l *LocalInstance) validateInstanceName() error {
 	re := regexp.MustCompile("^[a-z][a-z0-9]{0,15}$")
 	if !re.MatchString(l.Name) {
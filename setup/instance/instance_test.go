@@ -0,0 +1,42 @@
+package instance
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestIsApplied(t *testing.T) {
+	dir, err := ioutil.TempDir("", "l0-setup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l := &LocalInstance{Name: "name", Dir: dir}
+	cases := map[string]bool{
+		"":                                 false,
+		`{"modules": [{"resources": {}}]}`: false,
+		`{"modules": [{"resources": {"a": {}}}]}`: true,
+	}
+
+	for state, expected := range cases {
+		path := fmt.Sprintf("%s/%s", dir, STATE_FILE)
+		os.Remove(path)
+		if state != "" {
+			if err := ioutil.WriteFile(path, []byte(state), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		applied, err := l.IsApplied()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertEqual(t, applied, expected)
+	}
+}
@@ -2,16 +2,23 @@ package instance
 
 import (
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/quintilesims/layer0/setup/aws"
 )
 
 type Instance interface {
 	Apply(wait bool) error
 	Destroy(force bool) error
+	ForceUnlock(d aws.DynamoDBAPI) error
 	Init(dockercfgPath string, inputOverrides map[string]interface{}) error
+	IsApplied() (bool, error)
+	Lock(d aws.DynamoDBAPI, operation string) (*Lock, error)
 	Output(key string) (string, error)
 	Plan() error
-	Pull(s s3iface.S3API) error
+	Pull(s s3iface.S3API, force bool) error
 	Push(s s3iface.S3API) error
+	Rollback(d aws.DynamoDBAPI, force bool) error
 	Set(inputs map[string]interface{}) error
-	Upgrade(version string, force bool) error
+	Status(s s3iface.S3API) (*InstanceStatus, error)
+	Unlock(d aws.DynamoDBAPI, lock *Lock) error
+	Upgrade(d aws.DynamoDBAPI, version string, force, dryRun bool) error
 }
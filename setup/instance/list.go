@@ -19,9 +19,10 @@ func ListLocalInstances() ([]string, error) {
 		return nil, err
 	}
 
+	// hidden directories, such as the cli's completion cache, are not instances
 	instances := []string{}
 	for _, file := range files {
-		if file.IsDir() {
+		if file.IsDir() && !strings.HasPrefix(file.Name(), ".") {
 			instances = append(instances, file.Name())
 		}
 	}
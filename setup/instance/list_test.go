@@ -0,0 +1,38 @@
+package instance
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestListLocalInstances(t *testing.T) {
+	home, err := ioutil.TempDir("", "l0-setup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", home)
+
+	for _, dir := range []string{"prod", "dev", ".cache"} {
+		if err := os.MkdirAll(filepath.Join(home, ".layer0", dir), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(home, ".layer0", "profiles.json"), []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	instances, err := ListLocalInstances()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, instances, []string{"dev", "prod"})
+}
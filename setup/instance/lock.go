@@ -0,0 +1,242 @@
+package instance
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	setup_aws "github.com/quintilesims/layer0/setup/aws"
+)
+
+// the lock table of an instance holds a single item with this ID while the instance is locked
+const lockItemID = "lock"
+
+// ErrNoLockTable is returned by Lock if the instance was applied by a version of
+// l0-setup that did not create a lock table; applying the instance creates it
+var ErrNoLockTable = errors.New("the instance does not have a lock table")
+
+// Lock is stored in the lock table of an instance while an operation that changes its state is running
+type Lock struct {
+	Table     string
+	ID        string
+	Owner     string
+	Operation string
+	Created   time.Time
+}
+
+// LockTable returns the name of the DynamoDB table that holds the lock of the instance
+func LockTable(instanceName string) string {
+	return fmt.Sprintf("l0-%s-lock", instanceName)
+}
+
+// Lock takes the remote lock of the instance. The lock is written only if it does not already exist,
+// so two users can never hold it at the same time.
+func (l *LocalInstance) Lock(d setup_aws.DynamoDBAPI, operation string) (*Lock, error) {
+	if err := l.assertExists(); err != nil {
+		return nil, err
+	}
+
+	id, err := newLockID()
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &Lock{
+		Table:     LockTable(l.Name),
+		ID:        id,
+		Owner:     lockOwner(),
+		Operation: operation,
+		Created:   time.Now(),
+	}
+
+	logrus.Debugf("Taking lock %s of instance '%s'", lock.ID, l.Name)
+	input := &dynamodb.PutItemInput{
+		TableName:           aws.String(lock.Table),
+		Item:                lock.item(),
+		ConditionExpression: aws.String("attribute_not_exists(ID)"),
+	}
+
+	if _, err := d.PutItem(input); err != nil {
+		switch awsErrorCode(err) {
+		case dynamodb.ErrCodeResourceNotFoundException:
+			return nil, ErrNoLockTable
+		case dynamodb.ErrCodeConditionalCheckFailedException:
+			current, err := getLock(d, lock.Table)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, l.lockedError(current)
+		}
+
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+// Unlock releases lock, unless it has since been replaced by another user's lock
+func (l *LocalInstance) Unlock(d setup_aws.DynamoDBAPI, lock *Lock) error {
+	logrus.Debugf("Releasing lock %s of instance '%s'", lock.ID, l.Name)
+	input := &dynamodb.DeleteItemInput{
+		TableName:                 aws.String(lock.Table),
+		Key:                       lockKey(),
+		ConditionExpression:       aws.String("LockID = :lock_id"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":lock_id": {S: aws.String(lock.ID)}},
+	}
+
+	if _, err := d.DeleteItem(input); err != nil {
+		switch awsErrorCode(err) {
+		case dynamodb.ErrCodeResourceNotFoundException:
+			// destroying an instance deletes its lock table, and the lock with it
+			return nil
+		case dynamodb.ErrCodeConditionalCheckFailedException:
+			logrus.Warningf("The lock of instance '%s' was removed or replaced while it was held", l.Name)
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ForceUnlock releases the lock of the instance, regardless of who holds it
+func (l *LocalInstance) ForceUnlock(d setup_aws.DynamoDBAPI) error {
+	if err := l.assertExists(); err != nil {
+		return err
+	}
+
+	table := LockTable(l.Name)
+	current, err := getLock(d, table)
+	if err != nil {
+		return err
+	}
+
+	if current == nil {
+		return fmt.Errorf("Instance '%s' is not locked", l.Name)
+	}
+
+	logrus.Infof("Releasing lock held by %s for '%s' since %s", current.Owner, current.Operation, current.Created.Format(time.RFC1123))
+	input := &dynamodb.DeleteItemInput{
+		TableName: aws.String(table),
+		Key:       lockKey(),
+	}
+
+	_, err = d.DeleteItem(input)
+	return err
+}
+
+func (l *LocalInstance) lockedError(current *Lock) error {
+	if current == nil {
+		return fmt.Errorf("Failed to lock instance '%s': the lock was released while it was being taken; try again", l.Name)
+	}
+
+	text := fmt.Sprintf("Instance '%s' is locked by %s ", l.Name, current.Owner)
+	text += fmt.Sprintf("for '%s' since %s.\n", current.Operation, current.Created.Format(time.RFC1123))
+	text += "Wait for that operation to complete, or if it is no longer running, "
+	text += fmt.Sprintf("run `l0-setup unlock --force %s` to release the lock.", l.Name)
+	return errors.New(text)
+}
+
+// getLock returns nil if the instance is not locked
+func getLock(d setup_aws.DynamoDBAPI, table string) (*Lock, error) {
+	input := &dynamodb.GetItemInput{
+		TableName:      aws.String(table),
+		Key:            lockKey(),
+		ConsistentRead: aws.Bool(true),
+	}
+
+	output, err := d.GetItem(input)
+	if err != nil {
+		if awsErrorCode(err) == dynamodb.ErrCodeResourceNotFoundException {
+			return nil, ErrNoLockTable
+		}
+
+		return nil, err
+	}
+
+	if len(output.Item) == 0 {
+		return nil, nil
+	}
+
+	lock := &Lock{Table: table}
+	for name, value := range map[string]*string{
+		"LockID":    &lock.ID,
+		"Owner":     &lock.Owner,
+		"Operation": &lock.Operation,
+	} {
+		if attribute, ok := output.Item[name]; ok {
+			*value = aws.StringValue(attribute.S)
+		}
+	}
+
+	if attribute, ok := output.Item["Created"]; ok {
+		created, err := time.Parse(time.RFC3339, aws.StringValue(attribute.S))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse the lock in table %s: %v", table, err)
+		}
+
+		lock.Created = created
+	}
+
+	return lock, nil
+}
+
+func (l *Lock) item() map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"ID":        {S: aws.String(lockItemID)},
+		"LockID":    {S: aws.String(l.ID)},
+		"Owner":     {S: aws.String(l.Owner)},
+		"Operation": {S: aws.String(l.Operation)},
+		"Created":   {S: aws.String(l.Created.UTC().Format(time.RFC3339))},
+	}
+}
+
+func lockKey() map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"ID": {S: aws.String(lockItemID)},
+	}
+}
+
+func awsErrorCode(err error) string {
+	if err, ok := err.(awserr.Error); ok {
+		return err.Code()
+	}
+
+	return ""
+}
+
+func newLockID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func lockOwner() string {
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+
+	if name == "" {
+		name = "unknown"
+	}
+
+	if host, err := os.Hostname(); err == nil {
+		return fmt.Sprintf("%s@%s", name, host)
+	}
+
+	return name
+}
@@ -0,0 +1,78 @@
+package instance
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "l0-setup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l := &LocalInstance{Name: "name", Dir: dir}
+	table := newFakeTable()
+
+	lock, err := l.Lock(table, "apply")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, lock.Table, "l0-name-lock")
+
+	// the lock cannot be taken while it is held
+	if _, err := l.Lock(table, "destroy"); err == nil {
+		t.Fatal("error was nil!")
+	}
+
+	current, err := getLock(table, lock.Table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, current.ID, lock.ID)
+	testutils.AssertEqual(t, current.Operation, "apply")
+
+	// a lock that was replaced is not released
+	if err := l.Unlock(table, &Lock{Table: lock.Table, ID: "other"}); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(table.items), 1)
+
+	if err := l.Unlock(table, lock); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(table.items), 0)
+}
+
+func TestForceUnlock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "l0-setup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l := &LocalInstance{Name: "name", Dir: dir}
+	table := newFakeTable()
+
+	if err := l.ForceUnlock(table); err == nil {
+		t.Fatal("error was nil!")
+	}
+
+	if _, err := l.Lock(table, "apply"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.ForceUnlock(table); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(table.items), 0)
+}
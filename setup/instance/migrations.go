@@ -0,0 +1,199 @@
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/blang/semver"
+	"github.com/quintilesims/layer0/common/models"
+	setup_aws "github.com/quintilesims/layer0/setup/aws"
+)
+
+// UpgradePaths lists the minor versions each minor version can be upgraded to directly.
+// Patch upgrades are always compatible; other upgrades must go through the intermediate versions.
+var UpgradePaths = map[string][]string{
+	"0.9":  {"0.10"},
+	"0.10": {"0.11"},
+}
+
+// Tables are the names of the DynamoDB tables of an instance
+type Tables struct {
+	Tags string `json:"tags"`
+	Jobs string `json:"jobs"`
+}
+
+// Migration updates the data in the tables of an instance for a new version.
+// Migrations run before the new version is applied, so the data must still work with the previous version.
+type Migration struct {
+	// Version is the first version that requires the migration
+	Version     string
+	Description string
+	Run         func(d setup_aws.DynamoDBAPI, tables Tables) error
+}
+
+// Migrations are run in order
+var Migrations = []Migration{
+	{
+		Version:     "0.11.0",
+		Description: "delete tags with empty keys or empty label keys, which the API no longer accepts",
+		Run:         deleteInvalidTags,
+	},
+	{
+		Version:     "0.11.0",
+		Description: "set the meta of jobs created without one to an empty map",
+		Run:         initializeJobMeta,
+	},
+}
+
+// checkUpgradePath returns an error if the instance cannot be upgraded from current to desired directly
+func checkUpgradePath(currentVersion, desiredVersion string) error {
+	current, err := parseVersion(currentVersion)
+	if err != nil {
+		text := fmt.Sprintf("Failed to parse current version ('%s'): %v\n", currentVersion, err)
+		text += "Use --force to disable upgrade checks"
+		return errors.New(text)
+	}
+
+	desired, err := parseVersion(desiredVersion)
+	if err != nil {
+		return fmt.Errorf("Failed to parse desired version: %v", err)
+	}
+
+	if desired.LT(current) {
+		return fmt.Errorf("Cannot downgrade from %s to %s; use `l0-setup rollback` to undo an upgrade", current, desired)
+	}
+
+	if current.Major == desired.Major && current.Minor == desired.Minor {
+		return nil
+	}
+
+	from := fmt.Sprintf("%d.%d", current.Major, current.Minor)
+	to := fmt.Sprintf("%d.%d", desired.Major, desired.Minor)
+	for _, path := range UpgradePaths[from] {
+		if path == to {
+			return nil
+		}
+	}
+
+	text := fmt.Sprintf("Upgrading from %s to %s is not supported", current, desired)
+	if paths := UpgradePaths[from]; len(paths) > 0 {
+		text += fmt.Sprintf("; upgrade to the latest %s.x release first", paths[len(paths)-1])
+	}
+
+	return errors.New(text)
+}
+
+// migrationsBetween returns the migrations required to upgrade from current to desired
+func migrationsBetween(currentVersion, desiredVersion string) ([]Migration, error) {
+	current, err := parseVersion(currentVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	desired, err := parseVersion(desiredVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := []Migration{}
+	for _, migration := range Migrations {
+		version := semver.MustParse(migration.Version)
+		if version.GT(current) && version.LTE(desired) {
+			migrations = append(migrations, migration)
+		}
+	}
+
+	return migrations, nil
+}
+
+func parseVersion(version string) (semver.Version, error) {
+	return semver.Make(strings.TrimPrefix(version, "v"))
+}
+
+func deleteInvalidTags(d setup_aws.DynamoDBAPI, tables Tables) error {
+	items, err := scanTable(d, tables.Tags)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		tags := item["Tags"]
+		if tags == nil || tags.M == nil {
+			continue
+		}
+
+		// only the invalid keys are removed, so tags the api writes while the migration runs are kept
+		names := map[string]*string{"#tags": aws.String("Tags")}
+		removals := []string{}
+		for key := range tags.M {
+			if strings.TrimSpace(key) == "" || key == models.LabelPrefix {
+				placeholder := fmt.Sprintf("#key%d", len(removals))
+				names[placeholder] = aws.String(key)
+				removals = append(removals, fmt.Sprintf("#tags.%s", placeholder))
+			}
+		}
+
+		if len(removals) == 0 {
+			continue
+		}
+
+		input := &dynamodb.UpdateItemInput{
+			TableName:                aws.String(tables.Tags),
+			Key:                      map[string]*dynamodb.AttributeValue{"EntityType": item["EntityType"], "EntityID": item["EntityID"]},
+			UpdateExpression:         aws.String("REMOVE " + strings.Join(removals, ", ")),
+			ExpressionAttributeNames: names,
+		}
+
+		if _, err := d.UpdateItem(input); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func initializeJobMeta(d setup_aws.DynamoDBAPI, tables Tables) error {
+	items, err := scanTable(d, tables.Jobs)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if meta, ok := item["Meta"]; ok && meta.M != nil {
+			continue
+		}
+
+		input := &dynamodb.UpdateItemInput{
+			TableName:                 aws.String(tables.Jobs),
+			Key:                       map[string]*dynamodb.AttributeValue{"JobID": item["JobID"]},
+			UpdateExpression:          aws.String("SET Meta = :meta"),
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":meta": {M: map[string]*dynamodb.AttributeValue{}}},
+		}
+
+		if _, err := d.UpdateItem(input); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanTable(d setup_aws.DynamoDBAPI, table string) ([]map[string]*dynamodb.AttributeValue, error) {
+	items := []map[string]*dynamodb.AttributeValue{}
+	input := &dynamodb.ScanInput{
+		TableName:      aws.String(table),
+		ConsistentRead: aws.Bool(true),
+	}
+
+	if err := d.ScanPages(input, func(output *dynamodb.ScanOutput, lastPage bool) bool {
+		items = append(items, output.Items...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
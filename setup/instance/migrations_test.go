@@ -0,0 +1,259 @@
+package instance
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+// fakeTable is a DynamoDB table keyed by a single 'ID' attribute. It supports the condition expressions
+// 'attribute_not_exists(ID)' and '<attribute> = :<value>', and update expressions that remove map keys,
+// e.g. 'REMOVE #tags.#key0', for which items are matched by every attribute of the key.
+type fakeTable struct {
+	items map[string]map[string]*dynamodb.AttributeValue
+}
+
+func (f *fakeTable) checkCondition(condition *string, id string, values map[string]*dynamodb.AttributeValue) error {
+	if condition == nil {
+		return nil
+	}
+
+	item, exists := f.items[id]
+	var ok bool
+	switch expression := aws.StringValue(condition); {
+	case expression == "attribute_not_exists(ID)":
+		ok = !exists
+	case strings.Contains(expression, " = "):
+		split := strings.Split(expression, " = ")
+		ok = exists && aws.StringValue(item[split[0]].S) == aws.StringValue(values[split[1]].S)
+	default:
+		return fmt.Errorf("Unsupported condition '%s'", expression)
+	}
+
+	if !ok {
+		return awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "The conditional request failed", nil)
+	}
+
+	return nil
+}
+
+func newFakeTable(items ...map[string]*dynamodb.AttributeValue) *fakeTable {
+	f := &fakeTable{items: map[string]map[string]*dynamodb.AttributeValue{}}
+	for _, item := range items {
+		f.items[aws.StringValue(item["ID"].S)] = item
+	}
+
+	return f
+}
+
+func (f *fakeTable) DeleteItem(input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error) {
+	id := aws.StringValue(input.Key["ID"].S)
+	if err := f.checkCondition(input.ConditionExpression, id, input.ExpressionAttributeValues); err != nil {
+		return nil, err
+	}
+
+	delete(f.items, id)
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (f *fakeTable) DescribeTable(*dynamodb.DescribeTableInput) (*dynamodb.DescribeTableOutput, error) {
+	output := &dynamodb.DescribeTableOutput{
+		Table: &dynamodb.TableDescription{
+			KeySchema: []*dynamodb.KeySchemaElement{{AttributeName: aws.String("ID")}},
+		},
+	}
+
+	return output, nil
+}
+
+func (f *fakeTable) GetItem(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{Item: f.items[aws.StringValue(input.Key["ID"].S)]}, nil
+}
+
+func (f *fakeTable) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	id := aws.StringValue(input.Item["ID"].S)
+	if err := f.checkCondition(input.ConditionExpression, id, input.ExpressionAttributeValues); err != nil {
+		return nil, err
+	}
+
+	f.items[id] = input.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (f *fakeTable) ScanPages(input *dynamodb.ScanInput, fn func(*dynamodb.ScanOutput, bool) bool) error {
+	output := &dynamodb.ScanOutput{}
+	for _, item := range f.items {
+		output.Items = append(output.Items, item)
+	}
+
+	fn(output, true)
+	return nil
+}
+
+func (f *fakeTable) UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+	expression := aws.StringValue(input.UpdateExpression)
+	if !strings.HasPrefix(expression, "REMOVE ") {
+		return nil, fmt.Errorf("Unsupported update expression '%s'", expression)
+	}
+
+	for _, item := range f.items {
+		matches := true
+		for attribute, value := range input.Key {
+			if item[attribute] == nil || aws.StringValue(item[attribute].S) != aws.StringValue(value.S) {
+				matches = false
+			}
+		}
+
+		if !matches {
+			continue
+		}
+
+		for _, path := range strings.Split(strings.TrimPrefix(expression, "REMOVE "), ", ") {
+			split := strings.Split(path, ".")
+			attribute := aws.StringValue(input.ExpressionAttributeNames[split[0]])
+			delete(item[attribute].M, aws.StringValue(input.ExpressionAttributeNames[split[1]]))
+		}
+	}
+
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+func TestCheckUpgradePath(t *testing.T) {
+	cases := map[string]bool{
+		"v0.10.1 -> v0.10.4": true,
+		"v0.10.4 -> v0.11.0": true,
+		"0.9.2 -> 0.10.0":    true,
+		"v0.9.2 -> v0.11.0":  false,
+		"v0.11.0 -> v0.10.4": false,
+		"v0.11.0 -> v1.0.0":  false,
+		"latest -> v0.11.0":  false,
+	}
+
+	for c, expected := range cases {
+		var current, desired string
+		fmt.Sscanf(c, "%s -> %s", &current, &desired)
+
+		err := checkUpgradePath(current, desired)
+		if expected && err != nil {
+			t.Errorf("%s: unexpected error: %v", c, err)
+		}
+
+		if !expected && err == nil {
+			t.Errorf("%s: error was nil!", c)
+		}
+	}
+}
+
+func TestMigrationsBetween(t *testing.T) {
+	migrations, err := migrationsBetween("v0.10.4", "v0.11.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(migrations), 2)
+
+	migrations, err = migrationsBetween("v0.11.0", "v0.11.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(migrations), 0)
+}
+
+func TestDeleteInvalidTags(t *testing.T) {
+	tags := func(keys ...string) *dynamodb.AttributeValue {
+		m := map[string]*dynamodb.AttributeValue{}
+		for _, key := range keys {
+			m[key] = &dynamodb.AttributeValue{S: aws.String("value")}
+		}
+
+		return &dynamodb.AttributeValue{M: m}
+	}
+
+	item := func(id string, tags *dynamodb.AttributeValue) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{
+			"ID":         {S: aws.String(id)},
+			"EntityType": {S: aws.String("service")},
+			"EntityID":   {S: aws.String(id)},
+			"Tags":       tags,
+		}
+	}
+
+	table := newFakeTable(
+		item("id1", tags("name", "", " ", "label:")),
+		item("id2", tags("name", "label:team")),
+	)
+
+	if err := deleteInvalidTags(table, Tables{Tags: "tags"}); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(table.items["id1"]["Tags"].M), 1)
+	testutils.AssertEqual(t, len(table.items["id2"]["Tags"].M), 2)
+	if _, ok := table.items["id1"]["Tags"].M["name"]; !ok {
+		t.Fatal("Valid tag 'name' was removed")
+	}
+}
+
+func TestRestoreTable(t *testing.T) {
+	item := func(id, value string) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{"ID": {S: aws.String(id)}, "Value": {S: aws.String(value)}}
+	}
+
+	// backups are stored as json
+	data, err := json.Marshal(tableItems{item("id1", "before"), item("id2", "before")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var backup tableItems
+	if err := json.Unmarshal(data, &backup); err != nil {
+		t.Fatal(err)
+	}
+
+	table := newFakeTable(item("id1", "after"), item("id3", "after"))
+	if err := restoreTable(table, "table", backup, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(table.items), 2)
+	testutils.AssertEqual(t, aws.StringValue(table.items["id1"]["Value"].S), "before")
+	testutils.AssertEqual(t, aws.StringValue(table.items["id2"]["Value"].S), "before")
+}
+
+func TestRestoreTable_touched(t *testing.T) {
+	item := func(id, value string) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{"ID": {S: aws.String(id)}, "Value": {S: aws.String(value)}}
+	}
+
+	backup := tableItems{item("id1", "before"), item("id2", "before"), item("id3", "before")}
+	table := newFakeTable(backup...)
+
+	// the migration changes id1, and creates id4
+	recorder := newRecordingDynamoDB(table)
+	for _, item := range []map[string]*dynamodb.AttributeValue{item("id1", "migrated"), item("id4", "migrated")} {
+		if _, err := recorder.PutItem(&dynamodb.PutItemInput{TableName: aws.String("table"), Item: item}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the api changes id2, and creates id5, after the upgrade
+	table.items["id2"] = item("id2", "after")
+	table.items["id5"] = item("id5", "after")
+
+	if err := restoreTable(table, "table", backup, recorder.touched["table"]); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(table.items), 4)
+	testutils.AssertEqual(t, aws.StringValue(table.items["id1"]["Value"].S), "before")
+	testutils.AssertEqual(t, aws.StringValue(table.items["id2"]["Value"].S), "after")
+	testutils.AssertEqual(t, aws.StringValue(table.items["id3"]["Value"].S), "before")
+	testutils.AssertEqual(t, aws.StringValue(table.items["id5"]["Value"].S), "after")
+}
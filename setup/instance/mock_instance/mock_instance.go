@@ -5,10 +5,11 @@
 package mock_instance
 
 import (
-	reflect "reflect"
-
 	s3iface "github.com/aws/aws-sdk-go/service/s3/s3iface"
 	gomock "github.com/golang/mock/gomock"
+	aws "github.com/quintilesims/layer0/setup/aws"
+	instance "github.com/quintilesims/layer0/setup/instance"
+	reflect "reflect"
 )
 
 // MockInstance is a mock of Instance interface
@@ -58,6 +59,18 @@ func (mr *MockInstanceMockRecorder) Destroy(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockInstance)(nil).Destroy), arg0)
 }
 
+// ForceUnlock mocks base method
+func (m *MockInstance) ForceUnlock(arg0 aws.DynamoDBAPI) error {
+	ret := m.ctrl.Call(m, "ForceUnlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceUnlock indicates an expected call of ForceUnlock
+func (mr *MockInstanceMockRecorder) ForceUnlock(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceUnlock", reflect.TypeOf((*MockInstance)(nil).ForceUnlock), arg0)
+}
+
 // Init mocks base method
 func (m *MockInstance) Init(arg0 string, arg1 map[string]interface{}) error {
 	ret := m.ctrl.Call(m, "Init", arg0, arg1)
@@ -70,6 +83,32 @@ func (mr *MockInstanceMockRecorder) Init(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockInstance)(nil).Init), arg0, arg1)
 }
 
+// IsApplied mocks base method
+func (m *MockInstance) IsApplied() (bool, error) {
+	ret := m.ctrl.Call(m, "IsApplied")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsApplied indicates an expected call of IsApplied
+func (mr *MockInstanceMockRecorder) IsApplied() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsApplied", reflect.TypeOf((*MockInstance)(nil).IsApplied))
+}
+
+// Lock mocks base method
+func (m *MockInstance) Lock(arg0 aws.DynamoDBAPI, arg1 string) (*instance.Lock, error) {
+	ret := m.ctrl.Call(m, "Lock", arg0, arg1)
+	ret0, _ := ret[0].(*instance.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lock indicates an expected call of Lock
+func (mr *MockInstanceMockRecorder) Lock(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockInstance)(nil).Lock), arg0, arg1)
+}
+
 // Output mocks base method
 func (m *MockInstance) Output(arg0 string) (string, error) {
 	ret := m.ctrl.Call(m, "Output", arg0)
@@ -96,15 +135,15 @@ func (mr *MockInstanceMockRecorder) Plan() *gomock.Call {
 }
 
 // Pull mocks base method
-func (m *MockInstance) Pull(arg0 s3iface.S3API) error {
-	ret := m.ctrl.Call(m, "Pull", arg0)
+func (m *MockInstance) Pull(arg0 s3iface.S3API, arg1 bool) error {
+	ret := m.ctrl.Call(m, "Pull", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Pull indicates an expected call of Pull
-func (mr *MockInstanceMockRecorder) Pull(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockInstance)(nil).Pull), arg0)
+func (mr *MockInstanceMockRecorder) Pull(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockInstance)(nil).Pull), arg0, arg1)
 }
 
 // Push mocks base method
@@ -119,6 +158,18 @@ func (mr *MockInstanceMockRecorder) Push(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockInstance)(nil).Push), arg0)
 }
 
+// Rollback mocks base method
+func (m *MockInstance) Rollback(arg0 aws.DynamoDBAPI, arg1 bool) error {
+	ret := m.ctrl.Call(m, "Rollback", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback
+func (mr *MockInstanceMockRecorder) Rollback(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockInstance)(nil).Rollback), arg0, arg1)
+}
+
 // Set mocks base method
 func (m *MockInstance) Set(arg0 map[string]interface{}) error {
 	ret := m.ctrl.Call(m, "Set", arg0)
@@ -131,14 +182,39 @@ func (mr *MockInstanceMockRecorder) Set(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockInstance)(nil).Set), arg0)
 }
 
+// Status mocks base method
+func (m *MockInstance) Status(arg0 s3iface.S3API) (*instance.InstanceStatus, error) {
+	ret := m.ctrl.Call(m, "Status", arg0)
+	ret0, _ := ret[0].(*instance.InstanceStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status
+func (mr *MockInstanceMockRecorder) Status(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockInstance)(nil).Status), arg0)
+}
+
+// Unlock mocks base method
+func (m *MockInstance) Unlock(arg0 aws.DynamoDBAPI, arg1 *instance.Lock) error {
+	ret := m.ctrl.Call(m, "Unlock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock
+func (mr *MockInstanceMockRecorder) Unlock(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockInstance)(nil).Unlock), arg0, arg1)
+}
+
 // Upgrade mocks base method
-func (m *MockInstance) Upgrade(arg0 string, arg1 bool) error {
-	ret := m.ctrl.Call(m, "Upgrade", arg0, arg1)
+func (m *MockInstance) Upgrade(arg0 aws.DynamoDBAPI, arg1 string, arg2, arg3 bool) error {
+	ret := m.ctrl.Call(m, "Upgrade", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Upgrade indicates an expected call of Upgrade
-func (mr *MockInstanceMockRecorder) Upgrade(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upgrade", reflect.TypeOf((*MockInstance)(nil).Upgrade), arg0, arg1)
+func (mr *MockInstanceMockRecorder) Upgrade(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upgrade", reflect.TypeOf((*MockInstance)(nil).Upgrade), arg0, arg1, arg2, arg3)
 }
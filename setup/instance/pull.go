@@ -1,9 +1,11 @@
 package instance
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
@@ -12,7 +14,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 )
 
-func (l *LocalInstance) Pull(s s3iface.S3API) error {
+// Pull copies the instance from S3. Files with local changes that have not been pushed
+// are only overwritten if force is set.
+func (l *LocalInstance) Pull(s s3iface.S3API, force bool) error {
 	buckets, err := listRemoteInstanceBuckets(s)
 	if err != nil {
 		return err
@@ -39,6 +43,14 @@ func (l *LocalInstance) Pull(s s3iface.S3API) error {
 		return err
 	}
 
+	synced, err := l.loadSyncedHashes()
+	if err != nil {
+		return err
+	}
+
+	// download everything before writing anything, so nothing is written if there are local changes
+	files := map[string][]byte{}
+	changed := []string{}
 	for _, content := range output.Contents {
 		path := strings.Replace(aws.StringValue(content.Key), "terraform", l.Dir, 1)
 		logrus.Infof("Pulling s3://%s/%s to %s", bucket, aws.StringValue(content.Key), path)
@@ -62,17 +74,47 @@ func (l *LocalInstance) Pull(s s3iface.S3API) error {
 			continue
 		}
 
-		// otherwise, write the file locally
 		data, err := ioutil.ReadAll(output.Body)
 		if err != nil {
 			return err
 		}
 		defer output.Body.Close()
 
+		if !force {
+			hasChanges, err := l.hasLocalChanges(path, data, synced)
+			if err != nil {
+				return err
+			}
+
+			if hasChanges {
+				changed = append(changed, path)
+			}
+		}
+
+		files[path] = data
+	}
+
+	if len(changed) > 0 {
+		text := fmt.Sprintf("The following files of instance '%s' have local changes that have not been pushed:\n", l.Name)
+		for _, path := range changed {
+			text += fmt.Sprintf("\t%s\n", path)
+		}
+
+		text += fmt.Sprintf("Run `l0-setup push %s` to keep them, or use --force to overwrite them", l.Name)
+		return errors.New(text)
+	}
+
+	// otherwise, write the files locally
+	for path, data := range files {
+		// files can be nested, e.g. in the 'backups' directory
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return err
+		}
+
 		if err := ioutil.WriteFile(path, data, 0600); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return l.recordSyncedHashes(files)
 }
@@ -23,12 +23,13 @@ func (l *LocalInstance) Push(s s3iface.S3API) error {
 		return err
 	}
 
+	pushed := map[string][]byte{}
 	pushFiles := func(path string, f os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if f.IsDir() || strings.Contains(path, ".terraform") {
+		if f.IsDir() || strings.Contains(path, ".terraform") || f.Name() == SYNC_FILE {
 			return nil
 		}
 
@@ -51,8 +52,13 @@ func (l *LocalInstance) Push(s s3iface.S3API) error {
 			return err
 		}
 
+		pushed[path] = data
 		return nil
 	}
 
-	return filepath.Walk(l.Dir, pushFiles)
+	if err := filepath.Walk(l.Dir, pushFiles); err != nil {
+		return err
+	}
+
+	return l.recordSyncedHashes(pushed)
 }
@@ -0,0 +1,191 @@
+package instance
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/blang/semver"
+	"github.com/quintilesims/layer0/cli/client"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/waitutils"
+)
+
+const STATE_FILE = "terraform.tfstate"
+
+type InstanceStatus struct {
+	Name          string
+	Layer0Version string
+	// APIVersion is the version reported by the deployed API, or empty if it could not be reached
+	APIVersion     string
+	Drift          bool
+	PlanSummary    string
+	OutdatedInputs []string
+	// Applied is false if the instance has not been applied, in which case it has no remote state or API
+	Applied bool
+	// LocalStateModified and RemoteStateModified are zero if the state file does not exist
+	LocalStateModified  time.Time
+	RemoteStateModified time.Time
+	// LocalStateMD5 and RemoteStateETag are empty if the state file does not exist.
+	// Push uploads each file in a single part, so the ETag of the remote state is the MD5 of its content.
+	LocalStateMD5   string
+	RemoteStateETag string
+}
+
+// RemoteStateDiffers returns true if the state in S3 does not have the same content as the local state,
+// in which case a push could overwrite someone else's changes
+func (i *InstanceStatus) RemoteStateDiffers() bool {
+	return i.RemoteStateETag != "" && i.RemoteStateETag != i.LocalStateMD5
+}
+
+func (l *LocalInstance) Status(s s3iface.S3API) (*InstanceStatus, error) {
+	if err := l.assertExists(); err != nil {
+		return nil, err
+	}
+
+	config, err := l.loadLayer0Config()
+	if err != nil {
+		return nil, err
+	}
+
+	module := config.Modules["layer0"]
+	status := &InstanceStatus{
+		Name:           l.Name,
+		Layer0Version:  fmt.Sprintf("%v", module[INPUT_LAYER0_VERSION]),
+		OutdatedInputs: outdatedInputs(module),
+	}
+
+	drift, summary, err := l.Terraform.PlanChanges(l.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	status.Drift = drift
+	status.PlanSummary = summary
+
+	statePath := fmt.Sprintf("%s/%s", l.Dir, STATE_FILE)
+	if info, err := os.Stat(statePath); err == nil {
+		status.LocalStateModified = info.ModTime()
+
+		data, err := ioutil.ReadFile(statePath)
+		if err != nil {
+			return nil, err
+		}
+
+		status.LocalStateMD5 = fmt.Sprintf("%x", md5.Sum(data))
+	}
+
+	applied, err := l.IsApplied()
+	if err != nil {
+		return nil, err
+	}
+
+	// the remaining fields need the outputs of an applied instance
+	status.Applied = applied
+	if !applied {
+		return status, nil
+	}
+
+	etag, remoteModified, err := l.remoteState(s)
+	if err != nil {
+		return nil, err
+	}
+
+	status.RemoteStateETag = etag
+	status.RemoteStateModified = remoteModified
+
+	apiVersion, err := l.apiVersion()
+	if err != nil {
+		logrus.Warningf("Failed to get the API version of instance '%s': %v", l.Name, err)
+	}
+
+	status.APIVersion = apiVersion
+	return status, nil
+}
+
+// remoteState returns the ETag and modified time of the state in S3, which are empty if the instance has never been pushed
+func (l *LocalInstance) remoteState(s s3iface.S3API) (string, time.Time, error) {
+	bucket, err := l.Output(OUTPUT_S3_BUCKET)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	input := &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(fmt.Sprintf("terraform/%s", STATE_FILE)),
+	}
+
+	output, err := s.HeadObject(input)
+	if err != nil {
+		if err, ok := err.(awserr.Error); ok && err.Code() == "NotFound" {
+			return "", time.Time{}, nil
+		}
+
+		return "", time.Time{}, err
+	}
+
+	return strings.Trim(aws.StringValue(output.ETag), "\""), aws.TimeValue(output.LastModified), nil
+}
+
+func (l *LocalInstance) apiVersion() (string, error) {
+	endpoint, err := l.Output(OUTPUT_ENDPOINT)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := l.Output(OUTPUT_TOKEN)
+	if err != nil {
+		return "", err
+	}
+
+	apiClient := client.NewAPIClient(client.Config{
+		Endpoint:  endpoint,
+		Token:     token,
+		VerifySSL: config.ShouldVerifySSL(),
+		Clock:     waitutils.RealClock{},
+	})
+
+	return apiClient.GetVersion()
+}
+
+// outdatedInputs returns a description of each module input that is missing from the instance's configuration,
+// or that is set to an older version than this l0-setup binary
+func outdatedInputs(module map[string]interface{}) []string {
+	outdated := []string{}
+	for _, input := range Layer0ModuleInputs {
+		current, ok := module[input.Name]
+		if !ok {
+			outdated = append(outdated, fmt.Sprintf("%s (not set)", input.Name))
+			continue
+		}
+
+		if input.Name == INPUT_LAYER0_VERSION && isOlderVersion(fmt.Sprintf("%v", current), fmt.Sprintf("%v", input.Default)) {
+			outdated = append(outdated, fmt.Sprintf("%s (current: %v, latest: %v)", input.Name, current, input.Default))
+		}
+	}
+
+	return outdated
+}
+
+// isOlderVersion returns false if either version is not a semantic version, e.g. 'latest'
+func isOlderVersion(current, latest string) bool {
+	c, err := semver.Make(strings.TrimPrefix(current, "v"))
+	if err != nil {
+		return false
+	}
+
+	l, err := semver.Make(strings.TrimPrefix(latest, "v"))
+	if err != nil {
+		return false
+	}
+
+	return c.LT(l)
+}
@@ -0,0 +1,89 @@
+package instance
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// SYNC_FILE holds the MD5 of each file of the instance as of the last time it was pulled or pushed.
+// It is local to each copy of the instance, so it is never pushed.
+const SYNC_FILE = ".l0-sync.json"
+
+// loadSyncedHashes returns the hashes recorded in the sync file, keyed by the path of each file
+// relative to the instance directory
+func (l *LocalInstance) loadSyncedHashes() (map[string]string, error) {
+	hashes := map[string]string{}
+
+	data, err := ioutil.ReadFile(filepath.Join(l.Dir, SYNC_FILE))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return hashes, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &hashes); err != nil {
+		return nil, fmt.Errorf("Failed to parse %s of instance '%s': %v", SYNC_FILE, l.Name, err)
+	}
+
+	return hashes, nil
+}
+
+// recordSyncedHashes adds the hashes of files, keyed by absolute path, to the sync file
+func (l *LocalInstance) recordSyncedHashes(files map[string][]byte) error {
+	hashes, err := l.loadSyncedHashes()
+	if err != nil {
+		return err
+	}
+
+	for path, data := range files {
+		rel, err := filepath.Rel(l.Dir, path)
+		if err != nil {
+			return err
+		}
+
+		hashes[rel] = hash(data)
+	}
+
+	data, err := json.MarshalIndent(hashes, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(l.Dir, SYNC_FILE), data, 0600)
+}
+
+// hasLocalChanges returns true if the file at path differs from both the remote copy and the
+// content it had when it was last pulled or pushed. Files without a record, e.g. because the
+// instance was pulled by an older version of l0-setup, are treated as changed.
+func (l *LocalInstance) hasLocalChanges(path string, remote []byte, synced map[string]string) (bool, error) {
+	local, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if hash(local) == hash(remote) {
+		return false, nil
+	}
+
+	rel, err := filepath.Rel(l.Dir, path)
+	if err != nil {
+		return false, err
+	}
+
+	recorded, ok := synced[rel]
+	return !ok || recorded != hash(local), nil
+}
+
+func hash(data []byte) string {
+	return fmt.Sprintf("%x", md5.Sum(data))
+}
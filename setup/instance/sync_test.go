@@ -0,0 +1,72 @@
+package instance
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestHasLocalChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "l0-setup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l := &LocalInstance{Name: "name", Dir: dir}
+	path := filepath.Join(dir, "main.tf")
+
+	// files that do not exist locally have no changes
+	changed, err := l.hasLocalChanges(path, []byte("remote"), map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, changed, false)
+
+	if err := ioutil.WriteFile(path, []byte("pulled"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.recordSyncedHashes(map[string][]byte{path: []byte("pulled")}); err != nil {
+		t.Fatal(err)
+	}
+
+	synced, err := l.loadSyncedHashes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]bool{
+		// the file was changed remotely, but not locally
+		"pulled": false,
+		// the file was changed locally, but matches the remote copy
+		"remote": false,
+		// the file was changed both locally and remotely
+		"local": true,
+	}
+
+	for local, expected := range cases {
+		if err := ioutil.WriteFile(path, []byte(local), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		changed, err := l.hasLocalChanges(path, []byte("remote"), synced)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertEqual(t, changed, expected)
+	}
+
+	// files without a record are treated as changed
+	changed, err = l.hasLocalChanges(path, []byte("remote"), map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, changed, true)
+}
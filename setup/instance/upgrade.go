@@ -2,13 +2,19 @@ package instance
 
 import (
 	"fmt"
-	"strings"
+	"os"
+	"time"
 
-	"github.com/blang/semver"
+	"github.com/Sirupsen/logrus"
+	setup_aws "github.com/quintilesims/layer0/setup/aws"
 	"github.com/quintilesims/layer0/setup/terraform"
 )
 
-func (l *LocalInstance) Upgrade(version string, force bool) error {
+// Upgrade changes the version of the instance. Unless force is set, the upgrade must be in UpgradePaths.
+// If the instance has been applied, its configuration, state, and tables are backed up
+// and the Migrations for the new version are run; `l0-setup rollback` restores the backup.
+// If dryRun is set, the checks are run and the planned changes are printed, but nothing is changed.
+func (l *LocalInstance) Upgrade(d setup_aws.DynamoDBAPI, version string, force, dryRun bool) error {
 	if err := l.assertExists(); err != nil {
 		return err
 	}
@@ -25,10 +31,34 @@ func (l *LocalInstance) Upgrade(version string, force bool) error {
 	}
 
 	module := config.Modules["layer0"]
+	current, _ := module[INPUT_LAYER0_VERSION].(string)
 
-	// only patch upgrades are allowed
-	if current, ok := module["layer0_version"]; ok && !force {
-		if err := assertPatchUpgrade(current.(string), version); err != nil {
+	if current != "" && !force {
+		if err := checkUpgradePath(current, version); err != nil {
+			return err
+		}
+	}
+
+	migrations, err := migrationsBetween(current, version)
+	if err != nil {
+		logrus.Warningf("Skipping migrations: cannot compare versions '%s' and '%s': %v", current, version, err)
+	}
+
+	// only instances that have been applied have tables to back up and migrate;
+	// a destroyed instance keeps its state file, but the file has no resources
+	applied, err := l.IsApplied()
+	if err != nil {
+		return err
+	}
+
+	var tables *Tables
+	if applied {
+		if !dryRun && d == nil {
+			return fmt.Errorf("Instance '%s' has been applied, but no DynamoDB client was given to back up its tables", l.Name)
+		}
+
+		tables, err = l.tables()
+		if err != nil {
 			return err
 		}
 	}
@@ -39,6 +69,11 @@ func (l *LocalInstance) Upgrade(version string, force bool) error {
 		INPUT_LAYER0_VERSION: version,
 	}
 
+	if dryRun {
+		printUpgradePlan(l.Name, module, inputValues, tables, migrations)
+		return nil
+	}
+
 	for input, value := range inputValues {
 		if current, ok := module[input]; ok && current != value && !force {
 			fmt.Printf("This will update the '%s' input \n\tFrom: [%s] \n\tTo:   [%s]\n\n", input, current, value)
@@ -51,48 +86,157 @@ func (l *LocalInstance) Upgrade(version string, force bool) error {
 		module[input] = value
 	}
 
+	backup, err := l.backup(d, current, tables)
+	if err != nil {
+		return fmt.Errorf("Failed to back up instance '%s': %v", l.Name, err)
+	}
+
+	logrus.Infof("Backed up instance '%s' to %s", l.Name, backup.Dir)
+
 	// save the terraform config as ~/.layer0/<instance>/main.tf.json
 	path := fmt.Sprintf("%s/main.tf.json", l.Dir)
 	if err := terraform.WriteConfig(path, config); err != nil {
-		return err
+		return l.upgradeError(err)
 	}
 
 	// run `terraform get` to download terraform modules
 	if err := l.Terraform.Get(l.Dir); err != nil {
-		return err
+		return l.upgradeError(err)
 	}
 
 	// validate the terraform configuration
 	if err := l.Terraform.Validate(l.Dir); err != nil {
-		return err
+		return l.upgradeError(err)
+	}
+
+	if tables != nil {
+		// the touched items are recorded even if a migration fails, so a rollback restores them
+		recorder := newRecordingDynamoDB(d)
+		var migrationErr error
+		for _, migration := range migrations {
+			logrus.Infof("Running migration for %s: %s", migration.Version, migration.Description)
+			if migrationErr = migration.Run(recorder, *tables); migrationErr != nil {
+				break
+			}
+		}
+
+		if err := backup.recordTouched(recorder.touched); err != nil {
+			return l.upgradeError(err)
+		}
+
+		if migrationErr != nil {
+			return l.upgradeError(migrationErr)
+		}
 	}
 
 	return nil
 }
 
-func assertPatchUpgrade(currentVersion, desiredVersion string) error {
-	currentVersion = strings.TrimPrefix(currentVersion, "v")
-	desiredVersion = strings.TrimPrefix(desiredVersion, "v")
+// Rollback restores the configuration of the instance, and the items in its tables touched by the migrations,
+// from the backup taken by the last upgrade. Changes made to those items since the upgrade are lost, so the user
+// must confirm the rollback unless force is set. The resources of the instance are not changed until it is applied.
+func (l *LocalInstance) Rollback(d setup_aws.DynamoDBAPI, force bool) error {
+	if err := l.assertExists(); err != nil {
+		return err
+	}
 
-	current, err := semver.Make(currentVersion)
+	backup, err := l.latestBackup()
 	if err != nil {
-		text := fmt.Sprintf("Failed to parse current version ('%s'): %v\n", currentVersion, err)
-		text += "Use --force to disable semantic version checking"
-		return fmt.Errorf(text)
+		return err
+	}
+
+	if backup == nil {
+		return fmt.Errorf("Instance '%s' has no backups to roll back to", l.Name)
 	}
 
-	desired, err := semver.Make(desiredVersion)
+	touched, err := backup.loadTouched()
 	if err != nil {
-		return fmt.Errorf("Failed to parse desired version: %v", err)
+		return err
 	}
 
-	if current.Major != desired.Major {
-		return fmt.Errorf("Cannot change Major versions (current: %s)", current.String())
+	if !force && !confirmRollback(l.Name, backup, touched) {
+		return fmt.Errorf("Rollback of instance '%s' was cancelled", l.Name)
 	}
 
-	if current.Minor != desired.Minor {
-		return fmt.Errorf("Cannot change Minor versions (current: %s)", current.String())
+	logrus.Infof("Rolling back instance '%s' to version %s from %s", l.Name, backup.Version, backup.Dir)
+	if err := l.restore(d, backup, touched); err != nil {
+		return err
 	}
 
-	return nil
+	// run `terraform get` to download the previous terraform modules
+	if err := l.Terraform.Get(l.Dir); err != nil {
+		return err
+	}
+
+	// remove the backup so the next rollback restores the upgrade before it
+	return os.RemoveAll(backup.Dir)
+}
+
+// confirmRollback describes the changes a rollback makes and returns true if the user accepts them
+func confirmRollback(name string, b *Backup, touched touchedItems) bool {
+	fmt.Printf("Rolling back instance '%s' will:\n", name)
+	fmt.Printf("\trestore the configuration of version %s from %s\n", b.Version, b.Dir)
+
+	if b.Tables != nil {
+		if touched == nil {
+			fmt.Printf("\t[WARNING] replace every item in the tables %s and %s with the backup from %s; ",
+				b.Tables.Tags, b.Tables.Jobs, b.Created.Format(time.RFC1123))
+			fmt.Printf("tags and jobs created or changed since then will be lost\n")
+		}
+
+		for _, table := range []string{b.Tables.Tags, b.Tables.Jobs} {
+			if count := len(touched[table]); count > 0 {
+				fmt.Printf("\t[WARNING] restore the %d items in table %s changed by the upgrade's migrations; ", count, table)
+				fmt.Printf("changes made to those items since the upgrade will be lost\n")
+			}
+		}
+	}
+
+	fmt.Printf("\nThe resources of the instance are not changed until you run `l0-setup apply %s`, ", name)
+	fmt.Printf("which must follow the rollback.\n")
+	fmt.Printf("Type 'yes' to roll back: ")
+
+	var input string
+	fmt.Scanln(&input)
+
+	return input == "yes"
+}
+
+func (l *LocalInstance) tables() (*Tables, error) {
+	tags, err := l.Output(OUTPUT_AWS_DYNAMO_TAG_TABLE)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := l.Output(OUTPUT_AWS_DYNAMO_JOB_TABLE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tables{Tags: tags, Jobs: jobs}, nil
+}
+
+func (l *LocalInstance) upgradeError(err error) error {
+	return fmt.Errorf("Upgrade failed: %v\nRun `l0-setup rollback %s` to restore the instance from its backup", err, l.Name)
+}
+
+func printUpgradePlan(name string, module terraform.Module, inputValues map[string]string, tables *Tables, migrations []Migration) {
+	fmt.Printf("Upgrading instance '%s' would:\n", name)
+	for _, input := range []string{INPUT_SOURCE, INPUT_LAYER0_VERSION} {
+		fmt.Printf("\tupdate the '%s' input from [%v] to [%s]\n", input, module[input], inputValues[input])
+	}
+
+	if tables == nil {
+		fmt.Printf("\tback up the configuration (the instance has not been applied, so there is no state or data)\n")
+		return
+	}
+
+	fmt.Printf("\tback up the configuration, the state, and the tables %s and %s\n", tables.Tags, tables.Jobs)
+	for _, migration := range migrations {
+		fmt.Printf("\trun the migration for %s: %s\n", migration.Version, migration.Description)
+	}
+
+	if len(migrations) == 0 {
+		fmt.Printf("\trun no migrations\n")
+	}
 }
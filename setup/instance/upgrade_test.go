@@ -0,0 +1,27 @@
+package instance
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestUpgrade_dryRunDestroyed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "l0-setup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// a destroyed instance keeps a state file without resources, so it has no tables to look up
+	state := `{"modules": [{"resources": {}}]}`
+	if err := ioutil.WriteFile(fmt.Sprintf("%s/%s", dir, STATE_FILE), []byte(state), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &LocalInstance{Name: "name", Dir: dir}
+	if err := l.Upgrade(nil, "v0.10.0", false, true); err != nil {
+		t.Fatal(err)
+	}
+}
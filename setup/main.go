@@ -45,7 +45,10 @@ func main() {
 		commandFactory.Endpoint(),
 		commandFactory.Push(),
 		commandFactory.Pull(),
+		commandFactory.Rollback(),
 		commandFactory.Set(),
+		commandFactory.Status(),
+		commandFactory.Unlock(),
 		commandFactory.Upgrade(),
 	}
 
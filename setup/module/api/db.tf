@@ -76,3 +76,16 @@ resource "aws_appautoscaling_policy" "jobs_table_read_policy" {
     target_value = 70
   }
 }
+
+# l0-setup holds a lock in this table while it changes the instance
+resource "aws_dynamodb_table" "lock" {
+  name           = "l0-${var.name}-lock"
+  read_capacity  = 1
+  write_capacity = 1
+  hash_key       = "ID"
+
+  attribute {
+    name = "ID"
+    type = "S"
+  }
+}
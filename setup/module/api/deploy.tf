@@ -26,5 +26,9 @@ data "template_file" "container_definitions" {
     log_group_name       = "${aws_cloudwatch_log_group.mod.id}"
     dynamo_tag_table     = "${aws_dynamodb_table.tags.id}"
     dynamo_job_table     = "${aws_dynamodb_table.jobs.id}"
+    retention_count      = "${var.deploy_retention_count}"
+    docker_tls_ca        = "${base64encode(tls_self_signed_cert.docker_ca.cert_pem)}"
+    docker_tls_cert      = "${base64encode(tls_locally_signed_cert.docker_client.cert_pem)}"
+    docker_tls_key       = "${base64encode(tls_private_key.docker_client.private_key_pem)}"
   }
 }
@@ -0,0 +1,99 @@
+# the docker daemon on each environment instance only accepts connections from clients
+# with a certificate signed by this ca, which only the api is given
+resource "tls_private_key" "docker_ca" {
+  algorithm = "RSA"
+}
+
+resource "tls_self_signed_cert" "docker_ca" {
+  key_algorithm     = "${tls_private_key.docker_ca.algorithm}"
+  private_key_pem   = "${tls_private_key.docker_ca.private_key_pem}"
+  is_ca_certificate = true
+
+  subject {
+    common_name = "l0-${var.name}-docker-ca"
+  }
+
+  validity_period_hours = 87600
+
+  allowed_uses = [
+    "cert_signing",
+    "digital_signature",
+  ]
+}
+
+resource "tls_private_key" "docker_server" {
+  algorithm = "RSA"
+}
+
+resource "tls_cert_request" "docker_server" {
+  key_algorithm   = "${tls_private_key.docker_server.algorithm}"
+  private_key_pem = "${tls_private_key.docker_server.private_key_pem}"
+
+  # instances are addressed by ip, so the api verifies this name instead
+  dns_names = ["layer0-docker"]
+
+  subject {
+    common_name = "layer0-docker"
+  }
+}
+
+resource "tls_locally_signed_cert" "docker_server" {
+  cert_request_pem   = "${tls_cert_request.docker_server.cert_request_pem}"
+  ca_key_algorithm   = "${tls_private_key.docker_ca.algorithm}"
+  ca_private_key_pem = "${tls_private_key.docker_ca.private_key_pem}"
+  ca_cert_pem        = "${tls_self_signed_cert.docker_ca.cert_pem}"
+
+  validity_period_hours = 87600
+
+  allowed_uses = [
+    "key_encipherment",
+    "digital_signature",
+    "server_auth",
+  ]
+}
+
+resource "tls_private_key" "docker_client" {
+  algorithm = "RSA"
+}
+
+resource "tls_cert_request" "docker_client" {
+  key_algorithm   = "${tls_private_key.docker_client.algorithm}"
+  private_key_pem = "${tls_private_key.docker_client.private_key_pem}"
+
+  subject {
+    common_name = "l0-${var.name}-api"
+  }
+}
+
+resource "tls_locally_signed_cert" "docker_client" {
+  cert_request_pem   = "${tls_cert_request.docker_client.cert_request_pem}"
+  ca_key_algorithm   = "${tls_private_key.docker_ca.algorithm}"
+  ca_private_key_pem = "${tls_private_key.docker_ca.private_key_pem}"
+  ca_cert_pem        = "${tls_self_signed_cert.docker_ca.cert_pem}"
+
+  validity_period_hours = 87600
+
+  allowed_uses = [
+    "key_encipherment",
+    "digital_signature",
+    "client_auth",
+  ]
+}
+
+resource "aws_s3_bucket_object" "docker_ca" {
+  bucket  = "${aws_s3_bucket.mod.id}"
+  key     = "bootstrap/docker/ca.pem"
+  content = "${tls_self_signed_cert.docker_ca.cert_pem}"
+}
+
+resource "aws_s3_bucket_object" "docker_server_cert" {
+  bucket  = "${aws_s3_bucket.mod.id}"
+  key     = "bootstrap/docker/server-cert.pem"
+  content = "${tls_locally_signed_cert.docker_server.cert_pem}"
+}
+
+resource "aws_s3_bucket_object" "docker_server_key" {
+  bucket  = "${aws_s3_bucket.mod.id}"
+  key     = "bootstrap/docker/server-key.pem"
+  content = "${tls_private_key.docker_server.private_key_pem}"
+}
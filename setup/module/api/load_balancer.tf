@@ -53,11 +53,12 @@ resource "aws_elb" "api" {
   security_groups = ["${aws_security_group.api_env.id}", "${aws_security_group.api_lb.id}"]
   tags            = "${var.tags}"
 
+  # tcp is used instead of http so exec sessions can upgrade to websockets
   listener {
     instance_port      = 80
-    instance_protocol  = "http"
+    instance_protocol  = "tcp"
     lb_port            = 443
-    lb_protocol        = "https"
+    lb_protocol        = "ssl"
     ssl_certificate_id = "${aws_iam_server_certificate.api.arn}"
   }
 
@@ -69,3 +70,9 @@ resource "aws_elb" "api" {
     interval            = 6
   }
 }
+
+# the ssl listener does not set X-Forwarded-For, so the api reads the client's address from the proxy protocol header
+resource "aws_proxy_protocol_policy" "api" {
+  load_balancer  = "${aws_elb.api.name}"
+  instance_ports = ["80"]
+}
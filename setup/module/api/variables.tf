@@ -16,6 +16,11 @@ variable "ssh_key_pair" {}
 
 variable "dockercfg" {}
 
+variable "deploy_retention_count" {
+  description = "optional - the number of versions of each deploy to keep; 0 keeps every version"
+  default     = "0"
+}
+
 variable "tags" {
   description = "A map of tags to add to all resources"
   default     = {}
@@ -16,6 +16,11 @@ variable "username" {}
 
 variable "password" {}
 
+variable "deploy_retention_count" {
+  description = "optional - the number of versions of each deploy to keep; 0 keeps every version"
+  default     = "0"
+}
+
 variable "vpc_id" {
   description = "optional - use an empty string to provision a new vpc"
   type        = "string"
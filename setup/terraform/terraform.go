@@ -68,6 +68,47 @@ func (t *Terraform) Plan(dir string) error {
 	return t.run(dir, "plan")
 }
 
+// PlanChanges runs a non-interactive plan and returns whether it has changes to apply,
+// along with its summary line, e.g. 'Plan: 1 to add, 0 to change, 0 to destroy.'
+func (t *Terraform) PlanChanges(dir string) (bool, string, error) {
+	if err := t.validateTerraformVersion(); err != nil {
+		return false, "", err
+	}
+
+	cmd := exec.Command("terraform", "plan", "-detailed-exitcode", "-input=false", "-no-color")
+	cmd.Dir = dir
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		// exit code 2 is a successful plan with changes
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.ExitStatus() == 2 {
+				return true, planSummary(string(output)), nil
+			}
+		}
+
+		text := fmt.Sprintf("Error running %v from %s: %v\n", cmd.Args, cmd.Dir, err)
+		for _, line := range strings.Split(string(output), "\n") {
+			text += line + "\n"
+		}
+
+		return false, "", fmt.Errorf(text)
+	}
+
+	return false, planSummary(string(output)), nil
+}
+
+func planSummary(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Plan:") || strings.HasPrefix(line, "No changes.") {
+			return line
+		}
+	}
+
+	return ""
+}
+
 func (t *Terraform) run(dir string, args ...string) error {
 	if err := t.validateTerraformVersion(); err != nil {
 		return err
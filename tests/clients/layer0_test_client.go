@@ -62,7 +62,7 @@ func (l *Layer0TestClient) CreateLoadBalancer(name, environmentID string) *model
 
 	ports := []models.Port{{HostPort: 80, ContainerPort: 80, Protocol: "http"}}
 
-	loadBalancer, err := l.Client.CreateLoadBalancer(name, environmentID, hc, ports, true)
+	loadBalancer, err := l.Client.CreateLoadBalancer(name, environmentID, hc, ports, true, 60, true, "elb")
 	if err != nil {
 		l.T.Fatal(err)
 	}
@@ -71,7 +71,7 @@ func (l *Layer0TestClient) CreateLoadBalancer(name, environmentID string) *model
 }
 
 func (l *Layer0TestClient) CreateService(name, environmentID, deployID, loadBalancerID string) *models.Service {
-	service, err := l.Client.CreateService(name, environmentID, deployID, loadBalancerID)
+	service, err := l.Client.CreateService(name, environmentID, deployID, loadBalancerID, models.LoadBalancerRule{})
 	if err != nil {
 		l.T.Fatal(err)
 	}
@@ -179,7 +179,7 @@ func (l *Layer0TestClient) ScaleService(id string, scale int) *models.Service {
 }
 
 func (l *Layer0TestClient) CreateLink(id1, id2 string) {
-	if err := l.Client.CreateLink(id1, id2); err != nil {
+	if err := l.Client.CreateLink(id1, models.EnvironmentLink{EnvironmentID: id2}); err != nil {
 		l.T.Fatal(err)
 	}
 }
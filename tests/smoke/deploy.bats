@@ -1,9 +1,17 @@
 #!/usr/bin/env bats
 
+@test "deploy validate --name guestbook1 ./common/Service.Dockerrun.aws.json" {
+    l0 deploy validate --name guestbook1 ./common/Service.Dockerrun.aws.json
+}
+
 @test "deploy create guestbook1" {
     l0 deploy create ./common/Service.Dockerrun.aws.json guestbook1
 }
 
+@test "deploy create guestbook2 from compose" {
+    l0 deploy create ./common/docker-compose.yml guestbook2
+}
+
 @test "deploy list" {
     l0 deploy list
 }
@@ -24,6 +32,18 @@
     l0 deploy get guest\*
 }
 
+@test "deploy diff guestbook1:latest guestbook1:latest" {
+    l0 deploy diff guestbook1:latest guestbook1:latest
+}
+
+@test "deploy prune --dry-run" {
+    l0 deploy prune --dry-run
+}
+
 @test "deploy delete guestbook1" {
     l0 deploy delete guestbook1
 }
+
+@test "deploy delete guestbook2" {
+    l0 deploy delete guestbook2
+}
@@ -36,6 +36,22 @@
     l0 environment unlink test3 test4
 }
 
+@test "environment link --one-way --port 5432/tcp test3 test4" {
+    l0 environment link --one-way --port 5432/tcp test3 test4
+}
+
+@test "environment unlink test3 test4" {
+    l0 environment unlink test3 test4
+}
+
+@test "environment link --cidr 10.0.0.0/16 --port 443/tcp test3" {
+    l0 environment link --cidr 10.0.0.0/16 --port 443/tcp test3
+}
+
+@test "environment unlink --cidr 10.0.0.0/16 test3" {
+    l0 environment unlink --cidr 10.0.0.0/16 test3
+}
+
 @test "environment list" {
     l0 environment list
 }
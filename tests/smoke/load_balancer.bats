@@ -94,6 +94,10 @@ certificate_name="l0-$LAYER0_PREFIX-api"
     l0 loadbalancer create --disable-cross-zone test loadbalancer5
 }
 
+@test "loadbalancer health loadbalancer5" {
+    l0 loadbalancer health loadbalancer5
+}
+
 @test "loadbalancer cross-zone loadbalancer5" {
     l0 loadbalancer cross-zone loadbalancer5
 }
@@ -106,6 +110,18 @@ certificate_name="l0-$LAYER0_PREFIX-api"
     l0 loadbalancer cross-zone loadbalancer5 --enable
 }
 
+@test "loadbalancer connection-draining loadbalancer5 --enable --drain-timeout 60" {
+    l0 loadbalancer connection-draining loadbalancer5 --enable --drain-timeout 60
+}
+
+@test "loadbalancer connection-draining loadbalancer5 --disable" {
+    l0 loadbalancer connection-draining loadbalancer5 --disable
+}
+
+@test "loadbalancer access-logs loadbalancer5 --disable" {
+    l0 loadbalancer access-logs loadbalancer5 --disable
+}
+
 # this deletes the remaining service(s) and loadbalancer(s)
 @test "environment delete --wait test" {
     l0 environment delete --wait test
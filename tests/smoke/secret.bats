@@ -0,0 +1,42 @@
+#!/usr/bin/env bats
+
+@test "environment create test" {
+    l0 environment create test
+}
+
+@test "secret create test password hunter2" {
+    l0 secret create test password hunter2
+}
+
+@test "secret create --file ./common/user_data.sh test user_data" {
+    l0 secret create --file ./common/user_data.sh test user_data
+}
+
+@test "secret list test" {
+    l0 secret list test
+}
+
+@test "deploy create secret" {
+    l0 deploy create ./common/Secret.Dockerrun.aws.json secret
+}
+
+@test "task create --wait test task1 secret:latest" {
+    l0 task create --wait test task1 secret:latest
+}
+
+@test "task delete task1" {
+    l0 task delete task1
+}
+
+@test "deploy delete secret:latest" {
+    l0 deploy delete secret:latest
+}
+
+@test "secret delete test user_data" {
+    l0 secret delete test user_data
+}
+
+# this deletes the remaining secret(s)
+@test "environment delete --wait test" {
+    l0 environment delete --wait test
+}
@@ -16,6 +16,10 @@
     l0 task create --env alpine:key=val --copies 3 test task2 alpine:latest
 }
 
+@test "task create --command alpine:\"echo hello\" --memory alpine:128 test task3 alpine:latest" {
+    l0 task create --command alpine:"echo hello" --memory alpine:128 test task3 alpine:latest
+}
+
 @test "task list" {
     l0 task list
 }
@@ -32,6 +36,14 @@
     l0 task get t\*
 }
 
+@test "task get --details task1" {
+    l0 task get --details task1
+}
+
+@test "task wait task1" {
+    l0 task wait task1
+}
+
 @test "task logs task1" {
     l0 task logs task1
 }